@@ -0,0 +1,345 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/alecthomas/kong"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/errfmt"
+	gogapi "github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/internal/workpool"
+)
+
+const (
+	accountAll            = "all"
+	maxAccountConcurrency = 4
+
+	// Field names that tag merged items with the identity they came from.
+	fanoutTagAccount = "account"
+	fanoutTagUser    = "user"
+)
+
+// accountRunner is the Run signature shared by the commands that support
+// --account fan-out.
+type accountRunner interface {
+	Run(ctx context.Context, flags *RootFlags) error
+}
+
+type accountRun struct {
+	Account string
+	Values  []any
+	Err     error
+}
+
+// isFatalFanoutError reports whether err from one item of a concurrent fan-out
+// should abort the remaining items instead of being reported per item.
+func isFatalFanoutError(err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.Is(err, context.Canceled) || gogapi.IsCircuitBreakerError(err)
+}
+
+// isMultiAccount reports whether an --account value names several accounts
+// ("a@x.com,b@y.com" or "all").
+func isMultiAccount(value string) bool {
+	value = strings.TrimSpace(value)
+	return strings.EqualFold(value, accountAll) || strings.Contains(value, ",")
+}
+
+// supportsAccountFanout lists the read-only commands that may run once per account.
+func supportsAccountFanout(target any) bool {
+	switch target.(type) {
+	case *GmailSearchCmd, *GmailMessagesSearchCmd,
+		*CalendarEventsCmd, *CalendarCalendarsCmd,
+		*TasksListCmd, *TasksListsListCmd,
+		*DriveSearchCmd, *DriveLsCmd,
+		*ContactsSearchCmd, *ContactsListCmd,
+		*GmailLabelsListCmd, *GmailFiltersListCmd, *GmailForwardingListCmd,
+		*GmailAutoForwardGetCmd, *GmailSendAsListCmd, *GmailDelegatesListCmd,
+		*GmailVacationGetCmd, *CalendarAclCmd:
+		return true
+	default:
+		return false
+	}
+}
+
+func resolveFanoutAccounts(value string) ([]string, error) {
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(value, accountAll) {
+		return allStoredAccounts()
+	}
+
+	seen := make(map[string]struct{})
+	accounts := make([]string, 0, strings.Count(value, ",")+1)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if resolved, ok, err := resolveAccountAlias(part); err != nil {
+			return nil, err
+		} else if ok {
+			part = resolved
+		}
+		email := normalizeEmail(part)
+		if _, ok := seen[email]; ok {
+			continue
+		}
+		seen[email] = struct{}{}
+		accounts = append(accounts, email)
+	}
+	if len(accounts) == 0 {
+		return nil, usage("empty --account list")
+	}
+	return accounts, nil
+}
+
+// allStoredAccounts returns every account shown by `gog auth list`.
+func allStoredAccounts() ([]string, error) {
+	store, err := openSecretsStore()
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := store.ListTokens()
+	if err != nil {
+		return nil, err
+	}
+	serviceAccountEmails, err := config.ListServiceAccountEmails()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{})
+	accounts := make([]string, 0, len(tokens)+len(serviceAccountEmails))
+	add := func(email string) {
+		email = normalizeEmail(email)
+		if email == "" {
+			return
+		}
+		if _, ok := seen[email]; ok {
+			return
+		}
+		seen[email] = struct{}{}
+		accounts = append(accounts, email)
+	}
+	for _, tok := range tokens {
+		add(tok.Email)
+	}
+	for _, email := range serviceAccountEmails {
+		add(email)
+	}
+	if len(accounts) == 0 {
+		return nil, usage("no stored accounts for --account all (run `gog auth add <email>`)")
+	}
+	sort.Strings(accounts)
+	return accounts, nil
+}
+
+// selectedFanoutRunner returns the selected command if it may run once per account.
+func selectedFanoutRunner(kctx *kong.Context) (accountRunner, error) {
+	node := kctx.Selected()
+	if node == nil {
+		return nil, usage("running for several accounts requires a command")
+	}
+	target := node.Target.Addr().Interface()
+	runner, ok := target.(accountRunner)
+	if !ok || !supportsAccountFanout(target) {
+		return nil, usagef("`%s` does not support several accounts; pass a single --account", node.FullPath())
+	}
+	return runner, nil
+}
+
+// runSelectedForAccounts runs the selected command once per account named by
+// --account and merges the results.
+func runSelectedForAccounts(ctx context.Context, kctx *kong.Context, flags *RootFlags) error {
+	runner, err := selectedFanoutRunner(kctx)
+	if err != nil {
+		return err
+	}
+
+	accounts, err := resolveFanoutAccounts(flags.Account)
+	if err != nil {
+		return err
+	}
+
+	return runForAccounts(ctx, runner, flags, accounts, fanoutTagAccount)
+}
+
+func runForAccounts(ctx context.Context, runner accountRunner, flags *RootFlags, accounts []string, tag string) error {
+	if outfmt.IsJSON(ctx) {
+		return runAccountsJSON(ctx, runner, flags, accounts, tag)
+	}
+	return runAccountsPlain(ctx, runner, flags, accounts)
+}
+
+func runAccountsJSON(ctx context.Context, runner accountRunner, flags *RootFlags, accounts []string, tag string) error {
+	limit := workpool.Limit(ctx, maxAccountConcurrency)
+	runs, err := workpool.Map(ctx, limit, accounts, func(ctx context.Context, account string) (accountRun, error) {
+		accountFlags := *flags
+		accountFlags.Account = account
+		capture := &outfmt.Capture{}
+		runErr := runner.Run(outfmt.WithCapture(ctx, capture), &accountFlags)
+		if isFatalFanoutError(runErr) {
+			return accountRun{}, runErr
+		}
+		return accountRun{Account: account, Values: capture.Values(), Err: runErr}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if allAccountsFailed(runs) {
+		return accountRunsError(ctx, runs)
+	}
+
+	merged, err := mergeAccountRuns(runs, tag)
+	if err != nil {
+		return err
+	}
+	if err := outfmt.WriteJSON(ctx, os.Stdout, merged); err != nil {
+		return err
+	}
+
+	return accountRunsError(ctx, runs)
+}
+
+func runAccountsPlain(ctx context.Context, runner accountRunner, flags *RootFlags, accounts []string) error {
+	runs := make([]accountRun, 0, len(accounts))
+	for _, account := range accounts {
+		_, _ = fmt.Fprintf(os.Stdout, "# %s\n", account)
+		accountFlags := *flags
+		accountFlags.Account = account
+		runs = append(runs, accountRun{Account: account, Err: runner.Run(ctx, &accountFlags)})
+	}
+	return accountRunsError(ctx, runs)
+}
+
+// mergeAccountRuns concatenates list fields across accounts, tagging each item
+// with its account (field name tag). Object fields become one tagged item per
+// account; scalar fields (e.g. nextPageToken) and errors are reported per
+// account under "<tag>_status".
+func mergeAccountRuns(runs []accountRun, tag string) (map[string]any, error) {
+	merged := make(map[string]any)
+	status := make(map[string]any, len(runs))
+
+	for _, run := range runs {
+		entry := map[string]any{"ok": run.Err == nil || ExitCode(run.Err) == emptyResultsExitCode}
+		if run.Err != nil && ExitCode(run.Err) != emptyResultsExitCode {
+			err := stableExitCode(run.Err)
+			entry["error"] = map[string]any{
+				"message": strings.TrimSpace(errfmt.Format(err)),
+				"code":    exitCodeString(ExitCode(err)),
+			}
+		}
+
+		for _, v := range run.Values {
+			generic, err := toGenericJSON(v)
+			if err != nil {
+				return nil, err
+			}
+			switch doc := generic.(type) {
+			case map[string]any:
+				for k, field := range doc {
+					switch value := field.(type) {
+					case []any:
+						merged[k] = appendAccountItems(merged[k], value, tag, run.Account)
+					case map[string]any:
+						merged[k] = appendAccountItems(merged[k], []any{value}, tag, run.Account)
+					default:
+						entry[k] = field
+					}
+				}
+			case []any:
+				merged["results"] = appendAccountItems(merged["results"], doc, tag, run.Account)
+			default:
+				entry["result"] = doc
+			}
+		}
+		status[run.Account] = entry
+	}
+
+	merged[tag+"_status"] = status
+	return merged, nil
+}
+
+func appendAccountItems(existing any, items []any, tag string, account string) []any {
+	out, _ := existing.([]any)
+	if out == nil {
+		out = make([]any, 0, len(items))
+	}
+	for _, item := range items {
+		if m, ok := item.(map[string]any); ok {
+			m[tag] = account
+			out = append(out, m)
+			continue
+		}
+		out = append(out, map[string]any{tag: account, "value": item})
+	}
+	return out
+}
+
+func allAccountsFailed(runs []accountRun) bool {
+	for _, run := range runs {
+		if run.Err == nil || ExitCode(run.Err) == emptyResultsExitCode {
+			return false
+		}
+	}
+	return len(runs) > 0
+}
+
+func toGenericJSON(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshal: %w", err)
+	}
+	var out any
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+	return out, nil
+}
+
+// accountRunsError reports per-account failures on stderr. The command only
+// fails when every account failed (or every account was empty).
+func accountRunsError(ctx context.Context, runs []accountRun) error {
+	var firstErr error
+	failed, empty := 0, 0
+	for _, run := range runs {
+		if run.Err == nil {
+			continue
+		}
+		if ExitCode(run.Err) == emptyResultsExitCode {
+			empty++
+			continue
+		}
+		failed++
+		if firstErr == nil {
+			firstErr = run.Err
+		}
+		if u := ui.FromContext(ctx); u != nil {
+			u.Err().Printf("%s: %s", run.Account, strings.TrimSpace(errfmt.Format(stableExitCode(run.Err))))
+		}
+	}
+
+	switch {
+	case failed == len(runs):
+		return firstErr
+	case failed+empty == len(runs) && empty > 0:
+		for _, run := range runs {
+			if ExitCode(run.Err) == emptyResultsExitCode {
+				return run.Err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	gogapi "github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type fakeAccountRunner func(ctx context.Context, flags *RootFlags) error
+
+func (f fakeAccountRunner) Run(ctx context.Context, flags *RootFlags) error { return f(ctx, flags) }
+
+func TestIsMultiAccount(t *testing.T) {
+	for value, want := range map[string]bool{
+		"a@b.com":         false,
+		"all":             true,
+		"ALL":             true,
+		"a@b.com,c@d.com": true,
+		"":                false,
+	} {
+		if got := isMultiAccount(value); got != want {
+			t.Fatalf("isMultiAccount(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestRunAccountsJSON_MergesAndReportsErrors(t *testing.T) {
+	runner := fakeAccountRunner(func(ctx context.Context, flags *RootFlags) error {
+		if flags.Account == "bad@b.com" {
+			return errors.New("boom")
+		}
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"threads":       []map[string]any{{"id": flags.Account + "-1"}},
+			"nextPageToken": "tok-" + flags.Account,
+		})
+	})
+
+	u, uiErr := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if uiErr != nil {
+		t.Fatalf("ui.New: %v", uiErr)
+	}
+	ctx := outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{JSON: true})
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = runAccountsJSON(ctx, runner, &RootFlags{}, []string{"a@b.com", "bad@b.com", "c@d.com"}, fanoutTagAccount)
+	})
+	if runErr != nil {
+		t.Fatalf("expected partial success, got %v", runErr)
+	}
+
+	var doc struct {
+		Threads []struct {
+			ID      string `json:"id"`
+			Account string `json:"account"`
+		} `json:"threads"`
+		AccountStatus map[string]map[string]any `json:"account_status"`
+	}
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("decode: %v\n%s", err, out)
+	}
+	if len(doc.Threads) != 2 || doc.Threads[0].Account != "a@b.com" || doc.Threads[1].ID != "c@d.com-1" {
+		t.Fatalf("unexpected threads: %+v", doc.Threads)
+	}
+	if doc.AccountStatus["a@b.com"]["nextPageToken"] != "tok-a@b.com" {
+		t.Fatalf("expected per-account page token: %+v", doc.AccountStatus)
+	}
+	if doc.AccountStatus["bad@b.com"]["ok"] != false || doc.AccountStatus["bad@b.com"]["error"] == nil {
+		t.Fatalf("expected per-account error: %+v", doc.AccountStatus["bad@b.com"])
+	}
+}
+
+func TestRunAccountsJSON_AllFailed(t *testing.T) {
+	runner := fakeAccountRunner(func(context.Context, *RootFlags) error { return errors.New("boom") })
+
+	ctx := outfmt.WithMode(context.Background(), outfmt.Mode{JSON: true})
+	out := captureStdout(t, func() {
+		if err := runAccountsJSON(ctx, runner, &RootFlags{}, []string{"a@b.com", "c@d.com"}, fanoutTagAccount); err == nil {
+			t.Fatalf("expected error")
+		}
+	})
+	if out != "" {
+		t.Fatalf("expected no merged output, got %q", out)
+	}
+}
+
+func TestResolveFanoutAccounts_Dedupes(t *testing.T) {
+	got, err := resolveFanoutAccounts(" A@b.com, c@d.com,a@b.com ")
+	if err != nil {
+		t.Fatalf("resolveFanoutAccounts: %v", err)
+	}
+	if len(got) != 2 || got[0] != "a@b.com" || got[1] != "c@d.com" {
+		t.Fatalf("unexpected accounts: %v", got)
+	}
+}
+
+func TestRunAccountsJSON_CircuitBreakerAborts(t *testing.T) {
+	runner := fakeAccountRunner(func(ctx context.Context, flags *RootFlags) error {
+		if flags.Account == "a@b.com" {
+			return &gogapi.CircuitBreakerError{}
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	ctx := outfmt.WithMode(context.Background(), outfmt.Mode{JSON: true})
+	out := captureStdout(t, func() {
+		err := runAccountsJSON(ctx, runner, &RootFlags{}, []string{"a@b.com", "c@d.com"}, fanoutTagAccount)
+		if !gogapi.IsCircuitBreakerError(err) {
+			t.Fatalf("expected circuit breaker error, got %v", err)
+		}
+	})
+	if out != "" {
+		t.Fatalf("expected no merged output, got %q", out)
+	}
+}
@@ -129,6 +129,8 @@ func TestDesirePaths_RewriteFields_KeepsCalendarEventsWithPickAndProject(t *test
 	cases := [][]string{
 		{"--pick", "id", "calendar", "events", "--fields", "items(id)"},
 		{"--project", "id", "calendar", "events", "--fields", "items(id)"},
+		{"--users-from", "users.txt", "calendar", "events", "--fields", "items(id)"},
+		{"--concurrency", "4", "calendar", "events", "--fields", "items(id)"},
 	}
 	for _, in := range cases {
 		in := in
@@ -11,23 +11,33 @@ import (
 
 type AgentExitCodesCmd struct{}
 
+// stableExitCodes maps the documented exit code names to their values.
+func stableExitCodes() map[string]int {
+	return map[string]int{
+		"ok":                  0,
+		"error":               1,
+		"usage":               2,
+		"empty_results":       emptyResultsExitCode,
+		"auth_required":       exitCodeAuthRequired,
+		"not_found":           exitCodeNotFound,
+		"permission_denied":   exitCodePermissionDenied,
+		"rate_limited":        exitCodeRateLimited,
+		"retryable":           exitCodeRetryable,
+		"insufficient_scopes": exitCodeInsufficientScopes,
+		"config":              exitCodeConfig,
+		"api_disabled":        exitCodeAPIDisabled,
+		"quota_exhausted":     exitCodeQuotaExhausted,
+		"failed_precondition": exitCodeFailedPrecondition,
+		"domain_policy":       exitCodeDomainPolicy,
+		"cancelled":           exitCodeCancelled,
+	}
+}
+
 func (c *AgentExitCodesCmd) Run(ctx context.Context) error {
 	// Always emit untransformed JSON, even if the caller enabled global JSON transforms.
 	ctx = outfmt.WithJSONTransform(ctx, outfmt.JSONTransform{})
 
-	codes := map[string]int{
-		"ok":                0,
-		"error":             1,
-		"usage":             2,
-		"empty_results":     emptyResultsExitCode,
-		"auth_required":     exitCodeAuthRequired,
-		"not_found":         exitCodeNotFound,
-		"permission_denied": exitCodePermissionDenied,
-		"rate_limited":      exitCodeRateLimited,
-		"retryable":         exitCodeRetryable,
-		"config":            exitCodeConfig,
-		"cancelled":         exitCodeCancelled,
-	}
+	codes := stableExitCodes()
 
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"exit_codes": codes})
@@ -59,3 +69,9 @@ func (c *AgentExitCodesCmd) Run(ctx context.Context) error {
 	}
 	return nil
 }
+
+func (*AgentExitCodesCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		ExitCodes map[string]int `json:"exit_codes"`
+	}{}}
+}
@@ -70,6 +70,13 @@ func (c *AppScriptGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*AppScriptGetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Project   *scriptapi.Project `json:"project"`
+		EditorURL string             `json:"editor_url"`
+	}{}}
+}
+
 type AppScriptContentCmd struct {
 	ScriptID string `arg:"" name:"scriptId" help:"Script ID"`
 }
@@ -111,6 +118,12 @@ func (c *AppScriptContentCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*AppScriptContentCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Content *scriptapi.Content `json:"content"`
+	}{}}
+}
+
 type AppScriptRunCmd struct {
 	ScriptID string `arg:"" name:"scriptId" help:"Script ID"`
 	Function string `arg:"" name:"function" help:"Function name to run"`
@@ -188,6 +201,12 @@ func (c *AppScriptRunCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*AppScriptRunCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Operation *scriptapi.Operation `json:"operation"`
+	}{}}
+}
+
 type AppScriptCreateCmd struct {
 	Title    string `name:"title" help:"Project title" required:""`
 	ParentID string `name:"parent-id" help:"Optional Drive file ID to bind to"`
@@ -244,6 +263,14 @@ func (c *AppScriptCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*AppScriptCreateCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Created   bool               `json:"created"`
+		Project   *scriptapi.Project `json:"project"`
+		EditorURL string             `json:"editor_url"`
+	}{}}
+}
+
 func parseJSONArray(raw string) ([]interface{}, error) {
 	val := strings.TrimSpace(raw)
 	if val == "" {
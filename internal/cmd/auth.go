@@ -13,6 +13,7 @@ import (
 
 	"github.com/steipete/gogcli/internal/authclient"
 	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/googleapi"
 	"github.com/steipete/gogcli/internal/googleauth"
 	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/secrets"
@@ -48,6 +49,8 @@ const (
 	authTypeOAuth               = "oauth"
 	authTypeServiceAccount      = "service_account"
 	authTypeOAuthServiceAccount = "oauth+service_account"
+	authTypeADC                 = "adc"
+	authTypeEnv                 = "env"
 )
 
 type AuthCmd struct {
@@ -57,6 +60,7 @@ type AuthCmd struct {
 	List        AuthListCmd           `cmd:"" name:"list" help:"List stored accounts"`
 	Aliases     AuthAliasCmd          `cmd:"" name:"alias" help:"Manage account aliases"`
 	Status      AuthStatusCmd         `cmd:"" name:"status" help:"Show auth configuration and keyring backend"`
+	Doctor      AuthDoctorCmd         `cmd:"" name:"doctor" help:"Diagnose credentials, tokens, scopes, enabled APIs, keyring and clock"`
 	Keyring     AuthKeyringCmd        `cmd:"" name:"keyring" help:"Configure keyring backend"`
 	Remove      AuthRemoveCmd         `cmd:"" name:"remove" help:"Remove a stored refresh token"`
 	Tokens      AuthTokensCmd         `cmd:"" name:"tokens" help:"Manage stored refresh tokens"`
@@ -132,6 +136,21 @@ func (c *AuthCredentialsSetCmd) Run(ctx context.Context, _ *RootFlags) error {
 	return nil
 }
 
+func (*AuthCredentialsSetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Saved  bool   `json:"saved"`
+		Path   string `json:"path"`
+		Client string `json:"client"`
+	}{}}
+}
+
+type credentialsEntry struct {
+	Client  string   `json:"client"`
+	Path    string   `json:"path,omitempty"`
+	Default bool     `json:"default"`
+	Domains []string `json:"domains,omitempty"`
+}
+
 type AuthCredentialsListCmd struct{}
 
 func (c *AuthCredentialsListCmd) Run(ctx context.Context, _ *RootFlags) error {
@@ -157,19 +176,12 @@ func (c *AuthCredentialsListCmd) Run(ctx context.Context, _ *RootFlags) error {
 		domainMap[normalizedClient] = append(domainMap[normalizedClient], domain)
 	}
 
-	type entry struct {
-		Client  string   `json:"client"`
-		Path    string   `json:"path,omitempty"`
-		Default bool     `json:"default"`
-		Domains []string `json:"domains,omitempty"`
-	}
-
-	entries := make([]entry, 0, len(creds))
+	entries := make([]credentialsEntry, 0, len(creds))
 	seen := make(map[string]struct{})
 	for _, info := range creds {
 		domains := domainMap[info.Client]
 		sort.Strings(domains)
-		entries = append(entries, entry{
+		entries = append(entries, credentialsEntry{
 			Client:  info.Client,
 			Path:    info.Path,
 			Default: info.Default,
@@ -183,7 +195,7 @@ func (c *AuthCredentialsListCmd) Run(ctx context.Context, _ *RootFlags) error {
 			continue
 		}
 		sort.Strings(domains)
-		entries = append(entries, entry{
+		entries = append(entries, credentialsEntry{
 			Client:  client,
 			Domains: domains,
 		})
@@ -193,7 +205,7 @@ func (c *AuthCredentialsListCmd) Run(ctx context.Context, _ *RootFlags) error {
 
 	if len(entries) == 0 {
 		if outfmt.IsJSON(ctx) {
-			return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"clients": []entry{}})
+			return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"clients": []credentialsEntry{}})
 		}
 		u.Err().Println("No OAuth client credentials stored")
 		return nil
@@ -212,11 +224,18 @@ func (c *AuthCredentialsListCmd) Run(ctx context.Context, _ *RootFlags) error {
 	return nil
 }
 
+func (*AuthCredentialsListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "clients", Result: struct {
+		Clients []credentialsEntry `json:"clients"`
+	}{}}
+}
+
 type AuthTokensCmd struct {
 	List   AuthTokensListCmd   `cmd:"" name:"list" help:"List stored tokens (by key only)"`
 	Delete AuthTokensDeleteCmd `cmd:"" name:"delete" help:"Delete a stored refresh token"`
 	Export AuthTokensExportCmd `cmd:"" name:"export" help:"Export a refresh token to a file (contains secrets)"`
 	Import AuthTokensImportCmd `cmd:"" name:"import" help:"Import a refresh token file into keyring (contains secrets)"`
+	Check  AuthTokensCheckCmd  `cmd:"" name:"check" help:"Probe refresh tokens and report age, projected expiry and revocation"`
 }
 
 type AuthTokensListCmd struct{}
@@ -256,6 +275,12 @@ func (c *AuthTokensListCmd) Run(ctx context.Context, _ *RootFlags) error {
 	return nil
 }
 
+func (*AuthTokensListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "keys", Result: struct {
+		Keys []string `json:"keys"`
+	}{}}
+}
+
 type AuthTokensDeleteCmd struct {
 	Email string `arg:"" name:"email" help:"Email"`
 }
@@ -289,6 +314,19 @@ func (c *AuthTokensDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 	)
 }
 
+func (*AuthTokensDeleteCmd) jsonOutput() commandOutput {
+	return authRemovedOutput()
+}
+
+// authRemovedOutput declares the result of tokens delete and remove.
+func authRemovedOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Deleted bool   `json:"deleted"`
+		Email   string `json:"email"`
+		Client  string `json:"client"`
+	}{}}
+}
+
 type AuthTokensExportCmd struct {
 	Email     string                 `arg:"" name:"email" help:"Email"`
 	Output    OutputPathRequiredFlag `embed:""`
@@ -343,12 +381,17 @@ func (c *AuthTokensExportCmd) Run(ctx context.Context, _ *RootFlags) error {
 		Services     []string `json:"services,omitempty"`
 		Scopes       []string `json:"scopes,omitempty"`
 		CreatedAt    string   `json:"created_at,omitempty"`
+		IssuedAt     string   `json:"issued_at,omitempty"`
 		RefreshToken string   `json:"refresh_token"`
 	}
 	created := ""
 	if !tok.CreatedAt.IsZero() {
 		created = tok.CreatedAt.UTC().Format(time.RFC3339)
 	}
+	issued := ""
+	if !tok.IssuedAt.IsZero() {
+		issued = tok.IssuedAt.UTC().Format(time.RFC3339)
+	}
 
 	enc := json.NewEncoder(f)
 	enc.SetEscapeHTML(false)
@@ -359,6 +402,7 @@ func (c *AuthTokensExportCmd) Run(ctx context.Context, _ *RootFlags) error {
 		Services:     tok.Services,
 		Scopes:       tok.Scopes,
 		CreatedAt:    created,
+		IssuedAt:     issued,
 		RefreshToken: tok.RefreshToken,
 	}); encErr != nil {
 		return encErr
@@ -380,6 +424,15 @@ func (c *AuthTokensExportCmd) Run(ctx context.Context, _ *RootFlags) error {
 	return nil
 }
 
+func (*AuthTokensExportCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Exported bool   `json:"exported"`
+		Email    string `json:"email"`
+		Client   string `json:"client"`
+		Path     string `json:"path"`
+	}{}}
+}
+
 type AuthTokensImportCmd struct {
 	InPath string `arg:"" name:"inPath" help:"Input path or '-' for stdin"`
 }
@@ -408,6 +461,7 @@ func (c *AuthTokensImportCmd) Run(ctx context.Context, _ *RootFlags) error {
 		Services     []string `json:"services,omitempty"`
 		Scopes       []string `json:"scopes,omitempty"`
 		CreatedAt    string   `json:"created_at,omitempty"`
+		IssuedAt     string   `json:"issued_at,omitempty"`
 		RefreshToken string   `json:"refresh_token"`
 	}
 	var ex export
@@ -437,6 +491,14 @@ func (c *AuthTokensImportCmd) Run(ctx context.Context, _ *RootFlags) error {
 		}
 		createdAt = parsed
 	}
+	var issuedAt time.Time
+	if strings.TrimSpace(ex.IssuedAt) != "" {
+		parsed, parseErr := time.Parse(time.RFC3339, strings.TrimSpace(ex.IssuedAt))
+		if parseErr != nil {
+			return parseErr
+		}
+		issuedAt = parsed
+	}
 
 	// Pre-flight: ensure keychain is accessible before storing token
 	if keychainErr := ensureKeychainAccessIfNeeded(); keychainErr != nil {
@@ -454,6 +516,7 @@ func (c *AuthTokensImportCmd) Run(ctx context.Context, _ *RootFlags) error {
 		Services:     ex.Services,
 		Scopes:       ex.Scopes,
 		CreatedAt:    createdAt,
+		IssuedAt:     issuedAt,
 		RefreshToken: ex.RefreshToken,
 	}); err != nil {
 		return err
@@ -473,6 +536,14 @@ func (c *AuthTokensImportCmd) Run(ctx context.Context, _ *RootFlags) error {
 	return nil
 }
 
+func (*AuthTokensImportCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Imported bool   `json:"imported"`
+		Email    string `json:"email"`
+		Client   string `json:"client"`
+	}{}}
+}
+
 type AuthAddCmd struct {
 	Email        string        `arg:"" name:"email" help:"Email"`
 	Manual       bool          `name:"manual" help:"Browserless auth flow (paste redirect URL)"`
@@ -666,6 +737,32 @@ func (c *AuthAddCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*AuthAddCmd) jsonOutput() commandOutput {
+	return commandOutput{
+		Result: struct {
+			Stored   bool     `json:"stored"`
+			Email    string   `json:"email"`
+			Services []string `json:"services"`
+			Client   string   `json:"client"`
+		}{},
+		Variants: []any{struct {
+			AuthURL     string `json:"auth_url"`
+			StateReused bool   `json:"state_reused"`
+		}{}},
+	}
+}
+
+type authAccountItem struct {
+	Email     string   `json:"email"`
+	Client    string   `json:"client,omitempty"`
+	Services  []string `json:"services,omitempty"`
+	Scopes    []string `json:"scopes,omitempty"`
+	CreatedAt string   `json:"created_at,omitempty"`
+	Auth      string   `json:"auth"`
+	Valid     *bool    `json:"valid,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
 type AuthListCmd struct {
 	Check   bool          `name:"check" help:"Verify refresh tokens by exchanging for an access token (requires credentials.json)"`
 	Timeout time.Duration `name:"timeout" help:"Per-token check timeout" default:"15s"`
@@ -696,6 +793,17 @@ func (c *AuthStatusCmd) Run(ctx context.Context, flags *RootFlags) error {
 	credentialsPath := ""
 	credentialsExists := false
 
+	// Env mode: OAuth client and/or refresh token come from GOG_* variables.
+	_, envCredentialsSource, _, err := config.EnvClientCredentials()
+	if err != nil {
+		return err
+	}
+	_, envTokenSource, envTokenOK, err := secrets.EnvToken()
+	if err != nil {
+		return err
+	}
+	envMode := envCredentialsSource != "" || envTokenOK
+
 	if flags != nil {
 		if a, err := requireAccount(flags); err == nil {
 			account = a
@@ -705,7 +813,10 @@ func (c *AuthStatusCmd) Run(ctx context.Context, flags *RootFlags) error {
 			}
 			client = resolvedClient
 			path, pathErr := config.ClientCredentialsPathFor(client)
-			if pathErr == nil {
+			if envCredentialsSource != "" {
+				credentialsPath = envCredentialsSource
+				credentialsExists = true
+			} else if pathErr == nil {
 				credentialsPath = path
 				if st, statErr := os.Stat(path); statErr == nil && !st.IsDir() {
 					credentialsExists = true
@@ -715,9 +826,14 @@ func (c *AuthStatusCmd) Run(ctx context.Context, flags *RootFlags) error {
 				serviceAccountConfigured = true
 				serviceAccountPath = p
 			}
-			if serviceAccountConfigured {
+			switch {
+			case serviceAccountConfigured:
 				authPreferred = authTypeServiceAccount
-			} else {
+			case googleapi.UseADC():
+				authPreferred = authTypeADC
+			case envTokenOK:
+				authPreferred = authTypeEnv
+			default:
 				authPreferred = authTypeOAuth
 			}
 		}
@@ -733,6 +849,11 @@ func (c *AuthStatusCmd) Run(ctx context.Context, flags *RootFlags) error {
 				"backend": backendInfo.Value,
 				"source":  backendInfo.Source,
 			},
+			"env": map[string]any{
+				"active":             envMode,
+				"credentials_source": envCredentialsSource,
+				"token_source":       envTokenSource,
+			},
 			"account": map[string]any{
 				"email":                      account,
 				"client":                     client,
@@ -748,6 +869,15 @@ func (c *AuthStatusCmd) Run(ctx context.Context, flags *RootFlags) error {
 	u.Out().Printf("config_exists\t%t", configExists)
 	u.Out().Printf("keyring_backend\t%s", backendInfo.Value)
 	u.Out().Printf("keyring_backend_source\t%s", backendInfo.Source)
+	if envMode {
+		u.Out().Printf("env_mode\ttrue")
+		if envCredentialsSource != "" {
+			u.Out().Printf("env_credentials_source\t%s", envCredentialsSource)
+		}
+		if envTokenSource != "" {
+			u.Out().Printf("env_token_source\t%s", envTokenSource)
+		}
+	}
 	if account != "" {
 		u.Out().Printf("account\t%s", account)
 		u.Out().Printf("client\t%s", client)
@@ -764,6 +894,33 @@ func (c *AuthStatusCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*AuthStatusCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Config struct {
+			Path   string `json:"path"`
+			Exists bool   `json:"exists"`
+		} `json:"config"`
+		Keyring struct {
+			Backend string `json:"backend"`
+			Source  string `json:"source"`
+		} `json:"keyring"`
+		Env struct {
+			Active            bool   `json:"active"`
+			CredentialsSource string `json:"credentials_source"`
+			TokenSource       string `json:"token_source"`
+		} `json:"env"`
+		Account struct {
+			Email                    string `json:"email"`
+			Client                   string `json:"client"`
+			CredentialsPath          string `json:"credentials_path"`
+			CredentialsExists        bool   `json:"credentials_exists"`
+			AuthPreferred            string `json:"auth_preferred"`
+			ServiceAccountConfigured bool   `json:"service_account_configured"`
+			ServiceAccountPath       string `json:"service_account_path"`
+		} `json:"account"`
+	}{}}
+}
+
 func (c *AuthListCmd) Run(ctx context.Context, _ *RootFlags) error {
 	u := ui.FromContext(ctx)
 	store, err := openSecretsStore()
@@ -834,17 +991,7 @@ func (c *AuthListCmd) Run(ctx context.Context, _ *RootFlags) error {
 	sort.Slice(entries, func(i, j int) bool { return entries[i].Email < entries[j].Email })
 
 	if outfmt.IsJSON(ctx) {
-		type item struct {
-			Email     string   `json:"email"`
-			Client    string   `json:"client,omitempty"`
-			Services  []string `json:"services,omitempty"`
-			Scopes    []string `json:"scopes,omitempty"`
-			CreatedAt string   `json:"created_at,omitempty"`
-			Auth      string   `json:"auth"`
-			Valid     *bool    `json:"valid,omitempty"`
-			Error     string   `json:"error,omitempty"`
-		}
-		out := make([]item, 0, len(entries))
+		out := make([]authAccountItem, 0, len(entries))
 		for _, e := range entries {
 			auth := authTypeOAuth
 			if e.SA {
@@ -872,7 +1019,7 @@ func (c *AuthListCmd) Run(ctx context.Context, _ *RootFlags) error {
 				services = []string{"service-account"}
 			}
 
-			it := item{
+			it := authAccountItem{
 				Email:     e.Email,
 				Client:    "",
 				Services:  services,
@@ -955,6 +1102,12 @@ func (c *AuthListCmd) Run(ctx context.Context, _ *RootFlags) error {
 	return nil
 }
 
+func (*AuthListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "accounts", Result: struct {
+		Accounts []authAccountItem `json:"accounts"`
+	}{}}
+}
+
 func bestServiceAccountPathAndMtime(email string) (string, time.Time, bool) {
 	if p, err := config.ServiceAccountPath(email); err == nil {
 		if st, err := os.Stat(p); err == nil {
@@ -1006,6 +1159,12 @@ func (c *AuthServicesCmd) Run(ctx context.Context, _ *RootFlags) error {
 	return nil
 }
 
+func (*AuthServicesCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "services", Result: struct {
+		Services []googleauth.ServiceInfo `json:"services"`
+	}{}}
+}
+
 type AuthRemoveCmd struct {
 	Email string `arg:"" name:"email" help:"Email"`
 }
@@ -1038,6 +1197,10 @@ func (c *AuthRemoveCmd) Run(ctx context.Context, flags *RootFlags) error {
 	)
 }
 
+func (*AuthRemoveCmd) jsonOutput() commandOutput {
+	return authRemovedOutput()
+}
+
 type AuthManageCmd struct {
 	ForceConsent bool          `name:"force-consent" help:"Force consent screen when adding accounts"`
 	ServicesCSV  string        `name:"services" help:"Services to authorize: user|all or comma-separated ${auth_services} (Keep uses service account: gog auth service-account set)" default:"user"`
@@ -1123,6 +1286,15 @@ func (c *AuthKeepCmd) Run(ctx context.Context, _ *RootFlags) error {
 	return nil
 }
 
+func (*AuthKeepCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Stored bool     `json:"stored"`
+		Email  string   `json:"email"`
+		Path   string   `json:"path"`
+		Paths  []string `json:"paths"`
+	}{}}
+}
+
 func parseAuthServices(servicesCSV string) ([]googleauth.Service, error) {
 	trimmed := strings.ToLower(strings.TrimSpace(servicesCSV))
 	if trimmed == "" || trimmed == "user" || trimmed == literalAll {
@@ -47,6 +47,12 @@ func (c *AuthAliasListCmd) Run(ctx context.Context) error {
 	return nil
 }
 
+func (*AuthAliasListCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Aliases map[string]string `json:"aliases"`
+	}{}}
+}
+
 type AuthAliasSetCmd struct {
 	Alias string `arg:"" name:"alias" help:"Alias name (no spaces)"`
 	Email string `arg:"" name:"email" help:"Account email"`
@@ -88,6 +94,13 @@ func (c *AuthAliasSetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*AuthAliasSetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Alias string `json:"alias"`
+		Email string `json:"email"`
+	}{}}
+}
+
 type AuthAliasUnsetCmd struct {
 	Alias string `arg:"" name:"alias" help:"Alias name"`
 }
@@ -115,3 +128,10 @@ func (c *AuthAliasUnsetCmd) Run(ctx context.Context, flags *RootFlags) error {
 		kv("alias", alias),
 	)
 }
+
+func (*AuthAliasUnsetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Deleted bool   `json:"deleted"`
+		Alias   string `json:"alias"`
+	}{}}
+}
@@ -488,3 +488,43 @@ func TestAuthListRemoveTokensListDelete_JSON(t *testing.T) {
 		t.Fatalf("expected empty keys, got: %#v", emptyKeysResp.Keys)
 	}
 }
+
+func TestAuthStatus_JSON_EnvMode(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("GOG_KEYRING_BACKEND", "file")
+	t.Setenv("GOG_CREDENTIALS_JSON", `{"installed":{"client_id":"id","client_secret":"sec"}}`)
+	t.Setenv("GOG_REFRESH_TOKEN", "rt-env")
+
+	out := captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			if err := Execute([]string{"--json", "--account", "a@b.com", "auth", "status"}); err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+		})
+	})
+
+	var envelope struct {
+		Result struct {
+			Env struct {
+				Active            bool   `json:"active"`
+				CredentialsSource string `json:"credentials_source"`
+				TokenSource       string `json:"token_source"`
+			} `json:"env"`
+			Account struct {
+				AuthPreferred     string `json:"auth_preferred"`
+				CredentialsExists bool   `json:"credentials_exists"`
+			} `json:"account"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(out), &envelope); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	payload := envelope.Result
+	if !payload.Env.Active || payload.Env.CredentialsSource != "GOG_CREDENTIALS_JSON" || payload.Env.TokenSource != "GOG_REFRESH_TOKEN" {
+		t.Fatalf("unexpected env section: %+v", payload.Env)
+	}
+	if payload.Account.AuthPreferred != authTypeEnv || !payload.Account.CredentialsExists {
+		t.Fatalf("unexpected account section: %+v", payload.Account)
+	}
+}
@@ -0,0 +1,495 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	ggoogleapi "google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/config"
+	gogapi "github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/googleauth"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/secrets"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	doctorStatusOK   = "ok"
+	doctorStatusWarn = "warn"
+	doctorStatusFail = "fail"
+	doctorStatusSkip = "skip"
+
+	// OAuth token exchanges tolerate a few minutes of drift; warn well before that.
+	doctorClockSkewWarn = 30 * time.Second
+	doctorClockSkewFail = 5 * time.Minute
+)
+
+var (
+	doctorRefreshAccessToken = googleauth.RefreshAccessToken
+	doctorReadCredentials    = config.ReadClientCredentialsFor
+	doctorHTTPClient         = func(timeout time.Duration) *http.Client { return &http.Client{Timeout: timeout} }
+	doctorClockURL           = "https://oauth2.googleapis.com/tokeninfo"
+	doctorProbeURL           = func(probe googleauth.APIProbe) string { return probe.URL }
+)
+
+type AuthDoctorCmd struct {
+	Email       string        `arg:"" optional:"" name:"email" help:"Only check this account (default: all stored accounts)"`
+	ServicesCSV string        `name:"services" help:"Services to probe (default: services recorded with each token): comma-separated ${auth_services}"`
+	NoProbe     bool          `name:"no-probe" help:"Skip live API probes (credentials, refresh and scopes only)"`
+	Timeout     time.Duration `name:"timeout" help:"Per-request timeout" default:"15s"`
+}
+
+type doctorCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Fix     string `json:"fix,omitempty"`
+
+	exitCode int
+}
+
+type doctorAccount struct {
+	Email    string        `json:"email"`
+	Client   string        `json:"client,omitempty"`
+	Services []string      `json:"services,omitempty"`
+	Checks   []doctorCheck `json:"checks"`
+}
+
+type doctorReport struct {
+	Healthy  bool            `json:"healthy"`
+	Problems int             `json:"problems"`
+	Warnings int             `json:"warnings"`
+	Checks   []doctorCheck   `json:"checks"`
+	Accounts []doctorAccount `json:"accounts"`
+}
+
+func (c *AuthDoctorCmd) Run(ctx context.Context, _ *RootFlags) error {
+	u := ui.FromContext(ctx)
+
+	var onlyServices []googleauth.Service
+	if strings.TrimSpace(c.ServicesCSV) != "" {
+		svcs, err := parseDoctorServices(c.ServicesCSV)
+		if err != nil {
+			return err
+		}
+		onlyServices = svcs
+	}
+
+	report := doctorReport{Checks: []doctorCheck{}, Accounts: []doctorAccount{}}
+
+	keyringCheck, tokens := doctorKeyring()
+	report.Checks = append(report.Checks, keyringCheck, doctorClock(ctx, c.Timeout))
+
+	filter := normalizeEmail(c.Email)
+	if filter != "" {
+		filtered := tokens[:0]
+		for _, tok := range tokens {
+			if normalizeEmail(tok.Email) == filter {
+				filtered = append(filtered, tok)
+			}
+		}
+		tokens = filtered
+	}
+
+	clients := map[string]struct{}{}
+	for _, tok := range tokens {
+		clients[tok.Client] = struct{}{}
+	}
+	if infos, err := config.ListClientCredentials(); err == nil && filter == "" {
+		for _, info := range infos {
+			clients[info.Client] = struct{}{}
+		}
+	}
+	clientOK := make(map[string]bool, len(clients))
+	for _, client := range sortedKeys(clients) {
+		check := doctorClientCredentials(client)
+		clientOK[client] = check.Status == doctorStatusOK
+		report.Checks = append(report.Checks, check)
+	}
+
+	for _, tok := range tokens {
+		report.Accounts = append(report.Accounts, c.checkAccount(ctx, tok, clientOK[tok.Client], onlyServices))
+	}
+
+	if filter != "" && len(tokens) == 0 {
+		report.Checks = append(report.Checks, doctorFail("token", exitCodeAuthRequired,
+			fmt.Sprintf("no stored token for %s", filter)))
+	}
+
+	var firstFail *doctorCheck
+	tally := func(checks []doctorCheck) {
+		for i := range checks {
+			switch checks[i].Status {
+			case doctorStatusFail:
+				report.Problems++
+				if firstFail == nil {
+					firstFail = &checks[i]
+				}
+			case doctorStatusWarn:
+				report.Warnings++
+			}
+		}
+	}
+	tally(report.Checks)
+	for _, acct := range report.Accounts {
+		tally(acct.Checks)
+	}
+	report.Healthy = report.Problems == 0
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, report); err != nil {
+			return err
+		}
+	} else {
+		writeDoctorReport(ctx, u, report)
+	}
+
+	if firstFail != nil {
+		return &ExitError{Code: firstFail.exitCode, Err: fmt.Errorf("auth doctor found %d problem(s)", report.Problems)}
+	}
+	return nil
+}
+
+func (*AuthDoctorCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "checks", Result: doctorReport{}}
+}
+
+func (c *AuthDoctorCmd) checkAccount(ctx context.Context, tok secrets.Token, clientOK bool, onlyServices []googleauth.Service) doctorAccount {
+	acct := doctorAccount{
+		Email:    tok.Email,
+		Client:   tok.Client,
+		Services: tok.Services,
+		Checks:   []doctorCheck{},
+	}
+
+	services := onlyServices
+	if len(services) == 0 {
+		for _, name := range tok.Services {
+			if svc, err := googleauth.ParseService(name); err == nil {
+				services = append(services, svc)
+			}
+		}
+	}
+
+	if !clientOK {
+		acct.Checks = append(acct.Checks, doctorSkip("refresh", fmt.Sprintf("client %q credentials unusable", tok.Client)))
+		return acct
+	}
+
+	access, err := doctorRefreshAccessToken(ctx, tok.Client, tok.RefreshToken, tok.Scopes, c.Timeout)
+	if err != nil {
+		check := doctorFail("refresh", exitCodeAuthRequired, err.Error())
+		check.Fix = fmt.Sprintf("Token was revoked or expired. Run `gog auth add %s --services %s --force-consent`.", tok.Email, doctorServicesCSV(services))
+		acct.Checks = append(acct.Checks, check)
+		return acct
+	}
+	acct.Checks = append(acct.Checks, doctorOK("refresh", ""))
+
+	granted := googleauth.GrantedScopes(access)
+	for _, svc := range services {
+		acct.Checks = append(acct.Checks, doctorScopes(tok, svc, granted))
+	}
+
+	if c.NoProbe {
+		return acct
+	}
+	for _, svc := range services {
+		acct.Checks = append(acct.Checks, c.probe(ctx, tok.Email, access, svc))
+	}
+	return acct
+}
+
+func doctorKeyring() (doctorCheck, []secrets.Token) {
+	info, err := secrets.ResolveKeyringBackendInfo()
+	if err != nil {
+		return doctorFail("keyring", exitCodeConfig, err.Error()), nil
+	}
+	desc := fmt.Sprintf("backend %s (source: %s)", info.Value, info.Source)
+
+	store, err := openSecretsStore()
+	if err != nil {
+		check := doctorFail("keyring", exitCodeConfig, fmt.Sprintf("%s: %v", desc, err))
+		check.Fix = "Use `gog auth keyring file` (and set GOG_KEYRING_PASSWORD for non-interactive runs)."
+		return check, nil
+	}
+
+	tokens, err := store.ListTokens()
+	if err != nil {
+		return doctorFail("keyring", exitCodeConfig, fmt.Sprintf("%s: %v", desc, err)), nil
+	}
+
+	filtered := make([]secrets.Token, 0, len(tokens))
+	for _, tok := range tokens {
+		if strings.TrimSpace(tok.Email) == "" {
+			continue
+		}
+		filtered = append(filtered, tok)
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		if filtered[i].Email != filtered[j].Email {
+			return filtered[i].Email < filtered[j].Email
+		}
+		return filtered[i].Client < filtered[j].Client
+	})
+
+	if len(filtered) == 0 {
+		return doctorWarn("keyring", exitCodeAuthRequired, desc+"; no tokens stored"), nil
+	}
+	return doctorOK("keyring", fmt.Sprintf("%s; %d token(s)", desc, len(filtered))), filtered
+}
+
+func doctorClock(ctx context.Context, timeout time.Duration) doctorCheck {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, doctorClockURL, nil)
+	if err != nil {
+		return doctorSkip("clock", err.Error())
+	}
+
+	sent := time.Now()
+	resp, err := doctorHTTPClient(timeout).Do(req)
+	if err != nil {
+		return doctorSkip("clock", fmt.Sprintf("could not reach Google: %v", err))
+	}
+	_ = resp.Body.Close()
+
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return doctorSkip("clock", "response had no Date header")
+	}
+
+	// The Date header has one-second resolution and is stamped mid-flight.
+	local := sent.Add(time.Since(sent) / 2)
+	skew := local.Sub(serverTime)
+	if skew < 0 {
+		skew = -skew
+	}
+	msg := fmt.Sprintf("local clock differs from Google by %s", skew.Round(time.Second))
+
+	switch {
+	case skew >= doctorClockSkewFail:
+		check := doctorFail("clock", exitCodeConfig, msg)
+		check.Fix = "Sync the system clock (NTP); OAuth token exchanges fail with large clock skew."
+		return check
+	case skew >= doctorClockSkewWarn:
+		check := doctorWarn("clock", exitCodeConfig, msg)
+		check.Fix = "Sync the system clock (NTP)."
+		return check
+	default:
+		return doctorOK("clock", msg)
+	}
+}
+
+func doctorClientCredentials(client string) doctorCheck {
+	name := "credentials:" + client
+
+	creds, err := doctorReadCredentials(client)
+	if err != nil {
+		check := doctorFail(name, exitCodeConfig, err.Error())
+		var credErr *config.CredentialsMissingError
+		if errors.As(err, &credErr) && client != config.DefaultClientName {
+			check.Fix = fmt.Sprintf("Set OAuth credentials using `gog --client %s auth credentials <path-to-credentials.json>`.", client)
+		}
+		return check
+	}
+	if strings.TrimSpace(creds.ClientID) == "" || strings.TrimSpace(creds.ClientSecret) == "" {
+		return doctorFail(name, exitCodeConfig, "client_id or client_secret is empty")
+	}
+	return doctorOK(name, creds.ClientID)
+}
+
+func doctorScopes(tok secrets.Token, svc googleauth.Service, granted []string) doctorCheck {
+	name := "scopes:" + string(svc)
+
+	required, err := googleauth.Scopes(svc)
+	if err != nil {
+		return doctorSkip(name, err.Error())
+	}
+	if len(granted) == 0 {
+		return doctorSkip(name, "token response did not include granted scopes")
+	}
+
+	missing := googleauth.MissingScopes(granted, required)
+	if len(missing) == 0 {
+		return doctorOK(name, "")
+	}
+
+	// Accounts authorized with --readonly or --drive-scope never asked for the full
+	// scopes; only a missing scope that was requested means consent was withheld.
+	requestedMissing := googleauth.MissingScopes(granted, intersectScopes(tok.Scopes, required))
+	msg := "missing " + strings.Join(missing, ", ")
+	if len(requestedMissing) == 0 {
+		check := doctorWarn(name, exitCodePermissionDenied, msg+" (authorized with reduced scopes)")
+		check.Fix = fmt.Sprintf("For write access run `gog auth add %s --services %s --force-consent`.", tok.Email, svc)
+		return check
+	}
+
+	check := doctorFail(name, exitCodePermissionDenied, msg)
+	check.Fix = fmt.Sprintf("Re-consent with all boxes checked: `gog auth add %s --services %s --force-consent`.", tok.Email, svc)
+	return check
+}
+
+func (c *AuthDoctorCmd) probe(ctx context.Context, email string, access *oauth2.Token, svc googleauth.Service) doctorCheck {
+	name := "api:" + string(svc)
+
+	probe, ok := googleauth.ProbeForService(svc)
+	if !ok {
+		return doctorSkip(name, "no probe for service")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, doctorProbeURL(probe), nil)
+	if err != nil {
+		return doctorSkip(name, err.Error())
+	}
+	access.SetAuthHeader(req)
+
+	resp, err := doctorHTTPClient(c.Timeout).Do(req)
+	if err != nil {
+		check := doctorFail(name, exitCodeRetryable, err.Error())
+		check.Fix = fixForExitCode(exitCodeRetryable)
+		return check
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	apiErr := ggoogleapi.CheckResponse(resp)
+	if apiErr == nil {
+		return doctorOK(name, probe.API)
+	}
+	var gerr *ggoogleapi.Error
+	if !errors.As(apiErr, &gerr) {
+		return doctorFail(name, 1, apiErr.Error())
+	}
+
+	switch {
+	case isAPIDisabledError(gerr):
+		check := doctorFail(name, exitCodeConfig, fmt.Sprintf("%s is not enabled for this OAuth client's project", probe.API))
+		check.Fix = fmt.Sprintf("Enable it at %s (then wait a few minutes).", googleauth.EnableAPIURL(probe.API))
+		return check
+	case isInsufficientScopeError(gerr):
+		check := doctorFail(name, exitCodePermissionDenied, "access token lacks the scopes for "+probe.API)
+		check.Fix = fmt.Sprintf("Run `gog auth add %s --services %s --force-consent`.", email, svc)
+		return check
+	case gerr.Code == http.StatusNotFound || gerr.Code == http.StatusBadRequest:
+		// Placeholder resources (see googleauth.APIProbe) land here when the API is enabled.
+		return doctorOK(name, probe.API)
+	case gerr.Code == http.StatusForbidden:
+		// Enabled, but the account may not be entitled (e.g. Workspace-only APIs).
+		return doctorWarn(name, exitCodePermissionDenied, fmt.Sprintf("%s reachable but returned 403: %s", probe.API, gerr.Message))
+	default:
+		code := googleAPIExitCode(gerr)
+		return doctorFail(name, code, fmt.Sprintf("%s returned %d: %s", probe.API, gerr.Code, gerr.Message))
+	}
+}
+
+func isAPIDisabledError(gerr *ggoogleapi.Error) bool {
+	return gogapi.DecodeAPIError(gerr).Kind == gogapi.APIErrorKindAPIDisabled
+}
+
+func isInsufficientScopeError(gerr *ggoogleapi.Error) bool {
+	return gogapi.DecodeAPIError(gerr).Kind == gogapi.APIErrorKindInsufficientPermissions
+}
+
+func parseDoctorServices(csv string) ([]googleauth.Service, error) {
+	out := make([]googleauth.Service, 0)
+	seen := make(map[googleauth.Service]struct{})
+	for _, part := range splitCommaList(csv) {
+		svc, err := googleauth.ParseService(part)
+		if err != nil {
+			return nil, usage(err.Error())
+		}
+		if _, ok := seen[svc]; ok {
+			continue
+		}
+		seen[svc] = struct{}{}
+		out = append(out, svc)
+	}
+	return out, nil
+}
+
+func intersectScopes(a []string, b []string) []string {
+	set := make(map[string]struct{}, len(a))
+	for _, s := range a {
+		set[s] = struct{}{}
+	}
+	out := make([]string, 0, len(b))
+	for _, s := range b {
+		if _, ok := set[s]; ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func doctorServicesCSV(services []googleauth.Service) string {
+	if len(services) == 0 {
+		return "user"
+	}
+	names := make([]string, 0, len(services))
+	for _, svc := range services {
+		names = append(names, string(svc))
+	}
+	return strings.Join(names, ",")
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func doctorOK(name string, msg string) doctorCheck {
+	return doctorCheck{Name: name, Status: doctorStatusOK, Message: msg}
+}
+
+func doctorSkip(name string, msg string) doctorCheck {
+	return doctorCheck{Name: name, Status: doctorStatusSkip, Message: msg}
+}
+
+func doctorWarn(name string, code int, msg string) doctorCheck {
+	return doctorCheck{Name: name, Status: doctorStatusWarn, Message: msg, Code: exitCodeString(code), Fix: fixForExitCode(code), exitCode: code}
+}
+
+func doctorFail(name string, code int, msg string) doctorCheck {
+	return doctorCheck{Name: name, Status: doctorStatusFail, Message: msg, Code: exitCodeString(code), Fix: fixForExitCode(code), exitCode: code}
+}
+
+func writeDoctorReport(ctx context.Context, u *ui.UI, report doctorReport) {
+	w, done := tableWriter(ctx)
+
+	_, _ = fmt.Fprintln(w, "TARGET\tCHECK\tSTATUS\tDETAIL")
+	type fix struct{ target, check, text string }
+	var fixes []fix
+	emit := func(target string, checks []doctorCheck) {
+		for _, check := range checks {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", target, check.Name, check.Status, check.Message)
+			if check.Fix != "" && (check.Status == doctorStatusFail || check.Status == doctorStatusWarn) {
+				fixes = append(fixes, fix{target: target, check: check.Name, text: check.Fix})
+			}
+		}
+	}
+	emit("-", report.Checks)
+	for _, acct := range report.Accounts {
+		emit(acct.Email, acct.Checks)
+	}
+	done()
+
+	if u == nil {
+		return
+	}
+	for _, f := range fixes {
+		u.Err().Printf("fix %s %s: %s", f.target, f.check, f.text)
+	}
+	if report.Healthy {
+		u.Err().Printf("healthy (%d warning(s))", report.Warnings)
+	}
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/googleauth"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/secrets"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+func stubAuthDoctor(t *testing.T, handler http.HandlerFunc) *memStore {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg-config"))
+	t.Setenv("GOG_KEYRING_BACKEND", "file")
+
+	origOpen := openSecretsStore
+	origRefresh := doctorRefreshAccessToken
+	origRead := doctorReadCredentials
+	origClock := doctorClockURL
+	origProbe := doctorProbeURL
+	t.Cleanup(func() {
+		openSecretsStore = origOpen
+		doctorRefreshAccessToken = origRefresh
+		doctorReadCredentials = origRead
+		doctorClockURL = origClock
+		doctorProbeURL = origProbe
+	})
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	store := newMemStore()
+	openSecretsStore = func() (secrets.Store, error) { return store, nil }
+	doctorReadCredentials = func(string) (config.ClientCredentials, error) {
+		return config.ClientCredentials{ClientID: "id", ClientSecret: "secret"}, nil
+	}
+	doctorClockURL = srv.URL + "/clock"
+	doctorProbeURL = func(probe googleauth.APIProbe) string {
+		return srv.URL + "/" + probe.API
+	}
+	return store
+}
+
+func TestAuthDoctor_ReportsDisabledAPIAndMissingScopes(t *testing.T) {
+	store := stubAuthDoctor(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/clock":
+			w.Header().Set("Date", time.Now().UTC().Format(http.TimeFormat))
+		case "/gmail.googleapis.com":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"emailAddress": "a@b.com"})
+		case "/calendar-json.googleapis.com":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = io.WriteString(w, `{"error":{"code":403,"message":"Calendar API has not been used in project 1","errors":[{"reason":"accessNotConfigured","domain":"usageLimits"}]}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	_ = store.SetToken(config.DefaultClientName, "a@b.com", secrets.Token{
+		RefreshToken: "rt",
+		Services:     []string{"gmail", "calendar"},
+		Scopes:       []string{"https://www.googleapis.com/auth/gmail.modify", "https://www.googleapis.com/auth/calendar"},
+	})
+	doctorRefreshAccessToken = func(context.Context, string, string, []string, time.Duration) (*oauth2.Token, error) {
+		return (&oauth2.Token{AccessToken: "at"}).WithExtra(map[string]any{
+			"scope": "https://www.googleapis.com/auth/gmail.modify https://www.googleapis.com/auth/gmail.settings.basic https://www.googleapis.com/auth/gmail.settings.sharing",
+		}), nil
+	}
+
+	u, uiErr := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if uiErr != nil {
+		t.Fatalf("ui.New: %v", uiErr)
+	}
+	ctx := outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{JSON: true})
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = (&AuthDoctorCmd{Timeout: time.Second}).Run(ctx, &RootFlags{})
+	})
+	var ee *ExitError
+	if !errors.As(runErr, &ee) || ee.Code != exitCodePermissionDenied {
+		t.Fatalf("expected permission denied exit, got %v", runErr)
+	}
+
+	var report doctorReport
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("decode: %v\n%s", err, out)
+	}
+	if report.Healthy || report.Problems != 2 {
+		t.Fatalf("unexpected summary: %+v", report)
+	}
+	if len(report.Accounts) != 1 {
+		t.Fatalf("expected one account, got %d", len(report.Accounts))
+	}
+
+	byName := map[string]doctorCheck{}
+	for _, check := range report.Accounts[0].Checks {
+		byName[check.Name] = check
+	}
+	if byName["refresh"].Status != doctorStatusOK || byName["scopes:gmail"].Status != doctorStatusOK {
+		t.Fatalf("unexpected checks: %+v", byName)
+	}
+	if byName["scopes:calendar"].Status != doctorStatusFail {
+		t.Fatalf("expected calendar scope failure: %+v", byName["scopes:calendar"])
+	}
+	api := byName["api:calendar"]
+	if api.Status != doctorStatusFail || !strings.Contains(api.Fix, "console.cloud.google.com/apis/library/calendar-json.googleapis.com") {
+		t.Fatalf("expected disabled calendar API: %+v", api)
+	}
+	if byName["api:gmail"].Status != doctorStatusOK {
+		t.Fatalf("expected gmail API ok: %+v", byName["api:gmail"])
+	}
+}
+
+func TestAuthDoctor_RefreshFailureAndClockSkew(t *testing.T) {
+	store := stubAuthDoctor(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(-10*time.Minute).UTC().Format(http.TimeFormat))
+	})
+	_ = store.SetToken(config.DefaultClientName, "a@b.com", secrets.Token{RefreshToken: "rt", Services: []string{"gmail"}})
+	doctorRefreshAccessToken = func(context.Context, string, string, []string, time.Duration) (*oauth2.Token, error) {
+		return nil, errors.New("invalid_grant")
+	}
+
+	u, uiErr := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if uiErr != nil {
+		t.Fatalf("ui.New: %v", uiErr)
+	}
+	ctx := outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{Plain: true})
+
+	var runErr error
+	out := captureStdout(t, func() {
+		runErr = (&AuthDoctorCmd{Timeout: time.Second}).Run(ctx, &RootFlags{})
+	})
+	if ExitCode(runErr) != exitCodeConfig {
+		t.Fatalf("expected config exit (clock first), got %v", runErr)
+	}
+	if !strings.Contains(out, "clock\tfail") || !strings.Contains(out, "a@b.com\trefresh\tfail\tinvalid_grant") {
+		t.Fatalf("unexpected output:\n%s", out)
+	}
+}
@@ -130,3 +130,18 @@ func (c *AuthKeyringCmd) Run(ctx context.Context, flags *RootFlags) error {
 	u.Out().Printf("keyring_backend\t%s", backend)
 	return nil
 }
+
+func (*AuthKeyringCmd) jsonOutput() commandOutput {
+	return commandOutput{
+		Result: struct {
+			Written        bool   `json:"written"`
+			Path           string `json:"path"`
+			KeyringBackend string `json:"keyring_backend"`
+		}{},
+		Variants: []any{struct {
+			KeyringBackend string `json:"keyring_backend"`
+			Source         string `json:"source"`
+			Path           string `json:"path"`
+		}{}},
+	}
+}
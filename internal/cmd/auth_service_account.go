@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/googleapi"
 	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
 )
@@ -19,6 +20,7 @@ type AuthServiceAccountCmd struct {
 }
 
 type serviceAccountJSONInfo struct {
+	Type        string
 	ClientEmail string
 	ClientID    string
 }
@@ -28,11 +30,21 @@ func parseServiceAccountJSON(data []byte) (serviceAccountJSONInfo, error) {
 	if err := json.Unmarshal(data, &saJSON); err != nil {
 		return serviceAccountJSONInfo{}, fmt.Errorf("invalid service account JSON: %w", err)
 	}
-	if saJSON["type"] != "service_account" {
-		return serviceAccountJSONInfo{}, fmt.Errorf("invalid service account JSON: expected type=service_account")
-	}
 
 	info := serviceAccountJSONInfo{}
+	info.Type, _ = saJSON["type"].(string)
+	switch info.Type {
+	case "service_account":
+	case "external_account", "impersonated_service_account", "authorized_user":
+		// Keyless credentials (workload identity federation, impersonation, gcloud ADC).
+		if v, ok := saJSON["service_account_impersonation_url"].(string); ok {
+			info.ClientEmail = googleapi.ServiceAccountFromImpersonationURL(v)
+		}
+		return info, nil
+	default:
+		return serviceAccountJSONInfo{}, fmt.Errorf("invalid service account JSON: expected type=service_account, external_account, impersonated_service_account or authorized_user")
+	}
+
 	if v, ok := saJSON["client_email"].(string); ok {
 		info.ClientEmail = strings.TrimSpace(v)
 	}
@@ -44,7 +56,7 @@ func parseServiceAccountJSON(data []byte) (serviceAccountJSONInfo, error) {
 
 type AuthServiceAccountSetCmd struct {
 	Email string `arg:"" name:"email" help:"Email to impersonate (Workspace user email)" required:""`
-	Key   string `name:"key" required:"" help:"Path to service account JSON key file"`
+	Key   string `name:"key" required:"" help:"Path to service account JSON key, or keyless external_account (workload identity federation) / impersonated_service_account credentials file"`
 }
 
 func (c *AuthServiceAccountSetCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -101,6 +113,7 @@ func (c *AuthServiceAccountSetCmd) Run(ctx context.Context, flags *RootFlags) er
 			"stored":       true,
 			"email":        email,
 			"path":         destPath,
+			"type":         info.Type,
 			"client_email": info.ClientEmail,
 			"client_id":    info.ClientID,
 		})
@@ -117,6 +130,17 @@ func (c *AuthServiceAccountSetCmd) Run(ctx context.Context, flags *RootFlags) er
 	return nil
 }
 
+func (*AuthServiceAccountSetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Stored      bool   `json:"stored"`
+		Email       string `json:"email"`
+		Path        string `json:"path"`
+		Type        string `json:"type"`
+		ClientEmail string `json:"client_email"`
+		ClientID    string `json:"client_id"`
+	}{}}
+}
+
 type AuthServiceAccountUnsetCmd struct {
 	Email string `arg:"" name:"email" help:"Email (impersonated user)" required:""`
 }
@@ -156,6 +180,14 @@ func (c *AuthServiceAccountUnsetCmd) Run(ctx context.Context, flags *RootFlags)
 	)
 }
 
+func (*AuthServiceAccountUnsetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Deleted bool   `json:"deleted"`
+		Email   string `json:"email"`
+		Path    string `json:"path"`
+	}{}}
+}
+
 type AuthServiceAccountStatusCmd struct {
 	Email string `arg:"" name:"email" help:"Email (impersonated user)" required:""`
 }
@@ -204,6 +236,7 @@ func (c *AuthServiceAccountStatusCmd) Run(ctx context.Context) error {
 			"path":         path,
 			"exists":       true,
 			"stored":       true,
+			"type":         info.Type,
 			"client_email": info.ClientEmail,
 			"client_id":    info.ClientID,
 		})
@@ -219,3 +252,24 @@ func (c *AuthServiceAccountStatusCmd) Run(ctx context.Context) error {
 	}
 	return nil
 }
+
+func (*AuthServiceAccountStatusCmd) jsonOutput() commandOutput {
+	return commandOutput{
+		Result: struct {
+			Email       string `json:"email"`
+			Path        string `json:"path"`
+			Exists      bool   `json:"exists"`
+			Stored      bool   `json:"stored"`
+			Type        string `json:"type"`
+			ClientEmail string `json:"client_email"`
+			ClientID    string `json:"client_id"`
+		}{},
+		Variants: []any{struct {
+			Email   string `json:"email"`
+			Path    string `json:"path"`
+			Exists  bool   `json:"exists"`
+			Stored  bool   `json:"stored"`
+			Message string `json:"message"`
+		}{}},
+	}
+}
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/googleauth"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/secrets"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	tokenAppTesting = "testing"
+
+	tokenStatusOK       = "ok"
+	tokenStatusExpiring = "expiring"
+	tokenStatusRevoked  = "revoked"
+	tokenStatusError    = "error"
+
+	// Refresh tokens of OAuth apps in "Testing" publishing status expire after 7 days.
+	testingAppTokenLifetime = 7 * 24 * time.Hour
+)
+
+var (
+	tokenCheckRefresh = googleauth.RefreshAccessToken
+	tokenCheckRecord  = googleapi.RecordTokenRefresh
+	tokenCheckNow     = time.Now
+)
+
+type AuthTokensCheckCmd struct {
+	Email    string        `arg:"" optional:"" name:"email" help:"Only check this account (default: all stored tokens)"`
+	WarnDays int           `name:"warn-days" help:"Exit non-zero when a token is projected to expire within N days (revoked tokens always fail)" default:"0"`
+	App      string        `name:"app" help:"OAuth app publishing status when Google does not report token expiry: testing (7-day refresh tokens) or production" enum:"testing,production" default:"production"`
+	Timeout  time.Duration `name:"timeout" help:"Per-token refresh timeout" default:"15s"`
+}
+
+type tokenHealth struct {
+	Email         string `json:"email"`
+	Client        string `json:"client"`
+	Status        string `json:"status"`
+	App           string `json:"app"`
+	IssuedAt      string `json:"issued_at,omitempty"`
+	AgeDays       *int   `json:"age_days,omitempty"`
+	LastRefreshAt string `json:"last_refresh_at,omitempty"`
+	ExpiresAt     string `json:"expires_at,omitempty"`
+	DaysLeft      *int   `json:"days_left,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+func (c *AuthTokensCheckCmd) Run(ctx context.Context, _ *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if c.WarnDays < 0 {
+		return usage("--warn-days must be >= 0")
+	}
+
+	store, err := openSecretsStore()
+	if err != nil {
+		return err
+	}
+	tokens, err := store.ListTokens()
+	if err != nil {
+		return err
+	}
+
+	filter := normalizeEmail(c.Email)
+	results := make([]tokenHealth, 0, len(tokens))
+	for _, tok := range tokens {
+		if tok.Email == "" || (filter != "" && normalizeEmail(tok.Email) != filter) {
+			continue
+		}
+		results = append(results, c.check(ctx, tok))
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Email != results[j].Email {
+			return results[i].Email < results[j].Email
+		}
+		return results[i].Client < results[j].Client
+	})
+
+	if filter != "" && len(results) == 0 {
+		return usagef("no stored token for %s", filter)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"tokens": results}); err != nil {
+			return err
+		}
+	} else {
+		if len(results) == 0 {
+			u.Err().Println("No tokens stored")
+			return nil
+		}
+		w, done := tableWriter(ctx)
+		_, _ = fmt.Fprintln(w, "EMAIL\tCLIENT\tSTATUS\tAPP\tAGE_DAYS\tLAST_REFRESH\tEXPIRES\tDETAIL")
+		for _, r := range results {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				r.Email, r.Client, r.Status, r.App, optionalInt(r.AgeDays), formatDateTime(r.LastRefreshAt), formatDateTime(r.ExpiresAt), r.Error)
+		}
+		done()
+	}
+
+	return tokenHealthError(results)
+}
+
+func (*AuthTokensCheckCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "tokens", Result: struct {
+		Tokens []tokenHealth `json:"tokens"`
+	}{}}
+}
+
+func (c *AuthTokensCheckCmd) check(ctx context.Context, tok secrets.Token) tokenHealth {
+	now := tokenCheckNow().UTC()
+	h := tokenHealth{Email: tok.Email, Client: tok.Client, App: c.App}
+
+	issued := tok.IssuedAt
+	if issued.IsZero() {
+		issued = tok.CreatedAt
+	}
+	if !issued.IsZero() {
+		h.IssuedAt = issued.UTC().Format(time.RFC3339)
+		age := int(now.Sub(issued) / (24 * time.Hour))
+		h.AgeDays = &age
+	}
+
+	lastRefresh := tok.LastRefreshAt
+	access, err := tokenCheckRefresh(ctx, tok.Client, tok.RefreshToken, tok.Scopes, c.Timeout)
+	switch {
+	case err != nil && isRevokedTokenError(err):
+		h.Status = tokenStatusRevoked
+		h.Error = err.Error()
+	case err != nil:
+		h.Status = tokenStatusError
+		h.Error = err.Error()
+	default:
+		h.Status = tokenStatusOK
+		lastRefresh = now
+		_ = tokenCheckRecord(tok.Client, tok.Email, tok.RefreshToken, now)
+	}
+	if !lastRefresh.IsZero() {
+		h.LastRefreshAt = lastRefresh.UTC().Format(time.RFC3339)
+	}
+
+	if h.Status == tokenStatusRevoked {
+		return h
+	}
+
+	var expires time.Time
+	if secs := refreshTokenExpiresIn(access); secs > 0 {
+		// Google reports a lifetime for time-limited grants (including testing apps).
+		expires = now.Add(time.Duration(secs) * time.Second)
+		h.App = tokenAppTesting
+	} else if c.App == tokenAppTesting && !issued.IsZero() {
+		expires = issued.Add(testingAppTokenLifetime)
+	}
+	if !expires.IsZero() {
+		h.ExpiresAt = expires.UTC().Format(time.RFC3339)
+		daysLeft := int(expires.Sub(now) / (24 * time.Hour))
+		h.DaysLeft = &daysLeft
+		if h.Status == tokenStatusOK && expires.Sub(now) <= time.Duration(c.WarnDays)*24*time.Hour {
+			h.Status = tokenStatusExpiring
+		}
+	}
+
+	return h
+}
+
+// tokenHealthError fails the command when any token is revoked, expiring within
+// --warn-days, or could not be probed, so it can drive a cron health check.
+func tokenHealthError(results []tokenHealth) error {
+	var revoked, expiring, failed int
+	for _, r := range results {
+		switch r.Status {
+		case tokenStatusRevoked:
+			revoked++
+		case tokenStatusExpiring:
+			expiring++
+		case tokenStatusError:
+			failed++
+		}
+	}
+
+	switch {
+	case revoked > 0 || expiring > 0:
+		return &ExitError{Code: exitCodeAuthRequired, Err: fmt.Errorf("%d token(s) revoked, %d expiring soon; re-run `gog auth add <email>`", revoked, expiring)}
+	case failed > 0:
+		return fmt.Errorf("%d token(s) could not be checked", failed)
+	default:
+		return nil
+	}
+}
+
+// isRevokedTokenError reports whether the token endpoint rejected the refresh
+// token itself (revoked, expired, or otherwise invalid).
+func isRevokedTokenError(err error) bool {
+	var re *oauth2.RetrieveError
+	return errors.As(err, &re) && re.ErrorCode == "invalid_grant"
+}
+
+func refreshTokenExpiresIn(tok *oauth2.Token) int64 {
+	if tok == nil {
+		return 0
+	}
+
+	switch v := tok.Extra("refresh_token_expires_in").(type) {
+	case float64:
+		return int64(v)
+	case string:
+		n, _ := strconv.ParseInt(v, 10, 64)
+		return n
+	default:
+		return 0
+	}
+}
+
+func optionalInt(v *int) string {
+	if v == nil {
+		return "-"
+	}
+	return strconv.Itoa(*v)
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/secrets"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+func stubTokenCheck(t *testing.T, refresh func(refreshToken string) (*oauth2.Token, error)) (*memStore, *[]string) {
+	t.Helper()
+
+	origOpen := openSecretsStore
+	origRefresh := tokenCheckRefresh
+	origRecord := tokenCheckRecord
+	origNow := tokenCheckNow
+	t.Cleanup(func() {
+		openSecretsStore = origOpen
+		tokenCheckRefresh = origRefresh
+		tokenCheckRecord = origRecord
+		tokenCheckNow = origNow
+	})
+
+	store := newMemStore()
+	openSecretsStore = func() (secrets.Store, error) { return store, nil }
+	tokenCheckRefresh = func(_ context.Context, _ string, refreshToken string, _ []string, _ time.Duration) (*oauth2.Token, error) {
+		return refresh(refreshToken)
+	}
+	recorded := []string{}
+	tokenCheckRecord = func(_ string, email string, _ string, _ time.Time) error {
+		recorded = append(recorded, email)
+		return nil
+	}
+	tokenCheckNow = func() time.Time { return time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC) }
+	return store, &recorded
+}
+
+func TestAuthTokensCheck_WarnDaysAndRevoked(t *testing.T) {
+	store, recorded := stubTokenCheck(t, func(refreshToken string) (*oauth2.Token, error) {
+		if refreshToken == "rt-revoked" {
+			return nil, &oauth2.RetrieveError{ErrorCode: "invalid_grant"}
+		}
+		return &oauth2.Token{AccessToken: "at"}, nil
+	})
+	issued := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+	_ = store.SetToken("default", "a@b.com", secrets.Token{Client: "default", Email: "a@b.com", RefreshToken: "rt-ok", IssuedAt: issued})
+	_ = store.SetToken("default", "c@d.com", secrets.Token{Client: "default", Email: "c@d.com", RefreshToken: "rt-revoked", IssuedAt: issued})
+
+	u, uiErr := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if uiErr != nil {
+		t.Fatalf("ui.New: %v", uiErr)
+	}
+	ctx := outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{JSON: true})
+
+	var runErr error
+	out := captureStdout(t, func() {
+		cmd := AuthTokensCheckCmd{WarnDays: 3, App: tokenAppTesting, Timeout: time.Second}
+		runErr = cmd.Run(ctx, &RootFlags{})
+	})
+
+	var ee *ExitError
+	if !errors.As(runErr, &ee) || ee.Code != exitCodeAuthRequired {
+		t.Fatalf("expected auth exit code, got %v", runErr)
+	}
+
+	var doc struct {
+		Tokens []tokenHealth `json:"tokens"`
+	}
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("decode: %v\n%s", err, out)
+	}
+	if len(doc.Tokens) != 2 {
+		t.Fatalf("unexpected tokens: %+v", doc.Tokens)
+	}
+	ok, revoked := doc.Tokens[0], doc.Tokens[1]
+	if ok.Status != tokenStatusExpiring || ok.DaysLeft == nil || *ok.DaysLeft != 2 || ok.AgeDays == nil || *ok.AgeDays != 5 {
+		t.Fatalf("unexpected testing-app token health: %+v", ok)
+	}
+	if revoked.Status != tokenStatusRevoked || revoked.Error == "" {
+		t.Fatalf("unexpected revoked token health: %+v", revoked)
+	}
+	if len(*recorded) != 1 || (*recorded)[0] != "a@b.com" {
+		t.Fatalf("expected refresh recorded for healthy token only: %v", *recorded)
+	}
+}
+
+func TestAuthTokensCheck_ProductionHealthy(t *testing.T) {
+	store, _ := stubTokenCheck(t, func(string) (*oauth2.Token, error) {
+		return &oauth2.Token{AccessToken: "at"}, nil
+	})
+	_ = store.SetToken("default", "a@b.com", secrets.Token{Client: "default", Email: "a@b.com", RefreshToken: "rt", IssuedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)})
+
+	ctx := outfmt.WithMode(context.Background(), outfmt.Mode{JSON: true})
+	_ = captureStdout(t, func() {
+		cmd := AuthTokensCheckCmd{WarnDays: 30, App: "production", Timeout: time.Second}
+		if err := cmd.Run(ctx, &RootFlags{}); err != nil {
+			t.Fatalf("expected healthy production token, got %v", err)
+		}
+	})
+}
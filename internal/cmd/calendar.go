@@ -107,6 +107,13 @@ func (c *CalendarCalendarsCmd) Run(ctx context.Context, flags *RootFlags) error
 	return nil
 }
 
+func (*CalendarCalendarsCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "calendars", Result: struct {
+		Calendars     []*calendar.CalendarListEntry `json:"calendars"`
+		NextPageToken string                        `json:"nextPageToken"`
+	}{}}
+}
+
 type CalendarAclCmd struct {
 	CalendarID string `arg:"" name:"calendarId" help:"Calendar ID"`
 	Max        int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
@@ -195,6 +202,13 @@ func (c *CalendarAclCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*CalendarAclCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "rules", Result: struct {
+		Rules         []*calendar.AclRule `json:"rules"`
+		NextPageToken string              `json:"nextPageToken"`
+	}{}}
+}
+
 type CalendarEventsCmd struct {
 	CalendarID        string   `arg:"" name:"calendarId" optional:"" help:"Calendar ID (default: primary)"`
 	Cal               []string `name:"cal" help:"Calendar ID or name (can be repeated)"`
@@ -282,6 +296,13 @@ func (c *CalendarEventsCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return listCalendarEvents(ctx, svc, calendarID, from, to, c.Max, c.Page, c.AllPages, c.FailEmpty, c.Query, c.PrivatePropFilter, c.SharedPropFilter, c.Fields, c.Weekday)
 }
 
+func (*CalendarEventsCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "events", Result: struct {
+		Events        []*eventWithDays `json:"events"`
+		NextPageToken string           `json:"nextPageToken,omitempty"`
+	}{}}
+}
+
 type CalendarEventCmd struct {
 	CalendarID string `arg:"" name:"calendarId" help:"Calendar ID"`
 	EventID    string `arg:"" name:"eventId" help:"Event ID"`
@@ -322,3 +343,7 @@ func (c *CalendarEventCmd) Run(ctx context.Context, flags *RootFlags) error {
 	printCalendarEventWithTimezone(u, event, tz, loc)
 	return nil
 }
+
+func (*CalendarEventCmd) jsonOutput() commandOutput {
+	return eventOutput()
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"google.golang.org/api/calendar/v3"
+
+	"github.com/steipete/gogcli/internal/timeparse"
 )
 
 const tzUTC = "UTC"
@@ -259,3 +261,89 @@ func buildExtendedProperties(privateProps, sharedProps []string) *calendar.Event
 
 	return props
 }
+
+var calendarNow = time.Now
+
+// resolveEventTimes turns natural --from/--to values ("tomorrow 3pm",
+// "friday 9-11am", "next monday 10:00 for 45m", "mon-fri next week") into the
+// RFC3339 or date strings the API expects, in the configured timezone.
+// RFC3339 and YYYY-MM-DD values pass through untouched. A span or a day in
+// --from fills an empty --to, and a natural --from without a time of day
+// implies --all-day.
+func resolveEventTimes(from, to string, allDay bool) (string, string, bool, error) {
+	from = strings.TrimSpace(from)
+	to = strings.TrimSpace(to)
+	if (from == "" || isLiteralEventTime(from)) && (to == "" || isLiteralEventTime(to)) {
+		return from, to, allDay, nil
+	}
+
+	loc, err := resolveOutputLocation("", false)
+	if err != nil {
+		return "", "", false, err
+	}
+	weekStart, err := resolveWeekStart("")
+	if err != nil {
+		return "", "", false, err
+	}
+	opts := timeparse.Options{Now: calendarNow().In(loc), Location: loc, WeekStart: weekStart}
+
+	var fromSpan timeparse.Span
+	naturalFrom := from != "" && !isLiteralEventTime(from)
+	if naturalFrom {
+		fromSpan, err = timeparse.ParseNatural(from, opts)
+		if err != nil {
+			return "", "", false, usagef("invalid --from: %v", err)
+		}
+		if !fromSpan.HasTime {
+			allDay = true
+		}
+		from = formatEventStart(fromSpan.Start, allDay)
+	}
+
+	switch {
+	case to == "" && naturalFrom && (fromSpan.IsRange || !fromSpan.HasTime):
+		// A day ("monday") is an all-day start; end it with that day rather
+		// than leaving a timed end behind on update.
+		to = formatEventEnd(fromSpan.End, allDay)
+	case to != "" && !isLiteralEventTime(to):
+		toSpan, err := timeparse.ParseNatural(to, opts)
+		if err != nil {
+			return "", "", false, usagef("invalid --to: %v", err)
+		}
+		if !allDay && !toSpan.HasTime {
+			return "", "", false, usagef("--to %q has no time of day (try %q, or use --all-day)", to, to+" 5pm")
+		}
+		to = formatEventEnd(spanUpperBound(toSpan), allDay)
+	}
+
+	return from, to, allDay, nil
+}
+
+// isLiteralEventTime reports whether value is already an API-ready RFC3339
+// timestamp or YYYY-MM-DD date.
+func isLiteralEventTime(value string) bool {
+	if _, err := time.Parse("2006-01-02", value); err == nil {
+		return true
+	}
+	if !strings.Contains(value, "T") {
+		return false
+	}
+	_, err := timeparse.ParseDateTimeOrDate(value, time.UTC)
+	return err == nil
+}
+
+func formatEventStart(t time.Time, allDay bool) string {
+	if allDay {
+		return t.Format("2006-01-02")
+	}
+	return t.Format(time.RFC3339)
+}
+
+// formatEventEnd converts an inclusive natural end into the API's end: all-day
+// events end (exclusively) on the following date.
+func formatEventEnd(t time.Time, allDay bool) string {
+	if allDay {
+		return t.AddDate(0, 0, 1).Format("2006-01-02")
+	}
+	return t.Format(time.RFC3339)
+}
@@ -8,6 +8,8 @@ import (
 	"strconv"
 	"text/tabwriter"
 
+	"google.golang.org/api/calendar/v3"
+
 	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
 )
@@ -88,3 +90,10 @@ func (c *CalendarColorsCmd) Run(ctx context.Context, flags *RootFlags) error {
 
 	return nil
 }
+
+func (*CalendarColorsCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Event    map[string]calendar.ColorDefinition `json:"event"`
+		Calendar map[string]calendar.ColorDefinition `json:"calendar"`
+	}{}}
+}
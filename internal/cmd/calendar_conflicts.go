@@ -101,6 +101,13 @@ func (c *CalendarConflictsCmd) Run(ctx context.Context, flags *RootFlags) error
 	return nil
 }
 
+func (*CalendarConflictsCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "conflicts", Result: struct {
+		Conflicts []conflict `json:"conflicts"`
+		Count     int        `json:"count"`
+	}{}}
+}
+
 // detectConflicts finds overlapping busy periods across calendars
 func detectConflicts(calendars map[string]calendar.FreeBusyCalendar) []conflict {
 	if len(calendars) < 2 {
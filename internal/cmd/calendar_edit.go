@@ -16,8 +16,8 @@ import (
 type CalendarCreateCmd struct {
 	CalendarID            string   `arg:"" name:"calendarId" help:"Calendar ID"`
 	Summary               string   `name:"summary" help:"Event summary/title"`
-	From                  string   `name:"from" help:"Start time (RFC3339)"`
-	To                    string   `name:"to" help:"End time (RFC3339)"`
+	From                  string   `name:"from" help:"Start time (RFC3339, date, or natural: 'tomorrow 3pm', 'friday 9-11am', 'next monday 10:00 for 45m')"`
+	To                    string   `name:"to" help:"End time (RFC3339, date, or natural; optional when --from names a span)"`
 	Description           string   `name:"description" help:"Description"`
 	Location              string   `name:"location" help:"Location"`
 	Attendees             string   `name:"attendees" help:"Comma-separated attendee emails"`
@@ -67,7 +67,11 @@ func (c *CalendarCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if summary == "" {
 		summary = c.defaultSummaryForEventType(eventType)
 	}
-	if summary == "" || strings.TrimSpace(c.From) == "" || strings.TrimSpace(c.To) == "" {
+	from, to, allDay, err := resolveEventTimes(c.From, c.To, c.AllDay)
+	if err != nil {
+		return err
+	}
+	if summary == "" || from == "" || to == "" {
 		return usage("required: --summary, --from, --to")
 	}
 
@@ -92,7 +96,7 @@ func (c *CalendarCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
-	allDay, err := resolveCreateAllDay(c.From, c.To, c.AllDay, eventType)
+	allDay, err = resolveCreateAllDay(from, to, allDay, eventType)
 	if err != nil {
 		return err
 	}
@@ -102,8 +106,8 @@ func (c *CalendarCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 		Summary:            summary,
 		Description:        strings.TrimSpace(c.Description),
 		Location:           strings.TrimSpace(c.Location),
-		Start:              buildEventDateTime(c.From, allDay),
-		End:                buildEventDateTime(c.To, allDay),
+		Start:              buildEventDateTime(from, allDay),
+		End:                buildEventDateTime(to, allDay),
 		Attendees:          buildAttendees(c.Attendees),
 		Recurrence:         buildRecurrence(c.Recurrence),
 		Reminders:          reminders,
@@ -180,6 +184,10 @@ func (c *CalendarCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*CalendarCreateCmd) jsonOutput() commandOutput {
+	return eventOutput()
+}
+
 func (c *CalendarCreateCmd) resolveCreateEventType() (string, error) {
 	focusFlags := strings.TrimSpace(c.FocusAutoDecline) != "" ||
 		strings.TrimSpace(c.FocusDeclineMessage) != "" ||
@@ -313,8 +321,8 @@ type CalendarUpdateCmd struct {
 	CalendarID            string   `arg:"" name:"calendarId" help:"Calendar ID"`
 	EventID               string   `arg:"" name:"eventId" help:"Event ID"`
 	Summary               string   `name:"summary" help:"New summary/title (set empty to clear)"`
-	From                  string   `name:"from" help:"New start time (RFC3339; set empty to clear)"`
-	To                    string   `name:"to" help:"New end time (RFC3339; set empty to clear)"`
+	From                  string   `name:"from" help:"New start time (RFC3339, date, or natural: 'friday 9-11am'; set empty to clear)"`
+	To                    string   `name:"to" help:"New end time (RFC3339, date, or natural; set empty to clear)"`
 	Description           string   `name:"description" help:"New description (set empty to clear)"`
 	Location              string   `name:"location" help:"New location (set empty to clear)"`
 	Attendees             string   `name:"attendees" help:"Comma-separated attendee emails (replaces all; set empty to clear)"`
@@ -464,6 +472,10 @@ func (c *CalendarUpdateCmd) Run(ctx context.Context, kctx *kong.Context, flags *
 	return nil
 }
 
+func (*CalendarUpdateCmd) jsonOutput() commandOutput {
+	return eventOutput()
+}
+
 func (c *CalendarUpdateCmd) buildUpdatePatch(kctx *kong.Context) (*calendar.Event, bool, error) {
 	patch := &calendar.Event{}
 	changed := false
@@ -568,20 +580,29 @@ func resolveUpdateAllDay(value string, allDay bool, eventType string) (bool, err
 
 func (c *CalendarUpdateCmd) applyTimeFields(kctx *kong.Context, patch *calendar.Event, eventType string) (bool, error) {
 	changed := false
-	if flagProvided(kctx, "from") {
-		allDay, err := resolveUpdateAllDay(c.From, c.AllDay, eventType)
+	fromProvided, toProvided := flagProvided(kctx, "from"), flagProvided(kctx, "to")
+	if !fromProvided && !toProvided {
+		return false, nil
+	}
+	from, to, naturalAllDay, err := resolveEventTimes(c.From, c.To, c.AllDay)
+	if err != nil {
+		return false, err
+	}
+	if fromProvided {
+		allDay, err := resolveUpdateAllDay(from, naturalAllDay, eventType)
 		if err != nil {
 			return false, err
 		}
-		patch.Start = buildEventDateTime(c.From, allDay)
+		patch.Start = buildEventDateTime(from, allDay)
 		changed = true
 	}
-	if flagProvided(kctx, "to") {
-		allDay, err := resolveUpdateAllDay(c.To, c.AllDay, eventType)
+	// A span in --from ("friday 9-11am") also moves the end.
+	if toProvided || to != "" {
+		allDay, err := resolveUpdateAllDay(to, naturalAllDay, eventType)
 		if err != nil {
 			return false, err
 		}
-		patch.End = buildEventDateTime(c.To, allDay)
+		patch.End = buildEventDateTime(to, allDay)
 		changed = true
 	}
 	return changed, nil
@@ -955,3 +976,11 @@ func (c *CalendarDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 		kv("eventId", targetEventID),
 	)
 }
+
+func (*CalendarDeleteCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Deleted    bool   `json:"deleted"`
+		CalendarID string `json:"calendarId"`
+		EventID    string `json:"eventId"`
+	}{}}
+}
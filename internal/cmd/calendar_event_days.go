@@ -158,3 +158,11 @@ func resolveEventTimezone(event *calendar.Event, calendarTimezone string, loc *t
 	}
 	return calendarTimezone, loc
 }
+
+// eventOutput declares the {"event": ...} result of commands that write a
+// single event.
+func eventOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Event *eventWithDays `json:"event"`
+	}{}}
+}
@@ -85,6 +85,10 @@ func (c *CalendarFocusTimeCmd) Run(ctx context.Context, flags *RootFlags) error
 	return nil
 }
 
+func (*CalendarFocusTimeCmd) jsonOutput() commandOutput {
+	return eventOutput()
+}
+
 func validateAutoDeclineMode(s string) (string, error) {
 	s = strings.TrimSpace(strings.ToLower(s))
 	switch s {
@@ -71,3 +71,9 @@ func (c *CalendarFreeBusyCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}
 	return nil
 }
+
+func (*CalendarFreeBusyCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Calendars map[string]calendar.FreeBusyCalendar `json:"calendars"`
+	}{}}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/internal/workpool"
 )
 
 func calendarEventsListCall(ctx context.Context, svc *calendar.Service, calendarID, from, to string, maxResults int64, query, privatePropFilter, sharedPropFilter, fields, pageToken string) *calendar.EventsListCall {
@@ -125,12 +126,19 @@ func listSelectedCalendarsEvents(ctx context.Context, svc *calendar.Service, cal
 
 func listCalendarIDsEvents(ctx context.Context, svc *calendar.Service, calendarIDs []string, from, to string, maxResults int64, page string, allPages bool, failEmpty bool, query, privatePropFilter, sharedPropFilter, fields string, showWeekday bool) error {
 	u := ui.FromContext(ctx)
-	all := []*eventWithCalendar{}
+	ids := make([]string, 0, len(calendarIDs))
 	for _, calID := range calendarIDs {
-		calID = strings.TrimSpace(calID)
-		if calID == "" {
-			continue
+		if calID = strings.TrimSpace(calID); calID != "" {
+			ids = append(ids, calID)
 		}
+	}
+
+	type calendarEvents struct {
+		events []*calendar.Event
+		err    error
+	}
+
+	perCalendar, err := workpool.Map(ctx, workpool.Limit(ctx, workpool.DefaultLimit), ids, func(ctx context.Context, calID string) (calendarEvents, error) {
 		fetch := func(pageToken string) ([]*calendar.Event, string, error) {
 			resp, err := calendarEventsListCall(ctx, svc, calID, from, to, maxResults, query, privatePropFilter, sharedPropFilter, fields, pageToken).Do()
 			if err != nil {
@@ -142,21 +150,27 @@ func listCalendarIDsEvents(ctx context.Context, svc *calendar.Service, calendarI
 		var events []*calendar.Event
 		var err error
 		if allPages {
-			allEvents, collectErr := collectAllPages(page, fetch)
-			if collectErr != nil {
-				u.Err().Printf("calendar %s: %v", calID, collectErr)
-				continue
-			}
-			events = allEvents
+			events, err = collectAllPages(page, fetch)
 		} else {
 			events, _, err = fetch(page)
-			if err != nil {
-				u.Err().Printf("calendar %s: %v", calID, err)
-				continue
-			}
+		}
+		if isFatalFanoutError(err) {
+			return calendarEvents{}, err
+		}
+		return calendarEvents{events: events, err: err}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	all := []*eventWithCalendar{}
+	for i, calID := range ids {
+		if perCalendar[i].err != nil {
+			u.Err().Printf("calendar %s: %v", calID, perCalendar[i].err)
+			continue
 		}
 
-		for _, e := range events {
+		for _, e := range perCalendar[i].events {
 			startDay, endDay := eventDaysOfWeek(e)
 			evTimezone := eventTimezone(e)
 			startLocal := formatEventLocal(e.Start, nil)
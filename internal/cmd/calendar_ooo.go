@@ -75,3 +75,7 @@ func (c *CalendarOOOCmd) Run(ctx context.Context, flags *RootFlags) error {
 	printCalendarEventWithTimezone(u, created, tz, loc)
 	return nil
 }
+
+func (*CalendarOOOCmd) jsonOutput() commandOutput {
+	return eventOutput()
+}
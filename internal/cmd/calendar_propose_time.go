@@ -206,6 +206,22 @@ func (c *CalendarProposeTimeCmd) Run(ctx context.Context, flags *RootFlags) erro
 	return nil
 }
 
+func (*CalendarProposeTimeCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		EventID         string `json:"event_id"`
+		CalendarID      string `json:"calendar_id"`
+		Summary         string `json:"summary"`
+		ProposeURL      string `json:"propose_url"`
+		APILimitation   string `json:"api_limitation"`
+		IssueTrackerURL string `json:"issue_tracker_url"`
+		UpvoteAction    string `json:"upvote_action"`
+		CurrentStart    string `json:"current_start,omitempty"`
+		CurrentEnd      string `json:"current_end,omitempty"`
+		Declined        bool   `json:"declined,omitempty"`
+		Comment         string `json:"comment,omitempty"`
+	}{}}
+}
+
 // openProposeTimeBrowser opens the URL in the default browser.
 var openProposeTimeBrowser = func(url string) error {
 	var cmd *exec.Cmd
@@ -125,3 +125,7 @@ func (c *CalendarRespondCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}
 	return nil
 }
+
+func (*CalendarRespondCmd) jsonOutput() commandOutput {
+	return eventOutput()
+}
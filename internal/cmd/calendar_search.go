@@ -81,3 +81,10 @@ func (c *CalendarSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
 	_ = tw.Flush()
 	return nil
 }
+
+func (*CalendarSearchCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "events", Result: struct {
+		Events []*eventWithDays `json:"events"`
+		Query  string           `json:"query"`
+	}{}}
+}
@@ -6,13 +6,13 @@ import (
 	"os"
 	"sort"
 	"strings"
-	"sync"
 	"time"
 
 	"google.golang.org/api/calendar/v3"
 
 	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/internal/workpool"
 )
 
 type CalendarTeamCmd struct {
@@ -38,6 +38,13 @@ type teamEvent struct {
 	sortKey        time.Time
 }
 
+// teamBusyResult lists one member's busy blocks.
+type teamBusyResult struct {
+	Email  string   `json:"email"`
+	Busy   []string `json:"busy"`
+	Errors []string `json:"errors,omitempty"`
+}
+
 func (c *CalendarTeamCmd) Run(ctx context.Context, flags *RootFlags) error {
 	u := ui.FromContext(ctx)
 	account, err := requireAccount(flags)
@@ -84,6 +91,22 @@ func (c *CalendarTeamCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return c.runEvents(ctx, calSvc, u, memberEmails, tr)
 }
 
+func (*CalendarTeamCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "events", Result: struct {
+		Group    string      `json:"group"`
+		TimeMin  string      `json:"timeMin"`
+		TimeMax  string      `json:"timeMax"`
+		Timezone string      `json:"timezone"`
+		Events   []teamEvent `json:"events"`
+	}{}, Variants: []any{struct {
+		Group    string           `json:"group"`
+		TimeMin  string           `json:"timeMin"`
+		TimeMax  string           `json:"timeMax"`
+		Timezone string           `json:"timezone"`
+		FreeBusy []teamBusyResult `json:"freebusy"`
+	}{}}}
+}
+
 func (c *CalendarTeamCmd) runFreeBusy(ctx context.Context, svc *calendar.Service, emails []string, tr *TimeRange) error {
 	// Build FreeBusy request
 	items := make([]*calendar.FreeBusyRequestItem, len(emails))
@@ -100,20 +123,14 @@ func (c *CalendarTeamCmd) runFreeBusy(ctx context.Context, svc *calendar.Service
 		return fmt.Errorf("freebusy query: %w", err)
 	}
 
-	type busyResult struct {
-		Email  string   `json:"email"`
-		Busy   []string `json:"busy"`
-		Errors []string `json:"errors,omitempty"`
-	}
-
-	results := make([]busyResult, 0, len(emails))
+	results := make([]teamBusyResult, 0, len(emails))
 	for _, email := range emails {
 		cal, ok := resp.Calendars[email]
 		if !ok {
 			continue
 		}
 
-		result := busyResult{Email: email}
+		result := teamBusyResult{Email: email}
 
 		// Check for errors
 		if len(cal.Errors) > 0 {
@@ -163,99 +180,94 @@ func (c *CalendarTeamCmd) runFreeBusy(ctx context.Context, svc *calendar.Service
 }
 
 func (c *CalendarTeamCmd) runEvents(ctx context.Context, svc *calendar.Service, u *ui.UI, emails []string, tr *TimeRange) error {
-	var (
-		mu     sync.Mutex
+	type memberEvents struct {
 		events []teamEvent
-		errors []string
-		wg     sync.WaitGroup
-		sem    = make(chan struct{}, 10) // max 10 concurrent requests
-	)
+		err    string
+	}
 
 	queryLower := strings.ToLower(c.Query)
 
-	for _, email := range emails {
-		wg.Add(1)
-		go func(email string) {
-			defer wg.Done()
-			sem <- struct{}{}
-			defer func() { <-sem }()
-
-			call := svc.Events.List(email).
-				SingleEvents(true).
-				TimeMin(tr.From.Format(time.RFC3339)).
-				TimeMax(tr.To.Format(time.RFC3339)).
-				MaxResults(c.Max).
-				OrderBy("startTime").
-				Context(ctx)
-
-			resp, err := call.Do()
-			if err != nil {
-				mu.Lock()
-				errors = append(errors, fmt.Sprintf("%s: %v", email, err))
-				mu.Unlock()
-				return
+	perMember, err := workpool.Map(ctx, workpool.Limit(ctx, workpool.DefaultLimit), emails, func(ctx context.Context, email string) (memberEvents, error) {
+		call := svc.Events.List(email).
+			SingleEvents(true).
+			TimeMin(tr.From.Format(time.RFC3339)).
+			TimeMax(tr.To.Format(time.RFC3339)).
+			MaxResults(c.Max).
+			OrderBy("startTime").
+			Context(ctx)
+
+		resp, err := call.Do()
+		if err != nil {
+			if isFatalFanoutError(err) {
+				return memberEvents{}, err
 			}
+			return memberEvents{err: fmt.Sprintf("%s: %v", email, err)}, nil
+		}
 
-			for _, ev := range resp.Items {
-				if ev == nil {
-					continue
-				}
-
-				// Skip declined events
-				declined := false
-				for _, att := range ev.Attendees {
-					if att.Self && att.ResponseStatus == "declined" {
-						declined = true
-						break
-					}
-				}
-				if declined {
-					continue
-				}
+		var out memberEvents
+		for _, ev := range resp.Items {
+			if ev == nil {
+				continue
+			}
 
-				summary := ev.Summary
-				// Hide private events
-				if ev.Visibility == "private" || ev.Visibility == "confidential" {
-					summary = "(busy)"
+			// Skip declined events
+			declined := false
+			for _, att := range ev.Attendees {
+				if att.Self && att.ResponseStatus == "declined" {
+					declined = true
+					break
 				}
+			}
+			if declined {
+				continue
+			}
 
-				// Apply query filter
-				if queryLower != "" && !strings.Contains(strings.ToLower(summary), queryLower) {
-					continue
-				}
+			summary := ev.Summary
+			// Hide private events
+			if ev.Visibility == "private" || ev.Visibility == "confidential" {
+				summary = "(busy)"
+			}
 
-				start, end := formatEventTime(ev, tr.Location)
-				startDay, endDay := eventDaysOfWeek(ev)
-				startTime := parseEventStart(ev, tr.Location)
-				dedupeKey := eventDedupeKey(ev, startTime)
-
-				mu.Lock()
-				events = append(events, teamEvent{
-					Who:            email,
-					ID:             ev.Id,
-					Start:          start,
-					End:            end,
-					Summary:        summary,
-					Status:         ev.Status,
-					StartDayOfWeek: startDay,
-					EndDayOfWeek:   endDay,
-					dedupeKey:      dedupeKey,
-					sortKey:        startTime,
-				})
-				mu.Unlock()
+			// Apply query filter
+			if queryLower != "" && !strings.Contains(strings.ToLower(summary), queryLower) {
+				continue
 			}
-		}(email)
-	}
 
-	wg.Wait()
+			start, end := formatEventTime(ev, tr.Location)
+			startDay, endDay := eventDaysOfWeek(ev)
+			startTime := parseEventStart(ev, tr.Location)
+			dedupeKey := eventDedupeKey(ev, startTime)
+
+			out.events = append(out.events, teamEvent{
+				Who:            email,
+				ID:             ev.Id,
+				Start:          start,
+				End:            end,
+				Summary:        summary,
+				Status:         ev.Status,
+				StartDayOfWeek: startDay,
+				EndDayOfWeek:   endDay,
+				dedupeKey:      dedupeKey,
+				sortKey:        startTime,
+			})
+		}
+		return out, nil
+	})
+	if err != nil {
+		return err
+	}
 
-	// Print warnings for errors
-	for _, e := range errors {
-		u.Err().Printf("Warning: %s", e)
+	var events []teamEvent
+	for _, m := range perMember {
+		// Print warnings for errors
+		if m.err != "" {
+			u.Err().Printf("Warning: %s", m.err)
+		}
+		events = append(events, m.events...)
 	}
 
-	// Sort by start time
-	sort.Slice(events, func(i, j int) bool {
+	// Sort by start time (stable, so ties keep member order)
+	sort.SliceStable(events, func(i, j int) bool {
 		return events[i].sortKey.Before(events[j].sortKey)
 	})
 
@@ -66,3 +66,11 @@ func (c *CalendarTimeCmd) Run(ctx context.Context, flags *RootFlags) error {
 	u.Out().Printf("formatted\t%s", formatted)
 	return nil
 }
+
+func (*CalendarTimeCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Timezone    string `json:"timezone"`
+		CurrentTime string `json:"current_time"`
+		Formatted   string `json:"formatted"`
+	}{}}
+}
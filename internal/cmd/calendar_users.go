@@ -15,6 +15,11 @@ import (
 
 const calendarUsersRequestTimeout = 20 * time.Second
 
+type calendarUser struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
 type CalendarUsersCmd struct {
 	Max       int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
 	Page      string `name:"page" aliases:"cursor" help:"Page token"`
@@ -78,11 +83,7 @@ func (c *CalendarUsersCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}
 
 	if outfmt.IsJSON(ctx) {
-		type item struct {
-			Email string `json:"email"`
-			Name  string `json:"name,omitempty"`
-		}
-		items := make([]item, 0, len(peopleList))
+		items := make([]calendarUser, 0, len(peopleList))
 		for _, p := range peopleList {
 			if p == nil {
 				continue
@@ -91,7 +92,7 @@ func (c *CalendarUsersCmd) Run(ctx context.Context, flags *RootFlags) error {
 			if email == "" {
 				continue
 			}
-			items = append(items, item{
+			items = append(items, calendarUser{
 				Email: email,
 				Name:  primaryName(p),
 			})
@@ -142,3 +143,10 @@ func (c *CalendarUsersCmd) Run(ctx context.Context, flags *RootFlags) error {
 
 	return nil
 }
+
+func (*CalendarUsersCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "users", Result: struct {
+		Users         []calendarUser `json:"users"`
+		NextPageToken string         `json:"nextPageToken"`
+	}{}}
+}
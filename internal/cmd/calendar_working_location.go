@@ -77,6 +77,10 @@ func (c *CalendarWorkingLocationCmd) Run(ctx context.Context, flags *RootFlags)
 	return nil
 }
 
+func (*CalendarWorkingLocationCmd) jsonOutput() commandOutput {
+	return eventOutput()
+}
+
 func (c *CalendarWorkingLocationCmd) buildWorkingLocationProperties() (*calendar.EventWorkingLocationProperties, error) {
 	return buildWorkingLocationProperties(workingLocationInput{
 		Type:        c.Type,
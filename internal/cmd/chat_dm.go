@@ -101,6 +101,10 @@ func (c *ChatDMSendCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*ChatDMSendCmd) jsonOutput() commandOutput {
+	return chatMessageOutput()
+}
+
 type ChatDMSpaceCmd struct {
 	Email string `arg:"" name:"email" help:"Recipient email"`
 }
@@ -151,6 +155,10 @@ func (c *ChatDMSpaceCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*ChatDMSpaceCmd) jsonOutput() commandOutput {
+	return chatSpaceOutput()
+}
+
 func setupDMSpace(ctx context.Context, svc *chat.Service, email string) (*chat.Space, error) {
 	user := normalizeUser(email)
 	if user == "" {
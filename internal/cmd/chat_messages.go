@@ -17,6 +17,14 @@ type ChatMessagesCmd struct {
 	Send ChatMessagesSendCmd `cmd:"" name:"send" aliases:"create,post" help:"Send a message"`
 }
 
+type chatMessageItem struct {
+	Resource   string `json:"resource"`
+	Sender     string `json:"sender,omitempty"`
+	Text       string `json:"text,omitempty"`
+	CreateTime string `json:"createTime,omitempty"`
+	Thread     string `json:"thread,omitempty"`
+}
+
 type ChatMessagesListCmd struct {
 	Space     string `arg:"" name:"space" help:"Space name (spaces/...)"`
 	Max       int64  `name:"max" aliases:"limit" help:"Max results" default:"50"`
@@ -105,19 +113,12 @@ func (c *ChatMessagesListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}
 
 	if outfmt.IsJSON(ctx) {
-		type item struct {
-			Resource   string `json:"resource"`
-			Sender     string `json:"sender,omitempty"`
-			Text       string `json:"text,omitempty"`
-			CreateTime string `json:"createTime,omitempty"`
-			Thread     string `json:"thread,omitempty"`
-		}
-		items := make([]item, 0, len(messages))
+		items := make([]chatMessageItem, 0, len(messages))
 		for _, msg := range messages {
 			if msg == nil {
 				continue
 			}
-			items = append(items, item{
+			items = append(items, chatMessageItem{
 				Resource:   msg.Name,
 				Sender:     chatMessageSender(msg),
 				Text:       chatMessageText(msg),
@@ -160,6 +161,13 @@ func (c *ChatMessagesListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*ChatMessagesListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "messages", Result: struct {
+		Messages      []chatMessageItem `json:"messages"`
+		NextPageToken string            `json:"nextPageToken"`
+	}{}}
+}
+
 type ChatMessagesSendCmd struct {
 	Space  string `arg:"" name:"space" help:"Space name (spaces/...)"`
 	Text   string `name:"text" help:"Message text (required)"`
@@ -239,3 +247,15 @@ func (c *ChatMessagesSendCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}
 	return nil
 }
+
+func (*ChatMessagesSendCmd) jsonOutput() commandOutput {
+	return chatMessageOutput()
+}
+
+// chatMessageOutput declares the {"message": ...} result of messages send
+// and dm send.
+func chatMessageOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Message *chat.Message `json:"message"`
+	}{}}
+}
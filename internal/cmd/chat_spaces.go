@@ -18,6 +18,14 @@ type ChatSpacesCmd struct {
 	Create ChatSpacesCreateCmd `cmd:"" name:"create" aliases:"add,new" help:"Create a space"`
 }
 
+type chatSpaceItem struct {
+	Resource    string `json:"resource"`
+	Name        string `json:"name,omitempty"`
+	SpaceType   string `json:"type,omitempty"`
+	SpaceURI    string `json:"uri,omitempty"`
+	ThreadState string `json:"threading,omitempty"`
+}
+
 type ChatSpacesListCmd struct {
 	Max       int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
 	Page      string `name:"page" aliases:"cursor" help:"Page token"`
@@ -69,19 +77,12 @@ func (c *ChatSpacesListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}
 
 	if outfmt.IsJSON(ctx) {
-		type item struct {
-			Resource    string `json:"resource"`
-			Name        string `json:"name,omitempty"`
-			SpaceType   string `json:"type,omitempty"`
-			SpaceURI    string `json:"uri,omitempty"`
-			ThreadState string `json:"threading,omitempty"`
-		}
-		items := make([]item, 0, len(spaces))
+		items := make([]chatSpaceItem, 0, len(spaces))
 		for _, space := range spaces {
 			if space == nil {
 				continue
 			}
-			items = append(items, item{
+			items = append(items, chatSpaceItem{
 				Resource:    space.Name,
 				Name:        space.DisplayName,
 				SpaceType:   chatSpaceType(space),
@@ -123,6 +124,13 @@ func (c *ChatSpacesListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*ChatSpacesListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "spaces", Result: struct {
+		Spaces        []chatSpaceItem `json:"spaces"`
+		NextPageToken string          `json:"nextPageToken"`
+	}{}}
+}
+
 type ChatSpacesFindCmd struct {
 	DisplayName string `arg:"" name:"displayName" help:"Space display name"`
 	Max         int64  `name:"max" aliases:"limit" help:"Max results per page" default:"100"`
@@ -175,18 +183,12 @@ func (c *ChatSpacesFindCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}
 
 	if outfmt.IsJSON(ctx) {
-		type item struct {
-			Resource  string `json:"resource"`
-			Name      string `json:"name,omitempty"`
-			SpaceType string `json:"type,omitempty"`
-			SpaceURI  string `json:"uri,omitempty"`
-		}
-		items := make([]item, 0, len(matches))
+		items := make([]chatSpaceItem, 0, len(matches))
 		for _, space := range matches {
 			if space == nil {
 				continue
 			}
-			items = append(items, item{
+			items = append(items, chatSpaceItem{
 				Resource:  space.Name,
 				Name:      space.DisplayName,
 				SpaceType: chatSpaceType(space),
@@ -217,6 +219,12 @@ func (c *ChatSpacesFindCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*ChatSpacesFindCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "spaces", Result: struct {
+		Spaces []chatSpaceItem `json:"spaces"`
+	}{}}
+}
+
 type ChatSpacesCreateCmd struct {
 	DisplayName string   `arg:"" name:"displayName" help:"Space display name"`
 	Members     []string `name:"member" help:"Space members (email or users/...; repeatable or comma-separated)"`
@@ -297,3 +305,15 @@ func (c *ChatSpacesCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}
 	return nil
 }
+
+func (*ChatSpacesCreateCmd) jsonOutput() commandOutput {
+	return chatSpaceOutput()
+}
+
+// chatSpaceOutput declares the {"space": ...} result of spaces create and dm
+// space.
+func chatSpaceOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Space *chat.Space `json:"space"`
+	}{}}
+}
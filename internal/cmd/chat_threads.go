@@ -142,6 +142,19 @@ func (c *ChatThreadsListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*ChatThreadsListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "threads", Result: struct {
+		Threads []struct {
+			Thread     string `json:"thread"`
+			Message    string `json:"message"`
+			Sender     string `json:"sender"`
+			Text       string `json:"text"`
+			CreateTime string `json:"createTime"`
+		} `json:"threads"`
+		NextPageToken string `json:"nextPageToken"`
+	}{}}
+}
+
 type chatMessageThreadItem struct {
 	thread  string
 	message *chat.Message
@@ -122,6 +122,13 @@ func (c *ClassroomAnnouncementsListCmd) Run(ctx context.Context, flags *RootFlag
 	return nil
 }
 
+func (*ClassroomAnnouncementsListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "announcements", Result: struct {
+		Announcements []*classroom.Announcement `json:"announcements"`
+		NextPageToken string                    `json:"nextPageToken"`
+	}{}}
+}
+
 type ClassroomAnnouncementsGetCmd struct {
 	CourseID       string `arg:"" name:"courseId" help:"Course ID or alias"`
 	AnnouncementID string `arg:"" name:"announcementId" help:"Announcement ID"`
@@ -170,6 +177,10 @@ func (c *ClassroomAnnouncementsGetCmd) Run(ctx context.Context, flags *RootFlags
 	return nil
 }
 
+func (*ClassroomAnnouncementsGetCmd) jsonOutput() commandOutput {
+	return classroomAnnouncementOutput()
+}
+
 type ClassroomAnnouncementsCreateCmd struct {
 	CourseID  string `arg:"" name:"courseId" help:"Course ID or alias"`
 	Text      string `name:"text" help:"Announcement text" required:""`
@@ -225,6 +236,10 @@ func (c *ClassroomAnnouncementsCreateCmd) Run(ctx context.Context, flags *RootFl
 	return nil
 }
 
+func (*ClassroomAnnouncementsCreateCmd) jsonOutput() commandOutput {
+	return classroomAnnouncementOutput()
+}
+
 type ClassroomAnnouncementsUpdateCmd struct {
 	CourseID       string `arg:"" name:"courseId" help:"Course ID or alias"`
 	AnnouncementID string `arg:"" name:"announcementId" help:"Announcement ID"`
@@ -295,6 +310,10 @@ func (c *ClassroomAnnouncementsUpdateCmd) Run(ctx context.Context, flags *RootFl
 	return nil
 }
 
+func (*ClassroomAnnouncementsUpdateCmd) jsonOutput() commandOutput {
+	return classroomAnnouncementOutput()
+}
+
 type ClassroomAnnouncementsDeleteCmd struct {
 	CourseID       string `arg:"" name:"courseId" help:"Course ID or alias"`
 	AnnouncementID string `arg:"" name:"announcementId" help:"Announcement ID"`
@@ -336,6 +355,14 @@ func (c *ClassroomAnnouncementsDeleteCmd) Run(ctx context.Context, flags *RootFl
 	)
 }
 
+func (*ClassroomAnnouncementsDeleteCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Deleted        bool   `json:"deleted"`
+		CourseID       string `json:"courseId"`
+		AnnouncementID string `json:"announcementId"`
+	}{}}
+}
+
 type ClassroomAnnouncementsAssigneesCmd struct {
 	CourseID       string   `arg:"" name:"courseId" help:"Course ID or alias"`
 	AnnouncementID string   `arg:"" name:"announcementId" help:"Announcement ID"`
@@ -398,6 +425,10 @@ func (c *ClassroomAnnouncementsAssigneesCmd) Run(ctx context.Context, flags *Roo
 	return nil
 }
 
+func (*ClassroomAnnouncementsAssigneesCmd) jsonOutput() commandOutput {
+	return classroomAnnouncementOutput()
+}
+
 func truncateClassroomText(s string, maxLen int) string {
 	s = strings.TrimSpace(s)
 	if s == "" || maxLen <= 0 {
@@ -409,3 +440,11 @@ func truncateClassroomText(s string, maxLen int) string {
 	}
 	return string(r[:maxLen]) + "..."
 }
+
+// classroomAnnouncementOutput declares the {"announcement": ...} result of
+// commands that write one announcement.
+func classroomAnnouncementOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Announcement *classroom.Announcement `json:"announcement"`
+	}{}}
+}
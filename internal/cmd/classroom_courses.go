@@ -125,6 +125,13 @@ func (c *ClassroomCoursesListCmd) Run(ctx context.Context, flags *RootFlags) err
 	return nil
 }
 
+func (*ClassroomCoursesListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "courses", Result: struct {
+		Courses       []*classroom.Course `json:"courses"`
+		NextPageToken string              `json:"nextPageToken"`
+	}{}}
+}
+
 type ClassroomCoursesGetCmd struct {
 	CourseID string `arg:"" name:"courseId" help:"Course ID or alias"`
 }
@@ -181,6 +188,10 @@ func (c *ClassroomCoursesGetCmd) Run(ctx context.Context, flags *RootFlags) erro
 	return nil
 }
 
+func (*ClassroomCoursesGetCmd) jsonOutput() commandOutput {
+	return classroomCourseOutput()
+}
+
 type ClassroomCoursesCreateCmd struct {
 	Name               string `name:"name" help:"Course name" required:""`
 	OwnerID            string `name:"owner" help:"Owner user ID or email" default:"me"`
@@ -253,6 +264,10 @@ func (c *ClassroomCoursesCreateCmd) Run(ctx context.Context, flags *RootFlags) e
 	return nil
 }
 
+func (*ClassroomCoursesCreateCmd) jsonOutput() commandOutput {
+	return classroomCourseOutput()
+}
+
 type ClassroomCoursesUpdateCmd struct {
 	CourseID           string `arg:"" name:"courseId" help:"Course ID or alias"`
 	Name               string `name:"name" help:"Course name"`
@@ -340,6 +355,10 @@ func (c *ClassroomCoursesUpdateCmd) Run(ctx context.Context, flags *RootFlags) e
 	return nil
 }
 
+func (*ClassroomCoursesUpdateCmd) jsonOutput() commandOutput {
+	return classroomCourseOutput()
+}
+
 type ClassroomCoursesDeleteCmd struct {
 	CourseID string `arg:"" name:"courseId" help:"Course ID or alias"`
 }
@@ -375,6 +394,13 @@ func (c *ClassroomCoursesDeleteCmd) Run(ctx context.Context, flags *RootFlags) e
 	)
 }
 
+func (*ClassroomCoursesDeleteCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Deleted  bool   `json:"deleted"`
+		CourseID string `json:"courseId"`
+	}{}}
+}
+
 type ClassroomCoursesArchiveCmd struct {
 	CourseID string `arg:"" name:"courseId" help:"Course ID or alias"`
 }
@@ -383,6 +409,10 @@ func (c *ClassroomCoursesArchiveCmd) Run(ctx context.Context, flags *RootFlags)
 	return updateCourseState(ctx, flags, c.CourseID, "ARCHIVED")
 }
 
+func (*ClassroomCoursesArchiveCmd) jsonOutput() commandOutput {
+	return classroomCourseOutput()
+}
+
 type ClassroomCoursesUnarchiveCmd struct {
 	CourseID string `arg:"" name:"courseId" help:"Course ID or alias"`
 }
@@ -391,6 +421,10 @@ func (c *ClassroomCoursesUnarchiveCmd) Run(ctx context.Context, flags *RootFlags
 	return updateCourseState(ctx, flags, c.CourseID, "ACTIVE")
 }
 
+func (*ClassroomCoursesUnarchiveCmd) jsonOutput() commandOutput {
+	return classroomCourseOutput()
+}
+
 func updateCourseState(ctx context.Context, flags *RootFlags, courseID, state string) error {
 	u := ui.FromContext(ctx)
 	courseID = strings.TrimSpace(courseID)
@@ -511,6 +545,17 @@ func (c *ClassroomCoursesJoinCmd) Run(ctx context.Context, flags *RootFlags) err
 	}
 }
 
+func (*ClassroomCoursesJoinCmd) jsonOutput() commandOutput {
+	return commandOutput{
+		Result: struct {
+			Student *classroom.Student `json:"student"`
+		}{},
+		Variants: []any{struct {
+			Teacher *classroom.Teacher `json:"teacher"`
+		}{}},
+	}
+}
+
 type ClassroomCoursesLeaveCmd struct {
 	CourseID string `arg:"" name:"courseId" help:"Course ID or alias"`
 	Role     string `name:"role" help:"Role to remove: student|teacher" default:"student"`
@@ -564,6 +609,15 @@ func (c *ClassroomCoursesLeaveCmd) Run(ctx context.Context, flags *RootFlags) er
 	)
 }
 
+func (*ClassroomCoursesLeaveCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Removed  bool   `json:"removed"`
+		CourseID string `json:"courseId"`
+		UserID   string `json:"userId"`
+		Role     string `json:"role"`
+	}{}}
+}
+
 type ClassroomCoursesURLCmd struct {
 	CourseIDs []string `arg:"" name:"courseId" help:"Course IDs or aliases"`
 }
@@ -605,6 +659,15 @@ func (c *ClassroomCoursesURLCmd) Run(ctx context.Context, flags *RootFlags) erro
 	return nil
 }
 
+func (*ClassroomCoursesURLCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "urls", Result: struct {
+		URLs []struct {
+			ID  string `json:"id"`
+			URL string `json:"url"`
+		} `json:"urls"`
+	}{}}
+}
+
 func classroomCourseLink(ctx context.Context, svc *classroom.Service, courseID string) (string, error) {
 	id := strings.TrimSpace(courseID)
 	if id == "" {
@@ -616,3 +679,11 @@ func classroomCourseLink(ctx context.Context, svc *classroom.Service, courseID s
 	}
 	return course.AlternateLink, nil
 }
+
+// classroomCourseOutput declares the {"course": ...} result of commands that
+// write one course.
+func classroomCourseOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Course *classroom.Course `json:"course"`
+	}{}}
+}
@@ -150,6 +150,13 @@ func (c *ClassroomCourseworkListCmd) Run(ctx context.Context, flags *RootFlags)
 	return nil
 }
 
+func (*ClassroomCourseworkListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "coursework", Result: struct {
+		Coursework    []*classroom.CourseWork `json:"coursework"`
+		NextPageToken string                  `json:"nextPageToken"`
+	}{}}
+}
+
 type ClassroomCourseworkGetCmd struct {
 	CourseID     string `arg:"" name:"courseId" help:"Course ID or alias"`
 	CourseworkID string `arg:"" name:"courseworkId" help:"Coursework ID"`
@@ -209,6 +216,10 @@ func (c *ClassroomCourseworkGetCmd) Run(ctx context.Context, flags *RootFlags) e
 	return nil
 }
 
+func (*ClassroomCourseworkGetCmd) jsonOutput() commandOutput {
+	return classroomCourseworkOutput()
+}
+
 type ClassroomCourseworkCreateCmd struct {
 	CourseID    string  `arg:"" name:"courseId" help:"Course ID or alias"`
 	Title       string  `name:"title" help:"Title" required:""`
@@ -314,6 +325,10 @@ func (c *ClassroomCourseworkCreateCmd) Run(ctx context.Context, flags *RootFlags
 	return nil
 }
 
+func (*ClassroomCourseworkCreateCmd) jsonOutput() commandOutput {
+	return classroomCourseworkOutput()
+}
+
 type ClassroomCourseworkUpdateCmd struct {
 	CourseID     string  `arg:"" name:"courseId" help:"Course ID or alias"`
 	CourseworkID string  `arg:"" name:"courseworkId" help:"Coursework ID"`
@@ -439,6 +454,10 @@ func (c *ClassroomCourseworkUpdateCmd) Run(ctx context.Context, flags *RootFlags
 	return nil
 }
 
+func (*ClassroomCourseworkUpdateCmd) jsonOutput() commandOutput {
+	return classroomCourseworkOutput()
+}
+
 type ClassroomCourseworkDeleteCmd struct {
 	CourseID     string `arg:"" name:"courseId" help:"Course ID or alias"`
 	CourseworkID string `arg:"" name:"courseworkId" help:"Coursework ID"`
@@ -480,6 +499,14 @@ func (c *ClassroomCourseworkDeleteCmd) Run(ctx context.Context, flags *RootFlags
 	)
 }
 
+func (*ClassroomCourseworkDeleteCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Deleted      bool   `json:"deleted"`
+		CourseID     string `json:"courseId"`
+		CourseworkID string `json:"courseworkId"`
+	}{}}
+}
+
 type ClassroomCourseworkAssigneesCmd struct {
 	CourseID       string   `arg:"" name:"courseId" help:"Course ID or alias"`
 	CourseworkID   string   `arg:"" name:"courseworkId" help:"Coursework ID"`
@@ -541,3 +568,15 @@ func (c *ClassroomCourseworkAssigneesCmd) Run(ctx context.Context, flags *RootFl
 	u.Out().Printf("assignee_mode\t%s", updated.AssigneeMode)
 	return nil
 }
+
+func (*ClassroomCourseworkAssigneesCmd) jsonOutput() commandOutput {
+	return classroomCourseworkOutput()
+}
+
+// classroomCourseworkOutput declares the {"coursework": ...} result of
+// commands that write one coursework item.
+func classroomCourseworkOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Coursework *classroom.CourseWork `json:"coursework"`
+	}{}}
+}
@@ -109,6 +109,13 @@ func (c *ClassroomGuardiansListCmd) Run(ctx context.Context, flags *RootFlags) e
 	return nil
 }
 
+func (*ClassroomGuardiansListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "guardians", Result: struct {
+		Guardians     []*classroom.Guardian `json:"guardians"`
+		NextPageToken string                `json:"nextPageToken"`
+	}{}}
+}
+
 type ClassroomGuardiansGetCmd struct {
 	StudentID  string `arg:"" name:"studentId" help:"Student ID"`
 	GuardianID string `arg:"" name:"guardianId" help:"Guardian ID"`
@@ -150,6 +157,12 @@ func (c *ClassroomGuardiansGetCmd) Run(ctx context.Context, flags *RootFlags) er
 	return nil
 }
 
+func (*ClassroomGuardiansGetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Guardian *classroom.Guardian `json:"guardian"`
+	}{}}
+}
+
 type ClassroomGuardiansDeleteCmd struct {
 	StudentID  string `arg:"" name:"studentId" help:"Student ID"`
 	GuardianID string `arg:"" name:"guardianId" help:"Guardian ID"`
@@ -191,6 +204,14 @@ func (c *ClassroomGuardiansDeleteCmd) Run(ctx context.Context, flags *RootFlags)
 	)
 }
 
+func (*ClassroomGuardiansDeleteCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Deleted    bool   `json:"deleted"`
+		StudentID  string `json:"studentId"`
+		GuardianID string `json:"guardianId"`
+	}{}}
+}
+
 type ClassroomGuardianInvitesCmd struct {
 	List   ClassroomGuardianInvitesListCmd   `cmd:"" default:"withargs" aliases:"ls" help:"List guardian invitations"`
 	Get    ClassroomGuardianInvitesGetCmd    `cmd:"" aliases:"info,show" help:"Get a guardian invitation"`
@@ -297,6 +318,13 @@ func (c *ClassroomGuardianInvitesListCmd) Run(ctx context.Context, flags *RootFl
 	return nil
 }
 
+func (*ClassroomGuardianInvitesListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "invitations", Result: struct {
+		Invitations   []*classroom.GuardianInvitation `json:"invitations"`
+		NextPageToken string                          `json:"nextPageToken"`
+	}{}}
+}
+
 type ClassroomGuardianInvitesGetCmd struct {
 	StudentID    string `arg:"" name:"studentId" help:"Student ID"`
 	InvitationID string `arg:"" name:"invitationId" help:"Invitation ID"`
@@ -341,6 +369,10 @@ func (c *ClassroomGuardianInvitesGetCmd) Run(ctx context.Context, flags *RootFla
 	return nil
 }
 
+func (*ClassroomGuardianInvitesGetCmd) jsonOutput() commandOutput {
+	return guardianInvitationOutput()
+}
+
 type ClassroomGuardianInvitesCreateCmd struct {
 	StudentID string `arg:"" name:"studentId" help:"Student ID"`
 	Email     string `name:"email" help:"Guardian email address" required:""`
@@ -388,3 +420,15 @@ func (c *ClassroomGuardianInvitesCreateCmd) Run(ctx context.Context, flags *Root
 	u.Out().Printf("email\t%s", created.InvitedEmailAddress)
 	return nil
 }
+
+func (*ClassroomGuardianInvitesCreateCmd) jsonOutput() commandOutput {
+	return guardianInvitationOutput()
+}
+
+// guardianInvitationOutput declares the {"invitation": ...} result of
+// guardian-invitations get and create.
+func guardianInvitationOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Invitation *classroom.GuardianInvitation `json:"invitation"`
+	}{}}
+}
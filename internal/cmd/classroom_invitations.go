@@ -112,6 +112,13 @@ func (c *ClassroomInvitationsListCmd) Run(ctx context.Context, flags *RootFlags)
 	return nil
 }
 
+func (*ClassroomInvitationsListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "invitations", Result: struct {
+		Invitations   []*classroom.Invitation `json:"invitations"`
+		NextPageToken string                  `json:"nextPageToken"`
+	}{}}
+}
+
 type ClassroomInvitationsGetCmd struct {
 	InvitationID string `arg:"" name:"invitationId" help:"Invitation ID"`
 }
@@ -148,6 +155,10 @@ func (c *ClassroomInvitationsGetCmd) Run(ctx context.Context, flags *RootFlags)
 	return nil
 }
 
+func (*ClassroomInvitationsGetCmd) jsonOutput() commandOutput {
+	return classroomInvitationOutput()
+}
+
 type ClassroomInvitationsCreateCmd struct {
 	CourseID string `arg:"" name:"courseId" help:"Course ID or alias"`
 	UserID   string `arg:"" name:"userId" help:"User ID or email"`
@@ -201,6 +212,10 @@ func (c *ClassroomInvitationsCreateCmd) Run(ctx context.Context, flags *RootFlag
 	return nil
 }
 
+func (*ClassroomInvitationsCreateCmd) jsonOutput() commandOutput {
+	return classroomInvitationOutput()
+}
+
 type ClassroomInvitationsAcceptCmd struct {
 	InvitationID string `arg:"" name:"invitationId" help:"Invitation ID"`
 }
@@ -243,6 +258,13 @@ func (c *ClassroomInvitationsAcceptCmd) Run(ctx context.Context, flags *RootFlag
 	return nil
 }
 
+func (*ClassroomInvitationsAcceptCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Accepted     bool   `json:"accepted"`
+		InvitationID string `json:"invitationId"`
+	}{}}
+}
+
 type ClassroomInvitationsDeleteCmd struct {
 	InvitationID string `arg:"" name:"invitationId" help:"Invitation ID"`
 }
@@ -277,3 +299,18 @@ func (c *ClassroomInvitationsDeleteCmd) Run(ctx context.Context, flags *RootFlag
 		kv("invitationId", invitationID),
 	)
 }
+
+func (*ClassroomInvitationsDeleteCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Deleted      bool   `json:"deleted"`
+		InvitationID string `json:"invitationId"`
+	}{}}
+}
+
+// classroomInvitationOutput declares the {"invitation": ...} result of get
+// and create.
+func classroomInvitationOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Invitation *classroom.Invitation `json:"invitation"`
+	}{}}
+}
@@ -147,6 +147,13 @@ func (c *ClassroomMaterialsListCmd) Run(ctx context.Context, flags *RootFlags) e
 	return nil
 }
 
+func (*ClassroomMaterialsListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "materials", Result: struct {
+		Materials     []*classroom.CourseWorkMaterial `json:"materials"`
+		NextPageToken string                          `json:"nextPageToken"`
+	}{}}
+}
+
 type ClassroomMaterialsGetCmd struct {
 	CourseID   string `arg:"" name:"courseId" help:"Course ID or alias"`
 	MaterialID string `arg:"" name:"materialId" help:"Material ID"`
@@ -196,6 +203,10 @@ func (c *ClassroomMaterialsGetCmd) Run(ctx context.Context, flags *RootFlags) er
 	return nil
 }
 
+func (*ClassroomMaterialsGetCmd) jsonOutput() commandOutput {
+	return classroomMaterialOutput()
+}
+
 type ClassroomMaterialsCreateCmd struct {
 	CourseID    string `arg:"" name:"courseId" help:"Course ID or alias"`
 	Title       string `name:"title" help:"Title" required:""`
@@ -260,6 +271,10 @@ func (c *ClassroomMaterialsCreateCmd) Run(ctx context.Context, flags *RootFlags)
 	return nil
 }
 
+func (*ClassroomMaterialsCreateCmd) jsonOutput() commandOutput {
+	return classroomMaterialOutput()
+}
+
 type ClassroomMaterialsUpdateCmd struct {
 	CourseID    string `arg:"" name:"courseId" help:"Course ID or alias"`
 	MaterialID  string `arg:"" name:"materialId" help:"Material ID"`
@@ -341,6 +356,10 @@ func (c *ClassroomMaterialsUpdateCmd) Run(ctx context.Context, flags *RootFlags)
 	return nil
 }
 
+func (*ClassroomMaterialsUpdateCmd) jsonOutput() commandOutput {
+	return classroomMaterialOutput()
+}
+
 type ClassroomMaterialsDeleteCmd struct {
 	CourseID   string `arg:"" name:"courseId" help:"Course ID or alias"`
 	MaterialID string `arg:"" name:"materialId" help:"Material ID"`
@@ -381,3 +400,19 @@ func (c *ClassroomMaterialsDeleteCmd) Run(ctx context.Context, flags *RootFlags)
 		kv("materialId", materialID),
 	)
 }
+
+func (*ClassroomMaterialsDeleteCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Deleted    bool   `json:"deleted"`
+		CourseID   string `json:"courseId"`
+		MaterialID string `json:"materialId"`
+	}{}}
+}
+
+// classroomMaterialOutput declares the {"material": ...} result of get,
+// create and update.
+func classroomMaterialOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Material *classroom.CourseWorkMaterial `json:"material"`
+	}{}}
+}
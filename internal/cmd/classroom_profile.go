@@ -5,6 +5,8 @@ import (
 	"os"
 	"strings"
 
+	"google.golang.org/api/classroom/v1"
+
 	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
 )
@@ -51,3 +53,9 @@ func (c *ClassroomProfileGetCmd) Run(ctx context.Context, flags *RootFlags) erro
 	}
 	return nil
 }
+
+func (*ClassroomProfileGetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Profile *classroom.UserProfile `json:"profile"`
+	}{}}
+}
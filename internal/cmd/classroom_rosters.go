@@ -10,6 +10,7 @@ import (
 
 	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/internal/workpool"
 )
 
 type ClassroomStudentsCmd struct {
@@ -106,6 +107,13 @@ func (c *ClassroomStudentsListCmd) Run(ctx context.Context, flags *RootFlags) er
 	return nil
 }
 
+func (*ClassroomStudentsListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "students", Result: struct {
+		Students      []*classroom.Student `json:"students"`
+		NextPageToken string               `json:"nextPageToken"`
+	}{}}
+}
+
 type ClassroomStudentsGetCmd struct {
 	CourseID string `arg:"" name:"courseId" help:"Course ID or alias"`
 	UserID   string `arg:"" name:"userId" help:"Student user ID or email"`
@@ -149,6 +157,10 @@ func (c *ClassroomStudentsGetCmd) Run(ctx context.Context, flags *RootFlags) err
 	return nil
 }
 
+func (*ClassroomStudentsGetCmd) jsonOutput() commandOutput {
+	return classroomStudentOutput()
+}
+
 type ClassroomStudentsAddCmd struct {
 	CourseID       string `arg:"" name:"courseId" help:"Course ID or alias"`
 	UserID         string `arg:"" name:"userId" help:"Student user ID or email"`
@@ -203,6 +215,10 @@ func (c *ClassroomStudentsAddCmd) Run(ctx context.Context, flags *RootFlags) err
 	return nil
 }
 
+func (*ClassroomStudentsAddCmd) jsonOutput() commandOutput {
+	return classroomStudentOutput()
+}
+
 type ClassroomStudentsRemoveCmd struct {
 	CourseID string `arg:"" name:"courseId" help:"Course ID or alias"`
 	UserID   string `arg:"" name:"userId" help:"Student user ID or email"`
@@ -244,6 +260,10 @@ func (c *ClassroomStudentsRemoveCmd) Run(ctx context.Context, flags *RootFlags)
 	)
 }
 
+func (*ClassroomStudentsRemoveCmd) jsonOutput() commandOutput {
+	return classroomRemovedOutput()
+}
+
 type ClassroomTeachersCmd struct {
 	List   ClassroomTeachersListCmd   `cmd:"" default:"withargs" aliases:"ls" help:"List teachers"`
 	Get    ClassroomTeachersGetCmd    `cmd:"" aliases:"info,show" help:"Get a teacher"`
@@ -338,6 +358,13 @@ func (c *ClassroomTeachersListCmd) Run(ctx context.Context, flags *RootFlags) er
 	return nil
 }
 
+func (*ClassroomTeachersListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "teachers", Result: struct {
+		Teachers      []*classroom.Teacher `json:"teachers"`
+		NextPageToken string               `json:"nextPageToken"`
+	}{}}
+}
+
 type ClassroomTeachersGetCmd struct {
 	CourseID string `arg:"" name:"courseId" help:"Course ID or alias"`
 	UserID   string `arg:"" name:"userId" help:"Teacher user ID or email"`
@@ -378,6 +405,10 @@ func (c *ClassroomTeachersGetCmd) Run(ctx context.Context, flags *RootFlags) err
 	return nil
 }
 
+func (*ClassroomTeachersGetCmd) jsonOutput() commandOutput {
+	return classroomTeacherOutput()
+}
+
 type ClassroomTeachersAddCmd struct {
 	CourseID string `arg:"" name:"courseId" help:"Course ID or alias"`
 	UserID   string `arg:"" name:"userId" help:"Teacher user ID or email"`
@@ -426,6 +457,10 @@ func (c *ClassroomTeachersAddCmd) Run(ctx context.Context, flags *RootFlags) err
 	return nil
 }
 
+func (*ClassroomTeachersAddCmd) jsonOutput() commandOutput {
+	return classroomTeacherOutput()
+}
+
 type ClassroomTeachersRemoveCmd struct {
 	CourseID string `arg:"" name:"courseId" help:"Course ID or alias"`
 	UserID   string `arg:"" name:"userId" help:"Teacher user ID or email"`
@@ -467,6 +502,10 @@ func (c *ClassroomTeachersRemoveCmd) Run(ctx context.Context, flags *RootFlags)
 	)
 }
 
+func (*ClassroomTeachersRemoveCmd) jsonOutput() commandOutput {
+	return classroomRemovedOutput()
+}
+
 type ClassroomRosterCmd struct {
 	CourseID  string `arg:"" name:"courseId" help:"Course ID or alias"`
 	Students  bool   `name:"students" help:"Include students"`
@@ -502,55 +541,71 @@ func (c *ClassroomRosterCmd) Run(ctx context.Context, flags *RootFlags) error {
 	studentsNextPageToken := ""
 	teachersNextPageToken := ""
 
+	var roles []func(context.Context) error
 	if includeStudents {
-		fetch := func(pageToken string) ([]*classroom.Student, string, error) {
-			call := svc.Courses.Students.List(courseID).PageSize(c.Max).Context(ctx)
-			if strings.TrimSpace(pageToken) != "" {
-				call = call.PageToken(pageToken)
-			}
-			resp, callErr := call.Do()
-			if callErr != nil {
-				return nil, "", wrapClassroomError(callErr)
-			}
-			return resp.Students, resp.NextPageToken, nil
-		}
-		if c.All {
-			all, collectErr := collectAllPages(c.Page, fetch)
-			if collectErr != nil {
-				return collectErr
+		roles = append(roles, func(ctx context.Context) error {
+			fetch := func(pageToken string) ([]*classroom.Student, string, error) {
+				call := svc.Courses.Students.List(courseID).PageSize(c.Max).Context(ctx)
+				if strings.TrimSpace(pageToken) != "" {
+					call = call.PageToken(pageToken)
+				}
+				resp, callErr := call.Do()
+				if callErr != nil {
+					return nil, "", wrapClassroomError(callErr)
+				}
+				return resp.Students, resp.NextPageToken, nil
 			}
-			students = all
-		} else {
-			students, studentsNextPageToken, err = fetch(c.Page)
-			if err != nil {
-				return err
+			if c.All {
+				all, collectErr := collectAllPages(c.Page, fetch)
+				if collectErr != nil {
+					return collectErr
+				}
+				students = all
+			} else {
+				var err error
+				students, studentsNextPageToken, err = fetch(c.Page)
+				if err != nil {
+					return err
+				}
 			}
-		}
+			return nil
+		})
 	}
 	if includeTeachers {
-		fetch := func(pageToken string) ([]*classroom.Teacher, string, error) {
-			call := svc.Courses.Teachers.List(courseID).PageSize(c.Max).Context(ctx)
-			if strings.TrimSpace(pageToken) != "" {
-				call = call.PageToken(pageToken)
-			}
-			resp, callErr := call.Do()
-			if callErr != nil {
-				return nil, "", wrapClassroomError(callErr)
-			}
-			return resp.Teachers, resp.NextPageToken, nil
-		}
-		if c.All {
-			all, collectErr := collectAllPages(c.Page, fetch)
-			if collectErr != nil {
-				return collectErr
+		roles = append(roles, func(ctx context.Context) error {
+			fetch := func(pageToken string) ([]*classroom.Teacher, string, error) {
+				call := svc.Courses.Teachers.List(courseID).PageSize(c.Max).Context(ctx)
+				if strings.TrimSpace(pageToken) != "" {
+					call = call.PageToken(pageToken)
+				}
+				resp, callErr := call.Do()
+				if callErr != nil {
+					return nil, "", wrapClassroomError(callErr)
+				}
+				return resp.Teachers, resp.NextPageToken, nil
 			}
-			teachers = all
-		} else {
-			teachers, teachersNextPageToken, err = fetch(c.Page)
-			if err != nil {
-				return err
+			if c.All {
+				all, collectErr := collectAllPages(c.Page, fetch)
+				if collectErr != nil {
+					return collectErr
+				}
+				teachers = all
+			} else {
+				var err error
+				teachers, teachersNextPageToken, err = fetch(c.Page)
+				if err != nil {
+					return err
+				}
 			}
-		}
+			return nil
+		})
+	}
+
+	// Students and teachers are independent listings; fetch them side by side.
+	if err := workpool.Each(ctx, workpool.Limit(ctx, 2), roles, func(ctx context.Context, fetch func(context.Context) error) error {
+		return fetch(ctx)
+	}); err != nil {
+		return err
 	}
 
 	if outfmt.IsJSON(ctx) {
@@ -626,3 +681,36 @@ func (c *ClassroomRosterCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}
 	return nil
 }
+
+func (*ClassroomRosterCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		CourseID              string               `json:"courseId"`
+		Students              []*classroom.Student `json:"students,omitempty"`
+		StudentsNextPageToken string               `json:"studentsNextPageToken,omitempty"`
+		Teachers              []*classroom.Teacher `json:"teachers,omitempty"`
+		TeachersNextPageToken string               `json:"teachersNextPageToken,omitempty"`
+	}{}}
+}
+
+// classroomStudentOutput declares the {"student": ...} result of get and add.
+func classroomStudentOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Student *classroom.Student `json:"student"`
+	}{}}
+}
+
+// classroomRemovedOutput declares the result of students and teachers remove.
+func classroomRemovedOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Removed  bool   `json:"removed"`
+		CourseID string `json:"courseId"`
+		UserID   string `json:"userId"`
+	}{}}
+}
+
+// classroomTeacherOutput declares the {"teacher": ...} result of get and add.
+func classroomTeacherOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Teacher *classroom.Teacher `json:"teacher"`
+	}{}}
+}
@@ -141,6 +141,13 @@ func (c *ClassroomSubmissionsListCmd) Run(ctx context.Context, flags *RootFlags)
 	return nil
 }
 
+func (*ClassroomSubmissionsListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "submissions", Result: struct {
+		Submissions   []*classroom.StudentSubmission `json:"submissions"`
+		NextPageToken string                         `json:"nextPageToken"`
+	}{}}
+}
+
 type ClassroomSubmissionsGetCmd struct {
 	CourseID     string `arg:"" name:"courseId" help:"Course ID or alias"`
 	CourseworkID string `arg:"" name:"courseworkId" help:"Coursework ID"`
@@ -195,6 +202,10 @@ func (c *ClassroomSubmissionsGetCmd) Run(ctx context.Context, flags *RootFlags)
 	return nil
 }
 
+func (*ClassroomSubmissionsGetCmd) jsonOutput() commandOutput {
+	return classroomSubmissionOutput()
+}
+
 type ClassroomSubmissionsTurnInCmd struct {
 	CourseID     string `arg:"" name:"courseId" help:"Course ID or alias"`
 	CourseworkID string `arg:"" name:"courseworkId" help:"Coursework ID"`
@@ -205,6 +216,10 @@ func (c *ClassroomSubmissionsTurnInCmd) Run(ctx context.Context, flags *RootFlag
 	return submissionAction(ctx, flags, c.CourseID, c.CourseworkID, c.SubmissionID, "turn-in")
 }
 
+func (*ClassroomSubmissionsTurnInCmd) jsonOutput() commandOutput {
+	return submissionActionOutput()
+}
+
 type ClassroomSubmissionsReclaimCmd struct {
 	CourseID     string `arg:"" name:"courseId" help:"Course ID or alias"`
 	CourseworkID string `arg:"" name:"courseworkId" help:"Coursework ID"`
@@ -215,6 +230,10 @@ func (c *ClassroomSubmissionsReclaimCmd) Run(ctx context.Context, flags *RootFla
 	return submissionAction(ctx, flags, c.CourseID, c.CourseworkID, c.SubmissionID, "reclaim")
 }
 
+func (*ClassroomSubmissionsReclaimCmd) jsonOutput() commandOutput {
+	return submissionActionOutput()
+}
+
 type ClassroomSubmissionsReturnCmd struct {
 	CourseID     string `arg:"" name:"courseId" help:"Course ID or alias"`
 	CourseworkID string `arg:"" name:"courseworkId" help:"Coursework ID"`
@@ -225,6 +244,10 @@ func (c *ClassroomSubmissionsReturnCmd) Run(ctx context.Context, flags *RootFlag
 	return submissionAction(ctx, flags, c.CourseID, c.CourseworkID, c.SubmissionID, "return")
 }
 
+func (*ClassroomSubmissionsReturnCmd) jsonOutput() commandOutput {
+	return submissionActionOutput()
+}
+
 func submissionAction(ctx context.Context, flags *RootFlags, courseID, courseworkID, submissionID, action string) error {
 	u := ui.FromContext(ctx)
 	courseID = strings.TrimSpace(courseID)
@@ -372,3 +395,26 @@ func (c *ClassroomSubmissionsGradeCmd) Run(ctx context.Context, flags *RootFlags
 	u.Out().Printf("assigned_grade\t%s", formatFloatValue(updated.AssignedGrade))
 	return nil
 }
+
+func (*ClassroomSubmissionsGradeCmd) jsonOutput() commandOutput {
+	return classroomSubmissionOutput()
+}
+
+// classroomSubmissionOutput declares the {"submission": ...} result of get
+// and grade.
+func classroomSubmissionOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Submission *classroom.StudentSubmission `json:"submission"`
+	}{}}
+}
+
+// submissionActionOutput declares the result of turn-in, reclaim and return.
+func submissionActionOutput() commandOutput {
+	return commandOutput{Result: struct {
+		OK           bool   `json:"ok"`
+		CourseID     string `json:"courseId"`
+		CourseworkID string `json:"courseworkId"`
+		SubmissionID string `json:"submissionId"`
+		Action       string `json:"action"`
+	}{}}
+}
@@ -107,6 +107,13 @@ func (c *ClassroomTopicsListCmd) Run(ctx context.Context, flags *RootFlags) erro
 	return nil
 }
 
+func (*ClassroomTopicsListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "topics", Result: struct {
+		Topics        []*classroom.Topic `json:"topics"`
+		NextPageToken string             `json:"nextPageToken"`
+	}{}}
+}
+
 type ClassroomTopicsGetCmd struct {
 	CourseID string `arg:"" name:"courseId" help:"Course ID or alias"`
 	TopicID  string `arg:"" name:"topicId" help:"Topic ID"`
@@ -149,6 +156,10 @@ func (c *ClassroomTopicsGetCmd) Run(ctx context.Context, flags *RootFlags) error
 	return nil
 }
 
+func (*ClassroomTopicsGetCmd) jsonOutput() commandOutput {
+	return classroomTopicOutput()
+}
+
 type ClassroomTopicsCreateCmd struct {
 	CourseID string `arg:"" name:"courseId" help:"Course ID or alias"`
 	Name     string `name:"name" help:"Topic name" required:""`
@@ -196,6 +207,10 @@ func (c *ClassroomTopicsCreateCmd) Run(ctx context.Context, flags *RootFlags) er
 	return nil
 }
 
+func (*ClassroomTopicsCreateCmd) jsonOutput() commandOutput {
+	return classroomTopicOutput()
+}
+
 type ClassroomTopicsUpdateCmd struct {
 	CourseID string `arg:"" name:"courseId" help:"Course ID or alias"`
 	TopicID  string `arg:"" name:"topicId" help:"Topic ID"`
@@ -250,6 +265,10 @@ func (c *ClassroomTopicsUpdateCmd) Run(ctx context.Context, flags *RootFlags) er
 	return nil
 }
 
+func (*ClassroomTopicsUpdateCmd) jsonOutput() commandOutput {
+	return classroomTopicOutput()
+}
+
 type ClassroomTopicsDeleteCmd struct {
 	CourseID string `arg:"" name:"courseId" help:"Course ID or alias"`
 	TopicID  string `arg:"" name:"topicId" help:"Topic ID"`
@@ -290,3 +309,19 @@ func (c *ClassroomTopicsDeleteCmd) Run(ctx context.Context, flags *RootFlags) er
 		kv("topicId", topicID),
 	)
 }
+
+func (*ClassroomTopicsDeleteCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Deleted  bool   `json:"deleted"`
+		CourseID string `json:"courseId"`
+		TopicID  string `json:"topicId"`
+	}{}}
+}
+
+// classroomTopicOutput declares the {"topic": ...} result of get, create and
+// update.
+func classroomTopicOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Topic *classroom.Topic `json:"topic"`
+	}{}}
+}
@@ -273,8 +273,18 @@ func exitCodeString(code int) string {
 		return "RATE_LIMITED"
 	case exitCodeRetryable:
 		return "RETRYABLE"
+	case exitCodeInsufficientScopes:
+		return "INSUFFICIENT_SCOPES"
 	case exitCodeConfig:
 		return "CONFIG_ERROR"
+	case exitCodeAPIDisabled:
+		return "API_DISABLED"
+	case exitCodeQuotaExhausted:
+		return "QUOTA_EXHAUSTED"
+	case exitCodeFailedPrecondition:
+		return "FAILED_PRECONDITION"
+	case exitCodeDomainPolicy:
+		return "DOMAIN_POLICY"
 	case exitCodeCancelled:
 		return "CANCELLED"
 	default:
@@ -294,8 +304,18 @@ func fixForExitCode(code int) string {
 		return "Set OAuth credentials using `gog auth credentials <path-to-credentials.json>`."
 	case exitCodePermissionDenied:
 		return "Verify account permissions and OAuth scopes for the requested API."
+	case exitCodeInsufficientScopes:
+		return "Grant the missing scopes with `gog auth add <email> --services <services>` (see `gog auth doctor`)."
 	case exitCodeRateLimited, exitCodeRetryable:
 		return "Retry with backoff; if persistent, reduce request volume."
+	case exitCodeAPIDisabled:
+		return "Enable the API for the OAuth client's Cloud project at https://console.cloud.google.com/apis/library, then retry."
+	case exitCodeQuotaExhausted:
+		return "Daily quota is exhausted; retry after it resets or request more at https://console.cloud.google.com/iam-admin/quotas."
+	case exitCodeFailedPrecondition:
+		return "The API rejected a precondition; Workspace-only APIs need a Google Workspace account (see `gog auth service-account set`)."
+	case exitCodeDomainPolicy:
+		return "A Workspace admin policy blocks this app; ask an admin to trust it at https://admin.google.com/ac/owl/list?tab=configuredApps."
 	case exitCodeNotFound:
 		return "Verify IDs/names and confirm the resource exists for the selected account."
 	case exitCodeCancelled:
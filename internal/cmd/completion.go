@@ -29,6 +29,13 @@ func (c *CompletionCmd) Run(ctx context.Context) error {
 	return err
 }
 
+func (*CompletionCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Shell  string `json:"shell"`
+		Script string `json:"script"`
+	}{}}
+}
+
 type CompletionInternalCmd struct {
 	Cword int      `name:"cword" help:"Index of the current word" default:"-1"`
 	Words []string `arg:"" optional:"" name:"words" help:"Words to complete"`
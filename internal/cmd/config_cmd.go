@@ -45,6 +45,13 @@ func (c *ConfigGetCmd) Run(ctx context.Context) error {
 	return nil
 }
 
+func (*ConfigGetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+	}{}}
+}
+
 type ConfigKeysCmd struct{}
 
 func (c *ConfigKeysCmd) Run(ctx context.Context) error {
@@ -58,6 +65,12 @@ func (c *ConfigKeysCmd) Run(ctx context.Context) error {
 	return nil
 }
 
+func (*ConfigKeysCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "keys", Result: struct {
+		Keys []string `json:"keys"`
+	}{}}
+}
+
 type ConfigSetCmd struct {
 	Key   string `arg:"" help:"Config key to set (timezone)"`
 	Value string `arg:"" help:"Value to set"`
@@ -98,6 +111,14 @@ func (c *ConfigSetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*ConfigSetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Key   string `json:"key"`
+		Value string `json:"value"`
+		Saved bool   `json:"saved"`
+	}{}}
+}
+
 type ConfigUnsetCmd struct {
 	Key string `arg:"" help:"Config key to unset (timezone)"`
 }
@@ -136,6 +157,14 @@ func (c *ConfigUnsetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*ConfigUnsetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Key     string `json:"key"`
+		Value   string `json:"value"`
+		Removed bool   `json:"removed"`
+	}{}}
+}
+
 type ConfigListCmd struct{}
 
 func (c *ConfigListCmd) Run(ctx context.Context) error {
@@ -163,6 +192,11 @@ func (c *ConfigListCmd) Run(ctx context.Context) error {
 	return nil
 }
 
+// jsonOutput is the config file path under "path" plus one entry per key.
+func (*ConfigListCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: map[string]string{}}
+}
+
 type ConfigPathCmd struct{}
 
 func (c *ConfigPathCmd) Run(ctx context.Context) error {
@@ -178,6 +212,12 @@ func (c *ConfigPathCmd) Run(ctx context.Context) error {
 	return nil
 }
 
+func (*ConfigPathCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Path string `json:"path"`
+	}{}}
+}
+
 func formatConfigValue(value string, emptyHint func() string) string {
 	if value != "" {
 		return value
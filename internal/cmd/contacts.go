@@ -23,6 +23,14 @@ type ContactsCmd struct {
 	Other     ContactsOtherCmd     `cmd:"" name:"other" help:"Other contacts"`
 }
 
+// contactItem is the compact JSON form of a contact in list and search results.
+type contactItem struct {
+	Resource string `json:"resource"`
+	Name     string `json:"name,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Phone    string `json:"phone,omitempty"`
+}
+
 type ContactsSearchCmd struct {
 	Query []string `arg:"" name:"query" help:"Search query"`
 	Max   int64    `name:"max" aliases:"limit" help:"Max results" default:"50"`
@@ -50,19 +58,13 @@ func (c *ContactsSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 	if outfmt.IsJSON(ctx) {
-		type item struct {
-			Resource string `json:"resource"`
-			Name     string `json:"name,omitempty"`
-			Email    string `json:"email,omitempty"`
-			Phone    string `json:"phone,omitempty"`
-		}
-		items := make([]item, 0, len(resp.Results))
+		items := make([]contactItem, 0, len(resp.Results))
 		for _, r := range resp.Results {
 			p := r.Person
 			if p == nil {
 				continue
 			}
-			items = append(items, item{
+			items = append(items, contactItem{
 				Resource: p.ResourceName,
 				Name:     primaryName(p),
 				Email:    primaryEmail(p),
@@ -96,6 +98,12 @@ func (c *ContactsSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*ContactsSearchCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "contacts", Result: struct {
+		Contacts []contactItem `json:"contacts"`
+	}{}}
+}
+
 func primaryName(p *people.Person) string {
 	if p == nil || len(p.Names) == 0 || p.Names[0] == nil {
 		return ""
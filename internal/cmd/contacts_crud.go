@@ -47,18 +47,12 @@ func (c *ContactsListCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 	if outfmt.IsJSON(ctx) {
-		type item struct {
-			Resource string `json:"resource"`
-			Name     string `json:"name,omitempty"`
-			Email    string `json:"email,omitempty"`
-			Phone    string `json:"phone,omitempty"`
-		}
-		items := make([]item, 0, len(resp.Connections))
+		items := make([]contactItem, 0, len(resp.Connections))
 		for _, p := range resp.Connections {
 			if p == nil {
 				continue
 			}
-			items = append(items, item{
+			items = append(items, contactItem{
 				Resource: p.ResourceName,
 				Name:     primaryName(p),
 				Email:    primaryEmail(p),
@@ -94,6 +88,18 @@ func (c *ContactsListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*ContactsListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "contacts", Result: struct {
+		Contacts      []contactItem `json:"contacts"`
+		NextPageToken string        `json:"nextPageToken"`
+	}{}}
+}
+
+// contactOutput is the {"contact": ...} result of get, create and update.
+type contactOutput struct {
+	Contact *people.Person `json:"contact"`
+}
+
 type ContactsGetCmd struct {
 	Identifier string `arg:"" name:"resourceName" help:"Resource name (people/...) or email"`
 }
@@ -194,6 +200,15 @@ func (c *ContactsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*ContactsGetCmd) jsonOutput() commandOutput {
+	return commandOutput{
+		Result: contactOutput{},
+		Variants: []any{struct {
+			Found bool `json:"found"`
+		}{}},
+	}
+}
+
 type ContactsCreateCmd struct {
 	Given        string   `name:"given" help:"Given name (required)"`
 	Family       string   `name:"family" help:"Family name"`
@@ -345,6 +360,10 @@ func (c *ContactsCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*ContactsCreateCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: contactOutput{}}
+}
+
 type ContactsUpdateCmd struct {
 	ResourceName string   `arg:"" name:"resourceName" help:"Resource name (people/...)"`
 	Given        string   `name:"given" help:"Given name"`
@@ -511,6 +530,10 @@ func (c *ContactsUpdateCmd) Run(ctx context.Context, kctx *kong.Context, flags *
 	return nil
 }
 
+func (*ContactsUpdateCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: contactOutput{}}
+}
+
 type ContactsDeleteCmd struct {
 	ResourceName string `arg:"" name:"resourceName" help:"Resource name (people/...)"`
 }
@@ -547,3 +570,7 @@ func (c *ContactsDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}
 	return writeDeleteResult(ctx, u, resourceName)
 }
+
+func (*ContactsDeleteCmd) jsonOutput() commandOutput {
+	return deleteResultOutput()
+}
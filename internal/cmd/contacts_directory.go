@@ -23,6 +23,13 @@ type ContactsDirectoryCmd struct {
 	Search ContactsDirectorySearchCmd `cmd:"" name:"search" help:"Search people in the Workspace directory"`
 }
 
+// personItem is the compact JSON form of a directory profile.
+type personItem struct {
+	Resource string `json:"resource"`
+	Name     string `json:"name,omitempty"`
+	Email    string `json:"email,omitempty"`
+}
+
 type ContactsDirectoryListCmd struct {
 	Max       int64  `name:"max" aliases:"limit" help:"Max results" default:"50"`
 	Page      string `name:"page" aliases:"cursor" help:"Page token"`
@@ -78,17 +85,12 @@ func (c *ContactsDirectoryListCmd) Run(ctx context.Context, flags *RootFlags) er
 		}
 	}
 	if outfmt.IsJSON(ctx) {
-		type item struct {
-			Resource string `json:"resource"`
-			Name     string `json:"name,omitempty"`
-			Email    string `json:"email,omitempty"`
-		}
-		items := make([]item, 0, len(peopleList))
+		items := make([]personItem, 0, len(peopleList))
 		for _, p := range peopleList {
 			if p == nil {
 				continue
 			}
-			items = append(items, item{
+			items = append(items, personItem{
 				Resource: p.ResourceName,
 				Name:     primaryName(p),
 				Email:    primaryEmail(p),
@@ -128,6 +130,13 @@ func (c *ContactsDirectoryListCmd) Run(ctx context.Context, flags *RootFlags) er
 	return nil
 }
 
+func (*ContactsDirectoryListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "people", Result: struct {
+		People        []personItem `json:"people"`
+		NextPageToken string       `json:"nextPageToken"`
+	}{}}
+}
+
 type ContactsDirectorySearchCmd struct {
 	Query     []string `arg:"" name:"query" help:"Search query"`
 	Max       int64    `name:"max" aliases:"limit" help:"Max results" default:"50"`
@@ -185,17 +194,12 @@ func (c *ContactsDirectorySearchCmd) Run(ctx context.Context, flags *RootFlags)
 		}
 	}
 	if outfmt.IsJSON(ctx) {
-		type item struct {
-			Resource string `json:"resource"`
-			Name     string `json:"name,omitempty"`
-			Email    string `json:"email,omitempty"`
-		}
-		items := make([]item, 0, len(peopleList))
+		items := make([]personItem, 0, len(peopleList))
 		for _, p := range peopleList {
 			if p == nil {
 				continue
 			}
-			items = append(items, item{
+			items = append(items, personItem{
 				Resource: p.ResourceName,
 				Name:     primaryName(p),
 				Email:    primaryEmail(p),
@@ -235,6 +239,13 @@ func (c *ContactsDirectorySearchCmd) Run(ctx context.Context, flags *RootFlags)
 	return nil
 }
 
+func (*ContactsDirectorySearchCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "people", Result: struct {
+		People        []personItem `json:"people"`
+		NextPageToken string       `json:"nextPageToken"`
+	}{}}
+}
+
 type ContactsOtherCmd struct {
 	List   ContactsOtherListCmd   `cmd:"" name:"list" help:"List other contacts"`
 	Search ContactsOtherSearchCmd `cmd:"" name:"search" help:"Search other contacts"`
@@ -291,18 +302,12 @@ func (c *ContactsOtherListCmd) Run(ctx context.Context, flags *RootFlags) error
 		}
 	}
 	if outfmt.IsJSON(ctx) {
-		type item struct {
-			Resource string `json:"resource"`
-			Name     string `json:"name,omitempty"`
-			Email    string `json:"email,omitempty"`
-			Phone    string `json:"phone,omitempty"`
-		}
-		items := make([]item, 0, len(contacts))
+		items := make([]contactItem, 0, len(contacts))
 		for _, p := range contacts {
 			if p == nil {
 				continue
 			}
-			items = append(items, item{
+			items = append(items, contactItem{
 				Resource: p.ResourceName,
 				Name:     primaryName(p),
 				Email:    primaryEmail(p),
@@ -344,6 +349,13 @@ func (c *ContactsOtherListCmd) Run(ctx context.Context, flags *RootFlags) error
 	return nil
 }
 
+func (*ContactsOtherListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "contacts", Result: struct {
+		Contacts      []contactItem `json:"contacts"`
+		NextPageToken string        `json:"nextPageToken"`
+	}{}}
+}
+
 type ContactsOtherSearchCmd struct {
 	Query []string `arg:"" name:"query" help:"Search query"`
 	Max   int64    `name:"max" aliases:"limit" help:"Max results" default:"50"`
@@ -371,19 +383,13 @@ func (c *ContactsOtherSearchCmd) Run(ctx context.Context, flags *RootFlags) erro
 		return err
 	}
 	if outfmt.IsJSON(ctx) {
-		type item struct {
-			Resource string `json:"resource"`
-			Name     string `json:"name,omitempty"`
-			Email    string `json:"email,omitempty"`
-			Phone    string `json:"phone,omitempty"`
-		}
-		items := make([]item, 0, len(resp.Results))
+		items := make([]contactItem, 0, len(resp.Results))
 		for _, r := range resp.Results {
 			p := r.Person
 			if p == nil {
 				continue
 			}
-			items = append(items, item{
+			items = append(items, contactItem{
 				Resource: p.ResourceName,
 				Name:     primaryName(p),
 				Email:    primaryEmail(p),
@@ -416,6 +422,12 @@ func (c *ContactsOtherSearchCmd) Run(ctx context.Context, flags *RootFlags) erro
 	return nil
 }
 
+func (*ContactsOtherSearchCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "contacts", Result: struct {
+		Contacts []contactItem `json:"contacts"`
+	}{}}
+}
+
 type ContactsOtherDeleteCmd struct {
 	ResourceName string `arg:"" name:"resourceName" help:"Resource name (otherContacts/...)"`
 }
@@ -442,6 +454,10 @@ func (c *ContactsOtherDeleteCmd) Run(ctx context.Context, flags *RootFlags) erro
 	return writeDeleteResult(ctx, u, resourceName)
 }
 
+func (*ContactsOtherDeleteCmd) jsonOutput() commandOutput {
+	return deleteResultOutput()
+}
+
 func deleteOtherContact(ctx context.Context, account, resourceName string) error {
 	otherSvc, err := newPeopleOtherContactsService(ctx, account)
 	if err != nil {
@@ -12,3 +12,10 @@ func writeDeleteResult(ctx context.Context, u *ui.UI, resourceName string) error
 		kv("resource", resourceName),
 	)
 }
+
+func deleteResultOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Deleted  bool   `json:"deleted"`
+		Resource string `json:"resource"`
+	}{}}
+}
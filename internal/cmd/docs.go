@@ -50,6 +50,10 @@ func (c *DocsExportCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}, c.DocID, c.Output.Path, c.Format)
 }
 
+func (*DocsExportCmd) jsonOutput() commandOutput {
+	return exportOutput()
+}
+
 type DocsInfoCmd struct {
 	DocID string `arg:"" name:"docId" help:"Doc ID"`
 }
@@ -113,6 +117,13 @@ func (c *DocsInfoCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*DocsInfoCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		File     *drive.File    `json:"file"`
+		Document *docs.Document `json:"document"`
+	}{}}
+}
+
 type DocsCreateCmd struct {
 	Title  string `arg:"" name:"title" help:"Doc title"`
 	Parent string `name:"parent" help:"Destination folder ID"`
@@ -195,6 +206,10 @@ func (c *DocsCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*DocsCreateCmd) jsonOutput() commandOutput {
+	return driveFileOutput()
+}
+
 // insertImages performs pass 2: reads back the created doc, resolves image URLs,
 // and replaces placeholder text with inline images.
 func (c *DocsCreateCmd) insertImages(ctx context.Context, account string, driveSvc *drive.Service, docID string, images []markdownImage) error {
@@ -266,6 +281,10 @@ func (c *DocsCopyCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}, c.DocID, c.Title, c.Parent)
 }
 
+func (*DocsCopyCmd) jsonOutput() commandOutput {
+	return driveFileOutput()
+}
+
 type DocsCatCmd struct {
 	DocID    string `arg:"" name:"docId" help:"Doc ID"`
 	MaxBytes int64  `name:"max-bytes" help:"Max bytes to read (0 = unlimited)" default:"2000000"`
@@ -317,6 +336,28 @@ func (c *DocsCatCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return err
 }
 
+func (*DocsCatCmd) jsonOutput() commandOutput {
+	type tab struct {
+		ID    string `json:"id,omitempty"`
+		Title string `json:"title,omitempty"`
+		Index int64  `json:"index,omitempty"`
+		Text  string `json:"text"`
+	}
+	return commandOutput{
+		Result: struct {
+			Text string `json:"text"`
+		}{},
+		Variants: []any{
+			struct {
+				Tab tab `json:"tab"`
+			}{},
+			struct {
+				Tabs []tab `json:"tabs"`
+			}{},
+		},
+	}
+}
+
 type DocsUpdateCmd struct {
 	DocID       string `arg:"" name:"docId" help:"Doc ID"`
 	Content     string `name:"content" help:"Text content to insert (mutually exclusive with --content-file)"`
@@ -491,6 +532,16 @@ func (c *DocsUpdateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*DocsUpdateCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Success bool   `json:"success"`
+		DocID   string `json:"docId"`
+		Action  struct {
+			Append bool `json:"append"`
+		} `json:"action"`
+	}{}}
+}
+
 func (c *DocsCatCmd) runWithTabs(ctx context.Context, svc *docs.Service, id string) error {
 	doc, err := svc.Documents.Get(id).
 		IncludeTabsContent(true).
@@ -614,6 +665,18 @@ func (c *DocsListTabsCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*DocsListTabsCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "tabs", Result: struct {
+		Tabs []struct {
+			ID           string `json:"id,omitempty"`
+			Title        string `json:"title,omitempty"`
+			Index        int64  `json:"index,omitempty"`
+			NestingLevel int64  `json:"nestingLevel,omitempty"`
+			ParentTabID  string `json:"parentTabId,omitempty"`
+		} `json:"tabs"`
+	}{}}
+}
+
 // --- Write / Insert / Delete / Find-Replace commands ---
 
 type DocsWriteCmd struct {
@@ -649,6 +712,15 @@ func (c *DocsWriteCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return c.writePlainText(ctx, account, docID, content)
 }
 
+func (*DocsWriteCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		DocumentID string `json:"documentId"`
+		Written    int    `json:"written"`
+		Replaced   bool   `json:"replaced"`
+		Markdown   bool   `json:"markdown,omitempty"`
+	}{}}
+}
+
 func (c *DocsWriteCmd) writeMarkdown(ctx context.Context, account, docID, content string) error {
 	u := ui.FromContext(ctx)
 
@@ -827,6 +899,14 @@ func (c *DocsInsertCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*DocsInsertCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		DocumentID string `json:"documentId"`
+		Inserted   int    `json:"inserted"`
+		AtIndex    int64  `json:"atIndex"`
+	}{}}
+}
+
 type DocsDeleteCmd struct {
 	DocID string `arg:"" name:"docId" help:"Doc ID"`
 	Start int64  `name:"start" required:"" help:"Start index (>= 1)"`
@@ -886,6 +966,15 @@ func (c *DocsDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*DocsDeleteCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		DocumentID string `json:"documentId"`
+		Deleted    int64  `json:"deleted"`
+		StartIndex int64  `json:"startIndex"`
+		EndIndex   int64  `json:"endIndex"`
+	}{}}
+}
+
 type DocsFindReplaceCmd struct {
 	DocID       string `arg:"" name:"docId" help:"Doc ID"`
 	Find        string `arg:"" name:"find" help:"Text to find"`
@@ -949,6 +1038,15 @@ func (c *DocsFindReplaceCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*DocsFindReplaceCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		DocumentID   string `json:"documentId"`
+		Find         string `json:"find"`
+		Replace      string `json:"replace"`
+		Replacements int64  `json:"replacements"`
+	}{}}
+}
+
 // resolveContentInput reads content from an argument, file, or stdin.
 func resolveContentInput(content, filePath string) (string, error) {
 	if content != "" {
@@ -179,6 +179,14 @@ func (c *DocsCommentsListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*DocsCommentsListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "comments", Result: struct {
+		DocID         string           `json:"docId"`
+		Comments      []*drive.Comment `json:"comments"`
+		NextPageToken string           `json:"nextPageToken"`
+	}{}}
+}
+
 // DocsCommentsGetCmd retrieves a single comment by ID.
 type DocsCommentsGetCmd struct {
 	DocID     string `arg:"" name:"docId" help:"Google Doc ID or URL"`
@@ -248,6 +256,10 @@ func (c *DocsCommentsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*DocsCommentsGetCmd) jsonOutput() commandOutput {
+	return driveCommentOutput()
+}
+
 // DocsCommentsAddCmd creates a comment on a Google Doc.
 type DocsCommentsAddCmd struct {
 	DocID   string `arg:"" name:"docId" help:"Google Doc ID or URL"`
@@ -314,6 +326,10 @@ func (c *DocsCommentsAddCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*DocsCommentsAddCmd) jsonOutput() commandOutput {
+	return driveCommentOutput()
+}
+
 // DocsCommentsReplyCmd replies to a comment on a Google Doc.
 type DocsCommentsReplyCmd struct {
 	DocID     string `arg:"" name:"docId" help:"Google Doc ID or URL"`
@@ -372,6 +388,12 @@ func (c *DocsCommentsReplyCmd) Run(ctx context.Context, flags *RootFlags) error
 	return nil
 }
 
+func (*DocsCommentsReplyCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Reply *drive.Reply `json:"reply"`
+	}{}}
+}
+
 // DocsCommentsResolveCmd resolves a comment by posting an empty reply with action "resolve".
 // The Drive API resolves a comment when a reply is created with action="resolve".
 type DocsCommentsResolveCmd struct {
@@ -438,6 +460,15 @@ func (c *DocsCommentsResolveCmd) Run(ctx context.Context, flags *RootFlags) erro
 	return nil
 }
 
+func (*DocsCommentsResolveCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Resolved  bool         `json:"resolved"`
+		DocID     string       `json:"docId"`
+		CommentID string       `json:"commentId"`
+		Reply     *drive.Reply `json:"reply"`
+	}{}}
+}
+
 // DocsCommentsDeleteCmd deletes a comment on a Google Doc.
 type DocsCommentsDeleteCmd struct {
 	DocID     string `arg:"" name:"docId" help:"Google Doc ID or URL"`
@@ -480,6 +511,14 @@ func (c *DocsCommentsDeleteCmd) Run(ctx context.Context, flags *RootFlags) error
 	)
 }
 
+func (*DocsCommentsDeleteCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Deleted   bool   `json:"deleted"`
+		DocID     string `json:"docId"`
+		CommentID string `json:"commentId"`
+	}{}}
+}
+
 // filterOpenComments returns only non-resolved comments.
 func filterOpenComments(comments []*drive.Comment) []*drive.Comment {
 	var open []*drive.Comment
@@ -0,0 +1,524 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/alecthomas/kong"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	docsFormatMan      = "man"
+	docsFormatMarkdown = "markdown"
+)
+
+type DocsGenCmd struct {
+	Format        string `name:"format" help:"Page format: man|markdown" enum:"man,markdown" default:"markdown"`
+	Out           string `name:"out" aliases:"output,dir" help:"Output directory (created if missing)" required:""`
+	IncludeHidden bool   `name:"include-hidden" help:"Include hidden commands and flags"`
+}
+
+// docPage is one generated page: a command node, its own flags and the
+// commands around it. The root page also carries global flags and env vars.
+type docPage struct {
+	Path     string
+	Node     *kong.Node
+	Flags    []schemaFlag
+	Args     []schemaArg
+	Examples []string
+	Parent   *docPage
+	Children []*docPage
+}
+
+type envVarDoc struct {
+	Name string
+	Help string
+}
+
+// documentedEnvVars are the GOG_* variables read outside of flag defaults.
+var documentedEnvVars = []envVarDoc{
+	{"GOG_ACCOUNT", "Default account email or alias (instead of --account)."},
+	{"GOG_CLIENT", "OAuth client name (selects stored credentials and token bucket)."},
+	{"GOG_CREDENTIALS_JSON", "OAuth client JSON; overrides stored credentials for every client."},
+	{"GOG_CREDENTIALS_FILE", "Path to an OAuth client JSON file."},
+	{"GOG_REFRESH_TOKEN", "Refresh token for the account; bypasses the keyring."},
+	{"GOG_TOKEN_FILE", "Path to a token export file or a bare refresh token."},
+	{"GOG_USE_ADC", "Use Application Default Credentials (1/true)."},
+	{"GOG_IMPERSONATE_SERVICE_ACCOUNT", "Service account to impersonate via the IAM Credentials API."},
+	{"GOG_JSON", "Default to JSON output."},
+	{"GOG_PLAIN", "Default to plain (TSV) output."},
+	{"GOG_COLOR", "Color mode: auto, always or never."},
+	{"GOG_TIMEZONE", "Default output timezone (IANA name, UTC or local)."},
+	{"GOG_WEEK_START", "First day of the week for --week and natural ranges (sun, mon, ...)."},
+	{"GOG_CALENDAR_WEEKDAY", "Show weekday columns in calendar output by default."},
+	{"GOG_ENABLE_COMMANDS", "Comma-separated allowlist of top-level commands."},
+	{"GOG_KEYRING_BACKEND", "Keyring backend: auto, keychain or file (overrides config)."},
+	{"GOG_KEYRING_PASSWORD", "Password for the encrypted file keyring (non-interactive runs)."},
+	{"GOG_HEADLESS", "Treat the session as headless (no OS keychain UI)."},
+	{"GOG_HELP", "Set to full to expand all subcommands in --help."},
+	{"GOG_LOG_FORMAT", "Log line format: text (default) or json."},
+	{"GOG_LOG_FILE", "Append logs (including info-level retry and token events) to this file instead of stderr."},
+	{"GOG_INVOCATION_ID", "Invocation ID for log lines, JSON envelopes and the X-Request-Id header (default: random)."},
+}
+
+// commandExamples are shown in the EXAMPLES section, keyed by command path
+// ("" is the root page).
+var commandExamples = map[string][]string{
+	"": {
+		"gog auth add you@gmail.com",
+		"gog gmail search 'newer_than:7d' --max 10",
+		"gog --plain calendar events primary --today",
+	},
+	"auth add":             {"gog auth add you@gmail.com --services gmail,calendar"},
+	"gmail search":         {"gog gmail search 'is:unread from:boss@example.com'", "gog gmail search --since 'last monday' --max 50"},
+	"gmail send":           {"gog gmail send --to a@example.com --subject Hi --body 'Hello'", "gog gmail send --to a@example.com --subject Hi --body 'Hello' --at 'tomorrow 9am'"},
+	"gmail unsubscribe":    {"gog gmail unsubscribe --query 'category:promotions newer_than:90d' --dry-run", "gog gmail unsubscribe <messageId> --filter archive"},
+	"gmail outbox run":     {"gog gmail outbox run", "gog gmail outbox run --all --dry-run"},
+	"gmail thread get":     {"gog gmail thread get <threadId> --download --out-dir ./attachments"},
+	"calendar events":      {"gog calendar events primary --from today --to 'next friday'", "gog calendar events --all --week"},
+	"calendar create":      {"gog calendar create primary --summary Standup --from 'tomorrow 9am for 15m'"},
+	"drive ls":             {"gog drive ls --max 20", "gog drive ls --parent <folderId>"},
+	"drive search":         {"gog drive search 'quarterly report'"},
+	"drive download":       {"gog drive download <fileId> --out ./report.pdf"},
+	"tasks list":           {"gog tasks list <tasklistId>"},
+	"contacts search":      {"gog contacts search alice"},
+	"schema":               {"gog schema gmail search", "gog schema --output-schema gmail search"},
+	"agent exit-codes":     {"gog agent exit-codes --plain"},
+	"docs-gen":             {"gog docs-gen --format man --out ./man", "gog docs-gen --format markdown --out ./docs/reference"},
+	"completion":           {"gog completion zsh > \"${fpath[1]}/_gog\""},
+	"config set":           {"gog config set timezone Europe/Berlin"},
+	"calendar freebusy":    {"gog calendar freebusy a@example.com,b@example.com --from 2026-03-02T09:00:00Z --to 2026-03-02T18:00:00Z"},
+	"gmail labels list":    {"gog gmail labels list"},
+	"gmail filters list":   {"gog gmail filters list"},
+	"gmail filters export": {"gog gmail filters export > filters.yaml", "gog gmail filters export --out mailFilters.xml"},
+	"gmail filters apply":  {"gog gmail filters apply filters.yaml --dry-run", "gog gmail filters apply filters.yaml --prune"},
+	"gmail merge":          {"gog gmail merge --template body.md --data recipients.csv --subject '{{.Company}} update' --dry-run", "gog gmail merge --template body.txt --html-template body.html --data people.json --subject 'Welcome {{.Name}}' --draft"},
+	"gmail import":         {"gog gmail import --from ./legacy.mbox --label Imported", "gog gmail import --from ./mail --preserve-labels --never-mark-spam"},
+	"gmail export":         {"gog gmail export --query 'label:receipts' --out ./receipts.mbox", "gog gmail export --format eml-dir --out ./mail"},
+	"index sync":           {"gog index sync", "gog index sync --services gmail --bodies"},
+	"index search":         {"gog index search 'quarterly report' from:alice after:2026-01-01", "gog index search budget service:drive --max 5"},
+}
+
+func (c *DocsGenCmd) Run(ctx context.Context, kctx *kong.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+
+	format := strings.ToLower(strings.TrimSpace(c.Format))
+	outDir := strings.TrimSpace(c.Out)
+	if outDir == "" {
+		return usage("empty --out")
+	}
+	expanded, err := config.ExpandPath(outDir)
+	if err != nil {
+		return err
+	}
+	outDir = expanded
+
+	if err := dryRunExit(ctx, flags, "docs-gen", map[string]any{
+		"format": format,
+		"out":    outDir,
+	}); err != nil {
+		return err
+	}
+
+	root := buildDocPage(kctx.Model.Node, nil, !c.IncludeHidden)
+	pages := flattenDocPages(root)
+
+	if err := os.MkdirAll(outDir, 0o755); err != nil { //nolint:gosec // docs are meant to be readable
+		return fmt.Errorf("create %s: %w", outDir, err)
+	}
+
+	files := make([]string, 0, len(pages))
+	for _, page := range pages {
+		path := filepath.Join(outDir, page.fileName(format))
+		if err := writeDocPage(path, format, page, root); err != nil {
+			return err
+		}
+		files = append(files, path)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"format": format,
+			"out":    outDir,
+			"count":  len(files),
+			"files":  files,
+		})
+	}
+	for _, f := range files {
+		u.Out().Println(f)
+	}
+	return nil
+}
+
+func (*DocsGenCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "files", Result: struct {
+		Format string   `json:"format"`
+		Out    string   `json:"out"`
+		Count  int      `json:"count"`
+		Files  []string `json:"files"`
+	}{}}
+}
+
+func buildDocPage(node *kong.Node, parent *docPage, hide bool) *docPage {
+	page := &docPage{
+		Path:   commandPath(node),
+		Node:   node,
+		Args:   schemaPositionals(node),
+		Parent: parent,
+	}
+	page.Examples = commandExamples[page.Path]
+
+	for _, f := range node.Flags {
+		if f == nil || (hide && f.Hidden) {
+			continue
+		}
+		page.Flags = append(page.Flags, newSchemaFlag(f))
+	}
+	sort.Slice(page.Flags, func(i, j int) bool { return page.Flags[i].Name < page.Flags[j].Name })
+
+	for _, child := range node.Children {
+		if child == nil || child.Type != kong.CommandNode || (hide && child.Hidden) {
+			continue
+		}
+		page.Children = append(page.Children, buildDocPage(child, page, hide))
+	}
+	sort.Slice(page.Children, func(i, j int) bool { return page.Children[i].Path < page.Children[j].Path })
+
+	return page
+}
+
+func flattenDocPages(page *docPage) []*docPage {
+	out := []*docPage{page}
+	for _, child := range page.Children {
+		out = append(out, flattenDocPages(child)...)
+	}
+	return out
+}
+
+// pageName is the man page / file stem: "gog", "gog-gmail-search".
+func (p *docPage) pageName() string {
+	if p.Path == "" {
+		return "gog"
+	}
+	return "gog-" + strings.ReplaceAll(p.Path, " ", "-")
+}
+
+func (p *docPage) fileName(format string) string {
+	if format == docsFormatMan {
+		return p.pageName() + ".1"
+	}
+	return p.pageName() + ".md"
+}
+
+func (p *docPage) title() string {
+	return strings.TrimSpace("gog " + p.Path)
+}
+
+func (p *docPage) synopsis() string {
+	if p.Path == "" {
+		return "gog <command> [flags]"
+	}
+	return commandTemplateWithRoot(p.Node)
+}
+
+func writeDocPage(path, format string, page, root *docPage) error {
+	f, err := os.Create(path) //nolint:gosec // user-provided output directory
+	if err != nil {
+		return fmt.Errorf("create %s: %w", path, err)
+	}
+
+	if format == docsFormatMan {
+		err = renderManPage(f, page, root)
+	} else {
+		err = renderMarkdownPage(f, page, root)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
+
+type exitCodeDoc struct {
+	Code int
+	Name string
+}
+
+func sortedExitCodes() []exitCodeDoc {
+	codes := stableExitCodes()
+	out := make([]exitCodeDoc, 0, len(codes))
+	for name, code := range codes {
+		out = append(out, exitCodeDoc{Code: code, Name: name})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Code < out[j].Code })
+	return out
+}
+
+// flagSignature renders "-a, --account=STRING".
+func flagSignature(f schemaFlag) string {
+	sig := "--" + f.Name
+	if f.Short != "" {
+		sig = "-" + f.Short + ", " + sig
+	}
+	if f.Type != "bool" && f.Placeholder != "" {
+		sig += "=" + f.Placeholder
+	}
+	return sig
+}
+
+// flagNotes collects aliases, default, allowed values and env vars of a flag.
+func flagNotes(f schemaFlag) []string {
+	var notes []string
+	if len(f.Aliases) > 0 {
+		aliases := make([]string, len(f.Aliases))
+		for i, a := range f.Aliases {
+			aliases[i] = "--" + a
+		}
+		notes = append(notes, "aliases: "+strings.Join(aliases, ", "))
+	}
+	if f.Default != "" {
+		notes = append(notes, "default: "+f.Default)
+	}
+	if len(f.Enum) > 0 {
+		notes = append(notes, "one of: "+strings.Join(f.Enum, ", "))
+	}
+	if len(f.Envs) > 0 {
+		notes = append(notes, "env: "+strings.Join(f.Envs, ", "))
+	}
+	if f.Required {
+		notes = append(notes, "required")
+	}
+	return notes
+}
+
+func nodeDescription(node *kong.Node) string {
+	help := strings.TrimSpace(node.Help)
+	if detail := strings.TrimSpace(node.Detail); detail != "" {
+		help = strings.TrimSpace(help + "\n\n" + detail)
+	}
+	if help == "" && node.Type == kong.ApplicationNode {
+		help = baseDescription()
+	}
+	return help
+}
+
+func renderMarkdownPage(w io.Writer, page, root *docPage) error {
+	var b strings.Builder
+	node := page.Node
+
+	fmt.Fprintf(&b, "# %s\n\n", page.title())
+	if desc := nodeDescription(node); desc != "" {
+		b.WriteString(desc + "\n\n")
+	}
+	fmt.Fprintf(&b, "```\n%s\n```\n\n", page.synopsis())
+
+	if len(node.Aliases) > 0 {
+		fmt.Fprintf(&b, "Aliases: %s\n\n", "`"+strings.Join(sortedStrings(node.Aliases), "`, `")+"`")
+	}
+
+	if len(page.Args) > 0 {
+		b.WriteString("## Arguments\n\n| Name | Type | Description |\n| --- | --- | --- |\n")
+		for _, a := range page.Args {
+			help := a.Help
+			if !a.Required {
+				help = strings.TrimSpace(help + " (optional)")
+			}
+			fmt.Fprintf(&b, "| `<%s>` | %s | %s |\n", a.Name, a.Type, mdCell(help))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(page.Flags) > 0 {
+		heading := "Flags"
+		if page == root {
+			heading = "Global flags"
+		}
+		fmt.Fprintf(&b, "## %s\n\n| Flag | Description |\n| --- | --- |\n", heading)
+		for _, f := range page.Flags {
+			help := f.Help
+			if notes := flagNotes(f); len(notes) > 0 {
+				help = strings.TrimSpace(help + " (" + strings.Join(notes, "; ") + ")")
+			}
+			fmt.Fprintf(&b, "| `%s` | %s |\n", flagSignature(f), mdCell(help))
+		}
+		b.WriteString("\n")
+	}
+	if page != root {
+		fmt.Fprintf(&b, "Global flags: see [gog](%s).\n\n", root.fileName(docsFormatMarkdown))
+	}
+
+	if len(page.Examples) > 0 {
+		b.WriteString("## Examples\n\n```bash\n")
+		for _, ex := range page.Examples {
+			b.WriteString(ex + "\n")
+		}
+		b.WriteString("```\n\n")
+	}
+
+	if page == root {
+		b.WriteString("## Environment\n\n| Variable | Description |\n| --- | --- |\n")
+		for _, env := range documentedEnvVars {
+			fmt.Fprintf(&b, "| `%s` | %s |\n", env.Name, mdCell(env.Help))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Exit codes\n\n| Code | Name |\n| --- | --- |\n")
+	for _, c := range sortedExitCodes() {
+		fmt.Fprintf(&b, "| %d | `%s` |\n", c.Code, c.Name)
+	}
+	b.WriteString("\n")
+
+	if page == root {
+		b.WriteString("## Commands\n\n")
+		for _, p := range flattenDocPages(root)[1:] {
+			indent := strings.Repeat("  ", strings.Count(p.Path, " "))
+			fmt.Fprintf(&b, "%s- [%s](%s) - %s\n", indent, p.title(), p.fileName(docsFormatMarkdown), mdCell(strings.TrimSpace(p.Node.Help)))
+		}
+		b.WriteString("\n")
+	} else {
+		b.WriteString("## See also\n\n")
+		fmt.Fprintf(&b, "- [%s](%s)\n", page.Parent.title(), page.Parent.fileName(docsFormatMarkdown))
+		for _, child := range page.Children {
+			fmt.Fprintf(&b, "- [%s](%s) - %s\n", child.title(), child.fileName(docsFormatMarkdown), mdCell(strings.TrimSpace(child.Node.Help)))
+		}
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, strings.TrimRight(b.String(), "\n")+"\n")
+	return err
+}
+
+func mdCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func renderManPage(w io.Writer, page, root *docPage) error {
+	var b strings.Builder
+	node := page.Node
+	name := page.pageName()
+
+	fmt.Fprintf(&b, ".TH %s 1 \"\" %s \"gog manual\"\n", roffQuote(strings.ToUpper(name)), roffQuote("gog "+VersionString()))
+
+	b.WriteString(".SH NAME\n")
+	summary := strings.TrimSpace(node.Help)
+	if summary == "" {
+		summary = baseDescription()
+	}
+	fmt.Fprintf(&b, "%s \\- %s\n", roffEscape(name), roffEscape(summary))
+
+	b.WriteString(".SH SYNOPSIS\n")
+	fmt.Fprintf(&b, ".B %s\n", roffEscape(page.synopsis()))
+
+	if desc := nodeDescription(node); desc != "" {
+		b.WriteString(".SH DESCRIPTION\n")
+		b.WriteString(roffParagraphs(desc))
+	}
+	if len(node.Aliases) > 0 {
+		fmt.Fprintf(&b, ".PP\nAliases: %s\n", roffEscape(strings.Join(sortedStrings(node.Aliases), ", ")))
+	}
+
+	if len(page.Args) > 0 {
+		b.WriteString(".SH ARGUMENTS\n")
+		for _, a := range page.Args {
+			help := a.Help
+			if !a.Required {
+				help = strings.TrimSpace(help + " (optional)")
+			}
+			fmt.Fprintf(&b, ".TP\n.I %s\n%s\n", roffEscape(a.Name), roffEscape(help))
+		}
+	}
+
+	if len(page.Flags) > 0 {
+		if page == root {
+			b.WriteString(".SH GLOBAL OPTIONS\n")
+		} else {
+			b.WriteString(".SH OPTIONS\n")
+		}
+		for _, f := range page.Flags {
+			fmt.Fprintf(&b, ".TP\n.B %s\n%s\n", roffEscape(flagSignature(f)), roffEscape(f.Help))
+			if notes := flagNotes(f); len(notes) > 0 {
+				fmt.Fprintf(&b, ".br\n(%s)\n", roffEscape(strings.Join(notes, "; ")))
+			}
+		}
+	}
+	if page != root {
+		b.WriteString(".PP\nGlobal options are described in\n.BR gog (1).\n")
+	}
+
+	if len(page.Examples) > 0 {
+		b.WriteString(".SH EXAMPLES\n")
+		for _, ex := range page.Examples {
+			fmt.Fprintf(&b, ".PP\n.nf\n.RS\n%s\n.RE\n.fi\n", roffEscape(ex))
+		}
+	}
+
+	if page == root {
+		b.WriteString(".SH ENVIRONMENT\n")
+		for _, env := range documentedEnvVars {
+			fmt.Fprintf(&b, ".TP\n.B %s\n%s\n", env.Name, roffEscape(env.Help))
+		}
+	}
+
+	b.WriteString(".SH EXIT STATUS\n")
+	for _, c := range sortedExitCodes() {
+		fmt.Fprintf(&b, ".TP\n.B %d\n%s\n", c.Code, roffEscape(c.Name))
+	}
+
+	b.WriteString(".SH SEE ALSO\n")
+	var refs []string
+	if page.Parent != nil {
+		refs = append(refs, page.Parent.pageName())
+	}
+	for _, child := range page.Children {
+		refs = append(refs, child.pageName())
+	}
+	for i, ref := range refs {
+		sep := ","
+		if i == len(refs)-1 {
+			sep = ""
+		}
+		fmt.Fprintf(&b, ".BR %s (1)%s\n", roffEscape(ref), sep)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func roffParagraphs(s string) string {
+	var b strings.Builder
+	for i, para := range strings.Split(s, "\n\n") {
+		if i > 0 {
+			b.WriteString(".PP\n")
+		}
+		b.WriteString(roffEscape(strings.Join(strings.Fields(para), " ")) + "\n")
+	}
+	return b.String()
+}
+
+// roffEscape escapes text for a roff line: backslashes, hyphens (so they
+// render as ASCII minus and stay copy-pasteable) and leading control chars.
+func roffEscape(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\e")
+	s = strings.ReplaceAll(s, "-", "\\-")
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = "\\&" + s
+	}
+	return s
+}
+
+func roffQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "'") + `"`
+}
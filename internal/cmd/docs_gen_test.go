@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExecute_DocsGen_Markdown(t *testing.T) {
+	out := t.TempDir()
+	_ = captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			if err := Execute([]string{"--plain", "docs-gen", "--out", out}); err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+		})
+	})
+
+	root, err := os.ReadFile(filepath.Join(out, "gog.md"))
+	if err != nil {
+		t.Fatalf("read root page: %v", err)
+	}
+	for _, want := range []string{"## Global flags", "`GOG_ACCOUNT`", "| 130 | `cancelled` |", "[gog gmail search](gog-gmail-search.md)"} {
+		if !strings.Contains(string(root), want) {
+			t.Fatalf("root page missing %q", want)
+		}
+	}
+
+	page, err := os.ReadFile(filepath.Join(out, "gog-gmail-search.md"))
+	if err != nil {
+		t.Fatalf("read command page: %v", err)
+	}
+	for _, want := range []string{"# gog gmail search", "`--since=STRING`", "## Examples", "[gog gmail](gog-gmail.md)"} {
+		if !strings.Contains(string(page), want) {
+			t.Fatalf("command page missing %q:\n%s", want, page)
+		}
+	}
+	if strings.Contains(string(page), "--account") {
+		t.Fatalf("command page should not repeat global flags")
+	}
+	if _, err := os.Stat(filepath.Join(out, "gog-__complete.md")); err == nil {
+		t.Fatalf("hidden command should be skipped")
+	}
+}
+
+func TestExecute_DocsGen_Man(t *testing.T) {
+	out := t.TempDir()
+	_ = captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			if err := Execute([]string{"--plain", "docs-gen", "--format", "man", "--out", out}); err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+		})
+	})
+
+	page, err := os.ReadFile(filepath.Join(out, "gog-drive-ls.1"))
+	if err != nil {
+		t.Fatalf("read man page: %v", err)
+	}
+	for _, want := range []string{".TH \"GOG-DRIVE-LS\" 1", ".SH OPTIONS", ".B \\-\\-parent=STRING", ".SH EXIT STATUS", ".BR gog\\-drive (1)"} {
+		if !strings.Contains(string(page), want) {
+			t.Fatalf("man page missing %q:\n%s", want, page)
+		}
+	}
+}
+
+func TestCommandExamples_PathsExist(t *testing.T) {
+	parser, _, err := newParser("test")
+	if err != nil {
+		t.Fatalf("newParser: %v", err)
+	}
+	for path := range commandExamples {
+		if path == "" {
+			continue
+		}
+		node, err := findCommandNode(parser.Model.Node, splitCommandPath([]string{path}))
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		if got := commandPath(node); got != path {
+			t.Fatalf("%s resolves to %q", path, got)
+		}
+	}
+}
+
+func TestRoffEscape(t *testing.T) {
+	if got := roffEscape(`.hidden \n --flag`); got != `\&.hidden \en \-\-flag` {
+		t.Fatalf("roffEscape: %q", got)
+	}
+}
@@ -151,6 +151,18 @@ func (c *DriveLsCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*DriveLsCmd) jsonOutput() commandOutput {
+	return driveFilesOutput()
+}
+
+// driveFilesOutput declares the file list written by ls and search.
+func driveFilesOutput() commandOutput {
+	return commandOutput{Items: "files", Result: struct {
+		Files         []*drive.File `json:"files"`
+		NextPageToken string        `json:"nextPageToken"`
+	}{}}
+}
+
 type DriveSearchCmd struct {
 	Query     []string `arg:"" name:"query" help:"Search query"`
 	RawQuery  bool     `name:"raw-query" aliases:"raw" help:"Treat query as Drive query language (pass through; may error if invalid)"`
@@ -220,6 +232,10 @@ func (c *DriveSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*DriveSearchCmd) jsonOutput() commandOutput {
+	return driveFilesOutput()
+}
+
 type DriveGetCmd struct {
 	FileID string `arg:"" name:"fileId" help:"File ID"`
 }
@@ -269,6 +285,10 @@ func (c *DriveGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*DriveGetCmd) jsonOutput() commandOutput {
+	return driveFileOutput()
+}
+
 type DriveDownloadCmd struct {
 	FileID string         `arg:"" name:"fileId" help:"File ID"`
 	Output OutputPathFlag `embed:""`
@@ -332,6 +352,10 @@ func (c *DriveDownloadCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*DriveDownloadCmd) jsonOutput() commandOutput {
+	return exportOutput()
+}
+
 type DriveCopyCmd struct {
 	FileID string `arg:"" name:"fileId" help:"File ID"`
 	Name   string `arg:"" name:"name" help:"New file name"`
@@ -344,6 +368,10 @@ func (c *DriveCopyCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}, c.FileID, c.Name, c.Parent)
 }
 
+func (*DriveCopyCmd) jsonOutput() commandOutput {
+	return driveFileOutput()
+}
+
 type DriveUploadCmd struct {
 	LocalPath           string `arg:"" name:"localPath" help:"Path to local file"`
 	Name                string `name:"name" help:"Override filename (create) or rename target (replace)"`
@@ -501,6 +529,14 @@ func (c *DriveUploadCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*DriveUploadCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: driveFileResult{}, Variants: []any{struct {
+		File            *drive.File `json:"file"`
+		Replaced        bool        `json:"replaced"`
+		PreservedFileID bool        `json:"preservedFileId"`
+	}{}}}
+}
+
 type DriveMkdirCmd struct {
 	Name   string `arg:"" name:"name" help:"Folder name"`
 	Parent string `name:"parent" help:"Parent folder ID"`
@@ -552,6 +588,12 @@ func (c *DriveMkdirCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*DriveMkdirCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Folder *drive.File `json:"folder"`
+	}{}}
+}
+
 type DriveDeleteCmd struct {
 	FileID    string `arg:"" name:"fileId" help:"File ID"`
 	Permanent bool   `name:"permanent" help:"Permanently delete instead of moving to trash" default:"false"`
@@ -605,6 +647,14 @@ func (c *DriveDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 	)
 }
 
+func (*DriveDeleteCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Trashed bool   `json:"trashed"`
+		Deleted bool   `json:"deleted"`
+		ID      string `json:"id"`
+	}{}}
+}
+
 type DriveMoveCmd struct {
 	FileID string `arg:"" name:"fileId" help:"File ID"`
 	Parent string `name:"parent" help:"New parent folder ID (required)"`
@@ -661,6 +711,10 @@ func (c *DriveMoveCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*DriveMoveCmd) jsonOutput() commandOutput {
+	return driveFileOutput()
+}
+
 type DriveRenameCmd struct {
 	FileID  string `arg:"" name:"fileId" help:"File ID"`
 	NewName string `arg:"" name:"newName" help:"New name"`
@@ -704,6 +758,10 @@ func (c *DriveRenameCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*DriveRenameCmd) jsonOutput() commandOutput {
+	return driveFileOutput()
+}
+
 type DriveShareCmd struct {
 	FileID       string `arg:"" name:"fileId" help:"File ID"`
 	To           string `name:"to" help:"Share target: anyone|user|domain"`
@@ -827,6 +885,14 @@ func (c *DriveShareCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*DriveShareCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Link         string            `json:"link"`
+		PermissionID string            `json:"permissionId"`
+		Permission   *drive.Permission `json:"permission"`
+	}{}}
+}
+
 type DriveUnshareCmd struct {
 	FileID       string `arg:"" name:"fileId" help:"File ID"`
 	PermissionID string `arg:"" name:"permissionId" help:"Permission ID"`
@@ -867,6 +933,14 @@ func (c *DriveUnshareCmd) Run(ctx context.Context, flags *RootFlags) error {
 	)
 }
 
+func (*DriveUnshareCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Removed      bool   `json:"removed"`
+		FileID       string `json:"fileId"`
+		PermissionID string `json:"permissionId"`
+	}{}}
+}
+
 type DrivePermissionsCmd struct {
 	FileID string `arg:"" name:"fileId" help:"File ID"`
 	Max    int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
@@ -934,6 +1008,15 @@ func (c *DrivePermissionsCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*DrivePermissionsCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "permissions", Result: struct {
+		FileID          string              `json:"fileId"`
+		Permissions     []*drive.Permission `json:"permissions"`
+		PermissionCount int                 `json:"permissionCount"`
+		NextPageToken   string              `json:"nextPageToken"`
+	}{}}
+}
+
 type DriveURLCmd struct {
 	FileIDs []string `arg:"" name:"fileId" help:"File IDs"`
 }
@@ -975,6 +1058,15 @@ func (c *DriveURLCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*DriveURLCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "urls", Result: struct {
+		URLs []struct {
+			ID  string `json:"id"`
+			URL string `json:"url"`
+		} `json:"urls"`
+	}{}}
+}
+
 func buildDriveListQuery(folderID string, userQuery string) string {
 	q := strings.TrimSpace(userQuery)
 	parent := fmt.Sprintf("'%s' in parents", folderID)
@@ -151,6 +151,22 @@ func (c *DriveCommentsListCmd) Run(ctx context.Context, flags *RootFlags) error
 	return nil
 }
 
+func (*DriveCommentsListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "comments", Result: struct {
+		FileID        string           `json:"fileId"`
+		Comments      []*drive.Comment `json:"comments"`
+		NextPageToken string           `json:"nextPageToken"`
+	}{}}
+}
+
+// driveCommentOutput declares the {"comment": ...} result of get, create and
+// update.
+func driveCommentOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Comment *drive.Comment `json:"comment"`
+	}{}}
+}
+
 type DriveCommentsGetCmd struct {
 	FileID    string `arg:"" name:"fileId" help:"File ID"`
 	CommentID string `arg:"" name:"commentId" help:"Comment ID"`
@@ -205,6 +221,10 @@ func (c *DriveCommentsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*DriveCommentsGetCmd) jsonOutput() commandOutput {
+	return driveCommentOutput()
+}
+
 type DriveCommentsCreateCmd struct {
 	FileID  string `arg:"" name:"fileId" help:"File ID"`
 	Content string `arg:"" name:"content" help:"Comment text"`
@@ -270,6 +290,10 @@ func (c *DriveCommentsCreateCmd) Run(ctx context.Context, flags *RootFlags) erro
 	return nil
 }
 
+func (*DriveCommentsCreateCmd) jsonOutput() commandOutput {
+	return driveCommentOutput()
+}
+
 type DriveCommentsUpdateCmd struct {
 	FileID    string `arg:"" name:"fileId" help:"File ID"`
 	CommentID string `arg:"" name:"commentId" help:"Comment ID"`
@@ -331,6 +355,10 @@ func (c *DriveCommentsUpdateCmd) Run(ctx context.Context, flags *RootFlags) erro
 	return nil
 }
 
+func (*DriveCommentsUpdateCmd) jsonOutput() commandOutput {
+	return driveCommentOutput()
+}
+
 type DriveCommentsDeleteCmd struct {
 	FileID    string `arg:"" name:"fileId" help:"File ID"`
 	CommentID string `arg:"" name:"commentId" help:"Comment ID"`
@@ -372,6 +400,14 @@ func (c *DriveCommentsDeleteCmd) Run(ctx context.Context, flags *RootFlags) erro
 	)
 }
 
+func (*DriveCommentsDeleteCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Deleted   bool   `json:"deleted"`
+		FileID    string `json:"fileId"`
+		CommentID string `json:"commentId"`
+	}{}}
+}
+
 type DriveCommentReplyCmd struct {
 	FileID    string `arg:"" name:"fileId" help:"File ID"`
 	CommentID string `arg:"" name:"commentId" help:"Comment ID"`
@@ -433,6 +469,12 @@ func (c *DriveCommentReplyCmd) Run(ctx context.Context, flags *RootFlags) error
 	return nil
 }
 
+func (*DriveCommentReplyCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Reply *drive.Reply `json:"reply"`
+	}{}}
+}
+
 // truncateString truncates a string to maxLen and adds "..." if truncated
 func truncateString(s string, maxLen int) string {
 	// Replace newlines with spaces for table display
@@ -100,3 +100,13 @@ func copyViaDrive(ctx context.Context, flags *RootFlags, opts copyViaDriveOption
 	}
 	return nil
 }
+
+// driveFileOutput declares the {"file": ...} result of commands that write a
+// single Drive file.
+func driveFileOutput() commandOutput {
+	return commandOutput{Result: driveFileResult{}}
+}
+
+type driveFileResult struct {
+	File *drive.File `json:"file"`
+}
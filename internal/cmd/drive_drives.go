@@ -100,3 +100,10 @@ func (c *DriveDrivesCmd) Run(ctx context.Context, flags *RootFlags) error {
 	printNextPageHint(u, nextPageToken)
 	return nil
 }
+
+func (*DriveDrivesCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "drives", Result: struct {
+		Drives        []*drive.Drive `json:"drives"`
+		NextPageToken string         `json:"nextPageToken"`
+	}{}}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
@@ -16,6 +17,11 @@ func dryRunExit(ctx context.Context, flags *RootFlags, op string, request any) e
 	if flags == nil || !flags.DryRun {
 		return nil
 	}
+	// With --explain, pure API commands run on and the traced transport prints
+	// the first mutating request instead of sending it.
+	if flags.Explain && googleAPIDryRunOp(op) {
+		return nil
+	}
 
 	if outfmt.IsJSON(ctx) {
 		jsonCtx := outfmt.WithJSONTransform(ctx, outfmt.JSONTransform{})
@@ -51,3 +57,28 @@ func dryRunExit(ctx context.Context, flags *RootFlags, op string, request any) e
 	fmt.Printf("Dry run: would %s\n", op)
 	return &ExitError{Code: 0, Err: nil}
 }
+
+// googleAPIDryRunOp reports ops that are nothing but Google API calls, which
+// the traced transport intercepts under --explain. Everything else (config,
+// keyring, files on disk, local state, non-Google HTTP) stops in dryRunExit,
+// so an op missing here fails closed.
+func googleAPIDryRunOp(op string) bool {
+	switch {
+	case strings.HasPrefix(op, "appscript."), strings.HasPrefix(op, "calendar."), strings.HasPrefix(op, "chat."),
+		strings.HasPrefix(op, "classroom."), strings.HasPrefix(op, "sheets."), strings.HasPrefix(op, "tasks."),
+		strings.HasPrefix(op, "docs.comments."), strings.HasPrefix(op, "drive.comments."):
+		return true
+	}
+	switch op {
+	case "drive.copy", "forms.create",
+		"gmail.autoforward.update", "gmail.batch.modify", "gmail.delegates.add",
+		"gmail.drafts.create", "gmail.drafts.send", "gmail.drafts.update",
+		"gmail.filters.apply", "gmail.filters.create", "gmail.forwarding.create",
+		"gmail.labels.rename", "gmail.labels.update", "gmail.send",
+		"gmail.sendas.create", "gmail.sendas.update", "gmail.sendas.verify",
+		"gmail.thread.modify", "gmail.vacation.update":
+		return true
+	default:
+		return false
+	}
+}
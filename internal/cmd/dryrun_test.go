@@ -69,3 +69,25 @@ func TestDryRunExit_JSON_IgnoresSelectTransform(t *testing.T) {
 		t.Fatalf("expected request field, got=%v", got)
 	}
 }
+
+func TestDryRunExit_ExplainDefersAPIOps(t *testing.T) {
+	ctx := outfmt.WithMode(context.Background(), outfmt.Mode{JSON: true})
+	flags := &RootFlags{DryRun: true, Explain: true}
+
+	// API ops continue so the traced transport can print the real request.
+	if err := dryRunExit(ctx, flags, "calendar.create", nil); err != nil {
+		t.Fatalf("expected calendar.create to continue under --explain, got %v", err)
+	}
+
+	// Local ops, and any op not known to be a pure API call, still stop
+	// before touching the keyring/config/disk or non-Google endpoints.
+	for _, op := range []string{"auth.add", "gmail.unsubscribe", "gmail.send.schedule", "gmail.watch.start", "delete label \"x\"", "some.new.op"} {
+		_ = captureStdout(t, func() {
+			err := dryRunExit(ctx, flags, op, nil)
+			var exitErr *ExitError
+			if !errors.As(err, &exitErr) || exitErr.Code != 0 {
+				t.Fatalf("expected %s to exit, got %v", op, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,380 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/alecthomas/kong"
+)
+
+// ExecCmd runs another command from a JSON object, so agents never have to
+// shell-escape queries, bodies or JSON-valued flags. Execute expands it into
+// a regular argv before anything runs; Run is never reached.
+type ExecCmd struct {
+	Input string `arg:"" optional:"" name:"input" help:"JSON object {\"command\":\"gmail send\",\"flags\":{...},\"args\":[...]}, @file, or - for stdin (default: stdin)"`
+}
+
+func (c *ExecCmd) Run() error {
+	return errors.New("exec: request was not expanded")
+}
+
+// execOuterOnlyFlags are global flags an exec request may not set: they
+// restrict what gog may run, so only the outer command line can choose them.
+var execOuterOnlyFlags = map[string]bool{
+	"enable-commands": true,
+}
+
+type execRequest struct {
+	Command string         `json:"command"`
+	Flags   map[string]any `json:"flags"`
+	Args    []any          `json:"args"`
+}
+
+// expandExecArgs replaces `exec <input>` in args with the argv described by
+// the JSON request, keeping any global flags given on the outer command line.
+func expandExecArgs(root *kong.Node, args []string, input string) ([]string, error) {
+	spec := input
+	if strings.TrimSpace(spec) == "" {
+		spec = "-"
+	}
+	data, err := resolveInlineOrFileBytes(spec)
+	if err != nil {
+		return nil, usagef("exec: read request: %v", err)
+	}
+
+	req, err := decodeExecRequest(data)
+	if err != nil {
+		return nil, err
+	}
+
+	expanded, err := buildExecArgs(root, req)
+	if err != nil {
+		return nil, err
+	}
+
+	outer := make([]string, 0, len(args))
+	droppedExec, droppedInput := false, input == ""
+	for _, a := range args {
+		switch {
+		case !droppedExec && a == "exec":
+			droppedExec = true
+		case droppedExec && !droppedInput && a == input:
+			droppedInput = true
+		default:
+			outer = append(outer, a)
+		}
+	}
+
+	return append(outer, expanded...), nil
+}
+
+func decodeExecRequest(data []byte) (execRequest, error) {
+	var req execRequest
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		return req, usagef("exec: invalid request JSON: %v", err)
+	}
+	if dec.More() {
+		return req, usage("exec: request must be a single JSON object")
+	}
+	if strings.TrimSpace(req.Command) == "" {
+		return req, usage("exec: command: required (e.g. \"gmail send\")")
+	}
+	return req, nil
+}
+
+// buildExecArgs validates req against the Kong model and renders it as argv.
+// All problems are reported at once, each naming the offending field.
+func buildExecArgs(root *kong.Node, req execRequest) ([]string, error) {
+	node, err := findCommandNode(root, splitCommandPath([]string{req.Command}))
+	if err != nil {
+		return nil, usagef("exec: command: %v", err)
+	}
+	if node.DefaultCmd != nil {
+		node = node.DefaultCmd
+	}
+	if node == root || node.Type != kong.CommandNode {
+		return nil, usage("exec: command: required (e.g. \"gmail send\")")
+	}
+	if node.Target.IsValid() && node.Target.Type() == reflect.TypeFor[ExecCmd]() {
+		return nil, usage("exec: command: exec cannot run itself")
+	}
+	if len(visibleCommandChildren(node)) > 0 {
+		names := make([]string, 0, len(node.Children))
+		for _, child := range visibleCommandChildren(node) {
+			names = append(names, child.Name)
+		}
+		return nil, usagef("exec: command: %q needs a subcommand (one of: %s)", req.Command, strings.Join(names, ", "))
+	}
+
+	path := commandPath(node)
+	out := strings.Fields(path)
+	var problems []string
+
+	flagsByName := map[string]*kong.Flag{}
+	for _, group := range node.AllFlags(false) {
+		for _, f := range group {
+			flagsByName[f.Name] = f
+			for _, a := range f.Aliases {
+				flagsByName[a] = f
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(req.Flags))
+	for k := range req.Flags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	seen := map[string]bool{}
+	for _, key := range keys {
+		f, ok := flagsByName[strings.ReplaceAll(strings.TrimPrefix(key, "--"), "_", "-")]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("flags.%s: unknown flag for %q%s", key, path, suggestFlag(key, flagsByName)))
+			continue
+		}
+		if execOuterOnlyFlags[f.Name] {
+			problems = append(problems, fmt.Sprintf("flags.%s: not allowed in exec requests (set --%s on the outer command line)", key, f.Name))
+			continue
+		}
+		seen[f.Name] = true
+		rendered, err := execFlagArgs(f, req.Flags[key])
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("flags.%s: %v", key, err))
+			continue
+		}
+		out = append(out, rendered...)
+	}
+
+	for _, group := range node.AllFlags(false) {
+		for _, f := range group {
+			if f.Required && !seen[f.Name] {
+				problems = append(problems, fmt.Sprintf("flags.%s: required", f.Name))
+			}
+		}
+	}
+
+	positionals, posProblems := execPositionals(node, req.Args)
+	problems = append(problems, posProblems...)
+
+	if len(problems) > 0 {
+		return nil, usagef("exec %s: %s", path, strings.Join(problems, "; "))
+	}
+
+	if len(positionals) > 0 {
+		out = append(out, "--")
+		out = append(out, positionals...)
+	}
+	return out, nil
+}
+
+func execPositionals(node *kong.Node, values []any) ([]string, []string) {
+	var problems []string
+	out := make([]string, 0, len(values))
+	for i, v := range values {
+		s, err := execScalar(v)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("args[%d]: %v", i, err))
+			continue
+		}
+		out = append(out, s)
+	}
+
+	cumulative := len(node.Positional) > 0 && node.Positional[len(node.Positional)-1].IsCumulative()
+	if len(values) > len(node.Positional) && !cumulative {
+		problems = append(problems, fmt.Sprintf("args[%d]: unexpected argument (%q takes %d)", len(node.Positional), commandPath(node), len(node.Positional)))
+	}
+	for i, p := range node.Positional {
+		if p.Required && i >= len(values) {
+			problems = append(problems, fmt.Sprintf("args[%d] (<%s>): required", i, p.Name))
+		}
+	}
+	return out, problems
+}
+
+// execFlagArgs renders one JSON flag value as --name=value arguments.
+func execFlagArgs(f *kong.Flag, v any) ([]string, error) {
+	name := "--" + f.Name
+	if v == nil {
+		return nil, nil
+	}
+
+	switch {
+	case f.IsBool():
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected true or false, got %s", execTypeName(v))
+		}
+		if b {
+			return []string{name}, nil
+		}
+		return []string{name + "=false"}, nil
+	case f.IsMap():
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected an object, got %s", execTypeName(v))
+		}
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		out := make([]string, 0, len(keys))
+		for _, k := range keys {
+			s, err := execScalar(m[k])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+			out = append(out, name+"="+k+"="+s)
+		}
+		return out, nil
+	case f.IsSlice():
+		items, ok := v.([]any)
+		if !ok {
+			items = []any{v}
+		}
+		out := make([]string, 0, len(items))
+		for i, item := range items {
+			s, err := execFlagValue(f, item)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			out = append(out, name+"="+s)
+		}
+		return out, nil
+	default:
+		s, err := execFlagValue(f, v)
+		if err != nil {
+			return nil, err
+		}
+		return []string{name + "=" + s}, nil
+	}
+}
+
+func execFlagValue(f *kong.Flag, v any) (string, error) {
+	kind := f.Target.Kind()
+	if kind == reflect.Slice {
+		kind = f.Target.Type().Elem().Kind()
+	}
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, ok := execNumber(v); !ok {
+			return "", fmt.Errorf("expected an integer, got %s", execTypeName(v))
+		} else if _, err := n.Int64(); err != nil {
+			return "", fmt.Errorf("expected an integer, got %s", n)
+		}
+	case reflect.Float32, reflect.Float64:
+		if n, ok := execNumber(v); !ok {
+			return "", fmt.Errorf("expected a number, got %s", execTypeName(v))
+		} else if _, err := n.Float64(); err != nil {
+			return "", fmt.Errorf("expected a number, got %s", n)
+		}
+	}
+
+	s, err := execScalar(v)
+	if err != nil {
+		return "", err
+	}
+	if enum := sortedStrings(f.EnumSlice()); len(enum) > 0 && !slices.Contains(enum, s) {
+		return "", fmt.Errorf("%q is not one of %s", s, strings.Join(enum, ", "))
+	}
+	return s, nil
+}
+
+// execNumber accepts JSON numbers and numeric strings ("25").
+func execNumber(v any) (json.Number, bool) {
+	switch t := v.(type) {
+	case json.Number:
+		return t, true
+	case string:
+		return json.Number(strings.TrimSpace(t)), true
+	default:
+		return "", false
+	}
+}
+
+// execScalar renders a JSON value as a single argument. Objects and arrays
+// become compact JSON, for flags that take JSON documents.
+func execScalar(v any) (string, error) {
+	switch t := v.(type) {
+	case string:
+		return t, nil
+	case json.Number:
+		return t.String(), nil
+	case bool:
+		if t {
+			return "true", nil
+		}
+		return "false", nil
+	case nil:
+		return "", errors.New("null is not allowed here")
+	default:
+		b, err := json.Marshal(t)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+}
+
+func execTypeName(v any) string {
+	switch v.(type) {
+	case string:
+		return "a string"
+	case json.Number:
+		return "a number"
+	case bool:
+		return "a boolean"
+	case []any:
+		return "an array"
+	case map[string]any:
+		return "an object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+// suggestFlag returns ` (did you mean "x"?)` for the closest known flag name.
+func suggestFlag(key string, flags map[string]*kong.Flag) string {
+	key = strings.ToLower(strings.ReplaceAll(key, "_", "-"))
+	best, bestDist := "", 3
+	for name := range flags {
+		if d := levenshtein(key, name); d < bestDist || (d == bestDist && best != "" && name < best) {
+			best, bestDist = name, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %q?)", best)
+}
+
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
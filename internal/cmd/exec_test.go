@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kong"
+)
+
+type execTestSendCmd struct {
+	To      []string          `name:"to" help:"Recipients"`
+	Subject string            `name:"subject" required:""`
+	Max     int64             `name:"max" aliases:"limit" default:"10"`
+	Draft   bool              `name:"draft"`
+	Format  string            `name:"format" enum:"text,html" default:"text"`
+	Labels  map[string]string `name:"label"`
+	Payload string            `name:"payload-json"`
+	Query   []string          `arg:"" optional:"" name:"query"`
+}
+
+func (c *execTestSendCmd) Run() error { return nil }
+
+func execTestModel(t *testing.T) *kong.Node {
+	t.Helper()
+	var cli struct {
+		Account string `name:"account"`
+		Mail    struct {
+			Send execTestSendCmd `cmd:""`
+		} `cmd:""`
+	}
+	parser, err := kong.New(&cli, kong.Writers(io.Discard, io.Discard))
+	if err != nil {
+		t.Fatalf("kong.New: %v", err)
+	}
+	return parser.Model.Node
+}
+
+func TestBuildExecArgs_RendersFlagsAndArgs(t *testing.T) {
+	req, err := decodeExecRequest([]byte(`{
+		"command": "mail send",
+		"flags": {
+			"to": ["a@b.com", "c@d.com"],
+			"subject": "it's \"quoted\"",
+			"limit": 25,
+			"draft": false,
+			"format": "html",
+			"label": {"k": "v"},
+			"payload_json": {"x": [1, 2]},
+			"account": "me@x.com"
+		},
+		"args": ["-from:me", "is:unread"]
+	}`))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	got, err := buildExecArgs(execTestModel(t), req)
+	if err != nil {
+		t.Fatalf("buildExecArgs: %v", err)
+	}
+	want := []string{
+		"mail", "send",
+		"--account=me@x.com",
+		"--draft=false",
+		"--format=html",
+		"--label=k=v",
+		"--max=25",
+		`--payload-json={"x":[1,2]}`,
+		`--subject=it's "quoted"`,
+		"--to=a@b.com", "--to=c@d.com",
+		"--", "-from:me", "is:unread",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected argv:\n got %q\nwant %q", got, want)
+	}
+}
+
+func TestBuildExecArgs_NamesOffendingFields(t *testing.T) {
+	req, err := decodeExecRequest([]byte(`{"command":"mail send","flags":{"subjct":"x","max":"many","draft":"yes","format":"pdf"}}`))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	_, err = buildExecArgs(execTestModel(t), req)
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if ExitCode(err) != 2 {
+		t.Fatalf("expected usage exit code, got %d", ExitCode(err))
+	}
+	for _, want := range []string{
+		`flags.subjct: unknown flag for "mail send" (did you mean "subject"?)`,
+		"flags.max: expected an integer",
+		"flags.draft: expected true or false, got a string",
+		`flags.format: "pdf" is not one of html, text`,
+		"flags.subject: required",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("error %q missing %q", err, want)
+		}
+	}
+}
+
+func TestDecodeExecRequest_RejectsUnknownFields(t *testing.T) {
+	if _, err := decodeExecRequest([]byte(`{"command":"mail send","flag":{}}`)); err == nil || !strings.Contains(err.Error(), `unknown field "flag"`) {
+		t.Fatalf("expected unknown field error, got %v", err)
+	}
+	if _, err := decodeExecRequest([]byte(`{"flags":{}}`)); err == nil || !strings.Contains(err.Error(), "command: required") {
+		t.Fatalf("expected missing command error, got %v", err)
+	}
+}
+
+func TestExecute_Exec_FromFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+
+	path := filepath.Join(t.TempDir(), "req.json")
+	if err := os.WriteFile(path, []byte(`{"command":"time now","flags":{"timezone":"UTC"}}`), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	out := captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			if err := Execute([]string{"--plain", "exec", "@" + path}); err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+		})
+	})
+	if !strings.Contains(out, "timezone\tUTC") {
+		t.Fatalf("unexpected output: %q", out)
+	}
+}
+
+func TestExecute_Exec_KeepsOuterEnabledCommands(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+
+	for _, req := range []string{
+		`{"command":"auth status","flags":{"enable-commands":"auth"}}`,
+		`{"command":"auth status"}`,
+	} {
+		_ = captureStdout(t, func() {
+			_ = captureStderr(t, func() {
+				err := Execute([]string{"--enable-commands", "exec,version", "exec", req})
+				if ExitCode(err) != 2 {
+					t.Fatalf("%s: expected usage error, got %v", req, err)
+				}
+			})
+		})
+	}
+}
@@ -19,12 +19,17 @@ const (
 	// Exit code 2 is usage/parse error (see usage.go).
 	// Exit code 3 is empty results (see paging.go).
 
-	exitCodeAuthRequired     = 4
-	exitCodeNotFound         = 5
-	exitCodePermissionDenied = 6
-	exitCodeRateLimited      = 7
-	exitCodeRetryable        = 8
-	exitCodeConfig           = 10
+	exitCodeAuthRequired       = 4
+	exitCodeNotFound           = 5
+	exitCodePermissionDenied   = 6
+	exitCodeRateLimited        = 7
+	exitCodeRetryable          = 8
+	exitCodeInsufficientScopes = 9
+	exitCodeConfig             = 10
+	exitCodeAPIDisabled        = 11
+	exitCodeQuotaExhausted     = 12
+	exitCodeFailedPrecondition = 13
+	exitCodeDomainPolicy       = 14
 
 	// 130 is the conventional "interrupted" exit code (SIGINT / Ctrl-C).
 	exitCodeCancelled = 130
@@ -60,6 +65,11 @@ func stableExitCode(err error) error {
 		return &ExitError{Code: exitCodeAuthRequired, Err: err}
 	}
 
+	var scopeErr *gogapi.InsufficientScopesError
+	if errors.As(err, &scopeErr) {
+		return &ExitError{Code: exitCodeInsufficientScopes, Err: err}
+	}
+
 	var gerr *ggoogleapi.Error
 	if errors.As(err, &gerr) {
 		if code := googleAPIExitCode(gerr); code != 1 {
@@ -91,6 +101,20 @@ func googleAPIExitCode(err *ggoogleapi.Error) int {
 		return 1
 	}
 
+	// Reasons with a dedicated remediation get their own exit code.
+	switch gogapi.DecodeAPIError(err).Kind {
+	case gogapi.APIErrorKindAPIDisabled:
+		return exitCodeAPIDisabled
+	case gogapi.APIErrorKindDailyLimit:
+		return exitCodeQuotaExhausted
+	case gogapi.APIErrorKindFailedPrecondition:
+		return exitCodeFailedPrecondition
+	case gogapi.APIErrorKindDomainPolicy:
+		return exitCodeDomainPolicy
+	case gogapi.APIErrorKindInsufficientPermissions:
+		return exitCodeInsufficientScopes
+	}
+
 	// google.golang.org/api/googleapi.Error includes Code and a list of structured
 	// "reason" values; we map the common ones to stable exit codes.
 	reason := ""
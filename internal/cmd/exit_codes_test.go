@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/99designs/keyring"
@@ -31,6 +32,48 @@ func TestStableExitCode_AuthRequired(t *testing.T) {
 	}
 }
 
+func TestStableExitCode_InsufficientScopes(t *testing.T) {
+	in := &gogapi.InsufficientScopesError{
+		Service: "calendar",
+		Email:   "a@b.com",
+		Cause:   &ggoogleapi.Error{Code: 403, Errors: []ggoogleapi.ErrorItem{{Reason: "insufficientPermissions"}}},
+	}
+	out := stableExitCode(in)
+	if got := ExitCode(out); got != exitCodeInsufficientScopes {
+		t.Fatalf("expected exit code %d, got %d", exitCodeInsufficientScopes, got)
+	}
+	if fix := fixForError(out, exitCodeInsufficientScopes); fix != "Grant the missing scopes with `gog auth add a@b.com --services calendar`." {
+		t.Fatalf("unexpected fix: %q", fix)
+	}
+}
+
+func TestStableExitCode_GoogleAPIReasons(t *testing.T) {
+	cases := []struct {
+		code   int
+		reason string
+		want   int
+	}{
+		{403, "accessNotConfigured", exitCodeAPIDisabled},
+		{403, "dailyLimitExceeded", exitCodeQuotaExhausted},
+		{400, "failedPrecondition", exitCodeFailedPrecondition},
+		{403, "domainPolicy", exitCodeDomainPolicy},
+		{403, "insufficientPermissions", exitCodeInsufficientScopes},
+		{403, "rateLimitExceeded", exitCodeRateLimited},
+		{403, "forbidden", exitCodePermissionDenied},
+	}
+	for _, tc := range cases {
+		in := &ggoogleapi.Error{Code: tc.code, Message: "x", Errors: []ggoogleapi.ErrorItem{{Reason: tc.reason}}}
+		if got := ExitCode(stableExitCode(in)); got != tc.want {
+			t.Fatalf("%s: expected exit code %d, got %d", tc.reason, tc.want, got)
+		}
+	}
+
+	disabled := &ggoogleapi.Error{Code: 403, Message: "x", Errors: []ggoogleapi.ErrorItem{{Reason: "accessNotConfigured"}}}
+	if fix := fixForError(disabled, exitCodeAPIDisabled); !strings.Contains(fix, "console.cloud.google.com/apis/library") {
+		t.Fatalf("unexpected fix: %q", fix)
+	}
+}
+
 func TestStableExitCode_CredentialsMissing(t *testing.T) {
 	in := &config.CredentialsMissingError{Path: "/tmp/credentials.json", Cause: errors.New("missing")}
 	out := stableExitCode(in)
@@ -120,3 +120,11 @@ func exportViaDrive(ctx context.Context, flags *RootFlags, opts exportViaDriveOp
 	u.Out().Printf("size\t%s", formatDriveSize(size))
 	return nil
 }
+
+// exportOutput declares the {"path", "size"} result of downloads and exports.
+func exportOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Path string `json:"path"`
+		Size int64  `json:"size"`
+	}{}}
+}
@@ -62,6 +62,13 @@ func (c *FormsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*FormsGetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Form    *formsapi.Form `json:"form"`
+		EditURL string         `json:"edit_url"`
+	}{}}
+}
+
 type FormsCreateCmd struct {
 	Title       string `name:"title" help:"Form title" required:""`
 	Description string `name:"description" help:"Form description"`
@@ -114,6 +121,14 @@ func (c *FormsCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*FormsCreateCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Created bool           `json:"created"`
+		Form    *formsapi.Form `json:"form"`
+		EditURL string         `json:"edit_url"`
+	}{}}
+}
+
 type FormsResponsesListCmd struct {
 	FormID string `arg:"" name:"formId" help:"Form ID"`
 	Max    int    `name:"max" help:"Maximum responses" default:"20"`
@@ -174,6 +189,14 @@ func (c *FormsResponsesListCmd) Run(ctx context.Context, flags *RootFlags) error
 	return nil
 }
 
+func (*FormsResponsesListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "responses", Result: struct {
+		FormID        string                   `json:"form_id"`
+		Responses     []*formsapi.FormResponse `json:"responses"`
+		NextPageToken string                   `json:"nextPageToken"`
+	}{}}
+}
+
 type FormsResponseGetCmd struct {
 	FormID     string `arg:"" name:"formId" help:"Form ID"`
 	ResponseID string `arg:"" name:"responseId" help:"Response ID"`
@@ -221,6 +244,12 @@ func (c *FormsResponseGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*FormsResponseGetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Response *formsapi.FormResponse `json:"response"`
+	}{}}
+}
+
 func printFormSummary(u *ui.UI, form *formsapi.Form, fallbackID string) {
 	if u == nil || form == nil {
 		return
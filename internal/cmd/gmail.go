@@ -6,7 +6,6 @@ import (
 	"os"
 	"regexp"
 	"strings"
-	"sync"
 	"time"
 
 	"google.golang.org/api/gmail/v1"
@@ -14,6 +13,7 @@ import (
 	"github.com/steipete/gogcli/internal/googleapi"
 	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/internal/workpool"
 )
 
 var newGmailService = googleapi.NewGmail
@@ -26,13 +26,18 @@ type GmailCmd struct {
 	Attachment GmailAttachmentCmd `cmd:"" name:"attachment" group:"Read" help:"Download a single attachment"`
 	URL        GmailURLCmd        `cmd:"" name:"url" group:"Read" help:"Print Gmail web URLs for threads"`
 	History    GmailHistoryCmd    `cmd:"" name:"history" group:"Read" help:"Gmail history"`
+	Export     GmailExportCmd     `cmd:"" name:"export" group:"Read" help:"Export messages to mbox or a directory of .eml files (incremental)"`
 
-	Labels GmailLabelsCmd `cmd:"" name:"labels" aliases:"label" group:"Organize" help:"Label operations"`
-	Batch  GmailBatchCmd  `cmd:"" name:"batch" group:"Organize" help:"Batch operations"`
+	Labels      GmailLabelsCmd      `cmd:"" name:"labels" aliases:"label" group:"Organize" help:"Label operations"`
+	Batch       GmailBatchCmd       `cmd:"" name:"batch" group:"Organize" help:"Batch operations"`
+	Unsubscribe GmailUnsubscribeCmd `cmd:"" name:"unsubscribe" group:"Organize" help:"Unsubscribe via List-Unsubscribe (one-click POST or mailto), optionally filtering future mail"`
 
 	Send   GmailSendCmd   `cmd:"" name:"send" group:"Write" help:"Send an email"`
+	Outbox GmailOutboxCmd `cmd:"" name:"outbox" group:"Write" help:"Scheduled sends queued with 'gmail send --at'"`
 	Track  GmailTrackCmd  `cmd:"" name:"track" group:"Write" help:"Email open tracking"`
 	Drafts GmailDraftsCmd `cmd:"" name:"drafts" aliases:"draft" group:"Write" help:"Draft operations"`
+	Import GmailImportCmd `cmd:"" name:"import" group:"Write" help:"Import messages from mbox or .eml files (resumable)"`
+	Merge  GmailMergeCmd  `cmd:"" name:"merge" group:"Write" help:"Mail merge: send or draft templated messages per CSV/JSON row"`
 
 	Settings GmailSettingsCmd `cmd:"" name:"settings" group:"Admin" help:"Settings and admin"`
 
@@ -57,7 +62,9 @@ type GmailSettingsCmd struct {
 }
 
 type GmailSearchCmd struct {
-	Query     []string `arg:"" name:"query" help:"Search query"`
+	Query     []string `arg:"" optional:"" name:"query" help:"Search query"`
+	Since     string   `name:"since" help:"Only mail on/after this time (date, RFC3339, or natural: '2 weeks ago', 'last monday', 'last week'); adds after:"`
+	Until     string   `name:"until" help:"Only mail up to the end of this time (date, RFC3339, or natural: yesterday, 'end of last month'); adds before:"`
 	Max       int64    `name:"max" aliases:"limit" help:"Max results" default:"10"`
 	Page      string   `name:"page" aliases:"cursor" help:"Page token"`
 	All       bool     `name:"all" aliases:"all-pages,allpages" help:"Fetch all pages"`
@@ -73,7 +80,10 @@ func (c *GmailSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
-	query := strings.TrimSpace(strings.Join(c.Query, " "))
+	query, err := gmailDateQuery(strings.TrimSpace(strings.Join(c.Query, " ")), c.Since, c.Until, c.Timezone, c.Local)
+	if err != nil {
+		return err
+	}
 	if query == "" {
 		return usage("missing query")
 	}
@@ -178,6 +188,13 @@ func (c *GmailSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*GmailSearchCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "threads", Result: struct {
+		Threads       []threadItem `json:"threads"`
+		NextPageToken string       `json:"nextPageToken"`
+	}{}}
+}
+
 func firstMessage(t *gmail.Thread) *gmail.Message {
 	if t == nil || len(t.Messages) == 0 {
 		return nil
@@ -373,8 +390,8 @@ type threadItem struct {
 	MessageCount int      `json:"messageCount,omitempty"` // Number of messages in the thread
 }
 
-// fetchThreadDetails fetches thread metadata concurrently with bounded parallelism.
-// This eliminates N+1 queries by fetching all threads in parallel.
+// fetchThreadDetails fetches thread metadata concurrently with bounded parallelism
+// (--concurrency, default 10). This eliminates N+1 queries by fetching all threads in parallel.
 // When oldest is false (default), the date shown is from the last message in the thread.
 // When oldest is true, the date shown is from the first message in the thread.
 func fetchThreadDetails(ctx context.Context, svc *gmail.Service, threads []*gmail.Thread, idToName map[string]string, oldest bool, loc *time.Location) ([]threadItem, error) {
@@ -382,115 +399,51 @@ func fetchThreadDetails(ctx context.Context, svc *gmail.Service, threads []*gmai
 		return nil, nil
 	}
 
-	const maxConcurrency = 10 // Limit parallel requests to avoid rate limiting
-	sem := make(chan struct{}, maxConcurrency)
-
-	type result struct {
-		index int
-		item  threadItem
-		err   error
+	ids := make([]string, 0, len(threads))
+	for _, t := range threads {
+		if t.Id != "" {
+			ids = append(ids, t.Id)
+		}
 	}
 
-	results := make(chan result, len(threads))
-	var wg sync.WaitGroup
-
-	for i, t := range threads {
-		if t.Id == "" {
-			continue
+	items, err := workpool.Map(ctx, workpool.Limit(ctx, workpool.DefaultLimit), ids, func(ctx context.Context, threadID string) (threadItem, error) {
+		thread, err := svc.Users.Threads.Get("me", threadID).
+			Format("metadata").
+			MetadataHeaders("From", "Subject", "Date").
+			Context(ctx).
+			Do()
+		if err != nil {
+			return threadItem{}, err
 		}
 
-		wg.Add(1)
-		go func(idx int, threadID string) {
-			defer wg.Done()
-
-			// Acquire semaphore
-			select {
-			case sem <- struct{}{}:
-				defer func() { <-sem }()
-			case <-ctx.Done():
-				results <- result{index: idx, err: ctx.Err()}
-				return
-			}
-
-			thread, err := svc.Users.Threads.Get("me", threadID).
-				Format("metadata").
-				MetadataHeaders("From", "Subject", "Date").
-				Context(ctx).
-				Do()
-			if err != nil {
-				results <- result{index: idx, err: err}
-				return
-			}
-
-			item := threadItem{ID: threadID, MessageCount: len(thread.Messages)}
-			if first := firstMessage(thread); first != nil {
-				item.From = sanitizeTab(headerValue(first.Payload, "From"))
-				item.Subject = sanitizeTab(headerValue(first.Payload, "Subject"))
-				if len(first.LabelIds) > 0 {
-					names := make([]string, 0, len(first.LabelIds))
-					for _, lid := range first.LabelIds {
-						if n, ok := idToName[lid]; ok {
-							names = append(names, n)
-						} else {
-							names = append(names, lid)
-						}
+		item := threadItem{ID: threadID, MessageCount: len(thread.Messages)}
+		if first := firstMessage(thread); first != nil {
+			item.From = sanitizeTab(headerValue(first.Payload, "From"))
+			item.Subject = sanitizeTab(headerValue(first.Payload, "Subject"))
+			if len(first.LabelIds) > 0 {
+				names := make([]string, 0, len(first.LabelIds))
+				for _, lid := range first.LabelIds {
+					if n, ok := idToName[lid]; ok {
+						names = append(names, n)
+					} else {
+						names = append(names, lid)
 					}
-					item.Labels = names
 				}
+				item.Labels = names
 			}
-			// Date from newest message by default, oldest if --oldest
-			dateMsg := newestMessageByDate(thread)
-			if oldest {
-				dateMsg = oldestMessageByDate(thread)
-			}
-			if dateMsg != nil {
-				item.Date = formatGmailDateInLocation(headerValue(dateMsg.Payload, "Date"), loc)
-			}
-
-			results <- result{index: idx, item: item}
-		}(i, t.Id)
-	}
-
-	// Close results channel when all goroutines complete
-	go func() {
-		wg.Wait()
-		close(results)
-	}()
-
-	// Collect results in order
-	ordered := make([]threadItem, len(threads))
-	hasErr := false
-	for r := range results {
-		if r.err != nil {
-			hasErr = true
-			ordered[r.index] = threadItem{ID: "", Date: "", From: "", Subject: "", Labels: nil, MessageCount: 0}
-			continue
 		}
-		ordered[r.index] = r.item
-	}
-
-	if hasErr {
-		// Re-run sequentially to find and return the first actual error
-		for _, t := range threads {
-			if t.Id == "" {
-				continue
-			}
-			_, err := svc.Users.Threads.Get("me", t.Id).
-				Format("metadata").
-				MetadataHeaders("From", "Subject", "Date").
-				Context(ctx).
-				Do()
-			if err != nil {
-				return nil, err
-			}
+		// Date from newest message by default, oldest if --oldest
+		dateMsg := newestMessageByDate(thread)
+		if oldest {
+			dateMsg = oldestMessageByDate(thread)
 		}
-	}
-
-	items := make([]threadItem, 0, len(ordered))
-	for _, item := range ordered {
-		if item.ID != "" {
-			items = append(items, item)
+		if dateMsg != nil {
+			item.Date = formatGmailDateInLocation(headerValue(dateMsg.Payload, "Date"), loc)
 		}
+		return item, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return items, nil
 }
@@ -90,6 +90,14 @@ func (c *GmailAttachmentCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return printAttachmentDownloadResult(ctx, u, path, cached, bytes)
 }
 
+func (*GmailAttachmentCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Path   string `json:"path"`
+		Cached bool   `json:"cached"`
+		Bytes  int64  `json:"bytes"`
+	}{}}
+}
+
 type attachmentDest struct {
 	Path             string
 	EnsureDefaultDir bool
@@ -8,6 +8,7 @@ import (
 	"google.golang.org/api/gmail/v1"
 
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/internal/workpool"
 )
 
 type attachmentInfo struct {
@@ -118,7 +119,7 @@ func attachmentDownloadSummaries(attachments []attachmentDownloadOutput) []attac
 
 func attachmentDownloadDraftOutputs(attachments []attachmentDownloadOutput) []attachmentDownloadDraftOutput {
 	if len(attachments) == 0 {
-		return nil
+		return []attachmentDownloadDraftOutput{}
 	}
 	out := make([]attachmentDownloadDraftOutput, len(attachments))
 	for i, a := range attachments {
@@ -157,20 +158,18 @@ func downloadAttachmentOutputs(ctx context.Context, svc *gmail.Service, messageI
 	if len(attachments) == 0 {
 		return nil, nil
 	}
-	out := make([]attachmentDownloadOutput, 0, len(attachments))
-	for _, a := range attachments {
+	return workpool.Map(ctx, workpool.Limit(ctx, workpool.DefaultLimit), attachments, func(ctx context.Context, a attachmentInfo) (attachmentDownloadOutput, error) {
 		outPath, cached, err := downloadAttachment(ctx, svc, messageID, a, dir)
 		if err != nil {
-			return nil, err
+			return attachmentDownloadOutput{}, err
 		}
-		out = append(out, attachmentDownloadOutput{
+		return attachmentDownloadOutput{
 			MessageID:        messageID,
 			attachmentOutput: attachmentOutputFromInfo(a),
 			Path:             outPath,
 			Cached:           cached,
-		})
-	}
-	return out, nil
+		}, nil
+	})
 }
 
 func collectAttachments(p *gmail.MessagePart) []attachmentInfo {
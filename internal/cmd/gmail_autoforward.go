@@ -50,6 +50,12 @@ func (c *GmailAutoForwardGetCmd) Run(ctx context.Context, flags *RootFlags) erro
 	return nil
 }
 
+func (*GmailAutoForwardGetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		AutoForwarding *gmail.AutoForwarding `json:"autoForwarding"`
+	}{}}
+}
+
 type GmailAutoForwardUpdateCmd struct {
 	Enable      bool   `name:"enable" help:"Enable auto-forwarding"`
 	Disable     bool   `name:"disable" help:"Disable auto-forwarding"`
@@ -149,3 +155,9 @@ func (c *GmailAutoForwardUpdateCmd) Run(ctx context.Context, kctx *kong.Context,
 	}
 	return nil
 }
+
+func (*GmailAutoForwardUpdateCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		AutoForwarding *gmail.AutoForwarding `json:"autoForwarding"`
+	}{}}
+}
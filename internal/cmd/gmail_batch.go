@@ -66,6 +66,13 @@ func (c *GmailBatchDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*GmailBatchDeleteCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "deleted", Result: struct {
+		Deleted []string `json:"deleted"`
+		Count   int      `json:"count"`
+	}{}}
+}
+
 type GmailBatchModifyCmd struct {
 	MessageIDs []string `arg:"" name:"messageId" help:"Message IDs"`
 	Add        string   `name:"add" help:"Labels to add (comma-separated, name or ID)"`
@@ -138,3 +145,12 @@ func (c *GmailBatchModifyCmd) Run(ctx context.Context, flags *RootFlags) error {
 	u.Out().Printf("Modified %d messages", len(ids))
 	return nil
 }
+
+func (*GmailBatchModifyCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "modified", Result: struct {
+		Modified      []string `json:"modified"`
+		Count         int      `json:"count"`
+		AddedLabels   []string `json:"addedLabels"`
+		RemovedLabels []string `json:"removedLabels"`
+	}{}}
+}
@@ -35,3 +35,29 @@ func resolveBodyInput(body, bodyFile string) (string, error) {
 	}
 	return string(b), nil
 }
+
+// resolveComposeBodies returns the plain and HTML bodies for send/drafts.
+// A Markdown body (--body-markdown/--body-md-file) replaces --body,
+// --body-file and --body-html, and renders into both parts.
+func resolveComposeBodies(body, bodyFile, bodyHTML, markdown, markdownFile string) (string, string, error) {
+	if strings.TrimSpace(markdown) == "" && strings.TrimSpace(markdownFile) == "" {
+		plain, err := resolveBodyInput(body, bodyFile)
+		return plain, bodyHTML, err
+	}
+	if strings.TrimSpace(body) != "" || strings.TrimSpace(bodyFile) != "" || strings.TrimSpace(bodyHTML) != "" {
+		return "", "", usage("use --body-markdown/--body-md-file instead of --body, --body-file or --body-html")
+	}
+	if strings.TrimSpace(markdown) != "" && strings.TrimSpace(markdownFile) != "" {
+		return "", "", usage("use only one of --body-markdown or --body-md-file")
+	}
+
+	md, err := resolveBodyInput(markdown, markdownFile)
+	if err != nil {
+		return "", "", err
+	}
+	if strings.TrimSpace(md) == "" {
+		return "", "", usage("empty Markdown body")
+	}
+
+	return renderMarkdownEmail(md)
+}
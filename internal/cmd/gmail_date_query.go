@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/steipete/gogcli/internal/timeparse"
+)
+
+// gmailDateQuery appends after:/before: terms for --since/--until. Values are
+// parsed in the output timezone (so "yesterday" means the user's yesterday,
+// not Gmail's Pacific-time date) and sent as epoch seconds. A span in --since
+// ("last week") also bounds the end when --until is empty.
+func gmailDateQuery(query, since, until, timezone string, local bool) (string, error) {
+	since = strings.TrimSpace(since)
+	until = strings.TrimSpace(until)
+	if since == "" && until == "" {
+		return query, nil
+	}
+
+	loc, err := resolveOutputLocation(timezone, local)
+	if err != nil {
+		return "", err
+	}
+	weekStart, err := resolveWeekStart("")
+	if err != nil {
+		return "", err
+	}
+	opts := timeparse.Options{Now: calendarNow().In(loc), Location: loc, WeekStart: weekStart}
+
+	terms := []string{}
+	if query != "" {
+		terms = append(terms, query)
+	}
+
+	var sinceSpan timeparse.Span
+	if since != "" {
+		sinceSpan, err = timeparse.ParseNatural(since, opts)
+		if err != nil {
+			return "", usagef("invalid --since: %v", err)
+		}
+		terms = append(terms, "after:"+strconv.FormatInt(sinceSpan.Start.Unix(), 10))
+	}
+
+	switch {
+	case until != "":
+		untilSpan, err := timeparse.ParseNatural(until, opts)
+		if err != nil {
+			return "", usagef("invalid --until: %v", err)
+		}
+		terms = append(terms, "before:"+strconv.FormatInt(spanUpperBound(untilSpan).Unix()+1, 10))
+	case sinceSpan.IsRange:
+		terms = append(terms, "before:"+strconv.FormatInt(sinceSpan.End.Unix()+1, 10))
+	}
+
+	return strings.Join(terms, " "), nil
+}
@@ -59,6 +59,12 @@ func (c *GmailDelegatesListCmd) Run(ctx context.Context, flags *RootFlags) error
 	return nil
 }
 
+func (*GmailDelegatesListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "delegates", Result: struct {
+		Delegates []*gmail.Delegate `json:"delegates"`
+	}{}}
+}
+
 type GmailDelegatesGetCmd struct {
 	DelegateEmail string `arg:"" name:"delegateEmail" help:"Delegate email"`
 }
@@ -93,6 +99,12 @@ func (c *GmailDelegatesGetCmd) Run(ctx context.Context, flags *RootFlags) error
 	return nil
 }
 
+func (*GmailDelegatesGetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Delegate *gmail.Delegate `json:"delegate"`
+	}{}}
+}
+
 type GmailDelegatesAddCmd struct {
 	DelegateEmail string `arg:"" name:"delegateEmail" help:"Delegate email"`
 }
@@ -140,6 +152,12 @@ func (c *GmailDelegatesAddCmd) Run(ctx context.Context, flags *RootFlags) error
 	return nil
 }
 
+func (*GmailDelegatesAddCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Delegate *gmail.Delegate `json:"delegate"`
+	}{}}
+}
+
 type GmailDelegatesRemoveCmd struct {
 	DelegateEmail string `arg:"" name:"delegateEmail" help:"Delegate email"`
 }
@@ -180,3 +198,10 @@ func (c *GmailDelegatesRemoveCmd) Run(ctx context.Context, flags *RootFlags) err
 	u.Out().Printf("Delegate %s removed successfully", delegateEmail)
 	return nil
 }
+
+func (*GmailDelegatesRemoveCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Success       bool   `json:"success"`
+		DelegateEmail string `json:"delegateEmail"`
+	}{}}
+}
@@ -30,6 +30,12 @@ type GmailDraftsListCmd struct {
 	FailEmpty bool   `name:"fail-empty" aliases:"non-empty,require-results" help:"Exit with code 3 if no results"`
 }
 
+type draftListItem struct {
+	ID        string `json:"id"`
+	MessageID string `json:"messageId,omitempty"`
+	ThreadID  string `json:"threadId,omitempty"`
+}
+
 func (c *GmailDraftsListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	u := ui.FromContext(ctx)
 	account, err := requireAccount(flags)
@@ -70,12 +76,7 @@ func (c *GmailDraftsListCmd) Run(ctx context.Context, flags *RootFlags) error {
 		}
 	}
 	if outfmt.IsJSON(ctx) {
-		type item struct {
-			ID        string `json:"id"`
-			MessageID string `json:"messageId,omitempty"`
-			ThreadID  string `json:"threadId,omitempty"`
-		}
-		items := make([]item, 0, len(drafts))
+		items := make([]draftListItem, 0, len(drafts))
 		for _, d := range drafts {
 			if d == nil {
 				continue
@@ -85,7 +86,7 @@ func (c *GmailDraftsListCmd) Run(ctx context.Context, flags *RootFlags) error {
 				msgID = d.Message.Id
 				threadID = d.Message.ThreadId
 			}
-			items = append(items, item{ID: d.Id, MessageID: msgID, ThreadID: threadID})
+			items = append(items, draftListItem{ID: d.Id, MessageID: msgID, ThreadID: threadID})
 		}
 		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
 			"drafts":        items,
@@ -117,6 +118,13 @@ func (c *GmailDraftsListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*GmailDraftsListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "drafts", Result: struct {
+		Drafts        []draftListItem `json:"drafts"`
+		NextPageToken string          `json:"nextPageToken"`
+	}{}}
+}
+
 type GmailDraftsGetCmd struct {
 	DraftID  string `arg:"" name:"draftId" help:"Draft ID"`
 	Download bool   `name:"download" help:"Download draft attachments"`
@@ -205,6 +213,13 @@ func (c *GmailDraftsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*GmailDraftsGetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Draft      *gmail.Draft                    `json:"draft"`
+		Downloaded []attachmentDownloadDraftOutput `json:"downloaded,omitempty"`
+	}{}}
+}
+
 type GmailDraftsDeleteCmd struct {
 	DraftID string `arg:"" name:"draftId" help:"Draft ID"`
 }
@@ -239,6 +254,13 @@ func (c *GmailDraftsDeleteCmd) Run(ctx context.Context, flags *RootFlags) error
 	)
 }
 
+func (*GmailDraftsDeleteCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Deleted bool   `json:"deleted"`
+		DraftID string `json:"draftId"`
+	}{}}
+}
+
 type GmailDraftsSendCmd struct {
 	DraftID string `arg:"" name:"draftId" help:"Draft ID"`
 }
@@ -283,6 +305,13 @@ func (c *GmailDraftsSendCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*GmailDraftsSendCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		MessageID string `json:"messageId"`
+		ThreadID  string `json:"threadId"`
+	}{}}
+}
+
 type GmailDraftsCreateCmd struct {
 	To               string   `name:"to" help:"Recipients (comma-separated)"`
 	Cc               string   `name:"cc" help:"CC recipients (comma-separated)"`
@@ -291,9 +320,12 @@ type GmailDraftsCreateCmd struct {
 	Body             string   `name:"body" help:"Body (plain text; required unless --body-html is set)"`
 	BodyFile         string   `name:"body-file" help:"Body file path (plain text; '-' for stdin)"`
 	BodyHTML         string   `name:"body-html" help:"Body (HTML; optional)"`
+	BodyMarkdown     string   `name:"body-markdown" aliases:"body-md" help:"Body (Markdown; rendered to styled HTML with a plain-text alternative)"`
+	BodyMDFile       string   `name:"body-md-file" help:"Markdown body file path ('-' for stdin)"`
 	ReplyToMessageID string   `name:"reply-to-message-id" help:"Reply to Gmail message ID (sets In-Reply-To/References and thread)"`
 	ReplyTo          string   `name:"reply-to" help:"Reply-To header address"`
 	Attach           []string `name:"attach" help:"Attachment file path (repeatable)"`
+	Inline           []string `name:"inline" help:"Inline image as name=path, referenced as cid:name from the HTML body (repeatable)"`
 	From             string   `name:"from" help:"Send from this email address (must be a verified send-as alias)"`
 }
 
@@ -308,6 +340,7 @@ type draftComposeInput struct {
 	ReplyToThreadID  string
 	ReplyTo          string
 	Attach           []string
+	Inline           []mailAttachment
 	From             string
 }
 
@@ -316,7 +349,7 @@ func (c draftComposeInput) validate() error {
 		return usage("required: --subject")
 	}
 	if strings.TrimSpace(c.Body) == "" && strings.TrimSpace(c.BodyHTML) == "" {
-		return usage("required: --body, --body-file, or --body-html")
+		return usage("required: --body, --body-file, --body-html, or --body-markdown")
 	}
 	return nil
 }
@@ -366,6 +399,7 @@ func buildDraftMessage(ctx context.Context, svc *gmail.Service, account string,
 		InReplyTo:   inReplyTo,
 		References:  references,
 		Attachments: atts,
+		Inline:      input.Inline,
 	}, &rfc822Config{allowMissingTo: true})
 	if err != nil {
 		return nil, "", err
@@ -381,6 +415,15 @@ func buildDraftMessage(ctx context.Context, svc *gmail.Service, account string,
 	return msg, threadID, nil
 }
 
+// draftResultOutput declares what writeDraftResult prints.
+func draftResultOutput() commandOutput {
+	return commandOutput{Result: struct {
+		DraftID  string         `json:"draftId"`
+		Message  *gmail.Message `json:"message"`
+		ThreadID string         `json:"threadId"`
+	}{}}
+}
+
 func writeDraftResult(ctx context.Context, u *ui.UI, draft *gmail.Draft, threadID string) error {
 	if threadID == "" && draft != nil && draft.Message != nil {
 		threadID = draft.Message.ThreadId
@@ -405,7 +448,11 @@ func writeDraftResult(ctx context.Context, u *ui.UI, draft *gmail.Draft, threadI
 func (c *GmailDraftsCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	u := ui.FromContext(ctx)
 
-	body, err := resolveBodyInput(c.Body, c.BodyFile)
+	body, bodyHTML, err := resolveComposeBodies(c.Body, c.BodyFile, c.BodyHTML, c.BodyMarkdown, c.BodyMDFile)
+	if err != nil {
+		return err
+	}
+	bodyHTML, inline, err := resolveInlineImages(c.Inline, bodyHTML, c.BodyMarkdown != "" || c.BodyMDFile != "", c.BodyMDFile)
 	if err != nil {
 		return err
 	}
@@ -426,11 +473,12 @@ func (c *GmailDraftsCreateCmd) Run(ctx context.Context, flags *RootFlags) error
 		Bcc:              c.Bcc,
 		Subject:          c.Subject,
 		Body:             body,
-		BodyHTML:         c.BodyHTML,
+		BodyHTML:         bodyHTML,
 		ReplyToMessageID: replyToMessageID,
 		ReplyToThreadID:  "",
 		ReplyTo:          c.ReplyTo,
 		Attach:           attachPaths,
+		Inline:           inline,
 		From:             c.From,
 	}
 	if validateErr := input.validate(); validateErr != nil {
@@ -448,6 +496,7 @@ func (c *GmailDraftsCreateCmd) Run(ctx context.Context, flags *RootFlags) error
 		"reply_to":            strings.TrimSpace(input.ReplyTo),
 		"from":                strings.TrimSpace(input.From),
 		"attachments":         attachPaths,
+		"inline":              inlineOutputs(inline),
 	}); dryRunErr != nil {
 		return dryRunErr
 	}
@@ -474,6 +523,10 @@ func (c *GmailDraftsCreateCmd) Run(ctx context.Context, flags *RootFlags) error
 	return writeDraftResult(ctx, u, draft, threadID)
 }
 
+func (*GmailDraftsCreateCmd) jsonOutput() commandOutput {
+	return draftResultOutput()
+}
+
 type GmailDraftsUpdateCmd struct {
 	DraftID          string   `arg:"" name:"draftId" help:"Draft ID"`
 	To               *string  `name:"to" help:"Recipients (comma-separated; omit to keep existing)"`
@@ -483,9 +536,12 @@ type GmailDraftsUpdateCmd struct {
 	Body             string   `name:"body" help:"Body (plain text; required unless --body-html is set)"`
 	BodyFile         string   `name:"body-file" help:"Body file path (plain text; '-' for stdin)"`
 	BodyHTML         string   `name:"body-html" help:"Body (HTML; optional)"`
+	BodyMarkdown     string   `name:"body-markdown" aliases:"body-md" help:"Body (Markdown; rendered to styled HTML with a plain-text alternative)"`
+	BodyMDFile       string   `name:"body-md-file" help:"Markdown body file path ('-' for stdin)"`
 	ReplyToMessageID string   `name:"reply-to-message-id" help:"Reply to Gmail message ID (sets In-Reply-To/References and thread)"`
 	ReplyTo          string   `name:"reply-to" help:"Reply-To header address"`
 	Attach           []string `name:"attach" help:"Attachment file path (repeatable)"`
+	Inline           []string `name:"inline" help:"Inline image as name=path, referenced as cid:name from the HTML body (repeatable)"`
 	From             string   `name:"from" help:"Send from this email address (must be a verified send-as alias)"`
 }
 
@@ -503,7 +559,11 @@ func (c *GmailDraftsUpdateCmd) Run(ctx context.Context, flags *RootFlags) error
 		to = *c.To
 	}
 
-	body, err := resolveBodyInput(c.Body, c.BodyFile)
+	body, bodyHTML, err := resolveComposeBodies(c.Body, c.BodyFile, c.BodyHTML, c.BodyMarkdown, c.BodyMDFile)
+	if err != nil {
+		return err
+	}
+	bodyHTML, inline, err := resolveInlineImages(c.Inline, bodyHTML, c.BodyMarkdown != "" || c.BodyMDFile != "", c.BodyMDFile)
 	if err != nil {
 		return err
 	}
@@ -524,11 +584,12 @@ func (c *GmailDraftsUpdateCmd) Run(ctx context.Context, flags *RootFlags) error
 		Bcc:              c.Bcc,
 		Subject:          c.Subject,
 		Body:             body,
-		BodyHTML:         c.BodyHTML,
+		BodyHTML:         bodyHTML,
 		ReplyToMessageID: replyToMessageID,
 		ReplyToThreadID:  "",
 		ReplyTo:          c.ReplyTo,
 		Attach:           attachPaths,
+		Inline:           inline,
 		From:             c.From,
 	}
 	if validateErr := input.validate(); validateErr != nil {
@@ -548,6 +609,7 @@ func (c *GmailDraftsUpdateCmd) Run(ctx context.Context, flags *RootFlags) error
 		"reply_to":            strings.TrimSpace(input.ReplyTo),
 		"from":                strings.TrimSpace(input.From),
 		"attachments":         attachPaths,
+		"inline":              inlineOutputs(inline),
 	}); dryRunErr != nil {
 		return dryRunErr
 	}
@@ -599,3 +661,7 @@ func (c *GmailDraftsUpdateCmd) Run(ctx context.Context, flags *RootFlags) error
 	}
 	return writeDraftResult(ctx, u, draft, threadID)
 }
+
+func (*GmailDraftsUpdateCmd) jsonOutput() commandOutput {
+	return draftResultOutput()
+}
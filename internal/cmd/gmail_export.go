@@ -0,0 +1,569 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/api/gmail/v1"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/internal/workpool"
+)
+
+const (
+	gmailExportFormatMbox   = "mbox"
+	gmailExportFormatEMLDir = "eml-dir"
+
+	gmailExportStateVersion = 1
+	// gmailExportBatch bounds how many raw messages are held in memory; state
+	// is saved after each batch so an interrupted export resumes.
+	gmailExportBatch = 50
+	// gmailExportQuerySlack widens the after: bound of incremental query
+	// checks, since Gmail's after: works on whole days in some locales.
+	gmailExportQuerySlack = 48 * time.Hour
+)
+
+type GmailExportCmd struct {
+	Query            string `name:"query" short:"q" help:"Gmail search query selecting the messages to export (default: all mail)"`
+	Format           string `name:"format" help:"Output format: mbox (one file) or eml-dir (directory of .eml files)" enum:"mbox,eml-dir" default:"mbox"`
+	Out              string `name:"out" aliases:"output" required:"" help:"mbox file or .eml directory to write"`
+	State            string `name:"state" help:"State file recording the last historyId and exported IDs (default: <out>.gog-export.json, or <out>/.gog-export.json for eml-dir)"`
+	Full             bool   `name:"full" help:"Ignore saved state and export everything matching the query again (rewrites the mbox)"`
+	Max              int64  `name:"max" aliases:"limit" help:"Max messages in a full export (0 = no limit)" default:"0"`
+	IncludeSpamTrash bool   `name:"include-spam-trash" help:"Include messages from SPAM and TRASH"`
+}
+
+// gmailExportState is persisted next to the export so re-runs only fetch
+// messages added since HistoryID.
+type gmailExportState struct {
+	Version   int       `json:"version"`
+	Account   string    `json:"account"`
+	Query     string    `json:"query"`
+	Format    string    `json:"format"`
+	HistoryID string    `json:"historyId"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Exported  []string  `json:"exported"`
+}
+
+type gmailExportResult struct {
+	Account   string `json:"account"`
+	Format    string `json:"format"`
+	Out       string `json:"out"`
+	State     string `json:"state"`
+	Mode      string `json:"mode"`
+	Exported  int    `json:"exported"`
+	Skipped   int    `json:"skipped"`
+	Total     int    `json:"total"`
+	HistoryID string `json:"historyId"`
+}
+
+type gmailExportMessage struct {
+	ID       string
+	Raw      []byte
+	Labels   []string
+	Received time.Time
+}
+
+func (c *GmailExportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+
+	out, err := config.ExpandPath(strings.TrimSpace(c.Out))
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		return usage("empty --out")
+	}
+	format := strings.ToLower(strings.TrimSpace(c.Format))
+	statePath, err := c.statePath(out, format)
+	if err != nil {
+		return err
+	}
+	query := strings.TrimSpace(c.Query)
+
+	if err := dryRunExit(ctx, flags, "gmail.export", map[string]any{
+		"query":  query,
+		"format": format,
+		"out":    out,
+		"state":  statePath,
+		"full":   c.Full,
+	}); err != nil {
+		return err
+	}
+
+	state, err := loadGmailExportState(statePath)
+	if err != nil {
+		return err
+	}
+	if state != nil && !c.Full {
+		if state.Query != query || state.Format != format {
+			return usagef("state %s was written for --query %q --format %s; use --full or another --state", statePath, state.Query, state.Format)
+		}
+	}
+	if state == nil || c.Full {
+		state = &gmailExportState{Version: gmailExportStateVersion, Account: account, Query: query, Format: format}
+	}
+
+	svc, err := newGmailService(ctx, account)
+	if err != nil {
+		return err
+	}
+	labels, err := fetchLabelIDToName(svc)
+	if err != nil {
+		return err
+	}
+
+	w, err := openGmailExportWriter(out, format, c.Full)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	res := gmailExportResult{Account: account, Format: format, Out: out, State: statePath, Mode: indexModeIncremental}
+	ids, historyID, err := c.incrementalIDs(ctx, svc, state)
+	if err != nil {
+		return err
+	}
+	if ids == nil {
+		res.Mode = indexModeFull
+		ids, historyID, err = c.fullIDs(ctx, svc)
+		if err != nil {
+			return err
+		}
+	}
+
+	exported := make(map[string]bool, len(state.Exported))
+	for _, id := range state.Exported {
+		exported[id] = true
+	}
+	pending := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if exported[id] {
+			res.Skipped++
+			continue
+		}
+		pending = append(pending, id)
+	}
+
+	for start := 0; start < len(pending); start += gmailExportBatch {
+		batch := pending[start:min(start+gmailExportBatch, len(pending))]
+		msgs, err := fetchGmailExportMessages(ctx, svc, batch, labels, c.IncludeSpamTrash)
+		if err != nil {
+			return err
+		}
+		for _, m := range msgs {
+			if m == nil {
+				res.Skipped++
+				continue
+			}
+			if err := w.Write(m); err != nil {
+				return err
+			}
+			state.Exported = append(state.Exported, m.ID)
+			res.Exported++
+		}
+		if err := w.Sync(); err != nil {
+			return err
+		}
+		if err := saveGmailExportState(statePath, state); err != nil {
+			return err
+		}
+	}
+
+	if historyID != "" {
+		state.HistoryID = historyID
+	}
+	if err := saveGmailExportState(statePath, state); err != nil {
+		return err
+	}
+	res.Total = len(state.Exported)
+	res.HistoryID = state.HistoryID
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, res)
+	}
+
+	u.Out().Printf("mode\t%s", res.Mode)
+	u.Out().Printf("exported\t%d", res.Exported)
+	u.Out().Printf("skipped\t%d", res.Skipped)
+	u.Out().Printf("total\t%d", res.Total)
+	u.Out().Printf("out\t%s", res.Out)
+	u.Out().Printf("state\t%s", res.State)
+	u.Out().Printf("history_id\t%s", res.HistoryID)
+	return nil
+}
+
+func (*GmailExportCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: gmailExportResult{}}
+}
+
+func (c *GmailExportCmd) statePath(out, format string) (string, error) {
+	if s := strings.TrimSpace(c.State); s != "" {
+		return config.ExpandPath(s)
+	}
+	if format == gmailExportFormatEMLDir {
+		return filepath.Join(out, ".gog-export.json"), nil
+	}
+
+	return out + ".gog-export.json", nil
+}
+
+// incrementalIDs returns the messages added since the saved historyId that
+// match the query, oldest first. It returns nil IDs when a full export is
+// needed (no state, or the history has expired).
+func (c *GmailExportCmd) incrementalIDs(ctx context.Context, svc *gmail.Service, state *gmailExportState) ([]string, string, error) {
+	if state.HistoryID == "" {
+		return nil, "", nil
+	}
+
+	historyID, added, err := listGmailAddedSince(ctx, svc, state.HistoryID)
+	if err != nil {
+		if isStaleHistoryError(err) {
+			return nil, "", nil
+		}
+		return nil, "", err
+	}
+	if len(added) == 0 || state.Query == "" {
+		return nonNilStrings(added), historyID, nil
+	}
+
+	// History has no query filter: keep only new messages the query matches,
+	// bounded to the time since the last export.
+	since := state.UpdatedAt.Add(-gmailExportQuerySlack)
+	q := fmt.Sprintf("(%s) after:%d", state.Query, since.Unix())
+	matching, err := listGmailExportIDs(ctx, svc, q, 0, c.IncludeSpamTrash)
+	if err != nil {
+		return nil, "", err
+	}
+	match := make(map[string]bool, len(matching))
+	for _, id := range matching {
+		match[id] = true
+	}
+	out := make([]string, 0, len(added))
+	for _, id := range added {
+		if match[id] {
+			out = append(out, id)
+		}
+	}
+
+	return out, historyID, nil
+}
+
+// listGmailAddedSince returns the new history ID and the IDs of messages
+// added since startID, in history order.
+func listGmailAddedSince(ctx context.Context, svc *gmail.Service, startID string) (string, []string, error) {
+	start, err := parseHistoryID(startID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	historyID := startID
+	var ids []string
+	seen := map[string]bool{}
+	_, err = collectAllPages("", func(pageToken string) ([]struct{}, string, error) {
+		call := svc.Users.History.List("me").StartHistoryId(start).MaxResults(500).HistoryTypes("messageAdded")
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Context(ctx).Do()
+		if err != nil {
+			return nil, "", err
+		}
+		if resp.HistoryId != 0 {
+			historyID = formatHistoryID(resp.HistoryId)
+		}
+		for _, h := range resp.History {
+			if h == nil {
+				continue
+			}
+			for _, added := range h.MessagesAdded {
+				if added == nil || added.Message == nil || added.Message.Id == "" || seen[added.Message.Id] {
+					continue
+				}
+				seen[added.Message.Id] = true
+				ids = append(ids, added.Message.Id)
+			}
+		}
+		return nil, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return historyID, ids, nil
+}
+
+func (c *GmailExportCmd) fullIDs(ctx context.Context, svc *gmail.Service) ([]string, string, error) {
+	// Take the history cursor before listing, so messages arriving meanwhile
+	// are picked up by the next incremental run.
+	profile, err := svc.Users.GetProfile("me").Context(ctx).Do()
+	if err != nil {
+		return nil, "", err
+	}
+	ids, err := listGmailExportIDs(ctx, svc, strings.TrimSpace(c.Query), c.Max, c.IncludeSpamTrash)
+	if err != nil {
+		return nil, "", err
+	}
+	slices.Reverse(ids) // oldest first
+
+	return nonNilStrings(ids), formatHistoryID(profile.HistoryId), nil
+}
+
+func listGmailExportIDs(ctx context.Context, svc *gmail.Service, query string, maxMessages int64, includeSpamTrash bool) ([]string, error) {
+	var ids []string
+	pageToken := ""
+	for maxMessages <= 0 || int64(len(ids)) < maxMessages {
+		pageSize := int64(500)
+		if maxMessages > 0 {
+			pageSize = min(pageSize, maxMessages-int64(len(ids)))
+		}
+		call := svc.Users.Messages.List("me").MaxResults(pageSize).IncludeSpamTrash(includeSpamTrash)
+		if query != "" {
+			call = call.Q(query)
+		}
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Context(ctx).Do()
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range resp.Messages {
+			if m != nil && m.Id != "" {
+				ids = append(ids, m.Id)
+			}
+		}
+		if resp.NextPageToken == "" || resp.NextPageToken == pageToken {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+
+	return ids, nil
+}
+
+// fetchGmailExportMessages downloads raw messages; entries are nil for
+// messages deleted since they were listed, and for spam/trash unless
+// includeSpamTrash is set.
+func fetchGmailExportMessages(ctx context.Context, svc *gmail.Service, ids []string, labels map[string]string, includeSpamTrash bool) ([]*gmailExportMessage, error) {
+	return workpool.Map(ctx, workpool.Limit(ctx, workpool.DefaultLimit), ids, func(ctx context.Context, id string) (*gmailExportMessage, error) {
+		msg, err := svc.Users.Messages.Get("me", id).Format(gmailFormatRaw).Context(ctx).Do()
+		if err != nil {
+			if isNotFoundAPIError(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		if !includeSpamTrash && (slices.Contains(msg.LabelIds, "SPAM") || slices.Contains(msg.LabelIds, "TRASH")) {
+			return nil, nil
+		}
+		raw, err := decodeBase64URLBytes(msg.Raw)
+		if err != nil {
+			return nil, fmt.Errorf("decode message %s: %w", id, err)
+		}
+
+		names := make([]string, 0, len(msg.LabelIds))
+		for _, lid := range msg.LabelIds {
+			if name, ok := labels[lid]; ok {
+				names = append(names, name)
+			} else {
+				names = append(names, lid)
+			}
+		}
+
+		m := &gmailExportMessage{ID: msg.Id, Raw: raw, Labels: names}
+		if msg.InternalDate > 0 {
+			m.Received = time.UnixMilli(msg.InternalDate).UTC()
+		}
+		return m, nil
+	})
+}
+
+type gmailExportWriter interface {
+	Write(m *gmailExportMessage) error
+	Sync() error
+	Close() error
+}
+
+func openGmailExportWriter(out, format string, truncate bool) (gmailExportWriter, error) {
+	switch format {
+	case gmailExportFormatMbox:
+		if err := os.MkdirAll(filepath.Dir(out), 0o700); err != nil {
+			return nil, err
+		}
+		flag := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+		if truncate {
+			flag |= os.O_TRUNC
+		}
+		f, err := os.OpenFile(out, flag, 0o600) //nolint:gosec // user-provided export path
+		if err != nil {
+			return nil, err
+		}
+		return &mboxWriter{f: f, w: bufio.NewWriter(f)}, nil
+	case gmailExportFormatEMLDir:
+		if err := os.MkdirAll(out, 0o700); err != nil {
+			return nil, err
+		}
+		return &emlDirWriter{dir: out}, nil
+	default:
+		return nil, usagef("invalid --format %q (expected mbox|eml-dir)", format)
+	}
+}
+
+// mboxWriter appends messages in mboxrd format: a "From " separator line,
+// LF line endings and ">"-quoting of body lines starting with ">*From ".
+type mboxWriter struct {
+	f *os.File
+	w *bufio.Writer
+}
+
+func (m *mboxWriter) Write(msg *gmailExportMessage) error {
+	received := msg.Received
+	if received.IsZero() {
+		received = time.Unix(0, 0).UTC()
+	}
+	if _, err := fmt.Fprintf(m.w, "From MAILER-DAEMON %s\n", received.Format(time.ANSIC)); err != nil {
+		return err
+	}
+
+	data := withGmailLabelsHeader(msg.Raw, msg.Labels)
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	for _, line := range bytes.SplitAfter(data, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		if bytes.HasPrefix(bytes.TrimLeft(line, ">"), []byte("From ")) {
+			if err := m.w.WriteByte('>'); err != nil {
+				return err
+			}
+		}
+		if _, err := m.w.Write(line); err != nil {
+			return err
+		}
+	}
+	if !bytes.HasSuffix(data, []byte("\n")) {
+		if err := m.w.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+
+	return m.w.WriteByte('\n')
+}
+
+func (m *mboxWriter) Sync() error {
+	if err := m.w.Flush(); err != nil {
+		return err
+	}
+
+	return m.f.Sync()
+}
+
+func (m *mboxWriter) Close() error {
+	flushErr := m.w.Flush()
+	closeErr := m.f.Close()
+
+	return errors.Join(flushErr, closeErr)
+}
+
+// emlDirWriter writes <id>.eml files, delivering each through a temp file
+// and rename like Maildir, with the file time set to the received time.
+type emlDirWriter struct {
+	dir string
+}
+
+func (e *emlDirWriter) Write(msg *gmailExportMessage) error {
+	path := filepath.Join(e.dir, msg.ID+".eml")
+	tmp := filepath.Join(e.dir, ".tmp-"+msg.ID)
+	if err := os.WriteFile(tmp, withGmailLabelsHeader(msg.Raw, msg.Labels), 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	if !msg.Received.IsZero() {
+		_ = os.Chtimes(path, msg.Received, msg.Received)
+	}
+
+	return nil
+}
+
+func (e *emlDirWriter) Sync() error  { return nil }
+func (e *emlDirWriter) Close() error { return nil }
+
+// withGmailLabelsHeader prepends an X-Gmail-Labels header (as in Google
+// Takeout), using the line ending of the raw message.
+func withGmailLabelsHeader(raw []byte, labels []string) []byte {
+	if len(labels) == 0 {
+		return raw
+	}
+	eol := "\n"
+	if bytes.Contains(raw, []byte("\r\n")) {
+		eol = "\r\n"
+	}
+
+	header := "X-Gmail-Labels: " + strings.Join(labels, ",") + eol
+	out := make([]byte, 0, len(header)+len(raw))
+	out = append(out, header...)
+
+	return append(out, raw...)
+}
+
+func loadGmailExportState(path string) (*gmailExportState, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // user-provided state path
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var state gmailExportState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("read export state %s: %w", path, err)
+	}
+	if state.Version != gmailExportStateVersion {
+		return nil, fmt.Errorf("export state %s has unsupported version %d", path, state.Version)
+	}
+
+	return &state, nil
+}
+
+func saveGmailExportState(path string, state *gmailExportState) error {
+	state.UpdatedAt = time.Now().UTC()
+	payload, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(payload, '\n'), 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+
+	return s
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+func TestGmailExport_MboxFullThenIncremental(t *testing.T) {
+	origNew := newGmailService
+	t.Cleanup(func() { newGmailService = origNew })
+
+	rawMessage := func(id, labelID string) map[string]any {
+		raw := "From: Alice <alice@example.com>\r\nSubject: " + id + "\r\n\r\nFrom the desk of " + id + "\r\n>From quoted\r\n"
+		return map[string]any{
+			"id":           id,
+			"labelIds":     []string{"INBOX", labelID},
+			"internalDate": "1772445600000",
+			"raw":          base64.RawURLEncoding.EncodeToString([]byte(raw)),
+		}
+	}
+
+	var (
+		mu      sync.Mutex
+		fetched []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := r.URL.Path
+		var body any
+		switch {
+		case strings.HasSuffix(p, "/users/me/labels"):
+			body = map[string]any{"labels": []map[string]any{{"id": "INBOX", "name": "INBOX"}, {"id": "Label_1", "name": "Receipts"}}}
+		case strings.HasSuffix(p, "/users/me/profile"):
+			body = map[string]any{"historyId": "100"}
+		case strings.HasSuffix(p, "/users/me/messages"):
+			body = map[string]any{"messages": []map[string]any{{"id": "m2"}, {"id": "m1"}}}
+		case strings.HasSuffix(p, "/users/me/history"):
+			if got := r.URL.Query().Get("startHistoryId"); got != "100" {
+				t.Errorf("unexpected startHistoryId %q", got)
+			}
+			body = map[string]any{
+				"historyId": "110",
+				"history": []map[string]any{{
+					"id": "105",
+					"messagesAdded": []map[string]any{
+						{"message": map[string]any{"id": "m3"}},
+						{"message": map[string]any{"id": "m4"}},
+					},
+				}},
+			}
+		case strings.Contains(p, "/users/me/messages/"):
+			id := p[strings.LastIndex(p, "/")+1:]
+			if r.URL.Query().Get("format") != gmailFormatRaw {
+				t.Errorf("expected raw format, got %q", r.URL.RawQuery)
+			}
+			mu.Lock()
+			fetched = append(fetched, id)
+			mu.Unlock()
+			if id == "m4" {
+				body = rawMessage(id, "SPAM")
+			} else {
+				body = rawMessage(id, "Label_1")
+			}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer srv.Close()
+
+	svc, err := gmail.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("gmail.NewService: %v", err)
+	}
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return svc, nil }
+
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{JSON: true})
+	flags := &RootFlags{Account: "a@b.com"}
+	out := filepath.Join(t.TempDir(), "mail.mbox")
+
+	export := func() gmailExportResult {
+		t.Helper()
+		stdout := captureStdout(t, func() {
+			if err := runKong(t, &GmailExportCmd{}, []string{"--out", out}, ctx, flags); err != nil {
+				t.Fatalf("export: %v", err)
+			}
+		})
+		var parsed gmailExportResult
+		if err := json.Unmarshal([]byte(stdout), &parsed); err != nil {
+			t.Fatalf("json parse: %v\n%s", err, stdout)
+		}
+		return parsed
+	}
+
+	first := export()
+	if first.Mode != indexModeFull || first.Exported != 2 || first.HistoryID != "100" {
+		t.Fatalf("unexpected first export: %#v", first)
+	}
+	if strings.Join(fetched, ",") != "m1,m2" && strings.Join(fetched, ",") != "m2,m1" {
+		t.Fatalf("unexpected fetches: %v", fetched)
+	}
+
+	second := export()
+	if second.Mode != indexModeIncremental || second.Exported != 1 || second.Skipped != 1 || second.Total != 3 || second.HistoryID != "110" {
+		t.Fatalf("unexpected incremental export: %#v", second)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read mbox: %v", err)
+	}
+	mbox := string(data)
+	if got := strings.Count(mbox, "\nFrom MAILER-DAEMON "); got != 2 || !strings.HasPrefix(mbox, "From MAILER-DAEMON ") {
+		t.Fatalf("expected 3 messages, got:\n%s", mbox)
+	}
+	if !strings.Contains(mbox, "X-Gmail-Labels: INBOX,Receipts\nFrom: Alice") {
+		t.Fatalf("missing labels header:\n%s", mbox)
+	}
+	if !strings.Contains(mbox, "\n>From the desk of m1\n>>From quoted\n") || strings.Contains(mbox, "\r") {
+		t.Fatalf("expected mboxrd escaping and LF endings:\n%s", mbox)
+	}
+	if strings.Contains(mbox, "Subject: m4") {
+		t.Fatalf("spam message should not be exported:\n%s", mbox)
+	}
+
+	state, err := loadGmailExportState(out + ".gog-export.json")
+	if err != nil || state == nil || state.HistoryID != "110" || strings.Join(state.Exported, ",") != "m1,m2,m3" {
+		t.Fatalf("unexpected state %#v, %v", state, err)
+	}
+}
+
+func TestGmailExport_EMLDirWritesFiles(t *testing.T) {
+	dir := t.TempDir()
+	w, err := openGmailExportWriter(dir, gmailExportFormatEMLDir, false)
+	if err != nil {
+		t.Fatalf("openGmailExportWriter: %v", err)
+	}
+	msg := &gmailExportMessage{ID: "m1", Raw: []byte("Subject: Hi\r\n\r\nBody\r\n"), Labels: []string{"INBOX", "Work/Projects"}}
+	if err := w.Write(msg); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "m1.eml"))
+	if err != nil {
+		t.Fatalf("read eml: %v", err)
+	}
+	if string(data) != "X-Gmail-Labels: INBOX,Work/Projects\r\nSubject: Hi\r\n\r\nBody\r\n" {
+		t.Fatalf("unexpected eml %q", data)
+	}
+}
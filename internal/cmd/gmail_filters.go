@@ -19,6 +19,8 @@ type GmailFiltersCmd struct {
 	Get    GmailFiltersGetCmd    `cmd:"" name:"get" aliases:"info,show" help:"Get a specific filter"`
 	Create GmailFiltersCreateCmd `cmd:"" name:"create" aliases:"add,new" help:"Create a new email filter"`
 	Delete GmailFiltersDeleteCmd `cmd:"" name:"delete" aliases:"rm,del,remove" help:"Delete a filter"`
+	Export GmailFiltersExportCmd `cmd:"" name:"export" help:"Export filters as YAML, JSON or Gmail mailFilters.xml (label names, not IDs)"`
+	Apply  GmailFiltersApplyCmd  `cmd:"" name:"apply" aliases:"import,sync" help:"Apply a filters file: show the diff, then create missing filters (--prune deletes the rest)"`
 }
 
 type GmailFiltersListCmd struct{}
@@ -74,6 +76,12 @@ func (c *GmailFiltersListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*GmailFiltersListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "filters", Result: struct {
+		Filters []*gmail.Filter `json:"filters"`
+	}{}}
+}
+
 type GmailFiltersGetCmd struct {
 	FilterID string `arg:"" name:"filterId" help:"Filter ID"`
 }
@@ -149,6 +157,12 @@ func (c *GmailFiltersGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*GmailFiltersGetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Filter *gmail.Filter `json:"filter"`
+	}{}}
+}
+
 type GmailFiltersCreateCmd struct {
 	From          string `name:"from" help:"Match messages from this sender"`
 	To            string `name:"to" help:"Match messages to this recipient"`
@@ -340,6 +354,12 @@ func (c *GmailFiltersCreateCmd) Run(ctx context.Context, flags *RootFlags) error
 	return nil
 }
 
+func (*GmailFiltersCreateCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Filter *gmail.Filter `json:"filter"`
+	}{}}
+}
+
 type GmailFiltersDeleteCmd struct {
 	FilterID string `arg:"" name:"filterId" help:"Filter ID"`
 }
@@ -380,3 +400,10 @@ func (c *GmailFiltersDeleteCmd) Run(ctx context.Context, flags *RootFlags) error
 	u.Out().Printf("Filter %s deleted successfully", filterID)
 	return nil
 }
+
+func (*GmailFiltersDeleteCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Success  bool   `json:"success"`
+		FilterID string `json:"filterId"`
+	}{}}
+}
@@ -0,0 +1,903 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/gmail/v1"
+	"gopkg.in/yaml.v3"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// Declarative filters: `filters export` writes every filter with label names
+// instead of IDs and system labels as flags (archive, markRead, ...);
+// `filters apply` diffs a file against the account and creates what is
+// missing (and with --prune deletes what is not in the file). Gmail has no
+// filter update, so a changed filter is a delete plus a create.
+
+const (
+	gmailFiltersFormatYAML = "yaml"
+	gmailFiltersFormatJSON = "json"
+	gmailFiltersFormatXML  = "xml"
+
+	gmailFiltersDocVersion = 1
+)
+
+type gmailFiltersDoc struct {
+	Version int               `yaml:"version" json:"version"`
+	Filters []gmailFilterSpec `yaml:"filters" json:"filters"`
+}
+
+type gmailFilterSpec struct {
+	Criteria gmailFilterSpecCriteria `yaml:"criteria" json:"criteria"`
+	Action   gmailFilterSpecAction   `yaml:"action" json:"action"`
+}
+
+type gmailFilterSpecCriteria struct {
+	From           string `yaml:"from,omitempty" json:"from,omitempty"`
+	To             string `yaml:"to,omitempty" json:"to,omitempty"`
+	Subject        string `yaml:"subject,omitempty" json:"subject,omitempty"`
+	Query          string `yaml:"query,omitempty" json:"query,omitempty"`
+	NegatedQuery   string `yaml:"negatedQuery,omitempty" json:"negatedQuery,omitempty"`
+	HasAttachment  bool   `yaml:"hasAttachment,omitempty" json:"hasAttachment,omitempty"`
+	ExcludeChats   bool   `yaml:"excludeChats,omitempty" json:"excludeChats,omitempty"`
+	Size           int64  `yaml:"size,omitempty" json:"size,omitempty"`
+	SizeComparison string `yaml:"sizeComparison,omitempty" json:"sizeComparison,omitempty"`
+}
+
+type gmailFilterSpecAction struct {
+	AddLabels      []string `yaml:"addLabels,omitempty" json:"addLabels,omitempty"`
+	RemoveLabels   []string `yaml:"removeLabels,omitempty" json:"removeLabels,omitempty"`
+	Archive        bool     `yaml:"archive,omitempty" json:"archive,omitempty"`
+	MarkRead       bool     `yaml:"markRead,omitempty" json:"markRead,omitempty"`
+	Star           bool     `yaml:"star,omitempty" json:"star,omitempty"`
+	Trash          bool     `yaml:"trash,omitempty" json:"trash,omitempty"`
+	NeverSpam      bool     `yaml:"neverSpam,omitempty" json:"neverSpam,omitempty"`
+	Important      bool     `yaml:"important,omitempty" json:"important,omitempty"`
+	NeverImportant bool     `yaml:"neverImportant,omitempty" json:"neverImportant,omitempty"`
+	Category       string   `yaml:"category,omitempty" json:"category,omitempty"`
+	Forward        string   `yaml:"forward,omitempty" json:"forward,omitempty"`
+}
+
+// gmailFilterCategories maps category names to their system label IDs.
+var gmailFilterCategories = map[string]string{
+	"personal":   "CATEGORY_PERSONAL",
+	"social":     "CATEGORY_SOCIAL",
+	"promotions": "CATEGORY_PROMOTIONS",
+	"updates":    "CATEGORY_UPDATES",
+	"forums":     "CATEGORY_FORUMS",
+}
+
+// normalize folds system labels listed in addLabels/removeLabels into the
+// matching flags and sorts the remaining label names, so equal filters get
+// equal specs.
+func (s gmailFilterSpec) normalize() gmailFilterSpec {
+	a := s.Action
+	add := make([]string, 0, len(a.AddLabels))
+	for _, l := range a.AddLabels {
+		l = strings.TrimSpace(l)
+		switch strings.ToUpper(l) {
+		case "":
+		case "STARRED":
+			a.Star = true
+		case "TRASH":
+			a.Trash = true
+		case "IMPORTANT":
+			a.Important = true
+		default:
+			if cat, ok := gmailFilterCategoryName(l); ok {
+				a.Category = cat
+				continue
+			}
+			add = append(add, l)
+		}
+	}
+	remove := make([]string, 0, len(a.RemoveLabels))
+	for _, l := range a.RemoveLabels {
+		l = strings.TrimSpace(l)
+		switch strings.ToUpper(l) {
+		case "":
+		case "INBOX":
+			a.Archive = true
+		case "UNREAD":
+			a.MarkRead = true
+		case "SPAM":
+			a.NeverSpam = true
+		case "IMPORTANT":
+			a.NeverImportant = true
+		default:
+			remove = append(remove, l)
+		}
+	}
+	a.AddLabels = sortedUniqueFold(add)
+	a.RemoveLabels = sortedUniqueFold(remove)
+	a.Category = strings.ToLower(strings.TrimSpace(a.Category))
+	a.Forward = strings.TrimSpace(a.Forward)
+
+	c := s.Criteria
+	c.From = strings.TrimSpace(c.From)
+	c.To = strings.TrimSpace(c.To)
+	c.Subject = strings.TrimSpace(c.Subject)
+	c.Query = strings.TrimSpace(c.Query)
+	c.NegatedQuery = strings.TrimSpace(c.NegatedQuery)
+	c.SizeComparison = strings.ToLower(strings.TrimSpace(c.SizeComparison))
+	if c.Size == 0 {
+		c.SizeComparison = ""
+	}
+
+	return gmailFilterSpec{Criteria: c, Action: a}
+}
+
+// key identifies a normalized spec; label names compare case-insensitively
+// like Gmail does.
+func (s gmailFilterSpec) key() string {
+	n := s.normalize()
+	for i := range n.Action.AddLabels {
+		n.Action.AddLabels[i] = strings.ToLower(n.Action.AddLabels[i])
+	}
+	for i := range n.Action.RemoveLabels {
+		n.Action.RemoveLabels[i] = strings.ToLower(n.Action.RemoveLabels[i])
+	}
+	data, _ := json.Marshal(n)
+	return string(data)
+}
+
+// criteriaKey identifies what a filter matches; filters with the same
+// criteria but different actions are replaced rather than added.
+func (s gmailFilterSpec) criteriaKey() string {
+	data, _ := json.Marshal(s.normalize().Criteria)
+	return string(data)
+}
+
+func (s gmailFilterSpec) validate() error {
+	c := s.Criteria
+	if c.From == "" && c.To == "" && c.Subject == "" && c.Query == "" && c.NegatedQuery == "" && !c.HasAttachment && c.Size == 0 {
+		return fmt.Errorf("filter has no criteria")
+	}
+	if c.Size != 0 && c.SizeComparison != "larger" && c.SizeComparison != "smaller" {
+		return fmt.Errorf("size needs sizeComparison larger|smaller")
+	}
+	a := s.Action
+	if len(a.AddLabels) == 0 && len(a.RemoveLabels) == 0 && !a.Archive && !a.MarkRead && !a.Star && !a.Trash &&
+		!a.NeverSpam && !a.Important && !a.NeverImportant && a.Category == "" && a.Forward == "" {
+		return fmt.Errorf("filter has no action")
+	}
+	if a.Category != "" {
+		if _, ok := gmailFilterCategories[a.Category]; !ok {
+			return fmt.Errorf("unknown category %q (use personal, social, promotions, updates or forums)", a.Category)
+		}
+	}
+	return nil
+}
+
+// describe renders a spec as one line for diffs.
+func (s gmailFilterSpec) describe() string {
+	var parts []string
+	c := s.Criteria
+	for _, kv := range [][2]string{{"from", c.From}, {"to", c.To}, {"subject", c.Subject}, {"query", c.Query}, {"negatedQuery", c.NegatedQuery}} {
+		if kv[1] != "" {
+			parts = append(parts, kv[0]+":"+strconv.Quote(kv[1]))
+		}
+	}
+	if c.HasAttachment {
+		parts = append(parts, "has:attachment")
+	}
+	if c.ExcludeChats {
+		parts = append(parts, "-chats")
+	}
+	if c.Size != 0 {
+		parts = append(parts, fmt.Sprintf("size:%s:%d", c.SizeComparison, c.Size))
+	}
+	parts = append(parts, "->")
+	a := s.Action
+	for _, l := range a.AddLabels {
+		parts = append(parts, "+label:"+strconv.Quote(l))
+	}
+	for _, l := range a.RemoveLabels {
+		parts = append(parts, "-label:"+strconv.Quote(l))
+	}
+	for _, f := range []struct {
+		on   bool
+		name string
+	}{{a.Archive, "archive"}, {a.MarkRead, "markRead"}, {a.Star, "star"}, {a.Trash, "trash"}, {a.NeverSpam, "neverSpam"}, {a.Important, "important"}, {a.NeverImportant, "neverImportant"}} {
+		if f.on {
+			parts = append(parts, f.name)
+		}
+	}
+	if a.Category != "" {
+		parts = append(parts, "category:"+a.Category)
+	}
+	if a.Forward != "" {
+		parts = append(parts, "forward:"+a.Forward)
+	}
+	return strings.Join(parts, " ")
+}
+
+func gmailFilterCategoryName(labelID string) (string, bool) {
+	for name, id := range gmailFilterCategories {
+		if strings.EqualFold(labelID, id) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func sortedUniqueFold(items []string) []string {
+	if len(items) == 0 {
+		return nil
+	}
+	seen := map[string]bool{}
+	out := make([]string, 0, len(items))
+	for _, it := range items {
+		if k := strings.ToLower(it); !seen[k] {
+			seen[k] = true
+			out = append(out, it)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return strings.ToLower(out[i]) < strings.ToLower(out[j]) })
+	return out
+}
+
+// specFromFilter converts an API filter, mapping label IDs to names.
+func specFromFilter(f *gmail.Filter, idToName map[string]string) gmailFilterSpec {
+	var s gmailFilterSpec
+	if c := f.Criteria; c != nil {
+		s.Criteria = gmailFilterSpecCriteria{
+			From:           c.From,
+			To:             c.To,
+			Subject:        c.Subject,
+			Query:          c.Query,
+			NegatedQuery:   c.NegatedQuery,
+			HasAttachment:  c.HasAttachment,
+			ExcludeChats:   c.ExcludeChats,
+			Size:           c.Size,
+			SizeComparison: strings.ToLower(c.SizeComparison),
+		}
+	}
+	if a := f.Action; a != nil {
+		name := func(id string) string {
+			if n, ok := idToName[id]; ok && !isSystemFilterLabel(id) {
+				return n
+			}
+			return id
+		}
+		for _, id := range a.AddLabelIds {
+			s.Action.AddLabels = append(s.Action.AddLabels, name(id))
+		}
+		for _, id := range a.RemoveLabelIds {
+			s.Action.RemoveLabels = append(s.Action.RemoveLabels, name(id))
+		}
+		s.Action.Forward = a.Forward
+	}
+	return s.normalize()
+}
+
+func isSystemFilterLabel(id string) bool {
+	return id == strings.ToUpper(id) && !strings.HasPrefix(id, "Label_")
+}
+
+// filterFromSpec converts a spec to an API filter; nameToID maps lowercased
+// label names to IDs.
+func filterFromSpec(s gmailFilterSpec, nameToID map[string]string) *gmail.Filter {
+	s = s.normalize()
+	c := s.Criteria
+	f := &gmail.Filter{
+		Criteria: &gmail.FilterCriteria{
+			From:           c.From,
+			To:             c.To,
+			Subject:        c.Subject,
+			Query:          c.Query,
+			NegatedQuery:   c.NegatedQuery,
+			HasAttachment:  c.HasAttachment,
+			ExcludeChats:   c.ExcludeChats,
+			Size:           c.Size,
+			SizeComparison: c.SizeComparison,
+		},
+		Action: &gmail.FilterAction{
+			AddLabelIds:    resolveLabelIDs(s.Action.AddLabels, nameToID),
+			RemoveLabelIds: resolveLabelIDs(s.Action.RemoveLabels, nameToID),
+			Forward:        s.Action.Forward,
+		},
+	}
+	a := s.Action
+	add := func(on bool, id string, list *[]string) {
+		if on {
+			*list = append(*list, id)
+		}
+	}
+	add(a.Star, "STARRED", &f.Action.AddLabelIds)
+	add(a.Trash, "TRASH", &f.Action.AddLabelIds)
+	add(a.Important, "IMPORTANT", &f.Action.AddLabelIds)
+	add(a.Category != "", gmailFilterCategories[a.Category], &f.Action.AddLabelIds)
+	add(a.Archive, "INBOX", &f.Action.RemoveLabelIds)
+	add(a.MarkRead, "UNREAD", &f.Action.RemoveLabelIds)
+	add(a.NeverSpam, "SPAM", &f.Action.RemoveLabelIds)
+	add(a.NeverImportant, "IMPORTANT", &f.Action.RemoveLabelIds)
+	return f
+}
+
+func gmailFiltersFormatFor(path, format string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json":
+			format = gmailFiltersFormatJSON
+		case ".xml":
+			format = gmailFiltersFormatXML
+		default:
+			format = gmailFiltersFormatYAML
+		}
+	}
+	switch format {
+	case gmailFiltersFormatYAML, "yml":
+		return gmailFiltersFormatYAML, nil
+	case gmailFiltersFormatJSON, gmailFiltersFormatXML:
+		return format, nil
+	default:
+		return "", usagef("invalid --format %q (use yaml, json or xml)", format)
+	}
+}
+
+func encodeGmailFilters(w io.Writer, format, account string, specs []gmailFilterSpec) error {
+	doc := gmailFiltersDoc{Version: gmailFiltersDocVersion, Filters: specs}
+	switch format {
+	case gmailFiltersFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(doc)
+	case gmailFiltersFormatXML:
+		return writeMailFiltersXML(w, account, specs, time.Now())
+	default:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+}
+
+func decodeGmailFilters(data []byte, format string) ([]gmailFilterSpec, error) {
+	var doc gmailFiltersDoc
+	switch format {
+	case gmailFiltersFormatJSON:
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("parse filters: %w", err)
+		}
+	case gmailFiltersFormatXML:
+		specs, err := parseMailFiltersXML(data)
+		if err != nil {
+			return nil, fmt.Errorf("parse mailFilters.xml: %w", err)
+		}
+		doc.Filters = specs
+	default:
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(&doc); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("parse filters: %w", err)
+		}
+	}
+	if doc.Version > gmailFiltersDocVersion {
+		return nil, fmt.Errorf("unsupported filters version %d", doc.Version)
+	}
+
+	specs := make([]gmailFilterSpec, 0, len(doc.Filters))
+	for i, s := range doc.Filters {
+		s = s.normalize()
+		if err := s.validate(); err != nil {
+			return nil, fmt.Errorf("filter %d (%s): %w", i+1, s.describe(), err)
+		}
+		specs = append(specs, s)
+	}
+	return specs, nil
+}
+
+// Gmail settings export (Settings > Filters > Export): an Atom feed with one
+// entry per filter and apps:property name/value pairs.
+type mailFiltersFeed struct {
+	XMLName xml.Name          `xml:"http://www.w3.org/2005/Atom feed"`
+	Entries []mailFilterEntry `xml:"entry"`
+}
+
+type mailFilterEntry struct {
+	Properties []mailFilterProperty `xml:"http://schemas.google.com/apps/2006 property"`
+}
+
+type mailFilterProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+var mailFilterSmartLabels = map[string]string{
+	"personal":   "^smartlabel_personal",
+	"social":     "^smartlabel_social",
+	"promotions": "^smartlabel_promo",
+	"updates":    "^smartlabel_notification",
+	"forums":     "^smartlabel_group",
+}
+
+var mailFilterSizeUnits = map[string]int64{"s_sb": 1, "s_skb": 1 << 10, "s_smb": 1 << 20}
+
+func parseMailFiltersXML(data []byte) ([]gmailFilterSpec, error) {
+	var feed mailFiltersFeed
+	if err := xml.Unmarshal(data, &feed); err != nil {
+		return nil, err
+	}
+
+	specs := make([]gmailFilterSpec, 0, len(feed.Entries))
+	for _, e := range feed.Entries {
+		var s gmailFilterSpec
+		sizeUnit := int64(1)
+		for _, p := range e.Properties {
+			if u, ok := mailFilterSizeUnits[p.Value]; ok && p.Name == "sizeUnit" {
+				sizeUnit = u
+			}
+		}
+		for _, p := range e.Properties {
+			on := p.Value == strTrue
+			switch p.Name {
+			case "from":
+				s.Criteria.From = p.Value
+			case "to":
+				s.Criteria.To = p.Value
+			case "subject":
+				s.Criteria.Subject = p.Value
+			case "hasTheWord":
+				s.Criteria.Query = p.Value
+			case "doesNotHaveTheWord":
+				s.Criteria.NegatedQuery = p.Value
+			case "hasAttachment":
+				s.Criteria.HasAttachment = on
+			case "excludeChats":
+				s.Criteria.ExcludeChats = on
+			case "size":
+				n, err := strconv.ParseInt(p.Value, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid size %q", p.Value)
+				}
+				s.Criteria.Size = n * sizeUnit
+			case "sizeOperator":
+				s.Criteria.SizeComparison = map[string]string{"s_sl": "larger", "s_ss": "smaller"}[p.Value]
+			case "label":
+				s.Action.AddLabels = append(s.Action.AddLabels, p.Value)
+			case "shouldArchive":
+				s.Action.Archive = on
+			case "shouldMarkAsRead":
+				s.Action.MarkRead = on
+			case "shouldStar":
+				s.Action.Star = on
+			case "shouldTrash":
+				s.Action.Trash = on
+			case "shouldNeverSpam":
+				s.Action.NeverSpam = on
+			case "shouldAlwaysMarkAsImportant":
+				s.Action.Important = on
+			case "shouldNeverMarkAsImportant":
+				s.Action.NeverImportant = on
+			case "forwardTo":
+				s.Action.Forward = p.Value
+			case "smartLabelToApply":
+				for name, v := range mailFilterSmartLabels {
+					if v == p.Value {
+						s.Action.Category = name
+					}
+				}
+			}
+		}
+		specs = append(specs, s)
+	}
+	return specs, nil
+}
+
+func writeMailFiltersXML(w io.Writer, account string, specs []gmailFilterSpec, now time.Time) error {
+	updated := now.UTC().Format(time.RFC3339)
+	var b strings.Builder
+	b.WriteString("<?xml version='1.0' encoding='UTF-8'?>")
+	b.WriteString("<feed xmlns='http://www.w3.org/2005/Atom' xmlns:apps='http://schemas.google.com/apps/2006'>\n")
+	b.WriteString("\t<title>Mail Filters</title>\n")
+	fmt.Fprintf(&b, "\t<updated>%s</updated>\n", updated)
+	if account != "" {
+		b.WriteString("\t<author>\n\t\t<email>")
+		_ = xml.EscapeText(&b, []byte(account))
+		b.WriteString("</email>\n\t</author>\n")
+	}
+
+	for i, s := range specs {
+		b.WriteString("\t<entry>\n\t\t<category term='filter'></category>\n\t\t<title>Mail Filter</title>\n")
+		fmt.Fprintf(&b, "\t\t<id>tag:mail.google.com,2008:filter:%d</id>\n", i+1)
+		fmt.Fprintf(&b, "\t\t<updated>%s</updated>\n\t\t<content></content>\n", updated)
+		prop := func(name, value string) {
+			if value == "" {
+				return
+			}
+			b.WriteString("\t\t<apps:property name='" + name + "' value='")
+			_ = xml.EscapeText(&b, []byte(value))
+			b.WriteString("'/>\n")
+		}
+		flag := func(name string, on bool) {
+			if on {
+				prop(name, strTrue)
+			}
+		}
+		c, a := s.Criteria, s.Action
+		prop("from", c.From)
+		prop("to", c.To)
+		prop("subject", c.Subject)
+		prop("hasTheWord", c.Query)
+		prop("doesNotHaveTheWord", c.NegatedQuery)
+		flag("hasAttachment", c.HasAttachment)
+		flag("excludeChats", c.ExcludeChats)
+		if c.Size != 0 {
+			size, unit := c.Size, "s_sb"
+			switch {
+			case size%(1<<20) == 0:
+				size, unit = size>>20, "s_smb"
+			case size%(1<<10) == 0:
+				size, unit = size>>10, "s_skb"
+			}
+			prop("size", strconv.FormatInt(size, 10))
+			prop("sizeOperator", map[string]string{"larger": "s_sl", "smaller": "s_ss"}[c.SizeComparison])
+			prop("sizeUnit", unit)
+		}
+		for _, l := range a.AddLabels {
+			prop("label", l)
+		}
+		flag("shouldArchive", a.Archive)
+		flag("shouldMarkAsRead", a.MarkRead)
+		flag("shouldStar", a.Star)
+		flag("shouldTrash", a.Trash)
+		flag("shouldNeverSpam", a.NeverSpam)
+		flag("shouldAlwaysMarkAsImportant", a.Important)
+		flag("shouldNeverMarkAsImportant", a.NeverImportant)
+		prop("forwardTo", a.Forward)
+		prop("smartLabelToApply", mailFilterSmartLabels[a.Category])
+		b.WriteString("\t</entry>\n")
+	}
+	b.WriteString("</feed>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+type GmailFiltersExportCmd struct {
+	Format string `name:"format" help:"Output format: yaml|json|xml (Gmail mailFilters.xml); default from --out extension, else yaml"`
+	Out    string `name:"out" aliases:"output" help:"Write to this file instead of stdout (stdout gets the bare document, also in JSON mode)"`
+}
+
+func (c *GmailFiltersExportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	format, err := gmailFiltersFormatFor(c.Out, c.Format)
+	if err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newGmailService(ctx, account)
+	if err != nil {
+		return err
+	}
+	specs, _, err := fetchGmailFilterSpecs(ctx, svc)
+	if err != nil {
+		return err
+	}
+
+	// The document goes to stdout bare, even in JSON mode, so that
+	// `filters export > filters.yaml` can be fed back to `filters apply`.
+	if strings.TrimSpace(c.Out) == "" {
+		return encodeGmailFilters(os.Stdout, format, account, specs)
+	}
+
+	out, err := config.ExpandPath(c.Out)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := encodeGmailFilters(&buf, format, account, specs); err != nil {
+		return err
+	}
+	if err := os.WriteFile(out, buf.Bytes(), 0o600); err != nil {
+		return err
+	}
+	return writeResult(ctx, ui.FromContext(ctx),
+		kv("path", out),
+		kv("format", format),
+		kv("filters", len(specs)),
+	)
+}
+
+// jsonOutput declares the --out result; without --out the bare filters
+// document is written instead.
+func (*GmailFiltersExportCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Path    string `json:"path"`
+		Format  string `json:"format"`
+		Filters int    `json:"filters"`
+	}{}}
+}
+
+type gmailFilterExisting struct {
+	ID   string
+	Spec gmailFilterSpec
+}
+
+func fetchGmailFilterSpecs(ctx context.Context, svc *gmail.Service) ([]gmailFilterSpec, []gmailFilterExisting, error) {
+	resp, err := svc.Users.Settings.Filters.List("me").Context(ctx).Do()
+	if err != nil {
+		return nil, nil, err
+	}
+	idToName, err := fetchLabelIDToName(svc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	specs := make([]gmailFilterSpec, 0, len(resp.Filter))
+	existing := make([]gmailFilterExisting, 0, len(resp.Filter))
+	for _, f := range resp.Filter {
+		if f == nil {
+			continue
+		}
+		spec := specFromFilter(f, idToName)
+		specs = append(specs, spec)
+		existing = append(existing, gmailFilterExisting{ID: f.Id, Spec: spec})
+	}
+	return specs, existing, nil
+}
+
+type GmailFiltersApplyCmd struct {
+	File   string `arg:"" name:"file" help:"Filters file (yaml, json, or Gmail mailFilters.xml; '-' for stdin)"`
+	Format string `name:"format" help:"Input format: yaml|json|xml (default from file extension)"`
+	Prune  bool   `name:"prune" help:"Delete existing filters that are not in the file"`
+}
+
+type gmailFiltersDeletion struct {
+	ID     string          `json:"id"`
+	Filter gmailFilterSpec `json:"filter"`
+}
+
+// gmailFiltersReplacement is a filter whose criteria are in the file with
+// different actions: Gmail has no update, so it is recreated.
+type gmailFiltersReplacement struct {
+	ID   string          `json:"id"`
+	From gmailFilterSpec `json:"from"`
+	To   gmailFilterSpec `json:"to"`
+}
+
+type gmailFiltersPlan struct {
+	Create    []gmailFilterSpec         `json:"create"`
+	Replace   []gmailFiltersReplacement `json:"replace"`
+	Delete    []gmailFiltersDeletion    `json:"delete"`
+	Labels    []string                  `json:"labels"`
+	Unchanged int                       `json:"unchanged"`
+	// Extra counts filters not in the file, kept without --prune.
+	Extra int `json:"extra"`
+}
+
+type gmailFiltersApplyResult struct {
+	gmailFiltersPlan
+	Created        []string `json:"created_ids"`
+	Replaced       []string `json:"replaced_ids"`
+	Deleted        []string `json:"deleted_ids"`
+	CreatedLabels  []string `json:"created_labels"`
+	AlreadyApplied bool     `json:"up_to_date,omitempty"`
+}
+
+func (c *GmailFiltersApplyCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+
+	format, err := gmailFiltersFormatFor(c.File, c.Format)
+	if err != nil {
+		return err
+	}
+	data, err := resolveBodyInput("", c.File)
+	if err != nil {
+		return err
+	}
+	desired, err := decodeGmailFilters([]byte(data), format)
+	if err != nil {
+		return usage(err.Error())
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newGmailService(ctx, account)
+	if err != nil {
+		return err
+	}
+	_, existing, err := fetchGmailFilterSpecs(ctx, svc)
+	if err != nil {
+		return err
+	}
+	nameToID, err := fetchLabelNameToID(svc)
+	if err != nil {
+		return err
+	}
+
+	plan := planGmailFilters(desired, existing, nameToID, c.Prune)
+	if !outfmt.IsJSON(ctx) {
+		printGmailFiltersPlan(u, plan)
+	}
+
+	if err := dryRunExit(ctx, flags, "gmail.filters.apply", plan); err != nil {
+		return err
+	}
+
+	res := gmailFiltersApplyResult{gmailFiltersPlan: plan, Created: []string{}, Replaced: []string{}, Deleted: []string{}, CreatedLabels: []string{}}
+	if len(plan.Create) == 0 && len(plan.Replace) == 0 && len(plan.Delete) == 0 {
+		res.AlreadyApplied = true
+		if outfmt.IsJSON(ctx) {
+			return outfmt.WriteJSON(ctx, os.Stdout, res)
+		}
+		u.Err().Println("Filters are up to date")
+		return nil
+	}
+	if len(plan.Replace) > 0 || len(plan.Delete) > 0 {
+		var action string
+		switch {
+		case len(plan.Delete) == 0:
+			action = fmt.Sprintf("replace %d gmail filters", len(plan.Replace))
+		case len(plan.Replace) == 0:
+			action = fmt.Sprintf("delete %d gmail filters", len(plan.Delete))
+		default:
+			action = fmt.Sprintf("replace %d and delete %d gmail filters", len(plan.Replace), len(plan.Delete))
+		}
+		if err := confirmDestructive(ctx, flags, action); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range plan.Labels {
+		label, err := createLabel(ctx, svc, name)
+		if err != nil {
+			return mapLabelCreateError(err, name)
+		}
+		nameToID[strings.ToLower(label.Name)] = label.Id
+		res.CreatedLabels = append(res.CreatedLabels, label.Name)
+	}
+
+	// Create before deleting, so a failure never leaves filters missing.
+	for _, spec := range plan.Create {
+		created, err := svc.Users.Settings.Filters.Create("me", filterFromSpec(spec, nameToID)).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("create filter %s: %w", spec.describe(), err)
+		}
+		res.Created = append(res.Created, created.Id)
+	}
+	for _, r := range plan.Replace {
+		created, err := svc.Users.Settings.Filters.Create("me", filterFromSpec(r.To, nameToID)).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("create filter %s: %w", r.To.describe(), err)
+		}
+		res.Created = append(res.Created, created.Id)
+		if err := svc.Users.Settings.Filters.Delete("me", r.ID).Context(ctx).Do(); err != nil && !isNotFoundAPIError(err) {
+			return fmt.Errorf("delete replaced filter %s: %w", r.ID, err)
+		}
+		res.Replaced = append(res.Replaced, r.ID)
+	}
+	for _, d := range plan.Delete {
+		if err := svc.Users.Settings.Filters.Delete("me", d.ID).Context(ctx).Do(); err != nil && !isNotFoundAPIError(err) {
+			return fmt.Errorf("delete filter %s: %w", d.ID, err)
+		}
+		res.Deleted = append(res.Deleted, d.ID)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, res)
+	}
+	u.Err().Printf("created: %d, replaced: %d, deleted: %d, labels created: %d", len(res.Created)-len(res.Replaced), len(res.Replaced), len(res.Deleted), len(res.CreatedLabels))
+	return nil
+}
+
+func (*GmailFiltersApplyCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: gmailFiltersApplyResult{}}
+}
+
+// planGmailFilters matches existing filters to the file: identical filters
+// are unchanged, a filter with the same criteria but other actions is
+// replaced, and the rest are created. Gmail allows several filters with the
+// same criteria, so existing filters left over are deleted only with --prune.
+func planGmailFilters(desired []gmailFilterSpec, existing []gmailFilterExisting, nameToID map[string]string, prune bool) gmailFiltersPlan {
+	plan := gmailFiltersPlan{Create: []gmailFilterSpec{}, Replace: []gmailFiltersReplacement{}, Delete: []gmailFiltersDeletion{}, Labels: []string{}}
+
+	used := make([]bool, len(existing))
+	want := map[string]bool{}
+	pending := make([]gmailFilterSpec, 0, len(desired))
+	for _, spec := range desired {
+		k := spec.key()
+		if want[k] {
+			continue
+		}
+		want[k] = true
+
+		matched := false
+		for i, e := range existing {
+			if !used[i] && e.Spec.key() == k {
+				used[i] = true
+				matched = true
+				break
+			}
+		}
+		if matched {
+			plan.Unchanged++
+			continue
+		}
+		pending = append(pending, spec)
+	}
+
+	missingLabels := map[string]bool{}
+	needLabels := func(spec gmailFilterSpec) {
+		for _, l := range append(append([]string{}, spec.Action.AddLabels...), spec.Action.RemoveLabels...) {
+			if _, ok := nameToID[strings.ToLower(l)]; !ok && !missingLabels[strings.ToLower(l)] {
+				missingLabels[strings.ToLower(l)] = true
+				plan.Labels = append(plan.Labels, l)
+			}
+		}
+	}
+	for _, spec := range pending {
+		needLabels(spec)
+		replaced := false
+		for i, e := range existing {
+			if !used[i] && e.Spec.criteriaKey() == spec.criteriaKey() {
+				used[i] = true
+				replaced = true
+				plan.Replace = append(plan.Replace, gmailFiltersReplacement{ID: e.ID, From: e.Spec, To: spec})
+				break
+			}
+		}
+		if !replaced {
+			plan.Create = append(plan.Create, spec)
+		}
+	}
+
+	for i, e := range existing {
+		if used[i] {
+			continue
+		}
+		if prune {
+			plan.Delete = append(plan.Delete, gmailFiltersDeletion{ID: e.ID, Filter: e.Spec})
+		} else {
+			plan.Extra++
+		}
+	}
+
+	return plan
+}
+
+func printGmailFiltersPlan(u *ui.UI, plan gmailFiltersPlan) {
+	for _, l := range plan.Labels {
+		u.Err().Printf("+ label %q", l)
+	}
+	for _, d := range plan.Delete {
+		u.Err().Printf("- %s  (%s)", d.Filter.describe(), d.ID)
+	}
+	for _, r := range plan.Replace {
+		u.Err().Printf("~ %s  (%s)", r.From.describe(), r.ID)
+		u.Err().Printf("  => %s", r.To.describe())
+	}
+	for _, s := range plan.Create {
+		u.Err().Printf("+ %s", s.describe())
+	}
+	summary := fmt.Sprintf("%d to create, %d to replace, %d to delete, %d unchanged", len(plan.Create), len(plan.Replace), len(plan.Delete), plan.Unchanged)
+	if plan.Extra > 0 {
+		summary += fmt.Sprintf(", %d not in file (kept; use --prune to delete)", plan.Extra)
+	}
+	u.Err().Println(summary)
+}
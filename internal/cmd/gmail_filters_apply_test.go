@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+func TestGmailFilters_ExportApplyPrune(t *testing.T) {
+	origNew := newGmailService
+	t.Cleanup(func() { newGmailService = origNew })
+
+	var (
+		mu            sync.Mutex
+		createdLabels []string
+		created       []*gmail.Filter
+		deleted       []string
+	)
+	filters := []*gmail.Filter{
+		{
+			Id:       "f1",
+			Criteria: &gmail.FilterCriteria{From: "news@example.com"},
+			Action:   &gmail.FilterAction{AddLabelIds: []string{"Label_1"}, RemoveLabelIds: []string{"INBOX"}},
+		},
+		{
+			Id:       "f2",
+			Criteria: &gmail.FilterCriteria{Subject: "old"},
+			Action:   &gmail.FilterAction{AddLabelIds: []string{"TRASH"}},
+		},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		var body any
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/labels") && r.Method == http.MethodGet:
+			body = map[string]any{"labels": []map[string]any{
+				{"id": "INBOX", "name": "INBOX", "type": "system"},
+				{"id": "TRASH", "name": "TRASH", "type": "system"},
+				{"id": "Label_1", "name": "Newsletters", "type": "user"},
+			}}
+		case strings.HasSuffix(r.URL.Path, "/labels") && r.Method == http.MethodPost:
+			var l gmail.Label
+			_ = json.NewDecoder(r.Body).Decode(&l)
+			createdLabels = append(createdLabels, l.Name)
+			body = map[string]any{"id": "Label_2", "name": l.Name}
+		case strings.HasSuffix(r.URL.Path, "/settings/filters") && r.Method == http.MethodGet:
+			body = map[string]any{"filter": filters}
+		case strings.HasSuffix(r.URL.Path, "/settings/filters") && r.Method == http.MethodPost:
+			var f gmail.Filter
+			_ = json.NewDecoder(r.Body).Decode(&f)
+			created = append(created, &f)
+			body = map[string]any{"id": "f3"}
+		case strings.Contains(r.URL.Path, "/settings/filters/") && r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+			w.WriteHeader(http.StatusNoContent)
+			return
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer srv.Close()
+
+	svc, err := gmail.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("gmail.NewService: %v", err)
+	}
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return svc, nil }
+
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	textCtx := ui.WithUI(context.Background(), u)
+	jsonCtx := outfmt.WithMode(textCtx, outfmt.Mode{JSON: true})
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &GmailFiltersExportCmd{}, nil, textCtx, &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("export: %v", err)
+		}
+	})
+	for _, want := range []string{"version: 1", "from: news@example.com", "- Newsletters", "archive: true", "subject: old", "trash: true"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("export missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Label_1") || strings.Contains(out, "INBOX") {
+		t.Fatalf("export should use names and flags, not label IDs:\n%s", out)
+	}
+
+	// JSON is the default mode: export must still write a document apply reads back.
+	exported := filepath.Join(t.TempDir(), "exported.yaml")
+	out = captureStdout(t, func() {
+		if err := runKong(t, &GmailFiltersExportCmd{}, nil, jsonCtx, &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("export: %v", err)
+		}
+	})
+	if err := os.WriteFile(exported, []byte(out), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out = captureStdout(t, func() {
+		err := runKong(t, &GmailFiltersApplyCmd{}, []string{exported, "--prune"}, jsonCtx, &RootFlags{Account: "a@b.com", DryRun: true})
+		var exitErr *ExitError
+		if !errors.As(err, &exitErr) || exitErr.Code != 0 {
+			t.Fatalf("expected dry-run exit, got %v", err)
+		}
+	})
+	var roundTrip struct {
+		Request gmailFiltersPlan `json:"request"`
+	}
+	if err := json.Unmarshal([]byte(out), &roundTrip); err != nil {
+		t.Fatalf("json parse: %v\n%s", err, out)
+	}
+	if rt := roundTrip.Request; rt.Unchanged != 2 || len(rt.Create) != 0 || len(rt.Replace) != 0 || len(rt.Delete) != 0 {
+		t.Fatalf("exported filters should apply unchanged: %s", out)
+	}
+
+	// Keep f1 (same filter, different spelling), drop f2, add a new one.
+	path := filepath.Join(t.TempDir(), "filters.yaml")
+	doc := `version: 1
+filters:
+  - criteria:
+      from: news@example.com
+    action:
+      addLabels: [newsletters]
+      removeLabels: [INBOX]
+  - criteria:
+      to: team@example.com
+    action:
+      addLabels: [Team]
+      markRead: true
+`
+	if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	var plan gmailFiltersPlan
+	out = captureStdout(t, func() {
+		err := runKong(t, &GmailFiltersApplyCmd{}, []string{path, "--prune"}, jsonCtx, &RootFlags{Account: "a@b.com", DryRun: true})
+		var exitErr *ExitError
+		if !errors.As(err, &exitErr) || exitErr.Code != 0 {
+			t.Fatalf("expected dry-run exit, got %v", err)
+		}
+	})
+	var dry struct {
+		Request gmailFiltersPlan `json:"request"`
+	}
+	if err := json.Unmarshal([]byte(out), &dry); err != nil {
+		t.Fatalf("json parse: %v\n%s", err, out)
+	}
+	plan = dry.Request
+	if len(plan.Create) != 1 || len(plan.Delete) != 1 || plan.Delete[0].ID != "f2" || plan.Unchanged != 1 ||
+		len(plan.Labels) != 1 || plan.Labels[0] != "Team" {
+		t.Fatalf("unexpected plan: %s", out)
+	}
+	if len(created) != 0 || len(deleted) != 0 || len(createdLabels) != 0 {
+		t.Fatalf("dry-run must not change anything")
+	}
+
+	var res gmailFiltersApplyResult
+	out = captureStdout(t, func() {
+		if err := runKong(t, &GmailFiltersApplyCmd{}, []string{path, "--prune"}, jsonCtx, &RootFlags{Account: "a@b.com", Force: true}); err != nil {
+			t.Fatalf("apply: %v", err)
+		}
+	})
+	if err := json.Unmarshal([]byte(out), &res); err != nil {
+		t.Fatalf("json parse: %v\n%s", err, out)
+	}
+	if len(createdLabels) != 1 || createdLabels[0] != "Team" {
+		t.Fatalf("expected Team label to be created, got %v", createdLabels)
+	}
+	if len(created) != 1 || created[0].Criteria.To != "team@example.com" ||
+		strings.Join(created[0].Action.AddLabelIds, ",") != "Label_2" ||
+		strings.Join(created[0].Action.RemoveLabelIds, ",") != "UNREAD" {
+		t.Fatalf("unexpected created filter: %+v", created)
+	}
+	if len(deleted) != 1 || deleted[0] != "f2" || len(res.Deleted) != 1 || len(res.Created) != 1 {
+		t.Fatalf("unexpected apply result: %s (deleted %v)", out, deleted)
+	}
+}
+
+func TestMailFiltersXMLRoundTrip(t *testing.T) {
+	specs := []gmailFilterSpec{
+		{
+			Criteria: gmailFilterSpecCriteria{From: "a&b@example.com", Size: 2 << 20, SizeComparison: "larger"},
+			Action:   gmailFilterSpecAction{AddLabels: []string{"Big <files>"}, Archive: true, Category: "promotions"},
+		},
+		{
+			Criteria: gmailFilterSpecCriteria{Query: "list:dev.example.com", HasAttachment: true},
+			Action:   gmailFilterSpecAction{MarkRead: true, NeverSpam: true, Forward: "me@example.com"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeMailFiltersXML(&buf, "a@b.com", specs, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	xmlText := buf.String()
+	for _, want := range []string{"name='size' value='2'", "name='sizeUnit' value='s_smb'", "value='^smartlabel_promo'", "a&amp;b@example.com"} {
+		if !strings.Contains(xmlText, want) {
+			t.Fatalf("xml missing %q:\n%s", want, xmlText)
+		}
+	}
+
+	got, err := decodeGmailFilters(buf.Bytes(), gmailFiltersFormatXML)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got) != len(specs) {
+		t.Fatalf("got %d filters", len(got))
+	}
+	for i := range specs {
+		if got[i].key() != specs[i].key() {
+			t.Fatalf("filter %d mismatch:\n got %s\nwant %s", i, got[i].describe(), specs[i].describe())
+		}
+	}
+}
+
+func TestDecodeGmailFilters_Validation(t *testing.T) {
+	if _, err := decodeGmailFilters([]byte("filters:\n  - criteria: {from: x}\n"), gmailFiltersFormatYAML); err == nil || !strings.Contains(err.Error(), "no action") {
+		t.Fatalf("expected no-action error, got %v", err)
+	}
+	if _, err := decodeGmailFilters([]byte("filters:\n  - action: {archive: true}\n"), gmailFiltersFormatYAML); err == nil || !strings.Contains(err.Error(), "no criteria") {
+		t.Fatalf("expected no-criteria error, got %v", err)
+	}
+	if _, err := decodeGmailFilters([]byte("filters:\n  - criteria: {form: x}\n"), gmailFiltersFormatYAML); err == nil {
+		t.Fatalf("expected unknown field error")
+	}
+}
+
+func TestPlanGmailFilters_ReplaceByCriteria(t *testing.T) {
+	news := gmailFilterSpecCriteria{From: "news@example.com"}
+	existing := []gmailFilterExisting{
+		{ID: "f1", Spec: gmailFilterSpec{Criteria: news, Action: gmailFilterSpecAction{Archive: true}}},
+		{ID: "f2", Spec: gmailFilterSpec{Criteria: gmailFilterSpecCriteria{Subject: "old"}, Action: gmailFilterSpecAction{Trash: true}}},
+		{ID: "f3", Spec: gmailFilterSpec{Criteria: news, Action: gmailFilterSpecAction{Star: true}}},
+	}
+	desired := []gmailFilterSpec{
+		{Criteria: news, Action: gmailFilterSpecAction{AddLabels: []string{"News"}, Archive: true}},
+	}
+	nameToID := map[string]string{"news": "Label_1"}
+
+	plan := planGmailFilters(desired, existing, nameToID, false)
+	if len(plan.Replace) != 1 || plan.Replace[0].ID != "f1" || len(plan.Replace[0].To.Action.AddLabels) != 1 {
+		t.Fatalf("expected f1 to be replaced without --prune: %+v", plan)
+	}
+	// Gmail allows several filters with the same criteria, so f3 is kept like
+	// f2 unless --prune is given.
+	if len(plan.Create) != 0 || len(plan.Delete) != 0 || plan.Extra != 2 {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+
+	plan = planGmailFilters(desired, existing, nameToID, true)
+	if len(plan.Replace) != 1 || len(plan.Delete) != 2 || plan.Extra != 0 {
+		t.Fatalf("unexpected --prune plan: %+v", plan)
+	}
+}
@@ -59,6 +59,12 @@ func (c *GmailForwardingListCmd) Run(ctx context.Context, flags *RootFlags) erro
 	return nil
 }
 
+func (*GmailForwardingListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "forwardingAddresses", Result: struct {
+		ForwardingAddresses []*gmail.ForwardingAddress `json:"forwardingAddresses"`
+	}{}}
+}
+
 type GmailForwardingGetCmd struct {
 	ForwardingEmail string `arg:"" name:"forwardingEmail" help:"Forwarding email"`
 }
@@ -93,6 +99,12 @@ func (c *GmailForwardingGetCmd) Run(ctx context.Context, flags *RootFlags) error
 	return nil
 }
 
+func (*GmailForwardingGetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		ForwardingAddress *gmail.ForwardingAddress `json:"forwardingAddress"`
+	}{}}
+}
+
 type GmailForwardingCreateCmd struct {
 	ForwardingEmail string `arg:"" name:"forwardingEmail" help:"Forwarding email"`
 }
@@ -141,6 +153,12 @@ func (c *GmailForwardingCreateCmd) Run(ctx context.Context, flags *RootFlags) er
 	return nil
 }
 
+func (*GmailForwardingCreateCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		ForwardingAddress *gmail.ForwardingAddress `json:"forwardingAddress"`
+	}{}}
+}
+
 type GmailForwardingDeleteCmd struct {
 	ForwardingEmail string `arg:"" name:"forwardingEmail" help:"Forwarding email"`
 }
@@ -181,3 +199,10 @@ func (c *GmailForwardingDeleteCmd) Run(ctx context.Context, flags *RootFlags) er
 	u.Out().Printf("Forwarding address %s deleted successfully", forwardingEmail)
 	return nil
 }
+
+func (*GmailForwardingDeleteCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Success         bool   `json:"success"`
+		ForwardingEmail string `json:"forwardingEmail"`
+	}{}}
+}
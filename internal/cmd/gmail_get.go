@@ -7,6 +7,8 @@ import (
 	"os"
 	"strings"
 
+	"google.golang.org/api/gmail/v1"
+
 	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
 )
@@ -144,3 +146,13 @@ func (c *GmailGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return nil
 	}
 }
+
+func (*GmailGetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Message     *gmail.Message     `json:"message"`
+		Headers     map[string]string  `json:"headers"`
+		Unsubscribe string             `json:"unsubscribe,omitempty"`
+		Body        string             `json:"body,omitempty"`
+		Attachments []attachmentOutput `json:"attachments,omitempty"`
+	}{}}
+}
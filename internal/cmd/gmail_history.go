@@ -90,3 +90,11 @@ func (c *GmailHistoryCmd) Run(ctx context.Context, flags *RootFlags) error {
 	printNextPageHint(u, nextPageToken)
 	return nil
 }
+
+func (*GmailHistoryCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "messages", Result: struct {
+		HistoryID     string   `json:"historyId"`
+		Messages      []string `json:"messages"`
+		NextPageToken string   `json:"nextPageToken"`
+	}{}}
+}
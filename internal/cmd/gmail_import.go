@@ -0,0 +1,582 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/gmail/v1"
+	gapi "google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/internal/workpool"
+)
+
+const (
+	gmailImportModeImport = "import"
+	gmailImportModeInsert = "insert"
+
+	gmailImportStateVersion = 1
+	// gmailImportBatch is how many messages are uploaded between state saves.
+	gmailImportBatch = 25
+
+	gmailImportReasonSource   = "duplicate_in_source"
+	gmailImportReasonImported = "already_imported"
+	gmailImportReasonMailbox  = "exists_in_mailbox"
+)
+
+type GmailImportCmd struct {
+	From               string `name:"from" required:"" help:"mbox file, .eml file, or directory of .eml files (e.g. from gmail export)"`
+	Label              string `name:"label" help:"Labels to apply (comma-separated, name or ID; e.g. Imported,INBOX,UNREAD)"`
+	PreserveLabels     bool   `name:"preserve-labels" help:"Also apply labels named in each message's X-Gmail-Labels header"`
+	CreateLabels       bool   `name:"create-labels" help:"Create user labels that do not exist yet (default: true; use --no-create-labels to fail instead)" default:"true" negatable:"_"`
+	Mode               string `name:"mode" help:"import: deliver with standard scanning (messages.import); insert: store as-is like IMAP APPEND (messages.insert)" enum:"import,insert" default:"import"`
+	NeverMarkSpam      bool   `name:"never-mark-spam" help:"Never send imported messages to SPAM (import mode)"`
+	ProcessForCalendar bool   `name:"process-for-calendar" help:"Process calendar invites in imported messages (import mode)"`
+	InternalDateSource string `name:"internal-date-source" help:"Message date source: dateHeader or receivedTime" enum:"dateHeader,receivedTime" default:"dateHeader"`
+	SkipExisting       bool   `name:"skip-existing" help:"Skip messages whose Message-ID already exists in the mailbox (one search per message)"`
+	State              string `name:"state" help:"State file recording imported Message-IDs for resuming (default: <from>.gog-import.json, or <from>/.gog-import.json for directories)"`
+}
+
+// gmailImportState maps message keys (Message-ID, or a content hash for
+// messages without one) to the Gmail ID they were imported as.
+type gmailImportState struct {
+	Version   int               `json:"version"`
+	Account   string            `json:"account"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+	Imported  map[string]string `json:"imported"`
+}
+
+type gmailImportDuplicate struct {
+	Source    string `json:"source"`
+	MessageID string `json:"messageId,omitempty"`
+	Reason    string `json:"reason"`
+	GmailID   string `json:"gmailId,omitempty"`
+}
+
+type gmailImportFailure struct {
+	Source    string `json:"source"`
+	MessageID string `json:"messageId,omitempty"`
+	Error     string `json:"error"`
+}
+
+type gmailImportResult struct {
+	Account    string                 `json:"account"`
+	From       string                 `json:"from"`
+	State      string                 `json:"state"`
+	Mode       string                 `json:"mode"`
+	Read       int                    `json:"read"`
+	Imported   int                    `json:"imported"`
+	Duplicates []gmailImportDuplicate `json:"duplicates"`
+	Failed     []gmailImportFailure   `json:"failed"`
+}
+
+// gmailImportSource is one message read from the input.
+type gmailImportSource struct {
+	Name      string
+	Raw       []byte
+	MessageID string
+	Key       string
+	Labels    []string
+}
+
+func (c *GmailImportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+
+	from, err := config.ExpandPath(strings.TrimSpace(c.From))
+	if err != nil {
+		return err
+	}
+	if from == "" {
+		return usage("empty --from")
+	}
+	info, err := os.Stat(from)
+	if err != nil {
+		return err
+	}
+	statePath := strings.TrimSpace(c.State)
+	switch {
+	case statePath != "":
+		if statePath, err = config.ExpandPath(statePath); err != nil {
+			return err
+		}
+	case info.IsDir():
+		statePath = filepath.Join(from, ".gog-import.json")
+	default:
+		statePath = from + ".gog-import.json"
+	}
+	mode := strings.ToLower(strings.TrimSpace(c.Mode))
+	labelNames := splitCSV(c.Label)
+
+	if err := dryRunExit(ctx, flags, "gmail.import", map[string]any{
+		"from":            from,
+		"state":           statePath,
+		"mode":            mode,
+		"labels":          labelNames,
+		"preserve_labels": c.PreserveLabels,
+	}); err != nil {
+		return err
+	}
+
+	state, err := loadGmailImportState(statePath)
+	if err != nil {
+		return err
+	}
+	if state == nil {
+		state = &gmailImportState{Version: gmailImportStateVersion, Account: account, Imported: map[string]string{}}
+	} else if state.Account != "" && !strings.EqualFold(state.Account, account) {
+		return usagef("state %s belongs to %s; use another --state to import into %s", statePath, state.Account, account)
+	}
+
+	svc, err := newGmailService(ctx, account)
+	if err != nil {
+		return err
+	}
+	labels, err := newGmailImportLabels(svc, c.CreateLabels)
+	if err != nil {
+		return err
+	}
+	baseIDs, err := labels.resolve(ctx, labelNames)
+	if err != nil {
+		return err
+	}
+
+	reader, err := openGmailImportReader(from, info)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	res := gmailImportResult{Account: account, From: from, State: statePath, Mode: mode, Duplicates: []gmailImportDuplicate{}, Failed: []gmailImportFailure{}}
+	seen := map[string]string{}
+	batch := make([]*gmailImportSource, 0, gmailImportBatch)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := c.importBatch(ctx, svc, labels, baseIDs, batch, state, &res); err != nil {
+			return err
+		}
+		batch = batch[:0]
+		return saveGmailImportState(statePath, state)
+	}
+
+	for {
+		src, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		res.Read++
+
+		if first, ok := seen[src.Key]; ok {
+			res.Duplicates = append(res.Duplicates, gmailImportDuplicate{Source: src.Name, MessageID: src.MessageID, Reason: gmailImportReasonSource, GmailID: state.Imported[src.Key]})
+			if !outfmt.IsJSON(ctx) {
+				u.Err().Printf("duplicate\t%s\t%s (first seen in %s)", src.Name, src.MessageID, first)
+			}
+			continue
+		}
+		seen[src.Key] = src.Name
+		if id, ok := state.Imported[src.Key]; ok {
+			res.Duplicates = append(res.Duplicates, gmailImportDuplicate{Source: src.Name, MessageID: src.MessageID, Reason: gmailImportReasonImported, GmailID: id})
+			continue
+		}
+
+		batch = append(batch, src)
+		if len(batch) == gmailImportBatch {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	if err := saveGmailImportState(statePath, state); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, res)
+	}
+
+	for _, f := range res.Failed {
+		u.Err().Errorf("%s: %s", f.Source, f.Error)
+	}
+	u.Out().Printf("read\t%d", res.Read)
+	u.Out().Printf("imported\t%d", res.Imported)
+	u.Out().Printf("duplicates\t%d", len(res.Duplicates))
+	u.Out().Printf("failed\t%d", len(res.Failed))
+	u.Out().Printf("state\t%s", res.State)
+	return nil
+}
+
+func (*GmailImportCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: gmailImportResult{}}
+}
+
+// importBatch uploads a batch concurrently; successes are recorded in state
+// so a re-run skips them, failures are reported and retried next time.
+func (c *GmailImportCmd) importBatch(ctx context.Context, svc *gmail.Service, labels *gmailImportLabels, baseIDs []string, batch []*gmailImportSource, state *gmailImportState, res *gmailImportResult) error {
+	labelIDs := make([][]string, len(batch))
+	for i, src := range batch {
+		ids := append([]string{}, baseIDs...)
+		if c.PreserveLabels {
+			extra, err := labels.resolve(ctx, src.Labels)
+			if err != nil {
+				return err
+			}
+			for _, id := range extra {
+				ids = appendUnique(ids, id)
+			}
+		}
+		labelIDs[i] = ids
+	}
+
+	type outcome struct {
+		gmailID  string
+		existing bool
+		err      error
+	}
+	idx := make([]int, len(batch))
+	for i := range idx {
+		idx[i] = i
+	}
+	outcomes, err := workpool.Map(ctx, workpool.Limit(ctx, workpool.DefaultLimit), idx, func(ctx context.Context, i int) (outcome, error) {
+		src := batch[i]
+		if c.SkipExisting && src.MessageID != "" {
+			id, err := findGmailMessageByRFC822ID(ctx, svc, src.MessageID)
+			if err != nil {
+				return outcome{err: err}, nil
+			}
+			if id != "" {
+				return outcome{gmailID: id, existing: true}, nil
+			}
+		}
+		id, err := c.upload(ctx, svc, src.Raw, labelIDs[i])
+		return outcome{gmailID: id, err: err}, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, o := range outcomes {
+		src := batch[i]
+		switch {
+		case o.err != nil:
+			res.Failed = append(res.Failed, gmailImportFailure{Source: src.Name, MessageID: src.MessageID, Error: o.err.Error()})
+		case o.existing:
+			state.Imported[src.Key] = o.gmailID
+			res.Duplicates = append(res.Duplicates, gmailImportDuplicate{Source: src.Name, MessageID: src.MessageID, Reason: gmailImportReasonMailbox, GmailID: o.gmailID})
+		default:
+			state.Imported[src.Key] = o.gmailID
+			res.Imported++
+		}
+	}
+
+	return nil
+}
+
+func (c *GmailImportCmd) upload(ctx context.Context, svc *gmail.Service, raw []byte, labelIDs []string) (string, error) {
+	msg := &gmail.Message{LabelIds: labelIDs}
+	media := gapi.ContentType("message/rfc822")
+
+	var (
+		out *gmail.Message
+		err error
+	)
+	if strings.EqualFold(c.Mode, gmailImportModeInsert) {
+		out, err = svc.Users.Messages.Insert("me", msg).
+			InternalDateSource(c.InternalDateSource).
+			Media(bytes.NewReader(raw), media).
+			Fields("id").Context(ctx).Do()
+	} else {
+		out, err = svc.Users.Messages.Import("me", msg).
+			InternalDateSource(c.InternalDateSource).
+			NeverMarkSpam(c.NeverMarkSpam).
+			ProcessForCalendar(c.ProcessForCalendar).
+			Media(bytes.NewReader(raw), media).
+			Fields("id").Context(ctx).Do()
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return out.Id, nil
+}
+
+func findGmailMessageByRFC822ID(ctx context.Context, svc *gmail.Service, messageID string) (string, error) {
+	resp, err := svc.Users.Messages.List("me").Q("rfc822msgid:" + messageID).
+		IncludeSpamTrash(true).MaxResults(1).Context(ctx).Do()
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Messages) == 0 || resp.Messages[0] == nil {
+		return "", nil
+	}
+
+	return resp.Messages[0].Id, nil
+}
+
+// gmailImportLabels resolves label names to IDs via resolveLabelIDs,
+// creating missing user labels once when allowed.
+type gmailImportLabels struct {
+	svc      *gmail.Service
+	create   bool
+	nameToID map[string]string
+}
+
+// gmailImportSkipLabels cannot be applied to imported messages.
+var gmailImportSkipLabels = map[string]bool{"draft": true, "chat": true}
+
+func newGmailImportLabels(svc *gmail.Service, create bool) (*gmailImportLabels, error) {
+	nameToID, err := fetchLabelNameToID(svc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gmailImportLabels{svc: svc, create: create, nameToID: nameToID}, nil
+}
+
+func (l *gmailImportLabels) resolve(ctx context.Context, names []string) ([]string, error) {
+	var ids []string
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if name == "" || gmailImportSkipLabels[key] {
+			continue
+		}
+		if _, ok := l.nameToID[key]; !ok {
+			if !l.create {
+				return nil, usagef("label not found: %s (omit --no-create-labels to create it)", name)
+			}
+			label, err := createLabel(ctx, l.svc, name)
+			if err != nil && !isDuplicateLabelError(err) {
+				return nil, mapLabelCreateError(err, name)
+			}
+			if label != nil {
+				l.nameToID[key] = label.Id
+			} else if l.nameToID, err = fetchLabelNameToID(l.svc); err != nil {
+				return nil, err
+			}
+		}
+		for _, id := range resolveLabelIDs([]string{name}, l.nameToID) {
+			ids = appendUnique(ids, id)
+		}
+	}
+
+	return ids, nil
+}
+
+type gmailImportReader interface {
+	// Next returns the next message, or io.EOF.
+	Next() (*gmailImportSource, error)
+	Close() error
+}
+
+func openGmailImportReader(path string, info fs.FileInfo) (gmailImportReader, error) {
+	if info.IsDir() {
+		var files []string
+		err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			name := d.Name()
+			if strings.HasPrefix(name, ".") && p != path {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() {
+				return nil
+			}
+			// Maildir cur/ and new/ entries have no extension.
+			parent := filepath.Base(filepath.Dir(p))
+			if strings.EqualFold(filepath.Ext(name), ".eml") || parent == "cur" || parent == "new" {
+				files = append(files, p)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(files)
+		return &emlFilesReader{root: path, files: files}, nil
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".eml") {
+		return &emlFilesReader{root: filepath.Dir(path), files: []string{path}}, nil
+	}
+
+	f, err := os.Open(path) //nolint:gosec // user-provided import path
+	if err != nil {
+		return nil, err
+	}
+	return &mboxReader{path: path, f: f, r: bufio.NewReaderSize(f, 64*1024)}, nil
+}
+
+type emlFilesReader struct {
+	root  string
+	files []string
+	next  int
+}
+
+func (e *emlFilesReader) Next() (*gmailImportSource, error) {
+	if e.next >= len(e.files) {
+		return nil, io.EOF
+	}
+	path := e.files[e.next]
+	e.next++
+
+	raw, err := os.ReadFile(path) //nolint:gosec // file found under the import directory
+	if err != nil {
+		return nil, err
+	}
+	name, err := filepath.Rel(e.root, path)
+	if err != nil {
+		name = path
+	}
+
+	return newGmailImportSource(name, raw), nil
+}
+
+func (e *emlFilesReader) Close() error { return nil }
+
+// mboxReader streams messages from an mbox file, undoing mboxrd
+// ">From " quoting (which also covers the common mboxo files).
+type mboxReader struct {
+	path    string
+	f       *os.File
+	r       *bufio.Reader
+	pending bool // a "From " separator was consumed for the next message
+	count   int
+}
+
+func (m *mboxReader) Next() (*gmailImportSource, error) {
+	var buf bytes.Buffer
+	started := m.pending
+	for {
+		line, err := m.r.ReadBytes('\n')
+		if len(line) > 0 {
+			if bytes.HasPrefix(line, []byte("From ")) {
+				if started {
+					m.pending = true
+					return m.finish(buf.Bytes()), nil
+				}
+				started = true
+			} else if started {
+				if bytes.HasPrefix(bytes.TrimLeft(line, ">"), []byte("From ")) && line[0] == '>' {
+					line = line[1:]
+				}
+				buf.Write(line)
+			} else if len(bytes.TrimSpace(line)) > 0 {
+				return nil, fmt.Errorf("%s is not an mbox file (expected a \"From \" line)", m.path)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			m.pending = false
+			if !started {
+				return nil, io.EOF
+			}
+			return m.finish(buf.Bytes()), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (m *mboxReader) finish(data []byte) *gmailImportSource {
+	m.count++
+	// Drop the blank line that separates messages.
+	if bytes.HasSuffix(data, []byte("\r\n\r\n")) {
+		data = data[:len(data)-2]
+	} else if bytes.HasSuffix(data, []byte("\n\n")) {
+		data = data[:len(data)-1]
+	}
+
+	return newGmailImportSource(fmt.Sprintf("%s#%d", filepath.Base(m.path), m.count), append([]byte(nil), data...))
+}
+
+func (m *mboxReader) Close() error { return m.f.Close() }
+
+func newGmailImportSource(name string, raw []byte) *gmailImportSource {
+	src := &gmailImportSource{Name: name, Raw: raw}
+	if msg, err := mail.ReadMessage(bytes.NewReader(raw)); err == nil {
+		src.MessageID = strings.TrimSpace(msg.Header.Get("Message-Id"))
+		if v := msg.Header.Get("X-Gmail-Labels"); v != "" {
+			src.Labels = splitCSV(v)
+		}
+	}
+
+	if src.MessageID != "" {
+		src.Key = "msgid:" + strings.Trim(src.MessageID, "<>")
+	} else {
+		sum := sha256.Sum256(raw)
+		src.Key = "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	return src
+}
+
+func loadGmailImportState(path string) (*gmailImportState, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // user-provided state path
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var state gmailImportState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("read import state %s: %w", path, err)
+	}
+	if state.Version != gmailImportStateVersion {
+		return nil, fmt.Errorf("import state %s has unsupported version %d", path, state.Version)
+	}
+	if state.Imported == nil {
+		state.Imported = map[string]string{}
+	}
+
+	return &state, nil
+}
+
+func saveGmailImportState(path string, state *gmailImportState) error {
+	state.UpdatedAt = time.Now().UTC()
+	payload, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(payload, '\n'), 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
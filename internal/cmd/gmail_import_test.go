@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+func TestGmailImport_MboxResumableWithDuplicates(t *testing.T) {
+	origNew := newGmailService
+	t.Cleanup(func() { newGmailService = origNew })
+
+	var (
+		mu       sync.Mutex
+		uploads  []string
+		created  []string
+		queryErr string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := r.URL.Path
+		var body any
+		switch {
+		case strings.HasSuffix(p, "/users/me/labels") && r.Method == http.MethodGet:
+			body = map[string]any{"labels": []map[string]any{{"id": "INBOX", "name": "INBOX"}}}
+		case strings.HasSuffix(p, "/users/me/labels") && r.Method == http.MethodPost:
+			var label gmail.Label
+			_ = json.NewDecoder(r.Body).Decode(&label)
+			mu.Lock()
+			created = append(created, label.Name)
+			mu.Unlock()
+			body = map[string]any{"id": "Label_9", "name": label.Name}
+		case strings.HasSuffix(p, "/messages/import"):
+			q := r.URL.Query()
+			if q.Get("neverMarkSpam") != "true" || q.Get("internalDateSource") != "dateHeader" {
+				mu.Lock()
+				queryErr = r.URL.RawQuery
+				mu.Unlock()
+			}
+			data, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			uploads = append(uploads, string(data))
+			n := len(uploads)
+			mu.Unlock()
+			body = map[string]any{"id": "g" + string(rune('0'+n))}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer srv.Close()
+
+	svc, err := gmail.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("gmail.NewService: %v", err)
+	}
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return svc, nil }
+
+	mbox := "From MAILER-DAEMON Mon Mar  2 10:00:00 2026\n" +
+		"Message-ID: <one@example.com>\nSubject: One\n\n>From the archive\n\n" +
+		"From MAILER-DAEMON Mon Mar  2 11:00:00 2026\n" +
+		"Message-ID: <two@example.com>\nSubject: Two\n\nbody\n\n" +
+		"From MAILER-DAEMON Mon Mar  2 12:00:00 2026\n" +
+		"Message-ID: <two@example.com>\nSubject: Two again\n\nbody\n"
+	path := filepath.Join(t.TempDir(), "legacy.mbox")
+	if err := os.WriteFile(path, []byte(mbox), 0o600); err != nil {
+		t.Fatalf("write mbox: %v", err)
+	}
+
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{JSON: true})
+	flags := &RootFlags{Account: "a@b.com"}
+
+	run := func() gmailImportResult {
+		t.Helper()
+		stdout := captureStdout(t, func() {
+			args := []string{"--from", path, "--label", "Imported,INBOX", "--never-mark-spam"}
+			if err := runKong(t, &GmailImportCmd{}, args, ctx, flags); err != nil {
+				t.Fatalf("import: %v", err)
+			}
+		})
+		var parsed gmailImportResult
+		if err := json.Unmarshal([]byte(stdout), &parsed); err != nil {
+			t.Fatalf("json parse: %v\n%s", err, stdout)
+		}
+		return parsed
+	}
+
+	first := run()
+	if first.Read != 3 || first.Imported != 2 || len(first.Failed) != 0 {
+		t.Fatalf("unexpected first import: %#v", first)
+	}
+	if len(first.Duplicates) != 1 || first.Duplicates[0].Reason != gmailImportReasonSource || first.Duplicates[0].MessageID != "<two@example.com>" || first.Duplicates[0].Source != "legacy.mbox#3" {
+		t.Fatalf("unexpected duplicates: %#v", first.Duplicates)
+	}
+	if queryErr != "" {
+		t.Fatalf("unexpected import query: %s", queryErr)
+	}
+	if strings.Join(created, ",") != "Imported" {
+		t.Fatalf("expected Imported label to be created once, got %v", created)
+	}
+	joined := strings.Join(uploads, "\n----\n")
+	if !strings.Contains(joined, `"labelIds":["Label_9","INBOX"]`) {
+		t.Fatalf("expected resolved label IDs in upload:\n%s", joined)
+	}
+	if !strings.Contains(joined, "\nFrom the archive\n") || strings.Contains(joined, ">From the archive") {
+		t.Fatalf("expected mboxrd unescaping:\n%s", joined)
+	}
+
+	second := run()
+	if second.Imported != 0 || len(second.Duplicates) != 3 || len(uploads) != 2 {
+		t.Fatalf("expected resumed run to skip everything: %#v (uploads %d)", second, len(uploads))
+	}
+	if second.Duplicates[0].Reason != gmailImportReasonImported || second.Duplicates[0].GmailID == "" {
+		t.Fatalf("unexpected resumed duplicate: %#v", second.Duplicates[0])
+	}
+}
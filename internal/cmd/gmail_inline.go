@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/steipete/gogcli/internal/config"
+)
+
+var (
+	inlineNameRe   = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._@-]*$`)
+	inlineUnsafeRe = regexp.MustCompile(`[^A-Za-z0-9._@-]+`)
+	inlineImgSrcRe = regexp.MustCompile(`(<img\s[^>]*?src=")([^"]*)(")`)
+)
+
+// resolveInlineImages turns --inline name=path specs into inline parts
+// referenced as cid:name from the HTML body. For Markdown bodies, image
+// references to an --inline name or to a local file are rewritten to cid:
+// URLs, adding the local files as inline parts; mdFile (if any) is the base
+// for relative paths.
+func resolveInlineImages(specs []string, htmlBody string, markdown bool, mdFile string) (string, []mailAttachment, error) {
+	inline := make([]mailAttachment, 0, len(specs))
+	byName := map[string]string{}
+	for _, spec := range specs {
+		name, path, ok := strings.Cut(spec, "=")
+		if !ok {
+			path = name
+			name = ""
+		}
+		path = strings.TrimSpace(path)
+		if path == "" {
+			return "", nil, usagef("invalid --inline %q (expected name=path)", spec)
+		}
+		expanded, err := config.ExpandPath(path)
+		if err != nil {
+			return "", nil, err
+		}
+		name = strings.TrimSpace(name)
+		if name == "" {
+			name = inlineImageName(expanded)
+		}
+		if !inlineNameRe.MatchString(name) {
+			return "", nil, usagef("invalid --inline name %q (use letters, digits, '.', '_', '@' or '-')", name)
+		}
+		if _, dup := byName[name]; dup {
+			return "", nil, usagef("duplicate --inline name %q", name)
+		}
+		if err := checkInlineFile(expanded); err != nil {
+			return "", nil, err
+		}
+		byName[name] = expanded
+		inline = append(inline, mailAttachment{Path: expanded, ContentID: name})
+	}
+
+	if markdown && strings.TrimSpace(htmlBody) != "" {
+		baseDir := ""
+		if mdFile = strings.TrimSpace(mdFile); mdFile != "" && mdFile != "-" {
+			if expanded, err := config.ExpandPath(mdFile); err == nil {
+				baseDir = filepath.Dir(expanded)
+			}
+		}
+
+		byPath := map[string]string{}
+		for name, path := range byName {
+			byPath[path] = name
+		}
+
+		var linkErr error
+		htmlBody = inlineImgSrcRe.ReplaceAllStringFunc(htmlBody, func(tag string) string {
+			m := inlineImgSrcRe.FindStringSubmatch(tag)
+			src := html.UnescapeString(m[2])
+			if _, ok := byName[src]; ok {
+				return m[1] + "cid:" + src + m[3]
+			}
+			path, ok := localImagePath(src, baseDir)
+			if !ok || linkErr != nil {
+				return tag
+			}
+			name, seen := byPath[path]
+			if !seen {
+				if err := checkInlineFile(path); err != nil {
+					linkErr = err
+					return tag
+				}
+				name = uniqueInlineName(inlineImageName(path), byName)
+				byName[name] = path
+				byPath[path] = name
+				inline = append(inline, mailAttachment{Path: path, ContentID: name})
+			}
+			return m[1] + "cid:" + name + m[3]
+		})
+		if linkErr != nil {
+			return "", nil, linkErr
+		}
+	}
+
+	if len(inline) > 0 && strings.TrimSpace(htmlBody) == "" {
+		return "", nil, usage("--inline requires --body-html or --body-markdown")
+	}
+
+	return htmlBody, inline, nil
+}
+
+// inlineOutputs maps Content-IDs to file paths for dry-run output.
+func inlineOutputs(inline []mailAttachment) map[string]string {
+	out := make(map[string]string, len(inline))
+	for _, a := range inline {
+		out[a.ContentID] = a.Path
+	}
+	return out
+}
+
+func checkInlineFile(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("inline image: %w", err)
+	}
+	if info.IsDir() {
+		return usagef("inline image %q is a directory", path)
+	}
+	return nil
+}
+
+// localImagePath resolves a Markdown image destination to a local file path.
+// URLs with a scheme (https:, cid:, data:) are left alone.
+func localImagePath(src, baseDir string) (string, bool) {
+	if src == "" || strings.HasPrefix(src, "//") || strings.HasPrefix(src, "#") {
+		return "", false
+	}
+	if u, err := url.Parse(src); err != nil || u.Scheme != "" {
+		return "", false
+	}
+	if unescaped, err := url.PathUnescape(src); err == nil {
+		src = unescaped
+	}
+	path, err := config.ExpandPath(src)
+	if err != nil {
+		return "", false
+	}
+	if !filepath.IsAbs(path) && baseDir != "" {
+		path = filepath.Join(baseDir, path)
+	}
+	return path, true
+}
+
+func inlineImageName(path string) string {
+	name := strings.Trim(inlineUnsafeRe.ReplaceAllString(filepath.Base(path), "-"), "-.")
+	if name == "" {
+		return "image"
+	}
+	return name
+}
+
+func uniqueInlineName(name string, taken map[string]string) string {
+	if _, ok := taken[name]; !ok {
+		return name
+	}
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s-%d", name, i)
+		if _, ok := taken[candidate]; !ok {
+			return candidate
+		}
+	}
+}
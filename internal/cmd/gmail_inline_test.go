@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveInlineImages_MarkdownAutoLink(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"chart.png", "logo.png"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("png"), 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	mdFile := filepath.Join(dir, "note.md")
+	md := "![Logo](brand)\n\n![Chart](chart.png) ![Again](chart.png) ![Remote](https://example.com/x.png)\n"
+	if err := os.WriteFile(mdFile, []byte(md), 0o600); err != nil {
+		t.Fatalf("write md: %v", err)
+	}
+
+	_, html, err := resolveComposeBodies("", "", "", "", mdFile)
+	if err != nil {
+		t.Fatalf("resolveComposeBodies: %v", err)
+	}
+	html, inline, err := resolveInlineImages([]string{"brand=" + filepath.Join(dir, "logo.png")}, html, true, mdFile)
+	if err != nil {
+		t.Fatalf("resolveInlineImages: %v", err)
+	}
+
+	for _, want := range []string{`src="cid:brand"`, `src="cid:chart.png"`, `src="https://example.com/x.png"`} {
+		if !strings.Contains(html, want) {
+			t.Fatalf("html missing %q:\n%s", want, html)
+		}
+	}
+	got := inlineOutputs(inline)
+	if len(inline) != 2 || got["brand"] != filepath.Join(dir, "logo.png") || got["chart.png"] != filepath.Join(dir, "chart.png") {
+		t.Fatalf("unexpected inline parts: %#v", got)
+	}
+}
+
+func TestResolveInlineImages_Errors(t *testing.T) {
+	dir := t.TempDir()
+	img := filepath.Join(dir, "logo.png")
+	if err := os.WriteFile(img, []byte("png"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	cases := map[string]struct {
+		specs []string
+		html  string
+	}{
+		"no html":        {specs: []string{"logo=" + img}},
+		"bad name":       {specs: []string{"a b=" + img}, html: "<p>x</p>"},
+		"duplicate name": {specs: []string{"logo=" + img, "logo=" + img}, html: "<p>x</p>"},
+		"missing file":   {specs: []string{"logo=" + filepath.Join(dir, "nope.png")}, html: "<p>x</p>"},
+	}
+	for name, tc := range cases {
+		if _, _, err := resolveInlineImages(tc.specs, tc.html, false, ""); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+
+	_, inline, err := resolveInlineImages([]string{img}, "<p>x</p>", false, "")
+	if err != nil || len(inline) != 1 || inline[0].ContentID != "logo.png" {
+		t.Fatalf("expected name from file, got %#v (%v)", inline, err)
+	}
+}
@@ -16,7 +16,10 @@ type GmailLabelsCmd struct {
 	List   GmailLabelsListCmd   `cmd:"" name:"list" aliases:"ls" help:"List labels"`
 	Get    GmailLabelsGetCmd    `cmd:"" name:"get" aliases:"info,show" help:"Get label details (including counts)"`
 	Create GmailLabelsCreateCmd `cmd:"" name:"create" aliases:"add,new" help:"Create a new label"`
-	Modify GmailLabelsModifyCmd `cmd:"" name:"modify" aliases:"update,edit,set" help:"Modify labels on threads"`
+	Modify GmailLabelsModifyCmd `cmd:"" name:"modify" aliases:"edit,set" help:"Modify labels on threads"`
+	Update GmailLabelsUpdateCmd `cmd:"" name:"update" help:"Update a label's name, color or visibility"`
+	Rename GmailLabelsRenameCmd `cmd:"" name:"rename" aliases:"mv" help:"Rename a label (--recursive also renames nested labels)"`
+	Tree   GmailLabelsTreeCmd   `cmd:"" name:"tree" help:"Show nested labels with message and thread counts per subtree"`
 	Delete GmailLabelsDeleteCmd `cmd:"" name:"delete" aliases:"rm,del" help:"Delete a label"`
 }
 
@@ -66,6 +69,12 @@ func (c *GmailLabelsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*GmailLabelsGetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Label *gmail.Label `json:"label"`
+	}{}}
+}
+
 type GmailLabelsCreateCmd struct {
 	Name string `arg:"" help:"Label name"`
 }
@@ -104,6 +113,12 @@ func (c *GmailLabelsCreateCmd) Run(ctx context.Context, flags *RootFlags) error
 	return nil
 }
 
+func (*GmailLabelsCreateCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Label *gmail.Label `json:"label"`
+	}{}}
+}
+
 func createLabel(ctx context.Context, svc *gmail.Service, name string) (*gmail.Label, error) {
 	return svc.Users.Labels.Create("me", &gmail.Label{
 		Name:                  name,
@@ -147,12 +162,24 @@ func (c *GmailLabelsListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*GmailLabelsListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "labels", Result: struct {
+		Labels []*gmail.Label `json:"labels"`
+	}{}}
+}
+
 type GmailLabelsModifyCmd struct {
 	ThreadIDs []string `arg:"" name:"threadId" help:"Thread IDs"`
 	Add       string   `name:"add" help:"Labels to add (comma-separated, name or ID)"`
 	Remove    string   `name:"remove" help:"Labels to remove (comma-separated, name or ID)"`
 }
 
+type labelModifyResult struct {
+	ThreadID string `json:"threadId"`
+	Success  bool   `json:"success"`
+	Error    string `json:"error,omitempty"`
+}
+
 func (c *GmailLabelsModifyCmd) Run(ctx context.Context, flags *RootFlags) error {
 	u := ui.FromContext(ctx)
 	account, err := requireAccount(flags)
@@ -179,12 +206,7 @@ func (c *GmailLabelsModifyCmd) Run(ctx context.Context, flags *RootFlags) error
 	addIDs := resolveLabelIDs(addLabels, idMap)
 	removeIDs := resolveLabelIDs(removeLabels, idMap)
 
-	type result struct {
-		ThreadID string `json:"threadId"`
-		Success  bool   `json:"success"`
-		Error    string `json:"error,omitempty"`
-	}
-	results := make([]result, 0, len(threadIDs))
+	results := make([]labelModifyResult, 0, len(threadIDs))
 
 	for _, tid := range threadIDs {
 		_, err := svc.Users.Threads.Modify("me", tid, &gmail.ModifyThreadRequest{
@@ -192,13 +214,13 @@ func (c *GmailLabelsModifyCmd) Run(ctx context.Context, flags *RootFlags) error
 			RemoveLabelIds: removeIDs,
 		}).Context(ctx).Do()
 		if err != nil {
-			results = append(results, result{ThreadID: tid, Success: false, Error: err.Error()})
+			results = append(results, labelModifyResult{ThreadID: tid, Success: false, Error: err.Error()})
 			if !outfmt.IsJSON(ctx) {
 				u.Err().Errorf("%s: %s", tid, err.Error())
 			}
 			continue
 		}
-		results = append(results, result{ThreadID: tid, Success: true})
+		results = append(results, labelModifyResult{ThreadID: tid, Success: true})
 		if !outfmt.IsJSON(ctx) {
 			u.Out().Printf("%s\tok", tid)
 		}
@@ -209,6 +231,12 @@ func (c *GmailLabelsModifyCmd) Run(ctx context.Context, flags *RootFlags) error
 	return nil
 }
 
+func (*GmailLabelsModifyCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "results", Result: struct {
+		Results []labelModifyResult `json:"results"`
+	}{}}
+}
+
 func fetchLabelNameToID(svc *gmail.Service) (map[string]string, error) {
 	resp, err := svc.Users.Labels.List("me").Do()
 	if err != nil {
@@ -304,6 +332,14 @@ func (c *GmailLabelsDeleteCmd) Run(ctx context.Context, flags *RootFlags) error
 	)
 }
 
+func (*GmailLabelsDeleteCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Deleted bool   `json:"deleted"`
+		ID      string `json:"id"`
+		Name    string `json:"name"`
+	}{}}
+}
+
 func fetchLabelIDToName(svc *gmail.Service) (map[string]string, error) {
 	resp, err := svc.Users.Labels.List("me").Do()
 	if err != nil {
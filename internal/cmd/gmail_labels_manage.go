@@ -0,0 +1,453 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/gmail/v1"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/internal/workpool"
+)
+
+var labelColorRe = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// lookupLabel resolves a label ID or name (case-insensitive) through the
+// label list, then fetches it by ID.
+func lookupLabel(ctx context.Context, svc *gmail.Service, raw string) (*gmail.Label, error) {
+	idMap, err := fetchLabelNameToID(svc)
+	if err != nil {
+		return nil, err
+	}
+	id, ok := idMap[strings.ToLower(raw)]
+	if !ok {
+		return nil, fmt.Errorf("label not found: %s", raw)
+	}
+	return svc.Users.Labels.Get("me", id).Context(ctx).Do()
+}
+
+func normalizeLabelListVisibility(v string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "show", "labelshow":
+		return "labelShow", nil
+	case "hide", "labelhide":
+		return "labelHide", nil
+	case "show-if-unread", "showifunread", "labelshowifunread":
+		return "labelShowIfUnread", nil
+	default:
+		return "", usagef("invalid --label-list-visibility %q (use show, hide or show-if-unread)", v)
+	}
+}
+
+func normalizeMessageListVisibility(v string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(v)) {
+	case "show":
+		return "show", nil
+	case "hide":
+		return "hide", nil
+	default:
+		return "", usagef("invalid --message-list-visibility %q (use show or hide)", v)
+	}
+}
+
+type GmailLabelsUpdateCmd struct {
+	Label                 string `arg:"" name:"labelIdOrName" help:"Label ID or name"`
+	Name                  string `name:"name" help:"New label name (nested labels keep their old prefix; see 'labels rename --recursive')"`
+	Color                 string `name:"background-color" aliases:"bg-color" help:"Background color (#rrggbb from Gmail's label palette)"`
+	TextColor             string `name:"text-color" help:"Text color (#rrggbb from Gmail's label palette)"`
+	LabelListVisibility   string `name:"label-list-visibility" help:"Visibility in the label list: show|hide|show-if-unread"`
+	MessageListVisibility string `name:"message-list-visibility" help:"Visibility in the message list: show|hide"`
+}
+
+func (c *GmailLabelsUpdateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+
+	raw := strings.TrimSpace(c.Label)
+	if raw == "" {
+		return usage("empty label")
+	}
+
+	patch := &gmail.Label{}
+	changed := false
+	if name := strings.TrimSpace(c.Name); name != "" {
+		patch.Name = name
+		changed = true
+	}
+	for _, color := range []struct{ flag, value string }{{"--background-color", c.Color}, {"--text-color", c.TextColor}} {
+		if v := strings.TrimSpace(color.value); v != "" {
+			if !labelColorRe.MatchString(v) {
+				return usagef("invalid %s %q (expected #rrggbb)", color.flag, v)
+			}
+			changed = true
+		}
+	}
+	if strings.TrimSpace(c.LabelListVisibility) != "" {
+		v, err := normalizeLabelListVisibility(c.LabelListVisibility)
+		if err != nil {
+			return err
+		}
+		patch.LabelListVisibility = v
+		changed = true
+	}
+	if strings.TrimSpace(c.MessageListVisibility) != "" {
+		v, err := normalizeMessageListVisibility(c.MessageListVisibility)
+		if err != nil {
+			return err
+		}
+		patch.MessageListVisibility = v
+		changed = true
+	}
+	if !changed {
+		return usage("nothing to update (use --name, --background-color, --text-color, --label-list-visibility or --message-list-visibility)")
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newGmailService(ctx, account)
+	if err != nil {
+		return err
+	}
+	label, err := lookupLabel(ctx, svc, raw)
+	if err != nil {
+		return err
+	}
+	if label.Type == "system" && patch.Name != "" {
+		return fmt.Errorf("cannot rename system label %q", label.Name)
+	}
+
+	if strings.TrimSpace(c.Color) != "" || strings.TrimSpace(c.TextColor) != "" {
+		// Gmail needs both colors; keep the current one for the flag not given.
+		color := &gmail.LabelColor{
+			BackgroundColor: strings.ToLower(strings.TrimSpace(c.Color)),
+			TextColor:       strings.ToLower(strings.TrimSpace(c.TextColor)),
+		}
+		if label.Color != nil {
+			if color.BackgroundColor == "" {
+				color.BackgroundColor = label.Color.BackgroundColor
+			}
+			if color.TextColor == "" {
+				color.TextColor = label.Color.TextColor
+			}
+		}
+		if color.BackgroundColor == "" || color.TextColor == "" {
+			return usage("label has no color yet: set both --background-color and --text-color")
+		}
+		patch.Color = color
+	}
+	if patch.Name != "" && !strings.EqualFold(patch.Name, label.Name) {
+		if err := ensureLabelNameAvailable(svc, patch.Name); err != nil {
+			return err
+		}
+	}
+
+	if err := dryRunExit(ctx, flags, "gmail.labels.update", map[string]any{
+		"id":    label.Id,
+		"name":  label.Name,
+		"patch": patch,
+	}); err != nil {
+		return err
+	}
+
+	updated, err := svc.Users.Labels.Patch("me", label.Id, patch).Context(ctx).Do()
+	if err != nil {
+		return mapLabelCreateError(err, patch.Name)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"label": updated})
+	}
+	u.Out().Printf("Updated label: %s (id: %s)", updated.Name, updated.Id)
+	return nil
+}
+
+func (*GmailLabelsUpdateCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Label *gmail.Label `json:"label"`
+	}{}}
+}
+
+type GmailLabelsRenameCmd struct {
+	Label     string `arg:"" name:"labelIdOrName" help:"Label ID or name"`
+	NewName   string `arg:"" name:"newName" help:"New label name (may include '/' to move it under another parent)"`
+	Recursive bool   `name:"recursive" short:"r" help:"Also rename nested labels (Parent/Child) under the new name"`
+}
+
+type labelRename struct {
+	ID   string `json:"id"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+func (c *GmailLabelsRenameCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+
+	raw := strings.TrimSpace(c.Label)
+	newName := strings.Trim(strings.TrimSpace(c.NewName), "/")
+	if raw == "" || newName == "" {
+		return usage("label and new name are required")
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newGmailService(ctx, account)
+	if err != nil {
+		return err
+	}
+	label, err := lookupLabel(ctx, svc, raw)
+	if err != nil {
+		return err
+	}
+	if label.Type == "system" {
+		return fmt.Errorf("cannot rename system label %q", label.Name)
+	}
+	if newName == label.Name {
+		return usage("new name is the same as the current name")
+	}
+	if hasLabelPrefix(newName, label.Name) {
+		return usagef("cannot move %q under itself", label.Name)
+	}
+
+	resp, err := svc.Users.Labels.List("me").Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	renames := []labelRename{{ID: label.Id, From: label.Name, To: newName}}
+	nested := 0
+	for _, l := range resp.Labels {
+		if l.Id == label.Id || !hasLabelPrefix(l.Name, label.Name) {
+			continue
+		}
+		nested++
+		if c.Recursive {
+			renames = append(renames, labelRename{ID: l.Id, From: l.Name, To: newName + l.Name[len(label.Name):]})
+		}
+	}
+	nestedRenames := renames[1:]
+	sort.Slice(nestedRenames, func(i, j int) bool { return nestedRenames[i].From < nestedRenames[j].From })
+
+	renaming := map[string]bool{}
+	for _, r := range renames {
+		renaming[r.ID] = true
+	}
+	for _, l := range resp.Labels {
+		if renaming[l.Id] {
+			continue
+		}
+		for _, r := range renames {
+			if strings.EqualFold(l.Name, r.To) {
+				return usagef("label already exists: %s", l.Name)
+			}
+		}
+	}
+
+	if err := dryRunExit(ctx, flags, "gmail.labels.rename", map[string]any{
+		"renames":   renames,
+		"recursive": c.Recursive,
+	}); err != nil {
+		return err
+	}
+
+	// Parents first, so the hierarchy stays readable if a later call fails.
+	done := make([]labelRename, 0, len(renames))
+	for _, r := range renames {
+		if _, err := svc.Users.Labels.Patch("me", r.ID, &gmail.Label{Name: r.To}).Context(ctx).Do(); err != nil {
+			if len(done) > 0 {
+				u.Err().Printf("renamed %d of %d labels before the error", len(done), len(renames))
+			}
+			return fmt.Errorf("rename %q: %w", r.From, mapLabelCreateError(err, r.To))
+		}
+		done = append(done, r)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"renamed": done})
+	}
+	for _, r := range done {
+		u.Out().Printf("%s\t%s\t%s", r.ID, r.From, r.To)
+	}
+	if !c.Recursive && nested > 0 {
+		u.Err().Printf("%d nested labels still use %q; use --recursive to rename them too", nested, label.Name+"/")
+	}
+	return nil
+}
+
+func (*GmailLabelsRenameCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "renamed", Result: struct {
+		Renamed []labelRename `json:"renamed"`
+	}{}}
+}
+
+// hasLabelPrefix reports whether name is nested under parent.
+func hasLabelPrefix(name, parent string) bool {
+	return len(name) > len(parent)+1 && strings.EqualFold(name[:len(parent)+1], parent+"/")
+}
+
+type GmailLabelsTreeCmd struct {
+	Root   string `arg:"" optional:"" name:"labelName" help:"Only show this label and its nested labels"`
+	System bool   `name:"system" help:"Include system labels (INBOX, SENT, CATEGORY_*, ...)"`
+}
+
+type labelCounts struct {
+	Messages       int64 `json:"messages"`
+	MessagesUnread int64 `json:"messages_unread"`
+	Threads        int64 `json:"threads"`
+	ThreadsUnread  int64 `json:"threads_unread"`
+}
+
+func (c *labelCounts) add(o labelCounts) {
+	c.Messages += o.Messages
+	c.MessagesUnread += o.MessagesUnread
+	c.Threads += o.Threads
+	c.ThreadsUnread += o.ThreadsUnread
+}
+
+// labelTreeNode is one path segment. ID is empty for parents that only exist
+// implicitly through a nested label name. Total sums the subtree; a message
+// with several labels in the subtree is counted once per label.
+type labelTreeNode struct {
+	Name     string           `json:"name"`
+	Path     string           `json:"path"`
+	ID       string           `json:"id,omitempty"`
+	Counts   labelCounts      `json:"counts"`
+	Total    labelCounts      `json:"total"`
+	Children []*labelTreeNode `json:"children,omitempty"`
+}
+
+func (c *GmailLabelsTreeCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newGmailService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	resp, err := svc.Users.Labels.List("me").Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	root := strings.Trim(strings.TrimSpace(c.Root), "/")
+	ids := make([]string, 0, len(resp.Labels))
+	for _, l := range resp.Labels {
+		if l.Type == "system" && !c.System {
+			continue
+		}
+		if root != "" && !strings.EqualFold(l.Name, root) && !hasLabelPrefix(l.Name, root) {
+			continue
+		}
+		ids = append(ids, l.Id)
+	}
+	if root != "" && len(ids) == 0 {
+		return fmt.Errorf("label not found: %s", root)
+	}
+
+	// Labels.List has no counts; fetch each label.
+	labels, err := workpool.Map(ctx, workpool.Limit(ctx, workpool.DefaultLimit), ids, func(ctx context.Context, id string) (*gmail.Label, error) {
+		return svc.Users.Labels.Get("me", id).Context(ctx).Do()
+	})
+	if err != nil {
+		return err
+	}
+	nodes := buildLabelTree(labels)
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"labels": nodes})
+	}
+	if len(nodes) == 0 {
+		u.Err().Println("No labels")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "LABEL\tMESSAGES\tUNREAD\tTHREADS\tTOTAL_MESSAGES\tTOTAL_UNREAD\tTOTAL_THREADS")
+	var walk func(n *labelTreeNode, depth int)
+	walk = func(n *labelTreeNode, depth int) {
+		name := strings.Repeat("  ", depth) + sanitizeTab(n.Name)
+		if n.ID == "" {
+			fmt.Fprintf(w, "%s\t-\t-\t-\t%d\t%d\t%d\n", name, n.Total.Messages, n.Total.MessagesUnread, n.Total.Threads)
+		} else {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%d\n", name, n.Counts.Messages, n.Counts.MessagesUnread, n.Counts.Threads,
+				n.Total.Messages, n.Total.MessagesUnread, n.Total.Threads)
+		}
+		for _, child := range n.Children {
+			walk(child, depth+1)
+		}
+	}
+	for _, n := range nodes {
+		walk(n, 0)
+	}
+	return nil
+}
+
+func (*GmailLabelsTreeCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "labels", Result: struct {
+		Labels []*labelTreeNode `json:"labels"`
+	}{}}
+}
+
+// buildLabelTree nests labels by their '/'-separated names, sorted by name,
+// and fills in subtree totals.
+func buildLabelTree(labels []*gmail.Label) []*labelTreeNode {
+	var roots []*labelTreeNode
+	byPath := map[string]*labelTreeNode{}
+
+	sorted := make([]*gmail.Label, 0, len(labels))
+	for _, l := range labels {
+		if l != nil && l.Name != "" {
+			sorted = append(sorted, l)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return strings.ToLower(sorted[i].Name) < strings.ToLower(sorted[j].Name) })
+
+	for _, l := range sorted {
+		parts := strings.Split(l.Name, "/")
+		var parent *labelTreeNode
+		for i, part := range parts {
+			path := strings.Join(parts[:i+1], "/")
+			n, ok := byPath[strings.ToLower(path)]
+			if !ok {
+				n = &labelTreeNode{Name: part, Path: path}
+				byPath[strings.ToLower(path)] = n
+				if parent == nil {
+					roots = append(roots, n)
+				} else {
+					parent.Children = append(parent.Children, n)
+				}
+			}
+			parent = n
+		}
+		parent.ID = l.Id
+		parent.Path = l.Name
+		parent.Counts = labelCounts{
+			Messages:       l.MessagesTotal,
+			MessagesUnread: l.MessagesUnread,
+			Threads:        l.ThreadsTotal,
+			ThreadsUnread:  l.ThreadsUnread,
+		}
+	}
+
+	var total func(n *labelTreeNode) labelCounts
+	total = func(n *labelTreeNode) labelCounts {
+		n.Total = n.Counts
+		for _, child := range n.Children {
+			n.Total.add(total(child))
+		}
+		return n.Total
+	}
+	for _, n := range roots {
+		total(n)
+	}
+	return roots
+}
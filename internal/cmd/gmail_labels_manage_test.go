@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/gmail/v1"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// newLabelsManageServer serves label list/get/patch from an in-memory set.
+func newLabelsManageServer(t *testing.T, labels []*gmail.Label) (*httptest.Server, func() []map[string]any) {
+	t.Helper()
+
+	var (
+		mu      sync.Mutex
+		patches []map[string]any
+	)
+	byID := map[string]*gmail.Label{}
+	for _, l := range labels {
+		byID[l.Id] = l
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/labels") && r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(map[string]any{"labels": labels})
+			return
+		}
+		id := strings.TrimPrefix(r.URL.Path[strings.Index(r.URL.Path, "/labels/")+len("/labels/"):], "/")
+		l, ok := byID[id]
+		if !ok || !strings.Contains(r.URL.Path, "/labels/") {
+			// Gmail rejects many label names outright instead of returning 404.
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": 400, "message": "Invalid label id"}})
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(l)
+		case http.MethodPatch:
+			var patch map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&patch)
+			patch["id"] = id
+			patches = append(patches, patch)
+			if name, ok := patch["name"].(string); ok {
+				l.Name = name
+			}
+			_ = json.NewEncoder(w).Encode(l)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []map[string]any {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]map[string]any(nil), patches...)
+	}
+}
+
+func labelsManageContext(t *testing.T) context.Context {
+	t.Helper()
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	return outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{JSON: true})
+}
+
+func TestGmailLabelsRenameCmd_Recursive(t *testing.T) {
+	srv, patches := newLabelsManageServer(t, []*gmail.Label{
+		{Id: "INBOX", Name: "INBOX", Type: "system"},
+		{Id: "Label_1", Name: "Projects", Type: "user"},
+		{Id: "Label_2", Name: "Projects/Alpha", Type: "user"},
+		{Id: "Label_3", Name: "Projects/Alpha/Notes", Type: "user"},
+		{Id: "Label_4", Name: "ProjectsOld", Type: "user"},
+	})
+	stubGmailService(t, srv)
+	ctx := labelsManageContext(t)
+
+	out := captureStdout(t, func() {
+		if err := runKong(t, &GmailLabelsRenameCmd{}, []string{"projects", "Archive/Projects", "--recursive"}, ctx, &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("rename: %v", err)
+		}
+	})
+	var parsed struct {
+		Renamed []labelRename `json:"renamed"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json parse: %v\n%s", err, out)
+	}
+	want := []labelRename{
+		{ID: "Label_1", From: "Projects", To: "Archive/Projects"},
+		{ID: "Label_2", From: "Projects/Alpha", To: "Archive/Projects/Alpha"},
+		{ID: "Label_3", From: "Projects/Alpha/Notes", To: "Archive/Projects/Alpha/Notes"},
+	}
+	if len(parsed.Renamed) != len(want) {
+		t.Fatalf("unexpected renames: %s", out)
+	}
+	for i := range want {
+		if parsed.Renamed[i] != want[i] {
+			t.Fatalf("rename %d = %+v, want %+v", i, parsed.Renamed[i], want[i])
+		}
+	}
+	if got := patches(); len(got) != 3 || got[0]["name"] != "Archive/Projects" {
+		t.Fatalf("unexpected patches: %v", got)
+	}
+}
+
+func TestGmailLabelsRenameCmd_Conflict(t *testing.T) {
+	srv, patches := newLabelsManageServer(t, []*gmail.Label{
+		{Id: "Label_1", Name: "A", Type: "user"},
+		{Id: "Label_2", Name: "A/x", Type: "user"},
+		{Id: "Label_3", Name: "B/x", Type: "user"},
+	})
+	stubGmailService(t, srv)
+
+	err := runKong(t, &GmailLabelsRenameCmd{}, []string{"A", "B", "-r"}, labelsManageContext(t), &RootFlags{Account: "a@b.com"})
+	if err == nil || !strings.Contains(err.Error(), "label already exists: B/x") {
+		t.Fatalf("expected conflict, got %v", err)
+	}
+	if len(patches()) != 0 {
+		t.Fatalf("conflict must not patch anything")
+	}
+}
+
+func TestGmailLabelsUpdateCmd_ColorAndVisibility(t *testing.T) {
+	srv, patches := newLabelsManageServer(t, []*gmail.Label{
+		{Id: "Label_1", Name: "Work", Type: "user", Color: &gmail.LabelColor{BackgroundColor: "#000000", TextColor: "#ffffff"}},
+	})
+	stubGmailService(t, srv)
+
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &GmailLabelsUpdateCmd{}, []string{"work", "--background-color", "#FB4C2F", "--label-list-visibility", "hide", "--message-list-visibility", "hide"}, labelsManageContext(t), &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("update: %v", err)
+		}
+	})
+	got := patches()
+	if len(got) != 1 {
+		t.Fatalf("expected one patch, got %v", got)
+	}
+	color, _ := got[0]["color"].(map[string]any)
+	if color["backgroundColor"] != "#fb4c2f" || color["textColor"] != "#ffffff" ||
+		got[0]["labelListVisibility"] != "labelHide" || got[0]["messageListVisibility"] != "hide" {
+		t.Fatalf("unexpected patch: %v", got[0])
+	}
+	if _, ok := got[0]["name"]; ok {
+		t.Fatalf("name must not be patched: %v", got[0])
+	}
+
+	if err := runKong(t, &GmailLabelsUpdateCmd{}, []string{"work"}, labelsManageContext(t), &RootFlags{Account: "a@b.com"}); err == nil || !strings.Contains(err.Error(), "nothing to update") {
+		t.Fatalf("expected nothing-to-update error, got %v", err)
+	}
+}
+
+func TestGmailLabelsUpdateCmd_ResolvesNamesViaList(t *testing.T) {
+	srv, patches := newLabelsManageServer(t, []*gmail.Label{
+		{Id: "Label_1", Name: "Team Notes/2026", Type: "user"},
+	})
+	stubGmailService(t, srv)
+
+	_ = captureStdout(t, func() {
+		if err := runKong(t, &GmailLabelsUpdateCmd{}, []string{"team notes/2026", "--label-list-visibility", "hide"}, labelsManageContext(t), &RootFlags{Account: "a@b.com"}); err != nil {
+			t.Fatalf("update: %v", err)
+		}
+	})
+	if got := patches(); len(got) != 1 || got[0]["id"] != "Label_1" {
+		t.Fatalf("unexpected patches: %v", got)
+	}
+
+	err := runKong(t, &GmailLabelsUpdateCmd{}, []string{"Missing", "--label-list-visibility", "hide"}, labelsManageContext(t), &RootFlags{Account: "a@b.com"})
+	if err == nil || !strings.Contains(err.Error(), "label not found: Missing") {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestBuildLabelTree(t *testing.T) {
+	nodes := buildLabelTree([]*gmail.Label{
+		{Id: "Label_3", Name: "Projects/Beta", MessagesTotal: 2, ThreadsTotal: 1},
+		{Id: "Label_2", Name: "Projects/Alpha/Notes", MessagesTotal: 5, MessagesUnread: 1, ThreadsTotal: 4},
+		{Id: "Label_1", Name: "Inbox Zero", MessagesTotal: 7, ThreadsTotal: 7},
+	})
+	if len(nodes) != 2 || nodes[0].Name != "Inbox Zero" || nodes[1].Name != "Projects" {
+		t.Fatalf("unexpected roots: %+v", nodes)
+	}
+	projects := nodes[1]
+	if projects.ID != "" || projects.Total.Messages != 7 || projects.Total.MessagesUnread != 1 || projects.Total.Threads != 5 {
+		t.Fatalf("unexpected Projects node: %+v", projects)
+	}
+	if len(projects.Children) != 2 || projects.Children[0].Name != "Alpha" || projects.Children[0].ID != "" ||
+		projects.Children[0].Total.Messages != 5 || projects.Children[0].Children[0].Path != "Projects/Alpha/Notes" {
+		t.Fatalf("unexpected children: %+v", projects.Children)
+	}
+}
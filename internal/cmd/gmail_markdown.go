@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	extast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// markdownEmail renders CommonMark with GitHub tables, strikethrough,
+// autolinks and task lists. Raw HTML in the source is omitted.
+var markdownEmail = goldmark.New(
+	goldmark.WithExtensions(
+		extension.NewTable(extension.WithTableCellAlignMethod(extension.TableCellAlignAttribute)),
+		extension.Strikethrough,
+		extension.Linkify,
+		extension.TaskList,
+	),
+)
+
+// markdownEmailStyles are inlined into the rendered HTML, since many mail
+// clients ignore <style> blocks.
+var markdownEmailStyles = map[string]string{
+	"h1":         "font-size:1.6em;margin:0.67em 0 0.4em;",
+	"h2":         "font-size:1.35em;margin:0.8em 0 0.4em;",
+	"h3":         "font-size:1.15em;margin:0.8em 0 0.4em;",
+	"p":          "margin:0 0 0.8em;",
+	"ul":         "margin:0 0 0.8em;padding-left:1.6em;",
+	"ol":         "margin:0 0 0.8em;padding-left:1.6em;",
+	"blockquote": "margin:0 0 0.8em;padding:0 1em;color:#59636e;border-left:0.25em solid #d1d9e0;",
+	"pre":        "margin:0 0 0.8em;padding:12px;background:#f6f8fa;border-radius:6px;overflow:auto;font-size:0.9em;line-height:1.45;",
+	"code":       "font-family:SFMono-Regular,Menlo,Consolas,monospace;background:#f6f8fa;border-radius:4px;padding:0.1em 0.3em;",
+	"table":      "border-collapse:collapse;margin:0 0 0.8em;",
+	"th":         "border:1px solid #d1d9e0;padding:6px 12px;background:#f6f8fa;font-weight:600;",
+	"td":         "border:1px solid #d1d9e0;padding:6px 12px;",
+	"hr":         "border:0;border-top:1px solid #d1d9e0;margin:1.2em 0;",
+	"a":          "color:#0969da;",
+}
+
+var markdownEmailTagRe = regexp.MustCompile(`<(h1|h2|h3|p|ul|ol|blockquote|pre|code|table|th|td|hr|a)(\s[^>]*)?>`)
+
+// renderMarkdownEmail converts Markdown into a styled HTML body and a
+// readable plain-text alternative for multipart/alternative messages.
+func renderMarkdownEmail(src string) (plain string, html string, err error) {
+	source := []byte(src)
+	doc := markdownEmail.Parser().Parse(text.NewReader(source))
+
+	var b bytes.Buffer
+	if err := markdownEmail.Renderer().Render(&b, source, doc); err != nil {
+		return "", "", fmt.Errorf("render markdown: %w", err)
+	}
+
+	body := markdownEmailTagRe.ReplaceAllStringFunc(b.String(), func(tag string) string {
+		m := markdownEmailTagRe.FindStringSubmatch(tag)
+		style := markdownEmailStyles[m[1]]
+		if m[1] == "code" && strings.Contains(m[2], "language-") {
+			style = "font-family:SFMono-Regular,Menlo,Consolas,monospace;"
+		}
+		return "<" + m[1] + m[2] + ` style="` + style + `">`
+	})
+	// Mail clients drop form inputs; show task list boxes as characters.
+	body = strings.ReplaceAll(body, `<input checked="" disabled="" type="checkbox">`, "&#9745;")
+	body = strings.ReplaceAll(body, `<input disabled="" type="checkbox">`, "&#9744;")
+	// Code inside <pre> keeps the block background only.
+	body = strings.ReplaceAll(body, `<pre style="`+markdownEmailStyles["pre"]+`"><code style="`+markdownEmailStyles["code"]+`">`,
+		`<pre style="`+markdownEmailStyles["pre"]+`"><code style="font-family:SFMono-Regular,Menlo,Consolas,monospace;">`)
+
+	html = `<div style="font-family:-apple-system,BlinkMacSystemFont,'Segoe UI',Helvetica,Arial,sans-serif;font-size:14px;line-height:1.5;color:#1f2328;">` +
+		"\n" + body + "</div>\n"
+
+	p := &markdownPlain{source: source}
+	plain = strings.TrimSpace(p.blocks(doc)) + "\n"
+
+	return plain, html, nil
+}
+
+// markdownPlain renders a goldmark AST as plain text: markup is dropped,
+// links keep their URL, lists and quotes keep their markers and tables are
+// aligned with spaces.
+type markdownPlain struct {
+	source []byte
+}
+
+func (p *markdownPlain) blocks(parent ast.Node) string {
+	return p.blocksSep(parent, "\n\n")
+}
+
+func (p *markdownPlain) blocksSep(parent ast.Node, sep string) string {
+	parts := make([]string, 0, parent.ChildCount())
+	for n := parent.FirstChild(); n != nil; n = n.NextSibling() {
+		if s := p.block(n); s != "" {
+			parts = append(parts, s)
+		}
+	}
+
+	return strings.Join(parts, sep)
+}
+
+func (p *markdownPlain) block(n ast.Node) string {
+	switch n := n.(type) {
+	case *ast.Paragraph, *ast.TextBlock:
+		return p.inline(n)
+	case *ast.Heading:
+		title := p.inline(n)
+		switch n.Level {
+		case 1:
+			return title + "\n" + strings.Repeat("=", max(utf8.RuneCountInString(title), 3))
+		case 2:
+			return title + "\n" + strings.Repeat("-", max(utf8.RuneCountInString(title), 3))
+		default:
+			return title
+		}
+	case *ast.ThematicBreak:
+		return "----"
+	case *ast.CodeBlock, *ast.FencedCodeBlock:
+		lines := n.Lines()
+		out := make([]string, 0, lines.Len())
+		for i := 0; i < lines.Len(); i++ {
+			seg := lines.At(i)
+			out = append(out, "    "+strings.TrimRight(string(seg.Value(p.source)), "\r\n"))
+		}
+		return strings.Join(out, "\n")
+	case *ast.Blockquote:
+		inner := strings.Split(p.blocks(n), "\n")
+		for i, line := range inner {
+			inner[i] = strings.TrimRight("> "+line, " ")
+		}
+		return strings.Join(inner, "\n")
+	case *ast.List:
+		return p.list(n)
+	case *ast.HTMLBlock:
+		return ""
+	case *extast.Table:
+		return p.table(n)
+	default:
+		return p.blocks(n)
+	}
+}
+
+func (p *markdownPlain) list(n *ast.List) string {
+	sep := "\n"
+	if !n.IsTight {
+		sep = "\n\n"
+	}
+
+	num := n.Start
+	items := make([]string, 0, n.ChildCount())
+	for item := n.FirstChild(); item != nil; item = item.NextSibling() {
+		marker := "- "
+		if n.IsOrdered() {
+			marker = fmt.Sprintf("%d. ", num)
+			num++
+		}
+		indent := strings.Repeat(" ", len(marker))
+		lines := strings.Split(p.blocksSep(item, sep), "\n")
+		for i := range lines {
+			switch {
+			case i == 0:
+				lines[i] = marker + lines[i]
+			case lines[i] != "":
+				lines[i] = indent + lines[i]
+			}
+		}
+		items = append(items, strings.Join(lines, "\n"))
+	}
+
+	return strings.Join(items, sep)
+}
+
+func (p *markdownPlain) table(n *extast.Table) string {
+	var rows [][]string
+	for row := n.FirstChild(); row != nil; row = row.NextSibling() {
+		var cells []string
+		for cell := row.FirstChild(); cell != nil; cell = cell.NextSibling() {
+			cells = append(cells, strings.ReplaceAll(p.inline(cell), "\n", " "))
+		}
+		rows = append(rows, cells)
+	}
+
+	var widths []int
+	for _, row := range rows {
+		for i, cell := range row {
+			if i >= len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	out := make([]string, 0, len(rows)+1)
+	for r, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = cell + strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+		}
+		out = append(out, strings.TrimRight(strings.Join(cells, " | "), " "))
+		if r == 0 {
+			rules := make([]string, len(widths))
+			for i, w := range widths {
+				rules[i] = strings.Repeat("-", max(w, 3))
+			}
+			out = append(out, strings.Join(rules, "-|-"))
+		}
+	}
+
+	return strings.Join(out, "\n")
+}
+
+func (p *markdownPlain) inline(parent ast.Node) string {
+	var b strings.Builder
+	for n := parent.FirstChild(); n != nil; n = n.NextSibling() {
+		switch n := n.(type) {
+		case *ast.Text:
+			b.Write(n.Value(p.source))
+			if n.SoftLineBreak() || n.HardLineBreak() {
+				b.WriteByte('\n')
+			}
+		case *ast.String:
+			b.Write(n.Value)
+		case *ast.Link:
+			label := p.inline(n)
+			dest := string(n.Destination)
+			if label == "" || label == dest || "mailto:"+label == dest {
+				b.WriteString(dest)
+			} else {
+				b.WriteString(label + " (" + dest + ")")
+			}
+		case *ast.AutoLink:
+			b.Write(n.Label(p.source))
+		case *ast.Image:
+			if alt := p.inline(n); alt != "" {
+				b.WriteString("[" + alt + "] ")
+			}
+			b.WriteString("(" + string(n.Destination) + ")")
+		case *ast.RawHTML:
+			// Omitted, as in the HTML part.
+		case *extast.TaskCheckBox:
+			if n.IsChecked {
+				b.WriteString("[x] ")
+			} else {
+				b.WriteString("[ ] ")
+			}
+		default:
+			b.WriteString(p.inline(n))
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+func TestRenderMarkdownEmail(t *testing.T) {
+	src := "# Release notes\n\nHello **team**, see [the docs](https://example.com/docs).\n\n" +
+		"- one\n- two\n  - nested\n\n1. first\n2. second\n\n> quoted *text*\n\n" +
+		"```go\nfmt.Println(\"hi\")\n```\n\n| Name | Qty |\n|:-----|----:|\n| apples | 3 |\n| kiwi | 12 |\n\n" +
+		"- [x] done\n\nInline `code` and <b>raw</b>.\n"
+
+	plain, html, err := renderMarkdownEmail(src)
+	if err != nil {
+		t.Fatalf("renderMarkdownEmail: %v", err)
+	}
+
+	wantPlain := []string{
+		"Release notes\n=============\n\n",
+		"Hello team, see the docs (https://example.com/docs).",
+		"- one\n- two\n  - nested\n\n1. first\n2. second",
+		"> quoted text",
+		"    fmt.Println(\"hi\")",
+		"Name   | Qty\n-------|----\napples | 3\nkiwi   | 12",
+		"- [x] done",
+		"Inline code and raw.\n",
+	}
+	for _, want := range wantPlain {
+		if !strings.Contains(plain, want) {
+			t.Fatalf("plain part missing %q:\n%s", want, plain)
+		}
+	}
+
+	wantHTML := []string{
+		`<strong>team</strong>`,
+		`<a href="https://example.com/docs" style="color:#0969da;">the docs</a>`,
+		`<th align="right" style="`,
+		`<code class="language-go" style="font-family:`,
+		`&#9745; done`,
+		`<!-- raw HTML omitted -->`,
+	}
+	for _, want := range wantHTML {
+		if !strings.Contains(html, want) {
+			t.Fatalf("html part missing %q:\n%s", want, html)
+		}
+	}
+	if strings.Contains(html, "<b>raw</b>") || strings.Contains(html, "<input") {
+		t.Fatalf("html part should not contain raw HTML or inputs:\n%s", html)
+	}
+}
+
+func TestGmailSend_BodyMarkdown(t *testing.T) {
+	origNew := newGmailService
+	t.Cleanup(func() { newGmailService = origNew })
+
+	var raw string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body any
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/settings/sendAs"):
+			body = map[string]any{"sendAs": []map[string]any{}}
+		case strings.HasSuffix(r.URL.Path, "/messages/send"):
+			var msg gmail.Message
+			_ = json.NewDecoder(r.Body).Decode(&msg)
+			decoded, _ := base64.RawURLEncoding.DecodeString(msg.Raw)
+			raw = string(decoded)
+			body = map[string]any{"id": "m1", "threadId": "t1"}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer srv.Close()
+
+	svc, err := gmail.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("gmail.NewService: %v", err)
+	}
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return svc, nil }
+
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{JSON: true})
+	flags := &RootFlags{Account: "a@b.com"}
+
+	_ = captureStdout(t, func() {
+		args := []string{"--to", "x@example.com", "--subject", "Notes", "--body-markdown", "Hello **team**\n\n- one\n- two"}
+		if err := runKong(t, &GmailSendCmd{}, args, ctx, flags); err != nil {
+			t.Fatalf("send: %v", err)
+		}
+	})
+	if !strings.Contains(raw, "multipart/alternative") || !strings.Contains(raw, "Content-Type: text/plain") || !strings.Contains(raw, "Content-Type: text/html") {
+		t.Fatalf("expected multipart/alternative with text and html parts:\n%s", raw)
+	}
+
+	img := filepath.Join(t.TempDir(), "logo.png")
+	if writeErr := os.WriteFile(img, []byte("png"), 0o600); writeErr != nil {
+		t.Fatalf("write image: %v", writeErr)
+	}
+	_ = captureStdout(t, func() {
+		args := []string{"--to", "x@example.com", "--subject", "Logo", "--body-markdown", "![Logo](cid:logo)", "--inline", "logo=" + img}
+		if err := runKong(t, &GmailSendCmd{}, args, ctx, flags); err != nil {
+			t.Fatalf("send inline: %v", err)
+		}
+	})
+	if !strings.Contains(raw, "multipart/related") || !strings.Contains(raw, "Content-ID: <logo>") || !strings.Contains(raw, `src="cid:logo"`) {
+		t.Fatalf("expected multipart/related with inline logo:\n%s", raw)
+	}
+
+	err = runKong(t, &GmailSendCmd{}, []string{"--to", "x@example.com", "--subject", "Notes", "--body", "x", "--body-md", "y"}, ctx, flags)
+	if err == nil || !strings.Contains(err.Error(), "--body-markdown") {
+		t.Fatalf("expected conflict usage error, got %v", err)
+	}
+}
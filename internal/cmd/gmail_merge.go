@@ -0,0 +1,652 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"google.golang.org/api/gmail/v1"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/tracking"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const gmailMergeProgressVersion = 1
+
+type GmailMergeCmd struct {
+	Template     string        `name:"template" required:"" help:"Body template file (Go template; .md is rendered as Markdown, .html/.htm is the HTML body, anything else plain text)"`
+	HTMLTemplate string        `name:"html-template" help:"Additional HTML body template (with a plain-text --template)"`
+	Data         string        `name:"data" required:"" help:"Recipients: CSV with a header row, or a JSON array of objects"`
+	Subject      string        `name:"subject" required:"" help:"Subject template (e.g. '{{.Company}} update')"`
+	ToColumn     string        `name:"to-column" help:"Column holding the recipient address" default:"email"`
+	Cc           string        `name:"cc" help:"CC template (comma-separated after rendering)"`
+	Bcc          string        `name:"bcc" help:"BCC template (comma-separated after rendering)"`
+	ReplyTo      string        `name:"reply-to" help:"Reply-To header address"`
+	From         string        `name:"from" help:"Send from this email address (must be a verified send-as alias)"`
+	Attach       []string      `name:"attach" help:"Attachment path template, rendered per row (repeatable; empty results are skipped)"`
+	Draft        bool          `name:"draft" help:"Create drafts instead of sending"`
+	Track        bool          `name:"track" help:"Enable open tracking per recipient (requires an HTML template and tracking setup)"`
+	Delay        time.Duration `name:"delay" help:"Pause between messages (throttling)" default:"1s"`
+	Max          int           `name:"max" aliases:"limit" help:"Process at most N rows (0 = all)" default:"0"`
+	Progress     string        `name:"progress" help:"Progress file for resuming (default: <data>.gog-merge.json)"`
+	PreviewCount int           `name:"preview-count" help:"With --dry-run, number of rendered messages to show" default:"3"`
+}
+
+// gmailMergeMessage is one rendered row.
+type gmailMergeMessage struct {
+	Row         int      `json:"row"`
+	To          string   `json:"to"`
+	Cc          []string `json:"cc,omitempty"`
+	Bcc         []string `json:"bcc,omitempty"`
+	Subject     string   `json:"subject"`
+	Body        string   `json:"body,omitempty"`
+	BodyHTML    string   `json:"body_html,omitempty"`
+	Attachments []string `json:"attachments,omitempty"`
+}
+
+func (m *gmailMergeMessage) key() string {
+	return gmailMergeKey(m.Row, m.To)
+}
+
+// gmailMergeKey identifies a row by number and recipient: rows may share a
+// recipient and subject, and the recipient keeps an edited data file from
+// marking a different person as done.
+func gmailMergeKey(row int, to string) string {
+	return fmt.Sprintf("%d|%s", row, strings.ToLower(to))
+}
+
+type gmailMergeSent struct {
+	Row        int       `json:"row"`
+	To         string    `json:"to"`
+	Subject    string    `json:"subject"`
+	MessageID  string    `json:"messageId,omitempty"`
+	ThreadID   string    `json:"threadId,omitempty"`
+	DraftID    string    `json:"draftId,omitempty"`
+	TrackingID string    `json:"tracking_id,omitempty"`
+	At         time.Time `json:"at"`
+}
+
+// gmailMergeProgress records finished rows by row number and recipient, so
+// re-running the same merge skips them.
+type gmailMergeProgress struct {
+	Version   int                       `json:"version"`
+	Account   string                    `json:"account"`
+	UpdatedAt time.Time                 `json:"updatedAt"`
+	Done      map[string]gmailMergeSent `json:"done"`
+}
+
+type gmailMergeFailure struct {
+	Row   int    `json:"row"`
+	To    string `json:"to"`
+	Error string `json:"error"`
+}
+
+type gmailMergeResult struct {
+	Account  string              `json:"account"`
+	From     string              `json:"from"`
+	Mode     string              `json:"mode"`
+	Progress string              `json:"progress"`
+	Rows     int                 `json:"rows"`
+	Sent     int                 `json:"sent"`
+	Skipped  int                 `json:"skipped"`
+	Messages []gmailMergeSent    `json:"messages"`
+	Failed   []gmailMergeFailure `json:"failed"`
+}
+
+func (c *GmailMergeCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+
+	if c.Track && c.Draft {
+		return usage("--track cannot be combined with --draft")
+	}
+	if c.Delay < 0 {
+		return usage("--delay must be >= 0")
+	}
+
+	dataPath, err := config.ExpandPath(strings.TrimSpace(c.Data))
+	if err != nil {
+		return err
+	}
+	rows, err := loadGmailMergeData(dataPath)
+	if err != nil {
+		return err
+	}
+	if c.Max > 0 && len(rows) > c.Max {
+		rows = rows[:c.Max]
+	}
+	tmpl, err := c.parseTemplates()
+	if err != nil {
+		return err
+	}
+
+	// Render every row before sending anything, so a template or data error
+	// cannot leave a half-sent merge behind.
+	messages := make([]*gmailMergeMessage, 0, len(rows))
+	for i, row := range rows {
+		msg, renderErr := tmpl.render(i+1, row, c.ToColumn)
+		if renderErr != nil {
+			return renderErr
+		}
+		messages = append(messages, msg)
+	}
+	if len(messages) == 0 {
+		return usagef("no rows in %s", dataPath)
+	}
+	if c.Track {
+		for _, m := range messages {
+			if strings.TrimSpace(m.BodyHTML) == "" {
+				return usage("--track requires an HTML template (--html-template, or an .html or .md --template)")
+			}
+			if len(m.Cc) > 0 || len(m.Bcc) > 0 {
+				return usage("--track requires exactly 1 recipient per message (no --cc/--bcc)")
+			}
+		}
+	}
+
+	progressPath := strings.TrimSpace(c.Progress)
+	if progressPath == "" {
+		progressPath = dataPath + ".gog-merge.json"
+	} else if progressPath, err = config.ExpandPath(progressPath); err != nil {
+		return err
+	}
+
+	mode := "send"
+	if c.Draft {
+		mode = "draft"
+	}
+	preview := messages[:min(max(c.PreviewCount, 0), len(messages))]
+	if err := dryRunExit(ctx, flags, "gmail.merge", map[string]any{
+		"mode":     mode,
+		"data":     dataPath,
+		"rows":     len(messages),
+		"progress": progressPath,
+		"track":    c.Track,
+		"preview":  preview,
+	}); err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	progress, err := loadGmailMergeProgress(progressPath)
+	if err != nil {
+		return err
+	}
+	if progress == nil {
+		progress = &gmailMergeProgress{Version: gmailMergeProgressVersion, Account: account, Done: map[string]gmailMergeSent{}}
+	} else if progress.Account != "" && !strings.EqualFold(progress.Account, account) {
+		return usagef("progress %s belongs to %s; use another --progress for %s", progressPath, progress.Account, account)
+	}
+
+	if !c.Draft {
+		remaining := 0
+		for _, m := range messages {
+			if _, ok := progress.Done[m.key()]; !ok {
+				remaining++
+			}
+		}
+		if remaining > 0 {
+			if err := confirmDestructive(ctx, flags, fmt.Sprintf("send %d emails", remaining)); err != nil {
+				return err
+			}
+		}
+	}
+
+	svc, err := newGmailService(ctx, account)
+	if err != nil {
+		return err
+	}
+	fromAddr, _, err := resolveSendFrom(ctx, svc, account, c.From)
+	if err != nil {
+		return err
+	}
+	var trackingCfg *tracking.Config
+	if c.Track {
+		trackingCfg, err = tracking.LoadConfig(account)
+		if err != nil {
+			return fmt.Errorf("load tracking config: %w", err)
+		}
+		if !trackingCfg.IsConfigured() {
+			return fmt.Errorf("tracking not configured; run 'gog gmail track setup' first")
+		}
+	}
+
+	res := gmailMergeResult{Account: account, From: fromAddr, Mode: mode, Progress: progressPath, Rows: len(messages), Messages: []gmailMergeSent{}, Failed: []gmailMergeFailure{}}
+	first := true
+	for _, m := range messages {
+		if _, ok := progress.Done[m.key()]; ok {
+			res.Skipped++
+			continue
+		}
+		if !first && c.Delay > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(c.Delay):
+			}
+		}
+		first = false
+
+		sent, sendErr := c.deliver(ctx, svc, fromAddr, trackingCfg, m)
+		if sendErr != nil {
+			res.Failed = append(res.Failed, gmailMergeFailure{Row: m.Row, To: m.To, Error: sendErr.Error()})
+			if !outfmt.IsJSON(ctx) {
+				u.Err().Errorf("row %d (%s): %s", m.Row, m.To, sendErr.Error())
+			}
+			continue
+		}
+		progress.Done[m.key()] = sent
+		res.Messages = append(res.Messages, sent)
+		res.Sent++
+		if err := saveGmailMergeProgress(progressPath, progress); err != nil {
+			return err
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, res); err != nil {
+			return err
+		}
+		return gmailMergeError(res)
+	}
+
+	if len(res.Messages) > 0 {
+		w, flush := tableWriter(ctx)
+		fmt.Fprintln(w, "ROW\tTO\tID\tSUBJECT")
+		for _, s := range res.Messages {
+			id := s.MessageID
+			if s.DraftID != "" {
+				id = s.DraftID
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", s.Row, sanitizeTab(s.To), id, sanitizeTab(s.Subject))
+		}
+		flush()
+	}
+	u.Err().Printf("%s: %d, skipped (already done): %d, failed: %d", mode, res.Sent, res.Skipped, len(res.Failed))
+	return gmailMergeError(res)
+}
+
+// gmailMergeError fails the merge when any row failed; re-running retries
+// only those rows.
+func gmailMergeError(res gmailMergeResult) error {
+	if len(res.Failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d rows failed; re-run to retry them", len(res.Failed), res.Rows)
+}
+
+func (*GmailMergeCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "messages", Result: gmailMergeResult{}}
+}
+
+func (c *GmailMergeCmd) deliver(ctx context.Context, svc *gmail.Service, fromAddr string, trackingCfg *tracking.Config, m *gmailMergeMessage) (gmailMergeSent, error) {
+	sent := gmailMergeSent{Row: m.Row, To: m.To, Subject: m.Subject}
+	atts := make([]mailAttachment, 0, len(m.Attachments))
+	for _, p := range m.Attachments {
+		atts = append(atts, mailAttachment{Path: p})
+	}
+
+	if c.Draft {
+		raw, err := buildRFC822(mailOptions{
+			From:        fromAddr,
+			To:          []string{m.To},
+			Cc:          m.Cc,
+			Bcc:         m.Bcc,
+			ReplyTo:     c.ReplyTo,
+			Subject:     m.Subject,
+			Body:        m.Body,
+			BodyHTML:    m.BodyHTML,
+			Attachments: atts,
+		}, nil)
+		if err != nil {
+			return sent, err
+		}
+		draft, err := svc.Users.Drafts.Create("me", &gmail.Draft{
+			Message: &gmail.Message{Raw: base64.RawURLEncoding.EncodeToString(raw)},
+		}).Context(ctx).Do()
+		if err != nil {
+			return sent, err
+		}
+		sent.DraftID = draft.Id
+		if draft.Message != nil {
+			sent.MessageID = draft.Message.Id
+			sent.ThreadID = draft.Message.ThreadId
+		}
+		sent.At = time.Now().UTC()
+		return sent, nil
+	}
+
+	results, err := sendGmailBatches(ctx, svc, sendMessageOptions{
+		FromAddr:    fromAddr,
+		ReplyTo:     c.ReplyTo,
+		Subject:     m.Subject,
+		Body:        m.Body,
+		BodyHTML:    m.BodyHTML,
+		Attachments: atts,
+		Track:       c.Track,
+		TrackingCfg: trackingCfg,
+	}, buildSendBatches([]string{m.To}, m.Cc, m.Bcc, c.Track, false))
+	if err != nil {
+		return sent, err
+	}
+	if len(results) > 0 {
+		sent.MessageID = results[0].MessageID
+		sent.ThreadID = results[0].ThreadID
+		sent.TrackingID = results[0].TrackingID
+	}
+	sent.At = time.Now().UTC()
+	return sent, nil
+}
+
+type gmailMergeTemplates struct {
+	subject  *template.Template
+	cc       *template.Template
+	bcc      *template.Template
+	plain    *template.Template
+	markdown *template.Template
+	html     *htmltemplate.Template
+	attach   []*template.Template
+}
+
+// gmailMergeFuncs are available in every merge template.
+var gmailMergeFuncs = map[string]any{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"trim":  strings.TrimSpace,
+	"default": func(def string, v any) string {
+		if v == nil {
+			return def
+		}
+		if s := strings.TrimSpace(fmt.Sprint(v)); s != "" {
+			return s
+		}
+		return def
+	},
+}
+
+func (c *GmailMergeCmd) parseTemplates() (*gmailMergeTemplates, error) {
+	parseText := func(name, text string) (*template.Template, error) {
+		t, err := template.New(name).Funcs(gmailMergeFuncs).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, usagef("invalid %s template: %v", name, err)
+		}
+		return t, nil
+	}
+	parseHTML := func(name, text string) (*htmltemplate.Template, error) {
+		t, err := htmltemplate.New(name).Funcs(gmailMergeFuncs).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return nil, usagef("invalid %s template: %v", name, err)
+		}
+		return t, nil
+	}
+	readFile := func(path string) (string, string, error) {
+		expanded, err := config.ExpandPath(strings.TrimSpace(path))
+		if err != nil {
+			return "", "", err
+		}
+		b, err := os.ReadFile(expanded) //nolint:gosec // user-provided template path
+		if err != nil {
+			return "", "", err
+		}
+		return expanded, string(b), nil
+	}
+
+	out := &gmailMergeTemplates{}
+	var err error
+	if out.subject, err = parseText("subject", c.Subject); err != nil {
+		return nil, err
+	}
+	if out.cc, err = parseText("cc", c.Cc); err != nil {
+		return nil, err
+	}
+	if out.bcc, err = parseText("bcc", c.Bcc); err != nil {
+		return nil, err
+	}
+
+	path, text, err := readFile(c.Template)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".markdown":
+		if strings.TrimSpace(c.HTMLTemplate) != "" {
+			return nil, usage("--html-template cannot be combined with a Markdown --template")
+		}
+		if out.markdown, err = parseText("body", text); err != nil {
+			return nil, err
+		}
+	case ".html", ".htm":
+		if strings.TrimSpace(c.HTMLTemplate) != "" {
+			return nil, usage("--html-template requires a plain-text --template")
+		}
+		if out.html, err = parseHTML("body", text); err != nil {
+			return nil, err
+		}
+	default:
+		if out.plain, err = parseText("body", text); err != nil {
+			return nil, err
+		}
+	}
+	if strings.TrimSpace(c.HTMLTemplate) != "" {
+		if _, text, err = readFile(c.HTMLTemplate); err != nil {
+			return nil, err
+		}
+		if out.html, err = parseHTML("html body", text); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, a := range c.Attach {
+		t, err := parseText(fmt.Sprintf("attach[%d]", i), a)
+		if err != nil {
+			return nil, err
+		}
+		out.attach = append(out.attach, t)
+	}
+
+	return out, nil
+}
+
+func (t *gmailMergeTemplates) render(rowNum int, row map[string]any, toColumn string) (*gmailMergeMessage, error) {
+	renderText := func(tmpl *template.Template) (string, error) {
+		var b bytes.Buffer
+		if err := tmpl.Execute(&b, row); err != nil {
+			return "", fmt.Errorf("row %d: %w", rowNum, err)
+		}
+		return b.String(), nil
+	}
+
+	to := strings.TrimSpace(gmailMergeColumn(row, toColumn))
+	if to == "" {
+		return nil, usagef("row %d: empty recipient column %q", rowNum, toColumn)
+	}
+	m := &gmailMergeMessage{Row: rowNum, To: to}
+
+	var err error
+	if m.Subject, err = renderText(t.subject); err != nil {
+		return nil, err
+	}
+	m.Subject = strings.TrimSpace(m.Subject)
+	if m.Subject == "" {
+		return nil, usagef("row %d: subject renders empty", rowNum)
+	}
+	cc, err := renderText(t.cc)
+	if err != nil {
+		return nil, err
+	}
+	m.Cc = splitCSV(cc)
+	bcc, err := renderText(t.bcc)
+	if err != nil {
+		return nil, err
+	}
+	m.Bcc = splitCSV(bcc)
+
+	if t.plain != nil {
+		if m.Body, err = renderText(t.plain); err != nil {
+			return nil, err
+		}
+	}
+	if t.markdown != nil {
+		md, err := renderText(t.markdown)
+		if err != nil {
+			return nil, err
+		}
+		if m.Body, m.BodyHTML, err = renderMarkdownEmail(md); err != nil {
+			return nil, fmt.Errorf("row %d: %w", rowNum, err)
+		}
+	}
+	if t.html != nil {
+		var b bytes.Buffer
+		if err := t.html.Execute(&b, row); err != nil {
+			return nil, fmt.Errorf("row %d: %w", rowNum, err)
+		}
+		m.BodyHTML = b.String()
+	}
+
+	for _, a := range t.attach {
+		p, err := renderText(a)
+		if err != nil {
+			return nil, err
+		}
+		if p = strings.TrimSpace(p); p == "" {
+			continue
+		}
+		expanded, err := config.ExpandPath(p)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(expanded); err != nil {
+			return nil, fmt.Errorf("row %d: attachment: %w", rowNum, err)
+		}
+		m.Attachments = append(m.Attachments, expanded)
+	}
+
+	return m, nil
+}
+
+// gmailMergeColumn looks a column up by exact name, then case-insensitively.
+func gmailMergeColumn(row map[string]any, name string) string {
+	v, ok := row[name]
+	if !ok {
+		for k, kv := range row {
+			if strings.EqualFold(k, name) {
+				v, ok = kv, true
+				break
+			}
+		}
+	}
+	if !ok || v == nil {
+		return ""
+	}
+
+	return fmt.Sprint(v)
+}
+
+// loadGmailMergeData reads a JSON array of objects (.json) or a CSV file
+// with a header row.
+func loadGmailMergeData(path string) ([]map[string]any, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // user-provided data path
+	if err != nil {
+		return nil, err
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if strings.EqualFold(filepath.Ext(path), ".json") || bytes.HasPrefix(trimmed, []byte("[")) {
+		var rows []map[string]any
+		if err := json.Unmarshal(trimmed, &rows); err != nil {
+			return nil, fmt.Errorf("parse %s: expected a JSON array of objects: %w", path, err)
+		}
+		return rows, nil
+	}
+
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff"))))
+	r.FieldsPerRecord = -1
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := make([]string, len(records[0]))
+	for i, h := range records[0] {
+		header[i] = strings.TrimSpace(h)
+	}
+	rows := make([]map[string]any, 0, len(records)-1)
+	for _, rec := range records[1:] {
+		if strings.TrimSpace(strings.Join(rec, "")) == "" {
+			continue
+		}
+		row := make(map[string]any, len(header))
+		for i, h := range header {
+			if h == "" {
+				continue
+			}
+			if i < len(rec) {
+				row[h] = rec[i]
+			} else {
+				row[h] = ""
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func loadGmailMergeProgress(path string) (*gmailMergeProgress, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // user-provided progress path
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var p gmailMergeProgress
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("read merge progress %s: %w", path, err)
+	}
+	if p.Version != gmailMergeProgressVersion {
+		return nil, fmt.Errorf("merge progress %s has unsupported version %d", path, p.Version)
+	}
+	// Re-key from the recorded rows, so files keyed by an older scheme resume.
+	done := make(map[string]gmailMergeSent, len(p.Done))
+	for _, sent := range p.Done {
+		done[gmailMergeKey(sent.Row, sent.To)] = sent
+	}
+	p.Done = done
+
+	return &p, nil
+}
+
+func saveGmailMergeProgress(path string, p *gmailMergeProgress) error {
+	p.UpdatedAt = time.Now().UTC()
+	payload, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(payload, '\n'), 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
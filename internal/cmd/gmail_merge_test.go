@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+func writeMergeFixtures(t *testing.T) (dir string, args []string) {
+	t.Helper()
+	dir = t.TempDir()
+	files := map[string]string{
+		"recipients.csv": "email,Name,Company,Invoice\nalice@example.com,Alice,Acme,inv1\nbob@example.com,Bob,Globex,\n",
+		"body.txt":       "Hi {{.Name}},\nwelcome to {{.Company}}.\n",
+		"body.html":      "<p>Hi {{.Name}} from {{.Company}}</p>",
+		"inv1.pdf":       "%PDF-1.4",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	args = []string{
+		"--template", filepath.Join(dir, "body.txt"),
+		"--html-template", filepath.Join(dir, "body.html"),
+		"--data", filepath.Join(dir, "recipients.csv"),
+		"--subject", "{{.Company}} update",
+		"--attach", `{{if .Invoice}}` + dir + `/{{.Invoice}}.pdf{{end}}`,
+		"--delay", "0s",
+	}
+	return dir, args
+}
+
+func TestGmailMerge_DryRunPreview(t *testing.T) {
+	_, args := writeMergeFixtures(t)
+
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{JSON: true})
+
+	out := captureStdout(t, func() {
+		err := runKong(t, &GmailMergeCmd{}, append(args, "--preview-count", "1"), ctx, &RootFlags{DryRun: true})
+		var exitErr *ExitError
+		if err != nil && (!errors.As(err, &exitErr) || exitErr.Code != 0) {
+			t.Fatalf("merge dry-run: %v", err)
+		}
+	})
+
+	var parsed struct {
+		Request struct {
+			Rows    int                 `json:"rows"`
+			Preview []gmailMergeMessage `json:"preview"`
+		} `json:"request"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("json parse: %v\n%s", err, out)
+	}
+	if parsed.Request.Rows != 2 || len(parsed.Request.Preview) != 1 {
+		t.Fatalf("unexpected dry-run: %s", out)
+	}
+	p := parsed.Request.Preview[0]
+	if p.To != "alice@example.com" || p.Subject != "Acme update" || p.Body != "Hi Alice,\nwelcome to Acme.\n" ||
+		p.BodyHTML != "<p>Hi Alice from Acme</p>" || len(p.Attachments) != 1 {
+		t.Fatalf("unexpected preview: %#v", p)
+	}
+}
+
+func TestGmailMerge_SendsAndResumes(t *testing.T) {
+	origNew := newGmailService
+	t.Cleanup(func() { newGmailService = origNew })
+	_, args := writeMergeFixtures(t)
+
+	var raws []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body any
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/settings/sendAs"):
+			body = map[string]any{"sendAs": []map[string]any{{"sendAsEmail": "a@b.com", "displayName": "Ops", "isPrimary": true}}}
+		case strings.HasSuffix(r.URL.Path, "/messages/send"):
+			var msg gmail.Message
+			_ = json.NewDecoder(r.Body).Decode(&msg)
+			raw, _ := base64.RawURLEncoding.DecodeString(msg.Raw)
+			raws = append(raws, string(raw))
+			body = map[string]any{"id": "s" + string(rune('0'+len(raws))), "threadId": "t1"}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer srv.Close()
+
+	svc, err := gmail.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("gmail.NewService: %v", err)
+	}
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return svc, nil }
+
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{JSON: true})
+	flags := &RootFlags{Account: "a@b.com", Force: true}
+
+	err = runKong(t, &GmailMergeCmd{}, args, ctx, &RootFlags{Account: "a@b.com"})
+	if err == nil || !strings.Contains(err.Error(), "refusing to send 2 emails") || len(raws) != 0 {
+		t.Fatalf("expected live send to require --force, got %v (%d sent)", err, len(raws))
+	}
+
+	run := func() gmailMergeResult {
+		t.Helper()
+		out := captureStdout(t, func() {
+			if err := runKong(t, &GmailMergeCmd{}, args, ctx, flags); err != nil {
+				t.Fatalf("merge: %v", err)
+			}
+		})
+		var parsed gmailMergeResult
+		if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+			t.Fatalf("json parse: %v\n%s", err, out)
+		}
+		return parsed
+	}
+
+	first := run()
+	if first.Sent != 2 || first.Skipped != 0 || len(first.Failed) != 0 || first.From != "Ops <a@b.com>" {
+		t.Fatalf("unexpected first merge: %#v", first)
+	}
+	if len(raws) != 2 {
+		t.Fatalf("expected 2 sends, got %d", len(raws))
+	}
+	if !strings.Contains(raws[0], "To: alice@example.com") || !strings.Contains(raws[0], "Subject: Acme update") ||
+		!strings.Contains(raws[0], `filename="inv1.pdf"`) {
+		t.Fatalf("unexpected first message:\n%s", raws[0])
+	}
+	if !strings.Contains(raws[1], "To: bob@example.com") || strings.Contains(raws[1], "inv1.pdf") {
+		t.Fatalf("unexpected second message:\n%s", raws[1])
+	}
+
+	second := run()
+	if second.Sent != 0 || second.Skipped != 2 || len(raws) != 2 {
+		t.Fatalf("expected resumed merge to skip sent rows: %#v", second)
+	}
+}
+
+func TestGmailMerge_SameRecipientRowsAndFailures(t *testing.T) {
+	origNew := newGmailService
+	t.Cleanup(func() { newGmailService = origNew })
+	dir, args := writeMergeFixtures(t)
+	csv := "email,Name,Company,Invoice\nalice@example.com,Alice,Acme,\nalice@example.com,Al,Acme,\nbroken@example.com,Bob,Acme,\n"
+	if err := os.WriteFile(filepath.Join(dir, "recipients.csv"), []byte(csv), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	var raws []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body any
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/settings/sendAs"):
+			body = map[string]any{"sendAs": []map[string]any{{"sendAsEmail": "a@b.com", "isPrimary": true}}}
+		case strings.HasSuffix(r.URL.Path, "/messages/send"):
+			var msg gmail.Message
+			_ = json.NewDecoder(r.Body).Decode(&msg)
+			raw, _ := base64.RawURLEncoding.DecodeString(msg.Raw)
+			if strings.Contains(string(raw), "broken@example.com") {
+				http.Error(w, `{"error":{"code":400,"message":"bad recipient"}}`, http.StatusBadRequest)
+				return
+			}
+			raws = append(raws, string(raw))
+			body = map[string]any{"id": "s" + string(rune('0'+len(raws))), "threadId": "t1"}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer srv.Close()
+
+	svc, err := gmail.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("gmail.NewService: %v", err)
+	}
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return svc, nil }
+
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{JSON: true})
+
+	var res gmailMergeResult
+	out := captureStdout(t, func() {
+		err := runKong(t, &GmailMergeCmd{}, args, ctx, &RootFlags{Account: "a@b.com", Force: true})
+		if err == nil || !strings.Contains(err.Error(), "1 of 3 rows failed") {
+			t.Fatalf("expected failed row to fail the merge, got %v", err)
+		}
+	})
+	if err := json.Unmarshal([]byte(out), &res); err != nil {
+		t.Fatalf("json parse: %v\n%s", err, out)
+	}
+	if res.Sent != 2 || len(res.Failed) != 1 || res.Failed[0].Row != 3 || len(raws) != 2 {
+		t.Fatalf("expected both alice rows to be sent: %s", out)
+	}
+	if !strings.Contains(raws[0], "Hi Alice") || !strings.Contains(raws[1], "Hi Al,") {
+		t.Fatalf("unexpected bodies:\n%s\n%s", raws[0], raws[1])
+	}
+}
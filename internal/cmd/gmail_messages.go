@@ -5,13 +5,13 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"sync"
 	"time"
 
 	"google.golang.org/api/gmail/v1"
 
 	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/internal/workpool"
 )
 
 type GmailMessagesCmd struct {
@@ -19,7 +19,9 @@ type GmailMessagesCmd struct {
 }
 
 type GmailMessagesSearchCmd struct {
-	Query       []string `arg:"" name:"query" help:"Search query"`
+	Query       []string `arg:"" optional:"" name:"query" help:"Search query"`
+	Since       string   `name:"since" help:"Only mail on/after this time (date, RFC3339, or natural: '2 weeks ago', 'last monday', 'last week'); adds after:"`
+	Until       string   `name:"until" help:"Only mail up to the end of this time (date, RFC3339, or natural: yesterday, 'end of last month'); adds before:"`
 	Max         int64    `name:"max" aliases:"limit" help:"Max results" default:"10"`
 	Page        string   `name:"page" aliases:"cursor" help:"Page token"`
 	All         bool     `name:"all" aliases:"all-pages,allpages" help:"Fetch all pages"`
@@ -35,7 +37,10 @@ func (c *GmailMessagesSearchCmd) Run(ctx context.Context, flags *RootFlags) erro
 	if err != nil {
 		return err
 	}
-	query := strings.TrimSpace(strings.Join(c.Query, " "))
+	query, err := gmailDateQuery(strings.TrimSpace(strings.Join(c.Query, " ")), c.Since, c.Until, c.Timezone, c.Local)
+	if err != nil {
+		return err
+	}
 	if query == "" {
 		return usage("missing query")
 	}
@@ -148,6 +153,13 @@ func (c *GmailMessagesSearchCmd) Run(ctx context.Context, flags *RootFlags) erro
 	return nil
 }
 
+func (*GmailMessagesSearchCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "messages", Result: struct {
+		Messages      []messageItem `json:"messages"`
+		NextPageToken string        `json:"nextPageToken"`
+	}{}}
+}
+
 type messageItem struct {
 	ID       string   `json:"id"`
 	ThreadID string   `json:"threadId,omitempty"`
@@ -163,103 +175,54 @@ func fetchMessageDetails(ctx context.Context, svc *gmail.Service, messages []*gm
 		return nil, nil
 	}
 
-	const maxConcurrency = 10
-	sem := make(chan struct{}, maxConcurrency)
-
-	type result struct {
-		index     int
-		messageID string
-		item      messageItem
-		err       error
+	ids := make([]string, 0, len(messages))
+	for _, m := range messages {
+		if m != nil && m.Id != "" {
+			ids = append(ids, m.Id)
+		}
 	}
 
-	results := make(chan result, len(messages))
-	var wg sync.WaitGroup
-
-	for i, m := range messages {
-		if m == nil || m.Id == "" {
-			continue
+	items, err := workpool.Map(ctx, workpool.Limit(ctx, workpool.DefaultLimit), ids, func(ctx context.Context, messageID string) (messageItem, error) {
+		call := svc.Users.Messages.Get("me", messageID)
+		if includeBody {
+			call = call.Format("full")
+		} else {
+			call = call.Format("metadata").
+				MetadataHeaders("From", "Subject", "Date").
+				Fields("id,threadId,labelIds,payload(headers)")
+		}
+		msg, err := call.Context(ctx).Do()
+		if err != nil {
+			return messageItem{}, fmt.Errorf("message %s: %w", messageID, err)
 		}
-		wg.Add(1)
-		go func(idx int, messageID string) {
-			defer wg.Done()
-
-			select {
-			case sem <- struct{}{}:
-				defer func() { <-sem }()
-			case <-ctx.Done():
-				results <- result{index: idx, messageID: messageID, err: ctx.Err()}
-				return
-			}
-
-			call := svc.Users.Messages.Get("me", messageID)
-			if includeBody {
-				call = call.Format("full")
-			} else {
-				call = call.Format("metadata").
-					MetadataHeaders("From", "Subject", "Date").
-					Fields("id,threadId,labelIds,payload(headers)")
-			}
-			msg, err := call.Context(ctx).Do()
-			if err != nil {
-				results <- result{index: idx, messageID: messageID, err: fmt.Errorf("message %s: %w", messageID, err)}
-				return
-			}
 
-			item := messageItem{
-				ID:       messageID,
-				ThreadID: msg.ThreadId,
-			}
+		item := messageItem{
+			ID:       messageID,
+			ThreadID: msg.ThreadId,
+		}
 
-			item.From = sanitizeTab(headerValue(msg.Payload, "From"))
-			item.Subject = sanitizeTab(headerValue(msg.Payload, "Subject"))
-			item.Date = formatGmailDateInLocation(headerValue(msg.Payload, "Date"), loc)
-			if includeBody {
-				item.Body = bestBodyText(msg.Payload)
-			}
+		item.From = sanitizeTab(headerValue(msg.Payload, "From"))
+		item.Subject = sanitizeTab(headerValue(msg.Payload, "Subject"))
+		item.Date = formatGmailDateInLocation(headerValue(msg.Payload, "Date"), loc)
+		if includeBody {
+			item.Body = bestBodyText(msg.Payload)
+		}
 
-			if len(msg.LabelIds) > 0 {
-				names := make([]string, 0, len(msg.LabelIds))
-				for _, lid := range msg.LabelIds {
-					if n, ok := idToName[lid]; ok {
-						names = append(names, n)
-					} else {
-						names = append(names, lid)
-					}
+		if len(msg.LabelIds) > 0 {
+			names := make([]string, 0, len(msg.LabelIds))
+			for _, lid := range msg.LabelIds {
+				if n, ok := idToName[lid]; ok {
+					names = append(names, n)
+				} else {
+					names = append(names, lid)
 				}
-				item.Labels = names
-			}
-
-			results <- result{index: idx, messageID: messageID, item: item}
-		}(i, m.Id)
-	}
-
-	go func() {
-		wg.Wait()
-		close(results)
-	}()
-
-	ordered := make([]messageItem, len(messages))
-	var firstErr error
-	for r := range results {
-		if r.err != nil {
-			if firstErr == nil {
-				firstErr = r.err
 			}
-			ordered[r.index] = messageItem{}
-			continue
-		}
-		ordered[r.index] = r.item
-	}
-	if firstErr != nil {
-		return nil, firstErr
-	}
-
-	items := make([]messageItem, 0, len(ordered))
-	for _, item := range ordered {
-		if item.ID != "" {
-			items = append(items, item)
+			item.Labels = names
 		}
+		return item, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return items, nil
 }
@@ -20,6 +20,9 @@ type mailAttachment struct {
 	Filename string
 	MIMEType string
 	Data     []byte
+	// ContentID marks an inline part referenced as cid:<ContentID> from the
+	// HTML body.
+	ContentID string
 }
 
 type rfc822Config struct {
@@ -39,6 +42,8 @@ type mailOptions struct {
 	References        string
 	AdditionalHeaders map[string]string
 	Attachments       []mailAttachment
+	// Inline parts go into a multipart/related part next to the HTML body.
+	Inline []mailAttachment
 }
 
 func buildRFC822(opts mailOptions, cfg *rfc822Config) ([]byte, error) {
@@ -117,36 +122,17 @@ func buildRFC822(opts mailOptions, cfg *rfc822Config) ([]byte, error) {
 
 	plainBody := normalizeCRLF(opts.Body)
 	htmlBody := normalizeCRLF(opts.BodyHTML)
-	hasPlain := strings.TrimSpace(plainBody) != ""
-	hasHTML := strings.TrimSpace(htmlBody) != ""
+	if len(opts.Inline) > 0 && strings.TrimSpace(htmlBody) == "" {
+		return nil, errors.New("inline images require an HTML body")
+	}
 
+	// MIME tree: mixed(related(alternative(plain, html), inline...), attachments...),
+	// with each level omitted when it has a single child.
 	if len(opts.Attachments) == 0 {
-		switch {
-		case hasPlain && hasHTML:
-			altBoundary, err := randomBoundary()
-			if err != nil {
-				return nil, err
-			}
-			writeHeader(&b, "Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", altBoundary))
-			b.WriteString("\r\n")
-
-			writeTextPart(&b, altBoundary, "text/plain; charset=\"utf-8\"", plainBody)
-			writeTextPart(&b, altBoundary, "text/html; charset=\"utf-8\"", htmlBody)
-			b.WriteString(fmt.Sprintf("--%s--\r\n", altBoundary))
-			return b.Bytes(), nil
-		case hasHTML && !hasPlain:
-			writeHeader(&b, "Content-Type", "text/html; charset=\"utf-8\"")
-			writeHeader(&b, "Content-Transfer-Encoding", "7bit")
-			b.WriteString("\r\n")
-			writeBodyWithTrailingCRLF(&b, htmlBody)
-			return b.Bytes(), nil
-		default:
-			writeHeader(&b, "Content-Type", "text/plain; charset=\"utf-8\"")
-			writeHeader(&b, "Content-Transfer-Encoding", "7bit")
-			b.WriteString("\r\n")
-			writeBodyWithTrailingCRLF(&b, plainBody)
-			return b.Bytes(), nil
+		if err := writeBodyEntity(&b, plainBody, htmlBody, opts.Inline); err != nil {
+			return nil, err
 		}
+		return b.Bytes(), nil
 	}
 
 	mixedBoundary, err := randomBoundary()
@@ -159,55 +145,120 @@ func buildRFC822(opts mailOptions, cfg *rfc822Config) ([]byte, error) {
 
 	// Body part
 	b.WriteString(fmt.Sprintf("--%s\r\n", mixedBoundary))
+	if err := writeBodyEntity(&b, plainBody, htmlBody, opts.Inline); err != nil {
+		return nil, err
+	}
+
+	// Attachments
+	for _, a := range opts.Attachments {
+		a, err := loadMailAttachment(a)
+		if err != nil {
+			return nil, err
+		}
+
+		b.WriteString(fmt.Sprintf("\r\n--%s\r\n", mixedBoundary))
+		b.WriteString(fmt.Sprintf("Content-Type: %s\r\n", a.MIMEType))
+		b.WriteString("Content-Transfer-Encoding: base64\r\n")
+		b.WriteString(fmt.Sprintf("Content-Disposition: attachment; %s\r\n\r\n", contentDispositionFilename(a.Filename)))
+		b.WriteString(wrapBase64(a.Data))
+		b.WriteString("\r\n")
+	}
+
+	b.WriteString(fmt.Sprintf("--%s--\r\n", mixedBoundary))
+	return b.Bytes(), nil
+}
+
+// writeBodyEntity writes the Content-Type headers and content of the message
+// body: text, multipart/alternative, or both wrapped in multipart/related
+// together with the inline parts.
+func writeBodyEntity(b *bytes.Buffer, plainBody, htmlBody string, inline []mailAttachment) error {
+	if len(inline) == 0 {
+		return writeTextEntity(b, plainBody, htmlBody)
+	}
+
+	relatedBoundary, err := randomBoundary()
+	if err != nil {
+		return err
+	}
+	rootType := "text/html"
+	if strings.TrimSpace(plainBody) != "" {
+		rootType = "multipart/alternative"
+	}
+	b.WriteString(fmt.Sprintf("Content-Type: multipart/related; boundary=%q; type=%q\r\n\r\n", relatedBoundary, rootType))
+	b.WriteString(fmt.Sprintf("--%s\r\n", relatedBoundary))
+	if err := writeTextEntity(b, plainBody, htmlBody); err != nil {
+		return err
+	}
+
+	for _, a := range inline {
+		a, err := loadMailAttachment(a)
+		if err != nil {
+			return err
+		}
+		if err := validateHeaderValue(a.ContentID); err != nil {
+			return fmt.Errorf("invalid Content-ID: %w", err)
+		}
+
+		b.WriteString(fmt.Sprintf("\r\n--%s\r\n", relatedBoundary))
+		b.WriteString(fmt.Sprintf("Content-Type: %s\r\n", a.MIMEType))
+		b.WriteString("Content-Transfer-Encoding: base64\r\n")
+		b.WriteString(fmt.Sprintf("Content-ID: <%s>\r\n", a.ContentID))
+		b.WriteString(fmt.Sprintf("Content-Disposition: inline; %s\r\n\r\n", contentDispositionFilename(a.Filename)))
+		b.WriteString(wrapBase64(a.Data))
+		b.WriteString("\r\n")
+	}
+
+	b.WriteString(fmt.Sprintf("--%s--\r\n", relatedBoundary))
+	return nil
+}
+
+func writeTextEntity(b *bytes.Buffer, plainBody, htmlBody string) error {
+	hasPlain := strings.TrimSpace(plainBody) != ""
+	hasHTML := strings.TrimSpace(htmlBody) != ""
+
 	switch {
 	case hasPlain && hasHTML:
 		altBoundary, err := randomBoundary()
 		if err != nil {
-			return nil, err
+			return err
 		}
 		b.WriteString(fmt.Sprintf("Content-Type: multipart/alternative; boundary=%q\r\n\r\n", altBoundary))
-		writeTextPart(&b, altBoundary, "text/plain; charset=\"utf-8\"", plainBody)
-		writeTextPart(&b, altBoundary, "text/html; charset=\"utf-8\"", htmlBody)
+		writeTextPart(b, altBoundary, "text/plain; charset=\"utf-8\"", plainBody)
+		writeTextPart(b, altBoundary, "text/html; charset=\"utf-8\"", htmlBody)
 		b.WriteString(fmt.Sprintf("--%s--\r\n", altBoundary))
 	case hasHTML && !hasPlain:
 		b.WriteString("Content-Type: text/html; charset=\"utf-8\"\r\n")
 		b.WriteString("Content-Transfer-Encoding: 7bit\r\n\r\n")
-		writeBodyWithTrailingCRLF(&b, htmlBody)
+		writeBodyWithTrailingCRLF(b, htmlBody)
 	default:
 		b.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
 		b.WriteString("Content-Transfer-Encoding: 7bit\r\n\r\n")
-		writeBodyWithTrailingCRLF(&b, plainBody)
+		writeBodyWithTrailingCRLF(b, plainBody)
 	}
 
-	// Attachments
-	for _, a := range opts.Attachments {
-		if a.Filename == "" {
-			a.Filename = filepath.Base(a.Path)
-		}
+	return nil
+}
+
+// loadMailAttachment fills in the filename, MIME type and data of a.
+func loadMailAttachment(a mailAttachment) (mailAttachment, error) {
+	if a.Filename == "" {
+		a.Filename = filepath.Base(a.Path)
+	}
+	if a.MIMEType == "" {
+		a.MIMEType = mime.TypeByExtension(strings.ToLower(filepath.Ext(a.Filename)))
 		if a.MIMEType == "" {
-			a.MIMEType = mime.TypeByExtension(strings.ToLower(filepath.Ext(a.Filename)))
-			if a.MIMEType == "" {
-				a.MIMEType = "application/octet-stream"
-			}
+			a.MIMEType = "application/octet-stream"
 		}
-		if len(a.Data) == 0 {
-			data, err := os.ReadFile(a.Path)
-			if err != nil {
-				return nil, err
-			}
-			a.Data = data
+	}
+	if len(a.Data) == 0 {
+		data, err := os.ReadFile(a.Path)
+		if err != nil {
+			return a, err
 		}
-
-		b.WriteString(fmt.Sprintf("\r\n--%s\r\n", mixedBoundary))
-		b.WriteString(fmt.Sprintf("Content-Type: %s\r\n", a.MIMEType))
-		b.WriteString("Content-Transfer-Encoding: base64\r\n")
-		b.WriteString(fmt.Sprintf("Content-Disposition: attachment; %s\r\n\r\n", contentDispositionFilename(a.Filename)))
-		b.WriteString(wrapBase64(a.Data))
-		b.WriteString("\r\n")
+		a.Data = data
 	}
 
-	b.WriteString(fmt.Sprintf("--%s--\r\n", mixedBoundary))
-	return b.Bytes(), nil
+	return a, nil
 }
 
 func writeHeader(b *bytes.Buffer, name, value string) {
@@ -1,6 +1,12 @@
 package cmd
 
 import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/textproto"
 	"regexp"
 	"strings"
 	"testing"
@@ -352,3 +358,84 @@ func TestFormatAddressHeadersParsesCommaSeparatedList(t *testing.T) {
 		t.Fatalf("expected both addresses in output, got %q", got)
 	}
 }
+
+func TestBuildRFC822InlineRelatedTree(t *testing.T) {
+	raw, err := buildRFC822(mailOptions{
+		From:     "a@b.com",
+		To:       []string{"c@d.com"},
+		Subject:  "Hi",
+		Body:     "Plain",
+		BodyHTML: `<p><img src="cid:logo"></p>`,
+		Inline: []mailAttachment{
+			{Filename: "logo.png", MIMEType: "image/png", Data: []byte("png"), ContentID: "logo"},
+		},
+		Attachments: []mailAttachment{
+			{Filename: "x.txt", MIMEType: "text/plain", Data: []byte("abc")},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	var tree []string
+	var walk func(ctype string, body io.Reader, depth int, header textproto.MIMEHeader)
+	walk = func(ctype string, body io.Reader, depth int, header textproto.MIMEHeader) {
+		mediaType, params, parseErr := mime.ParseMediaType(ctype)
+		if parseErr != nil {
+			t.Fatalf("media type %q: %v", ctype, parseErr)
+		}
+		entry := strings.Repeat("  ", depth) + mediaType
+		if cid := header.Get("Content-Id"); cid != "" {
+			entry += " " + cid + " " + header.Get("Content-Disposition")
+		}
+		tree = append(tree, entry)
+		if !strings.HasPrefix(mediaType, "multipart/") {
+			return
+		}
+		r := multipart.NewReader(body, params["boundary"])
+		for {
+			part, partErr := r.NextPart()
+			if partErr == io.EOF {
+				return
+			}
+			if partErr != nil {
+				t.Fatalf("next part: %v", partErr)
+			}
+			walk(part.Header.Get("Content-Type"), part, depth+1, part.Header)
+		}
+	}
+	walk(msg.Header.Get("Content-Type"), msg.Body, 0, textproto.MIMEHeader{})
+
+	want := []string{
+		"multipart/mixed",
+		"  multipart/related",
+		"    multipart/alternative",
+		"      text/plain",
+		"      text/html",
+		`    image/png <logo> inline; filename="logo.png"`,
+		"  text/plain",
+	}
+	if strings.Join(tree, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected MIME tree:\n%s\n\nraw:\n%s", strings.Join(tree, "\n"), raw)
+	}
+	if !strings.Contains(string(raw), `type="multipart/alternative"`) {
+		t.Fatalf("expected related type parameter: %q", raw)
+	}
+}
+
+func TestBuildRFC822InlineRequiresHTML(t *testing.T) {
+	_, err := buildRFC822(mailOptions{
+		From:    "a@b.com",
+		To:      []string{"c@d.com"},
+		Subject: "Hi",
+		Body:    "Plain",
+		Inline:  []mailAttachment{{Filename: "logo.png", Data: []byte("png"), ContentID: "logo"}},
+	}, nil)
+	if err == nil {
+		t.Fatalf("expected error for inline image without HTML body")
+	}
+}
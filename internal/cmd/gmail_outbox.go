@@ -0,0 +1,670 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/gmail/v1"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/timeparse"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// The Gmail API has no scheduled send. `gmail send --at` stores the built
+// messages as one JSON file per send in the outbox dir; `gmail outbox run`
+// (from cron/systemd) sends what is due. A run claims an entry by renaming
+// <id>.json to <id>.sending, so overlapping runs never send it twice. A
+// claim not touched for gmailOutboxClaimTimeout belongs to a run that died;
+// the next run puts it back in the queue. Sent messages are recorded in the
+// entry as they go out, so only the unsent ones are retried.
+
+const (
+	gmailOutboxVersion   = 1
+	gmailOutboxExt       = ".json"
+	gmailOutboxClaimExt  = ".sending"
+	gmailOutboxIDTimeFmt = "20060102T150405"
+
+	gmailOutboxClaimTimeout = 30 * time.Minute
+
+	gmailOutboxPending = "pending"
+	gmailOutboxDue     = "due"
+	gmailOutboxFailed  = "failed"
+	gmailOutboxSending = "sending"
+)
+
+type gmailOutboxEntry struct {
+	Version   int                  `json:"version"`
+	ID        string               `json:"id"`
+	Account   string               `json:"account"`
+	From      string               `json:"from"`
+	Subject   string               `json:"subject"`
+	SendAt    time.Time            `json:"send_at"`
+	CreatedAt time.Time            `json:"created_at"`
+	DraftID   string               `json:"draft_id,omitempty"`
+	Attempts  int                  `json:"attempts,omitempty"`
+	LastError string               `json:"last_error,omitempty"`
+	Messages  []gmailOutboxMessage `json:"messages"`
+}
+
+type gmailOutboxMessage struct {
+	To         string `json:"to"`
+	ThreadID   string `json:"thread_id,omitempty"`
+	TrackingID string `json:"tracking_id,omitempty"`
+	// Raw is the base64url-encoded RFC822 message, as sent to messages.send.
+	Raw string `json:"raw"`
+	// SentID is set once the message is sent, so a retry skips it.
+	SentID string `json:"sent_id,omitempty"`
+}
+
+type gmailOutboxSummary struct {
+	ID        string    `json:"id"`
+	Account   string    `json:"account"`
+	From      string    `json:"from"`
+	To        []string  `json:"to"`
+	Subject   string    `json:"subject"`
+	SendAt    time.Time `json:"send_at"`
+	Status    string    `json:"status"`
+	DraftID   string    `json:"draft_id,omitempty"`
+	Attempts  int       `json:"attempts,omitempty"`
+	LastError string    `json:"last_error,omitempty"`
+}
+
+func (e *gmailOutboxEntry) summary(status string) gmailOutboxSummary {
+	to := make([]string, 0, len(e.Messages))
+	for _, m := range e.Messages {
+		to = append(to, m.To)
+	}
+	return gmailOutboxSummary{
+		ID:        e.ID,
+		Account:   e.Account,
+		From:      e.From,
+		To:        to,
+		Subject:   e.Subject,
+		SendAt:    e.SendAt,
+		Status:    status,
+		DraftID:   e.DraftID,
+		Attempts:  e.Attempts,
+		LastError: e.LastError,
+	}
+}
+
+func (e *gmailOutboxEntry) status(now time.Time) string {
+	switch {
+	case e.LastError != "":
+		return gmailOutboxFailed
+	case !e.SendAt.After(now):
+		return gmailOutboxDue
+	default:
+		return gmailOutboxPending
+	}
+}
+
+// parseSendAt parses --at in the configured timezone. It needs a time of day
+// and must not be in the past.
+func parseSendAt(value string, now time.Time) (time.Time, error) {
+	loc, err := resolveOutputLocation("", false)
+	if err != nil {
+		return time.Time{}, err
+	}
+	weekStart, err := resolveWeekStart("")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	span, err := timeparse.ParseNatural(value, timeparse.Options{Now: now.In(loc), Location: loc, WeekStart: weekStart})
+	if err != nil {
+		return time.Time{}, usagef("invalid --at: %v", err)
+	}
+	if !span.HasTime {
+		return time.Time{}, usagef("--at %q needs a time of day (e.g. \"tomorrow 9am\")", value)
+	}
+	if span.Start.Before(now.Add(-time.Minute)) {
+		return time.Time{}, usagef("--at %q is in the past (%s)", value, span.Start.Format(time.RFC3339))
+	}
+
+	return span.Start, nil
+}
+
+func formatSendAt(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func newGmailOutboxID(sendAt time.Time) (string, error) {
+	var b [3]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return sendAt.UTC().Format(gmailOutboxIDTimeFmt) + "-" + hex.EncodeToString(b[:]), nil
+}
+
+func validGmailOutboxID(id string) bool {
+	return id != "" && !strings.ContainsAny(id, `/\`) && !strings.HasPrefix(id, ".")
+}
+
+func gmailOutboxPath(dir, id string) string {
+	return filepath.Join(dir, id+gmailOutboxExt)
+}
+
+func gmailOutboxClaimPath(dir, id string) string {
+	return filepath.Join(dir, id+gmailOutboxClaimExt)
+}
+
+func writeGmailOutboxEntry(path string, entry *gmailOutboxEntry) error {
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func readGmailOutboxEntry(path string) (*gmailOutboxEntry, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // outbox dir
+	if err != nil {
+		return nil, err
+	}
+	var entry gmailOutboxEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("read outbox entry %s: %w", filepath.Base(path), err)
+	}
+	return &entry, nil
+}
+
+// loadGmailOutbox returns all queued entries ordered by send time, with
+// claimed (currently sending) entries flagged.
+func loadGmailOutbox(dir string) ([]*gmailOutboxEntry, map[string]bool, error) {
+	files, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, map[string]bool{}, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var entries []*gmailOutboxEntry
+	claimed := map[string]bool{}
+	for _, f := range files {
+		name := f.Name()
+		ext := filepath.Ext(name)
+		if f.IsDir() || strings.HasPrefix(name, ".") || (ext != gmailOutboxExt && ext != gmailOutboxClaimExt) {
+			continue
+		}
+		entry, err := readGmailOutboxEntry(filepath.Join(dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue // claimed or removed by a concurrent run
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if ext == gmailOutboxClaimExt {
+			claimed[entry.ID] = true
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].SendAt.Equal(entries[j].SendAt) {
+			return entries[i].SendAt.Before(entries[j].SendAt)
+		}
+		return entries[i].ID < entries[j].ID
+	})
+
+	return entries, claimed, nil
+}
+
+// queueGmailOutbox stores prepared messages for a scheduled send, optionally
+// creating a Gmail draft of the first message as a visible placeholder.
+func queueGmailOutbox(ctx context.Context, u *ui.UI, svc *gmail.Service, account, fromAddr, subject string, sendAt time.Time, prepared []preparedSend, placeholder bool) error {
+	dir, err := config.EnsureGmailOutboxDir()
+	if err != nil {
+		return err
+	}
+	id, err := newGmailOutboxID(sendAt)
+	if err != nil {
+		return err
+	}
+
+	entry := &gmailOutboxEntry{
+		Version:   gmailOutboxVersion,
+		ID:        id,
+		Account:   account,
+		From:      fromAddr,
+		Subject:   subject,
+		SendAt:    sendAt,
+		CreatedAt: time.Now().UTC(),
+		Messages:  make([]gmailOutboxMessage, 0, len(prepared)),
+	}
+	for _, p := range prepared {
+		entry.Messages = append(entry.Messages, gmailOutboxMessage{
+			To:         p.To,
+			ThreadID:   p.Message.ThreadId,
+			TrackingID: p.TrackingID,
+			Raw:        p.Message.Raw,
+		})
+	}
+
+	if placeholder && len(prepared) > 0 {
+		first := prepared[0].Message
+		draft, err := svc.Users.Drafts.Create("me", &gmail.Draft{Message: &gmail.Message{Raw: first.Raw, ThreadId: first.ThreadId}}).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("create placeholder draft: %w", err)
+		}
+		entry.DraftID = draft.Id
+	}
+
+	if err := writeGmailOutboxEntry(gmailOutboxPath(dir, id), entry); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"scheduled": true,
+			"outbox":    entry.summary(gmailOutboxPending),
+		})
+	}
+	u.Out().Printf("outbox_id\t%s", entry.ID)
+	u.Out().Printf("send_at\t%s", entry.SendAt.Format(time.RFC3339))
+	u.Out().Printf("messages\t%d", len(entry.Messages))
+	if entry.DraftID != "" {
+		u.Out().Printf("draft_id\t%s", entry.DraftID)
+	}
+	u.Err().Println("Queued; deliver with 'gog gmail outbox run' (e.g. from cron every few minutes)")
+	return nil
+}
+
+type GmailOutboxCmd struct {
+	List   GmailOutboxListCmd   `cmd:"" name:"list" aliases:"ls" default:"withargs" help:"List scheduled messages"`
+	Cancel GmailOutboxCancelCmd `cmd:"" name:"cancel" aliases:"rm,delete" help:"Cancel scheduled messages"`
+	Run    GmailOutboxRunCmd    `cmd:"" name:"run" help:"Send scheduled messages that are due (for cron/systemd)"`
+}
+
+type GmailOutboxListCmd struct{}
+
+func (c *GmailOutboxListCmd) Run(ctx context.Context) error {
+	u := ui.FromContext(ctx)
+
+	dir, err := config.GmailOutboxDir()
+	if err != nil {
+		return err
+	}
+	entries, claimed, err := loadGmailOutbox(dir)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	items := make([]gmailOutboxSummary, 0, len(entries))
+	for _, e := range entries {
+		status := e.status(now)
+		if claimed[e.ID] {
+			status = gmailOutboxSending
+		}
+		items = append(items, e.summary(status))
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"entries": items})
+	}
+	if len(items) == 0 {
+		u.Err().Println("Outbox is empty")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ID\tSEND_AT\tSTATUS\tACCOUNT\tTO\tSUBJECT")
+	for _, it := range items {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			it.ID,
+			it.SendAt.Local().Format("2006-01-02 15:04"),
+			it.Status,
+			sanitizeTab(it.Account),
+			sanitizeTab(strings.Join(it.To, ", ")),
+			sanitizeTab(it.Subject),
+		)
+	}
+	return nil
+}
+
+func (*GmailOutboxListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "entries", Result: struct {
+		Entries []gmailOutboxSummary `json:"entries"`
+	}{}}
+}
+
+type GmailOutboxCancelCmd struct {
+	IDs []string `arg:"" name:"id" help:"Outbox IDs (from 'gmail outbox list')"`
+}
+
+func (c *GmailOutboxCancelCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+
+	ids := make([]string, 0, len(c.IDs))
+	for _, id := range c.IDs {
+		id = strings.TrimSpace(id)
+		if !validGmailOutboxID(id) {
+			return usagef("invalid outbox id %q", id)
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return usage("required: outbox id")
+	}
+
+	if err := dryRunExit(ctx, flags, "gmail.outbox.cancel", map[string]any{"ids": ids}); err != nil {
+		return err
+	}
+
+	dir, err := config.GmailOutboxDir()
+	if err != nil {
+		return err
+	}
+
+	cancelled := make([]gmailOutboxSummary, 0, len(ids))
+	for _, id := range ids {
+		path := gmailOutboxPath(dir, id)
+		entry, err := readGmailOutboxEntry(path)
+		if errors.Is(err, os.ErrNotExist) {
+			if _, claimErr := os.Stat(gmailOutboxClaimPath(dir, id)); claimErr == nil {
+				return fmt.Errorf("outbox entry %s is being sent", id)
+			}
+			return usagef("outbox entry %s not found", id)
+		}
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+		if entry.DraftID != "" {
+			svc, svcErr := newGmailService(ctx, entry.Account)
+			if svcErr == nil {
+				svcErr = deleteGmailOutboxDraft(ctx, svc, entry.DraftID)
+			}
+			if svcErr != nil {
+				u.Err().Printf("warning: %s: delete placeholder draft %s: %v", id, entry.DraftID, svcErr)
+			}
+		}
+		cancelled = append(cancelled, entry.summary("cancelled"))
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"cancelled": cancelled})
+	}
+	for _, s := range cancelled {
+		u.Out().Printf("cancelled\t%s\t%s", s.ID, s.Subject)
+	}
+	return nil
+}
+
+func (*GmailOutboxCancelCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "cancelled", Result: struct {
+		Cancelled []gmailOutboxSummary `json:"cancelled"`
+	}{}}
+}
+
+type GmailOutboxRunCmd struct {
+	All bool `name:"all" help:"Also send messages that are not due yet"`
+}
+
+type gmailOutboxRunSent struct {
+	ID         string `json:"id"`
+	Account    string `json:"account"`
+	To         string `json:"to"`
+	MessageID  string `json:"messageId"`
+	ThreadID   string `json:"threadId,omitempty"`
+	TrackingID string `json:"tracking_id,omitempty"`
+}
+
+type gmailOutboxRunSkip struct {
+	ID     string `json:"id"`
+	Reason string `json:"reason"`
+}
+
+type gmailOutboxRunFailure struct {
+	ID    string `json:"id"`
+	Error string `json:"error"`
+}
+
+type gmailOutboxRunResult struct {
+	Sent    []gmailOutboxRunSent    `json:"sent"`
+	Skipped []gmailOutboxRunSkip    `json:"skipped"`
+	Failed  []gmailOutboxRunFailure `json:"failed"`
+	Pending int                     `json:"pending"`
+}
+
+func (c *GmailOutboxRunCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+
+	dir, err := config.GmailOutboxDir()
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	if err := reclaimStaleGmailOutbox(dir, now); err != nil {
+		return err
+	}
+	entries, claimed, err := loadGmailOutbox(dir)
+	if err != nil {
+		return err
+	}
+
+	res := gmailOutboxRunResult{Sent: []gmailOutboxRunSent{}, Skipped: []gmailOutboxRunSkip{}, Failed: []gmailOutboxRunFailure{}}
+	var due []*gmailOutboxEntry
+	for _, e := range entries {
+		switch {
+		case claimed[e.ID]:
+			res.Skipped = append(res.Skipped, gmailOutboxRunSkip{ID: e.ID, Reason: "being sent by another run"})
+		case c.All || !e.SendAt.After(now):
+			due = append(due, e)
+		default:
+			res.Pending++
+		}
+	}
+
+	if err := dryRunExit(ctx, flags, "gmail.outbox.run", map[string]any{
+		"due":     summarizeGmailOutbox(due, now),
+		"pending": res.Pending,
+	}); err != nil {
+		return err
+	}
+
+	services := map[string]*gmail.Service{}
+	for _, e := range due {
+		svc, ok := services[e.Account]
+		if !ok {
+			svc, err = newGmailService(ctx, e.Account)
+			if err != nil {
+				res.Failed = append(res.Failed, gmailOutboxRunFailure{ID: e.ID, Error: err.Error()})
+				continue
+			}
+			services[e.Account] = svc
+		}
+
+		sent, skip, sendErr := sendGmailOutboxEntry(ctx, svc, dir, e)
+		res.Sent = append(res.Sent, sent...)
+		switch {
+		case sendErr != nil:
+			res.Failed = append(res.Failed, gmailOutboxRunFailure{ID: e.ID, Error: sendErr.Error()})
+			if !outfmt.IsJSON(ctx) {
+				u.Err().Errorf("%s: %s", e.ID, sendErr.Error())
+			}
+		case skip != "":
+			res.Skipped = append(res.Skipped, gmailOutboxRunSkip{ID: e.ID, Reason: skip})
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, res); err != nil {
+			return err
+		}
+		return gmailOutboxRunError(res)
+	}
+
+	if len(res.Sent) > 0 {
+		w, flush := tableWriter(ctx)
+		fmt.Fprintln(w, "ID\tACCOUNT\tTO\tMESSAGE_ID")
+		for _, s := range res.Sent {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.ID, sanitizeTab(s.Account), sanitizeTab(s.To), s.MessageID)
+		}
+		flush()
+	}
+	for _, s := range res.Skipped {
+		u.Err().Printf("skipped %s: %s", s.ID, s.Reason)
+	}
+	u.Err().Printf("sent: %d, failed: %d, pending: %d", len(res.Sent), len(res.Failed), res.Pending)
+
+	return gmailOutboxRunError(res)
+}
+
+// gmailOutboxRunError fails the run when any entry could not be sent, so
+// cron/systemd report it.
+func gmailOutboxRunError(res gmailOutboxRunResult) error {
+	if len(res.Failed) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d outbox entries failed to send (kept for the next run)", len(res.Failed))
+}
+
+// reclaimStaleGmailOutbox returns claims older than gmailOutboxClaimTimeout
+// to the queue. Renaming is atomic, so only one run reclaims each entry.
+func reclaimStaleGmailOutbox(dir string, now time.Time) error {
+	files, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		name := f.Name()
+		if f.IsDir() || filepath.Ext(name) != gmailOutboxClaimExt {
+			continue
+		}
+		info, err := f.Info()
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if now.Sub(info.ModTime()) < gmailOutboxClaimTimeout {
+			continue
+		}
+		id := strings.TrimSuffix(name, gmailOutboxClaimExt)
+		if err := os.Rename(filepath.Join(dir, name), gmailOutboxPath(dir, id)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (*GmailOutboxRunCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "sent", Result: gmailOutboxRunResult{}}
+}
+
+func summarizeGmailOutbox(entries []*gmailOutboxEntry, now time.Time) []gmailOutboxSummary {
+	out := make([]gmailOutboxSummary, 0, len(entries))
+	for _, e := range entries {
+		out = append(out, e.summary(e.status(now)))
+	}
+	return out
+}
+
+// sendGmailOutboxEntry claims and sends one entry. On success the entry is
+// removed; on failure it is released with the error recorded, keeping the
+// IDs of messages that did go out. A placeholder draft that no longer exists
+// (deleted or sent from Gmail) cancels the entry.
+func sendGmailOutboxEntry(ctx context.Context, svc *gmail.Service, dir string, entry *gmailOutboxEntry) ([]gmailOutboxRunSent, string, error) {
+	path := gmailOutboxPath(dir, entry.ID)
+	claim := gmailOutboxClaimPath(dir, entry.ID)
+	if err := os.Rename(path, claim); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, "claimed or cancelled by another run", nil
+		}
+		return nil, "", err
+	}
+	// Rename keeps the queued file's mtime; the claim's age starts now.
+	now := time.Now()
+	if err := os.Chtimes(claim, now, now); err != nil {
+		return nil, "", releaseGmailOutboxEntry(claim, path, entry, err)
+	}
+
+	if entry.DraftID != "" {
+		if _, err := svc.Users.Drafts.Get("me", entry.DraftID).Format(gmailFormatMetadata).Context(ctx).Do(); err != nil {
+			if isNotFoundAPIError(err) {
+				return nil, "placeholder draft was deleted or sent in Gmail", os.Remove(claim)
+			}
+			return nil, "", releaseGmailOutboxEntry(claim, path, entry, err)
+		}
+	}
+
+	var sent []gmailOutboxRunSent
+	for i := range entry.Messages {
+		m := &entry.Messages[i]
+		if m.SentID != "" {
+			continue
+		}
+		msg, err := svc.Users.Messages.Send("me", &gmail.Message{Raw: m.Raw, ThreadId: m.ThreadID}).Context(ctx).Do()
+		if err != nil {
+			return sent, "", releaseGmailOutboxEntry(claim, path, entry, err)
+		}
+		m.SentID = msg.Id
+		sent = append(sent, gmailOutboxRunSent{
+			ID:         entry.ID,
+			Account:    entry.Account,
+			To:         m.To,
+			MessageID:  msg.Id,
+			ThreadID:   msg.ThreadId,
+			TrackingID: m.TrackingID,
+		})
+		// Persist progress so a crash after this point never resends it.
+		if err := writeGmailOutboxEntry(claim, entry); err != nil {
+			return sent, "", err
+		}
+	}
+
+	if entry.DraftID != "" {
+		// Best effort: the messages are out, a leftover draft is harmless.
+		_ = deleteGmailOutboxDraft(ctx, svc, entry.DraftID)
+	}
+	return sent, "", os.Remove(claim)
+}
+
+func releaseGmailOutboxEntry(claim, path string, entry *gmailOutboxEntry, sendErr error) error {
+	entry.Attempts++
+	entry.LastError = sendErr.Error()
+	if err := writeGmailOutboxEntry(claim, entry); err != nil {
+		return errors.Join(sendErr, err)
+	}
+	if err := os.Rename(claim, path); err != nil {
+		return errors.Join(sendErr, err)
+	}
+	return sendErr
+}
+
+func deleteGmailOutboxDraft(ctx context.Context, svc *gmail.Service, draftID string) error {
+	err := svc.Users.Drafts.Delete("me", draftID).Context(ctx).Do()
+	if err != nil && !isNotFoundAPIError(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+func TestParseSendAt(t *testing.T) {
+	t.Setenv("GOG_TIMEZONE", "UTC")
+	now := time.Date(2026, 3, 2, 22, 0, 0, 0, time.UTC)
+
+	got, err := parseSendAt("tomorrow 9am", now)
+	if err != nil || !got.Equal(time.Date(2026, 3, 3, 9, 0, 0, 0, time.UTC)) {
+		t.Fatalf("tomorrow 9am: %v (%v)", got, err)
+	}
+	if _, err := parseSendAt("tomorrow", now); err == nil || !strings.Contains(err.Error(), "time of day") {
+		t.Fatalf("expected time-of-day error, got %v", err)
+	}
+	if _, err := parseSendAt("2026-03-01 09:00", now); err == nil || !strings.Contains(err.Error(), "in the past") {
+		t.Fatalf("expected past error, got %v", err)
+	}
+}
+
+func TestGmailOutbox_QueueListRunCancel(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg-config"))
+
+	origNew := newGmailService
+	t.Cleanup(func() { newGmailService = origNew })
+
+	var (
+		mu      sync.Mutex
+		sent    []string
+		deleted []string
+		drafts  int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		var body any
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/settings/sendAs"):
+			body = map[string]any{"sendAs": []map[string]any{}}
+		case strings.HasSuffix(r.URL.Path, "/drafts") && r.Method == http.MethodPost:
+			drafts++
+			body = map[string]any{"id": "d" + string(rune('0'+drafts))}
+		case strings.Contains(r.URL.Path, "/drafts/") && r.Method == http.MethodGet:
+			body = map[string]any{"id": strings.TrimPrefix(r.URL.Path[strings.LastIndex(r.URL.Path, "/"):], "/")}
+		case strings.Contains(r.URL.Path, "/drafts/") && r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case strings.HasSuffix(r.URL.Path, "/messages/send"):
+			var msg gmail.Message
+			_ = json.NewDecoder(r.Body).Decode(&msg)
+			sent = append(sent, msg.Raw)
+			body = map[string]any{"id": "m1", "threadId": "t1"}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer srv.Close()
+
+	svc, err := gmail.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("gmail.NewService: %v", err)
+	}
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return svc, nil }
+
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{JSON: true})
+	flags := &RootFlags{Account: "a@b.com"}
+
+	run := func(cmd any, args ...string) string {
+		t.Helper()
+		return captureStdout(t, func() {
+			if err := runKong(t, cmd, args, ctx, flags); err != nil {
+				t.Fatalf("%T %v: %v", cmd, args, err)
+			}
+		})
+	}
+
+	queue := func(subject string) gmailOutboxSummary {
+		t.Helper()
+		out := run(&GmailSendCmd{}, "--to", "x@example.com", "--subject", subject, "--body", "hi", "--at", "in 2 hours", "--placeholder-draft")
+		var parsed struct {
+			Scheduled bool               `json:"scheduled"`
+			Outbox    gmailOutboxSummary `json:"outbox"`
+		}
+		if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+			t.Fatalf("json parse: %v\n%s", err, out)
+		}
+		if !parsed.Scheduled || parsed.Outbox.ID == "" || parsed.Outbox.DraftID == "" {
+			t.Fatalf("unexpected queue result: %s", out)
+		}
+		return parsed.Outbox
+	}
+
+	first := queue("Morning update")
+	second := queue("Cancelled update")
+	if len(sent) != 0 {
+		t.Fatalf("scheduled send must not send immediately")
+	}
+
+	var listed struct {
+		Entries []gmailOutboxSummary `json:"entries"`
+	}
+	out := run(&GmailOutboxListCmd{})
+	if err := json.Unmarshal([]byte(out), &listed); err != nil {
+		t.Fatalf("json parse: %v\n%s", err, out)
+	}
+	if len(listed.Entries) != 2 || listed.Entries[0].Status != gmailOutboxPending || listed.Entries[0].To[0] != "x@example.com" {
+		t.Fatalf("unexpected list: %s", out)
+	}
+
+	out = run(&GmailOutboxCancelCmd{}, second.ID)
+	if !strings.Contains(out, second.ID) || len(deleted) != 1 || deleted[0] != second.DraftID {
+		t.Fatalf("unexpected cancel: %s (deleted %v)", out, deleted)
+	}
+
+	var result gmailOutboxRunResult
+	out = run(&GmailOutboxRunCmd{})
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("json parse: %v\n%s", err, out)
+	}
+	if len(result.Sent) != 0 || result.Pending != 1 || len(sent) != 0 {
+		t.Fatalf("expected nothing due yet: %s", out)
+	}
+
+	out = run(&GmailOutboxRunCmd{}, "--all")
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("json parse: %v\n%s", err, out)
+	}
+	if len(result.Sent) != 1 || result.Sent[0].ID != first.ID || result.Sent[0].MessageID != "m1" || len(sent) != 1 {
+		t.Fatalf("unexpected run: %s", out)
+	}
+	if len(deleted) != 2 || deleted[1] != first.DraftID {
+		t.Fatalf("expected placeholder draft to be deleted after send, got %v", deleted)
+	}
+
+	out = run(&GmailOutboxListCmd{})
+	if err := json.Unmarshal([]byte(out), &listed); err != nil {
+		t.Fatalf("json parse: %v\n%s", err, out)
+	}
+	if len(listed.Entries) != 0 {
+		t.Fatalf("expected empty outbox: %s", out)
+	}
+
+	// A claim left behind by a run that died is requeued once it is stale.
+	crashed := queue("Crashed update")
+	dir, err := config.GmailOutboxDir()
+	if err != nil {
+		t.Fatalf("outbox dir: %v", err)
+	}
+	claim := gmailOutboxClaimPath(dir, crashed.ID)
+	if err := os.Rename(gmailOutboxPath(dir, crashed.ID), claim); err != nil {
+		t.Fatalf("rename: %v", err)
+	}
+	out = run(&GmailOutboxRunCmd{}, "--all")
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("json parse: %v\n%s", err, out)
+	}
+	if len(result.Sent) != 0 || len(result.Skipped) != 1 || len(sent) != 1 {
+		t.Fatalf("a fresh claim must be left alone: %s", out)
+	}
+	old := time.Now().Add(-2 * gmailOutboxClaimTimeout)
+	if err := os.Chtimes(claim, old, old); err != nil {
+		t.Fatalf("chtimes: %v", err)
+	}
+	out = run(&GmailOutboxRunCmd{}, "--all")
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("json parse: %v\n%s", err, out)
+	}
+	if len(result.Sent) != 1 || result.Sent[0].ID != crashed.ID || len(sent) != 2 {
+		t.Fatalf("expected stale claim to be sent: %s", out)
+	}
+
+	// Failed sends stay queued and fail the run.
+	failing := queue("Failing update")
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return nil, errors.New("boom") }
+	out = captureStdout(t, func() {
+		if err := runKong(t, &GmailOutboxRunCmd{}, []string{"--all"}, ctx, flags); err == nil {
+			t.Fatalf("expected failed run to return an error")
+		}
+	})
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("json parse: %v\n%s", err, out)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].ID != failing.ID {
+		t.Fatalf("unexpected failed run: %s", out)
+	}
+	if _, err := os.Stat(gmailOutboxPath(dir, failing.ID)); err != nil {
+		t.Fatalf("failed entry should stay queued: %v", err)
+	}
+}
+
+func TestGmailSendAt_DryRunExplainLeavesOutboxEmpty(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg-config"))
+
+	origNew := newGmailService
+	t.Cleanup(func() { newGmailService = origNew })
+	newGmailService = func(context.Context, string) (*gmail.Service, error) {
+		t.Fatalf("dry run must not create a Gmail service")
+		return nil, nil
+	}
+
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{JSON: true})
+	flags := &RootFlags{Account: "a@b.com", DryRun: true, Explain: true}
+
+	out := captureStdout(t, func() {
+		err := runKong(t, &GmailSendCmd{}, []string{"--to", "x@example.com", "--subject", "Later", "--body", "hi", "--at", "in 2 hours", "--placeholder-draft"}, ctx, flags)
+		var exitErr *ExitError
+		if !errors.As(err, &exitErr) || exitErr.Code != 0 {
+			t.Fatalf("expected dry-run exit, got %v", err)
+		}
+	})
+	if !strings.Contains(out, `"gmail.send.schedule"`) {
+		t.Fatalf("unexpected dry-run output: %s", out)
+	}
+
+	dir, err := config.GmailOutboxDir()
+	if err != nil {
+		t.Fatalf("GmailOutboxDir: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatalf("read outbox: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("dry run wrote %d outbox entries", len(entries))
+	}
+}
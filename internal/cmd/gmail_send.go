@@ -8,6 +8,7 @@ import (
 	"net/mail"
 	"os"
 	"strings"
+	"time"
 
 	"google.golang.org/api/gmail/v1"
 
@@ -25,15 +26,20 @@ type GmailSendCmd struct {
 	Body             string   `name:"body" help:"Body (plain text; required unless --body-html is set)"`
 	BodyFile         string   `name:"body-file" help:"Body file path (plain text; '-' for stdin)"`
 	BodyHTML         string   `name:"body-html" help:"Body (HTML; optional)"`
+	BodyMarkdown     string   `name:"body-markdown" aliases:"body-md" help:"Body (Markdown; rendered to styled HTML with a plain-text alternative)"`
+	BodyMDFile       string   `name:"body-md-file" help:"Markdown body file path ('-' for stdin)"`
 	ReplyToMessageID string   `name:"reply-to-message-id" aliases:"in-reply-to" help:"Reply to Gmail message ID (sets In-Reply-To/References and thread)"`
 	ThreadID         string   `name:"thread-id" help:"Reply within a Gmail thread (uses latest message for headers)"`
 	ReplyAll         bool     `name:"reply-all" help:"Auto-populate recipients from original message (requires --reply-to-message-id or --thread-id)"`
 	ReplyTo          string   `name:"reply-to" help:"Reply-To header address"`
 	Attach           []string `name:"attach" help:"Attachment file path (repeatable)"`
+	Inline           []string `name:"inline" help:"Inline image as name=path, referenced as cid:name from the HTML body (repeatable)"`
 	From             string   `name:"from" help:"Send from this email address (must be a verified send-as alias)"`
 	Track            bool     `name:"track" help:"Enable open tracking (requires tracking setup)"`
 	TrackSplit       bool     `name:"track-split" help:"Send tracked messages separately per recipient"`
 	Quote            bool     `name:"quote" help:"Include quoted original message in reply (requires --reply-to-message-id or --thread-id)"`
+	At               string   `name:"at" help:"Schedule the send (e.g. 'tomorrow 9am', '2026-03-02 08:30'); queued locally for 'gmail outbox run'"`
+	PlaceholderDraft bool     `name:"placeholder-draft" help:"With --at: keep a Gmail draft as a visible placeholder (deleted once sent; deleting it cancels the send)"`
 }
 
 type sendBatch struct {
@@ -58,6 +64,7 @@ type sendMessageOptions struct {
 	BodyHTML    string
 	ReplyInfo   *replyInfo
 	Attachments []mailAttachment
+	Inline      []mailAttachment
 	Track       bool
 	TrackingCfg *tracking.Config
 }
@@ -68,7 +75,7 @@ func (c *GmailSendCmd) Run(ctx context.Context, flags *RootFlags) error {
 	replyToMessageID := normalizeGmailMessageID(c.ReplyToMessageID)
 	threadID := normalizeGmailThreadID(c.ThreadID)
 
-	body, err := resolveBodyInput(c.Body, c.BodyFile)
+	body, bodyHTML, err := resolveComposeBodies(c.Body, c.BodyFile, c.BodyHTML, c.BodyMarkdown, c.BodyMDFile)
 	if err != nil {
 		return err
 	}
@@ -94,14 +101,28 @@ func (c *GmailSendCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if strings.TrimSpace(c.Subject) == "" {
 		return usage("required: --subject")
 	}
-	if strings.TrimSpace(body) == "" && strings.TrimSpace(c.BodyHTML) == "" {
-		return usage("required: --body, --body-file, or --body-html")
+	if strings.TrimSpace(body) == "" && strings.TrimSpace(bodyHTML) == "" {
+		return usage("required: --body, --body-file, --body-html, or --body-markdown")
 	}
 	if c.TrackSplit && !c.Track {
 		return usage("--track-split requires --track")
 	}
-	if c.Track && strings.TrimSpace(c.BodyHTML) == "" {
-		return fmt.Errorf("--track requires --body-html (pixel must be in HTML)")
+	if c.Track && strings.TrimSpace(bodyHTML) == "" {
+		return fmt.Errorf("--track requires --body-html or --body-markdown (pixel must be in HTML)")
+	}
+	bodyHTML, inline, err := resolveInlineImages(c.Inline, bodyHTML, c.BodyMarkdown != "" || c.BodyMDFile != "", c.BodyMDFile)
+	if err != nil {
+		return err
+	}
+
+	var sendAt time.Time
+	if strings.TrimSpace(c.At) != "" {
+		sendAt, err = parseSendAt(c.At, time.Now())
+		if err != nil {
+			return err
+		}
+	} else if c.PlaceholderDraft {
+		return usage("--placeholder-draft requires --at")
 	}
 
 	attachPaths := make([]string, 0, len(c.Attach))
@@ -113,7 +134,13 @@ func (c *GmailSendCmd) Run(ctx context.Context, flags *RootFlags) error {
 		attachPaths = append(attachPaths, expanded)
 	}
 
-	if dryRunErr := dryRunExit(ctx, flags, "gmail.send", map[string]any{
+	// A scheduled send writes the local outbox (and maybe a draft), so it is
+	// not a pure API op and stops here even under --explain.
+	op := "gmail.send"
+	if !sendAt.IsZero() {
+		op = "gmail.send.schedule"
+	}
+	if dryRunErr := dryRunExit(ctx, flags, op, map[string]any{
 		"to":                  splitCSV(c.To),
 		"cc":                  splitCSV(c.Cc),
 		"bcc":                 splitCSV(c.Bcc),
@@ -124,10 +151,13 @@ func (c *GmailSendCmd) Run(ctx context.Context, flags *RootFlags) error {
 		"reply_to":            strings.TrimSpace(c.ReplyTo),
 		"from":                strings.TrimSpace(c.From),
 		"body_len":            len(strings.TrimSpace(body)),
-		"body_html_len":       len(strings.TrimSpace(c.BodyHTML)),
+		"body_html_len":       len(strings.TrimSpace(bodyHTML)),
 		"attachments":         attachPaths,
+		"inline":              inlineOutputs(inline),
 		"track":               c.Track,
 		"track_split":         c.TrackSplit,
+		"send_at":             formatSendAt(sendAt),
+		"placeholder_draft":   c.PlaceholderDraft,
 	}); dryRunErr != nil {
 		return dryRunErr
 	}
@@ -142,48 +172,9 @@ func (c *GmailSendCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
-	sendAsList, sendAsListErr := listSendAs(ctx, svc)
-
-	// Determine the From address
-	fromAddr := account
-	sendingEmail := account // The email we're sending from (without display name)
-	if fromEmail := strings.TrimSpace(c.From); fromEmail != "" {
-		// Validate that this is a configured and verified send-as alias.
-		var sa *gmail.SendAs
-		if sendAsListErr == nil {
-			sa = findSendAsByEmail(sendAsList, fromEmail)
-			if sa == nil {
-				return fmt.Errorf("invalid --from address %q: not found in send-as settings", fromEmail)
-			}
-		} else {
-			// Fallback: preserve legacy behavior if we cannot list settings.
-			var getErr error
-			sa, getErr = svc.Users.Settings.SendAs.Get("me", fromEmail).Context(ctx).Do()
-			if getErr != nil {
-				return fmt.Errorf("invalid --from address %q: %w", fromEmail, getErr)
-			}
-		}
-
-		if sa.VerificationStatus != gmailVerificationAccepted {
-			return fmt.Errorf("--from address %q is not verified (status: %s)", fromEmail, sa.VerificationStatus)
-		}
-
-		sendingEmail = fromEmail
-		fromAddr = fromEmail
-
-		if displayName := strings.TrimSpace(sa.DisplayName); displayName != "" {
-			fromAddr = displayName + " <" + fromEmail + ">"
-		}
-	} else {
-		// No --from specified: best-effort look up the primary account's display name.
-		displayName := ""
-		if sendAsListErr == nil {
-			displayName = primaryDisplayNameFromSendAsList(sendAsList, account)
-		}
-		if displayName != "" {
-			fromAddr = displayName + " <" + account + ">"
-		}
-		// If lookup fails, we just use the plain email address (no error)
+	fromAddr, sendingEmail, err := resolveSendFrom(ctx, svc, account, c.From)
+	if err != nil {
+		return err
 	}
 
 	// Fetch reply info (includes recipient headers for reply-all, and body for quoting)
@@ -192,7 +183,7 @@ func (c *GmailSendCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
-	body, htmlBody := applyQuoteToBodies(body, c.BodyHTML, c.Quote, replyInfo)
+	body, htmlBody := applyQuoteToBodies(body, bodyHTML, c.Quote, replyInfo)
 
 	// Determine recipients
 	var toRecipients, ccRecipients []string
@@ -230,7 +221,7 @@ func (c *GmailSendCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}
 
 	batches := buildSendBatches(toRecipients, ccRecipients, bccRecipients, c.Track, c.TrackSplit)
-	results, err := sendGmailBatches(ctx, svc, sendMessageOptions{
+	opts := sendMessageOptions{
 		FromAddr:    fromAddr,
 		ReplyTo:     c.ReplyTo,
 		Subject:     c.Subject,
@@ -238,9 +229,19 @@ func (c *GmailSendCmd) Run(ctx context.Context, flags *RootFlags) error {
 		BodyHTML:    htmlBody,
 		ReplyInfo:   replyInfo,
 		Attachments: atts,
+		Inline:      inline,
 		Track:       c.Track,
 		TrackingCfg: trackingCfg,
-	}, batches)
+	}
+	if !sendAt.IsZero() {
+		prepared, prepareErr := prepareGmailBatches(opts, batches)
+		if prepareErr != nil {
+			return prepareErr
+		}
+		return queueGmailOutbox(ctx, u, svc, account, fromAddr, c.Subject, sendAt, prepared, c.PlaceholderDraft)
+	}
+
+	results, err := sendGmailBatches(ctx, svc, opts, batches)
 	if err != nil {
 		return err
 	}
@@ -248,6 +249,85 @@ func (c *GmailSendCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return writeSendResults(ctx, u, fromAddr, results)
 }
 
+// jsonOutput covers the three results of send: one message, one message per
+// recipient (--track-split), or a scheduled outbox entry (--at).
+func (*GmailSendCmd) jsonOutput() commandOutput {
+	return commandOutput{
+		Items: "messages",
+		Result: struct {
+			Messages []struct {
+				MessageID  string `json:"messageId"`
+				ThreadID   string `json:"threadId"`
+				From       string `json:"from"`
+				To         string `json:"to,omitempty"`
+				TrackingID string `json:"tracking_id,omitempty"`
+			} `json:"messages"`
+		}{},
+		Variants: []any{
+			struct {
+				MessageID  string `json:"messageId"`
+				ThreadID   string `json:"threadId"`
+				From       string `json:"from"`
+				TrackingID string `json:"tracking_id,omitempty"`
+			}{},
+			struct {
+				Scheduled bool               `json:"scheduled"`
+				Outbox    gmailOutboxSummary `json:"outbox"`
+			}{},
+		},
+	}
+}
+
+// resolveSendFrom returns the From header and sending address for from (a
+// verified send-as alias) or, when empty, the account with its display name.
+func resolveSendFrom(ctx context.Context, svc *gmail.Service, account, from string) (string, string, error) {
+	sendAsList, sendAsListErr := listSendAs(ctx, svc)
+
+	// Determine the From address
+	fromAddr := account
+	sendingEmail := account // The email we're sending from (without display name)
+	if fromEmail := strings.TrimSpace(from); fromEmail != "" {
+		// Validate that this is a configured and verified send-as alias.
+		var sa *gmail.SendAs
+		if sendAsListErr == nil {
+			sa = findSendAsByEmail(sendAsList, fromEmail)
+			if sa == nil {
+				return "", "", fmt.Errorf("invalid --from address %q: not found in send-as settings", fromEmail)
+			}
+		} else {
+			// Fallback: preserve legacy behavior if we cannot list settings.
+			var getErr error
+			sa, getErr = svc.Users.Settings.SendAs.Get("me", fromEmail).Context(ctx).Do()
+			if getErr != nil {
+				return "", "", fmt.Errorf("invalid --from address %q: %w", fromEmail, getErr)
+			}
+		}
+
+		if sa.VerificationStatus != gmailVerificationAccepted {
+			return "", "", fmt.Errorf("--from address %q is not verified (status: %s)", fromEmail, sa.VerificationStatus)
+		}
+
+		sendingEmail = fromEmail
+		fromAddr = fromEmail
+
+		if displayName := strings.TrimSpace(sa.DisplayName); displayName != "" {
+			fromAddr = displayName + " <" + fromEmail + ">"
+		}
+	} else {
+		// No --from specified: best-effort look up the primary account's display name.
+		displayName := ""
+		if sendAsListErr == nil {
+			displayName = primaryDisplayNameFromSendAsList(sendAsList, account)
+		}
+		if displayName != "" {
+			fromAddr = displayName + " <" + account + ">"
+		}
+		// If lookup fails, we just use the plain email address (no error)
+	}
+
+	return fromAddr, sendingEmail, nil
+}
+
 func (c *GmailSendCmd) resolveTrackingConfig(account string, toRecipients, ccRecipients, bccRecipients []string, htmlBody string) (*tracking.Config, error) {
 	totalRecipients := len(toRecipients) + len(ccRecipients) + len(bccRecipients)
 	if totalRecipients != 1 && !c.TrackSplit {
@@ -346,13 +426,46 @@ func buildSendBatches(toRecipients, ccRecipients, bccRecipients []string, track,
 	}}
 }
 
+// preparedSend is a fully built message for one send batch.
+type preparedSend struct {
+	To         string
+	TrackingID string
+	Message    *gmail.Message
+}
+
 func sendGmailBatches(ctx context.Context, svc *gmail.Service, opts sendMessageOptions, batches []sendBatch) ([]sendResult, error) {
+	prepared, err := prepareGmailBatches(opts, batches)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]sendResult, 0, len(prepared))
+	for _, p := range prepared {
+		sent, err := svc.Users.Messages.Send("me", p.Message).Context(ctx).Do()
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, sendResult{
+			To:         p.To,
+			MessageID:  sent.Id,
+			ThreadID:   sent.ThreadId,
+			TrackingID: p.TrackingID,
+		})
+	}
+
+	return results, nil
+}
+
+// prepareGmailBatches builds the raw message for every batch, injecting a
+// tracking pixel per batch when enabled.
+func prepareGmailBatches(opts sendMessageOptions, batches []sendBatch) ([]preparedSend, error) {
 	reply := replyInfo{}
 	if opts.ReplyInfo != nil {
 		reply = *opts.ReplyInfo
 	}
 
-	results := make([]sendResult, 0, len(batches))
+	prepared := make([]preparedSend, 0, len(batches))
 	for _, batch := range batches {
 		htmlBody := opts.BodyHTML
 		trackingID := ""
@@ -384,6 +497,7 @@ func sendGmailBatches(ctx context.Context, svc *gmail.Service, opts sendMessageO
 			InReplyTo:   reply.InReplyTo,
 			References:  reply.References,
 			Attachments: opts.Attachments,
+			Inline:      opts.Inline,
 		}, nil)
 		if err != nil {
 			return nil, err
@@ -396,24 +510,18 @@ func sendGmailBatches(ctx context.Context, svc *gmail.Service, opts sendMessageO
 			msg.ThreadId = reply.ThreadID
 		}
 
-		sent, err := svc.Users.Messages.Send("me", msg).Context(ctx).Do()
-		if err != nil {
-			return nil, err
-		}
-
 		resultRecipient := strings.TrimSpace(batch.TrackingRecipient)
 		if resultRecipient == "" {
 			resultRecipient = strings.TrimSpace(firstRecipient(batch.To, batch.Cc, batch.Bcc))
 		}
-		results = append(results, sendResult{
+		prepared = append(prepared, preparedSend{
 			To:         resultRecipient,
-			MessageID:  sent.Id,
-			ThreadID:   sent.ThreadId,
 			TrackingID: trackingID,
+			Message:    msg,
 		})
 	}
 
-	return results, nil
+	return prepared, nil
 }
 
 func writeSendResults(ctx context.Context, u *ui.UI, fromAddr string, results []sendResult) error {
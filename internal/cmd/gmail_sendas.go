@@ -76,6 +76,12 @@ func (c *GmailSendAsListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*GmailSendAsListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "sendAs", Result: struct {
+		SendAs []*gmail.SendAs `json:"sendAs"`
+	}{}}
+}
+
 type GmailSendAsGetCmd struct {
 	Email string `arg:"" name:"email" help:"Send-as email"`
 }
@@ -116,6 +122,12 @@ func (c *GmailSendAsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*GmailSendAsGetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		SendAs *gmail.SendAs `json:"sendAs"`
+	}{}}
+}
+
 type GmailSendAsCreateCmd struct {
 	Email        string `arg:"" name:"email" help:"Send-as email"`
 	DisplayName  string `name:"display-name" help:"Name that appears in the From field"`
@@ -170,6 +182,12 @@ func (c *GmailSendAsCreateCmd) Run(ctx context.Context, flags *RootFlags) error
 	return nil
 }
 
+func (*GmailSendAsCreateCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		SendAs *gmail.SendAs `json:"sendAs"`
+	}{}}
+}
+
 type GmailSendAsVerifyCmd struct {
 	Email string `arg:"" name:"email" help:"Send-as email"`
 }
@@ -213,6 +231,13 @@ func (c *GmailSendAsVerifyCmd) Run(ctx context.Context, flags *RootFlags) error
 	return nil
 }
 
+func (*GmailSendAsVerifyCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Email   string `json:"email"`
+		Message string `json:"message"`
+	}{}}
+}
+
 type GmailSendAsDeleteCmd struct {
 	Email string `arg:"" name:"email" help:"Send-as email"`
 }
@@ -254,6 +279,13 @@ func (c *GmailSendAsDeleteCmd) Run(ctx context.Context, flags *RootFlags) error
 	return nil
 }
 
+func (*GmailSendAsDeleteCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Email   string `json:"email"`
+		Deleted bool   `json:"deleted"`
+	}{}}
+}
+
 type GmailSendAsUpdateCmd struct {
 	Email        string `arg:"" name:"email" help:"Send-as email"`
 	DisplayName  string `name:"display-name" help:"Name that appears in the From field"`
@@ -339,3 +371,9 @@ func (c *GmailSendAsUpdateCmd) Run(ctx context.Context, kctx *kong.Context, flag
 	u.Out().Printf("Updated send-as alias: %s", updated.SendAsEmail)
 	return nil
 }
+
+func (*GmailSendAsUpdateCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		SendAs *gmail.SendAs `json:"sendAs"`
+	}{}}
+}
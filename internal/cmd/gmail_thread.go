@@ -174,6 +174,13 @@ func (c *GmailThreadGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*GmailThreadGetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Thread     *gmail.Thread               `json:"thread"`
+		Downloaded []attachmentDownloadSummary `json:"downloaded"`
+	}{}}
+}
+
 type GmailThreadModifyCmd struct {
 	ThreadID string `arg:"" name:"threadId" help:"Thread ID"`
 	Add      string `name:"add" help:"Labels to add (comma-separated, name or ID)"`
@@ -242,6 +249,14 @@ func (c *GmailThreadModifyCmd) Run(ctx context.Context, flags *RootFlags) error
 	return nil
 }
 
+func (*GmailThreadModifyCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Modified      string   `json:"modified"`
+		AddedLabels   []string `json:"addedLabels"`
+		RemovedLabels []string `json:"removedLabels"`
+	}{}}
+}
+
 // GmailThreadAttachmentsCmd lists all attachments in a thread.
 type GmailThreadAttachmentsCmd struct {
 	ThreadID  string        `arg:"" name:"threadId" help:"Thread ID"`
@@ -339,6 +354,13 @@ func (c *GmailThreadAttachmentsCmd) Run(ctx context.Context, flags *RootFlags) e
 	return nil
 }
 
+func (*GmailThreadAttachmentsCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "attachments", Result: struct {
+		ThreadID    string                     `json:"threadId"`
+		Attachments []attachmentDownloadOutput `json:"attachments"`
+	}{}}
+}
+
 type GmailURLCmd struct {
 	ThreadIDs []string `arg:"" name:"threadId" help:"Thread IDs"`
 }
@@ -368,6 +390,15 @@ func (c *GmailURLCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*GmailURLCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "urls", Result: struct {
+		URLs []struct {
+			ID  string `json:"id"`
+			URL string `json:"url"`
+		} `json:"urls"`
+	}{}}
+}
+
 func bestBodyText(p *gmail.MessagePart) string {
 	if p == nil {
 		return ""
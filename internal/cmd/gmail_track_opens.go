@@ -44,6 +44,52 @@ func (c *GmailTrackOpensCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return c.queryAdmin(ctx, cfg, u)
 }
 
+// trackingAdminOpens is the tracker's /opens response, as printed in JSON mode.
+type trackingAdminOpens struct {
+	Opens []struct {
+		TrackingID  string `json:"tracking_id"`
+		Recipient   string `json:"recipient"`
+		SubjectHash string `json:"subject_hash"`
+		SentAt      string `json:"sent_at"`
+		OpenedAt    string `json:"opened_at"`
+		IsBot       bool   `json:"is_bot"`
+		Location    *struct {
+			City    string `json:"city"`
+			Region  string `json:"region"`
+			Country string `json:"country"`
+		} `json:"location"`
+	} `json:"opens"`
+}
+
+// jsonOutput declares the --to/--since listing; a tracking ID prints the
+// tracker's /q/<id> response as is.
+func (*GmailTrackOpensCmd) jsonOutput() commandOutput {
+	type open struct {
+		At       string  `json:"at"`
+		IsBot    bool    `json:"is_bot"`
+		BotType  *string `json:"bot_type"`
+		Location *struct {
+			City     string `json:"city"`
+			Region   string `json:"region"`
+			Country  string `json:"country"`
+			Timezone string `json:"timezone"`
+		} `json:"location"`
+	}
+	return commandOutput{
+		Items:  "opens",
+		Result: trackingAdminOpens{},
+		Variants: []any{struct {
+			TrackingID     string `json:"tracking_id"`
+			Recipient      string `json:"recipient"`
+			SentAt         string `json:"sent_at"`
+			Opens          []open `json:"opens"`
+			TotalOpens     int    `json:"total_opens"`
+			HumanOpens     int    `json:"human_opens"`
+			FirstHumanOpen *open  `json:"first_human_open"`
+		}{}},
+	}
+}
+
 func (c *GmailTrackOpensCmd) queryByTrackingID(ctx context.Context, cfg *tracking.Config, u *ui.UI) error {
 	reqURL := fmt.Sprintf("%s/q/%s", cfg.WorkerURL, c.TrackingID)
 
@@ -151,22 +197,7 @@ func (c *GmailTrackOpensCmd) queryAdmin(ctx context.Context, cfg *tracking.Confi
 		return fmt.Errorf("tracker returned %d: %s", resp.StatusCode, body)
 	}
 
-	var result struct {
-		Opens []struct {
-			TrackingID  string `json:"tracking_id"`
-			Recipient   string `json:"recipient"`
-			SubjectHash string `json:"subject_hash"`
-			SentAt      string `json:"sent_at"`
-			OpenedAt    string `json:"opened_at"`
-			IsBot       bool   `json:"is_bot"`
-			Location    *struct {
-				City    string `json:"city"`
-				Region  string `json:"region"`
-				Country string `json:"country"`
-			} `json:"location"`
-		} `json:"opens"`
-	}
-
+	var result trackingAdminOpens
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
 		return fmt.Errorf("decode response: %w", err)
 	}
@@ -0,0 +1,413 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/api/gmail/v1"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/internal/workpool"
+)
+
+// unsubscribeHTTPClient performs RFC 8058 one-click POSTs; tests replace it.
+var unsubscribeHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+const (
+	unsubscribeMethodPost   = "post"
+	unsubscribeMethodMailto = "mailto"
+	unsubscribeMethodManual = "manual"
+	unsubscribeMethodNone   = "none"
+
+	unsubscribeStatusDone   = "unsubscribed"
+	unsubscribeStatusFailed = "failed"
+	unsubscribeStatusManual = "manual"
+	unsubscribeStatusNone   = "no_header"
+)
+
+type GmailUnsubscribeCmd struct {
+	MessageIDs []string `arg:"" optional:"" name:"messageId" help:"Message IDs whose senders to unsubscribe from"`
+	Query      string   `name:"query" short:"q" help:"Select messages by Gmail query instead (e.g. 'category:promotions newer_than:30d')"`
+	Max        int64    `name:"max" aliases:"limit" default:"500" help:"Max messages to scan with --query"`
+	Method     string   `name:"method" enum:"auto,post,mailto" default:"auto" help:"Unsubscribe method: auto (one-click POST, else mailto), post, or mailto"`
+	Filter     string   `name:"filter" enum:",archive,trash" default:"" help:"Also create a filter for future mail from each sender: archive|trash"`
+}
+
+type gmailUnsubscribeSender struct {
+	Sender    string `json:"sender"`
+	Name      string `json:"name,omitempty"`
+	Messages  int    `json:"messages"`
+	MessageID string `json:"messageId"`
+	Subject   string `json:"subject,omitempty"`
+	OneClick  bool   `json:"one_click"`
+	HTTP      string `json:"http,omitempty"`
+	Mailto    string `json:"mailto,omitempty"`
+	Method    string `json:"method"`
+	Status    string `json:"status,omitempty"`
+	Error     string `json:"error,omitempty"`
+	FilterID  string `json:"filter_id,omitempty"`
+}
+
+type gmailUnsubscribeResult struct {
+	Scanned      int                       `json:"scanned"`
+	Unsubscribed int                       `json:"unsubscribed"`
+	Failed       int                       `json:"failed"`
+	Manual       int                       `json:"manual"`
+	Filters      int                       `json:"filters"`
+	Senders      []*gmailUnsubscribeSender `json:"senders"`
+}
+
+func (c *GmailUnsubscribeCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+
+	query := strings.TrimSpace(c.Query)
+	ids := make([]string, 0, len(c.MessageIDs))
+	for _, id := range c.MessageIDs {
+		if id = normalizeGmailMessageID(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 && query == "" {
+		return usage("required: messageId or --query")
+	}
+	if len(ids) > 0 && query != "" {
+		return usage("use either messageId arguments or --query, not both")
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := newGmailService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	if query != "" {
+		ids, err = listGmailExportIDs(ctx, svc, query, c.Max, false)
+		if err != nil {
+			return err
+		}
+	}
+	senders, err := collectUnsubscribeSenders(ctx, svc, ids, c.Method)
+	if err != nil {
+		return err
+	}
+
+	if dryRunErr := dryRunExit(ctx, flags, "gmail.unsubscribe", map[string]any{
+		"scanned": len(ids),
+		"method":  c.Method,
+		"filter":  c.Filter,
+		"senders": senders,
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+
+	actionable := 0
+	for _, s := range senders {
+		if s.Method == unsubscribeMethodPost || s.Method == unsubscribeMethodMailto || c.Filter != "" {
+			actionable++
+		}
+	}
+	if actionable > 0 {
+		if confirmErr := confirmDestructive(ctx, flags, fmt.Sprintf("unsubscribe from %d senders", actionable)); confirmErr != nil {
+			return confirmErr
+		}
+	}
+
+	res := gmailUnsubscribeResult{Scanned: len(ids), Senders: senders}
+	var fromAddr string
+	var existingFilters []*gmail.Filter
+	if c.Filter != "" {
+		list, listErr := svc.Users.Settings.Filters.List("me").Context(ctx).Do()
+		if listErr != nil {
+			return listErr
+		}
+		existingFilters = list.Filter
+	}
+
+	for _, s := range senders {
+		var actErr error
+		switch s.Method {
+		case unsubscribeMethodPost:
+			actErr = postOneClickUnsubscribe(ctx, s.HTTP)
+		case unsubscribeMethodMailto:
+			if fromAddr == "" {
+				fromAddr, _, err = resolveSendFrom(ctx, svc, account, "")
+				if err != nil {
+					return err
+				}
+			}
+			actErr = sendMailtoUnsubscribe(ctx, svc, fromAddr, s.Mailto)
+		case unsubscribeMethodManual:
+			s.Status = unsubscribeStatusManual
+			res.Manual++
+		default:
+			s.Status = unsubscribeStatusNone
+		}
+		if s.Method == unsubscribeMethodPost || s.Method == unsubscribeMethodMailto {
+			if actErr != nil {
+				s.Status = unsubscribeStatusFailed
+				s.Error = actErr.Error()
+				res.Failed++
+				if !outfmt.IsJSON(ctx) {
+					u.Err().Errorf("%s: %s", s.Sender, actErr.Error())
+				}
+			} else {
+				s.Status = unsubscribeStatusDone
+				res.Unsubscribed++
+			}
+		}
+
+		if c.Filter != "" {
+			filterID, filterErr := ensureUnsubscribeFilter(ctx, svc, existingFilters, s.Sender, c.Filter)
+			if filterErr != nil {
+				if s.Error == "" {
+					s.Error = "filter: " + filterErr.Error()
+				}
+				if !outfmt.IsJSON(ctx) {
+					u.Err().Errorf("%s: filter: %s", s.Sender, filterErr.Error())
+				}
+				continue
+			}
+			s.FilterID = filterID
+			res.Filters++
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, res); err != nil {
+			return err
+		}
+		return gmailUnsubscribeError(res)
+	}
+
+	if len(senders) == 0 {
+		u.Err().Println("No senders found")
+		return nil
+	}
+	w, flush := tableWriter(ctx)
+	fmt.Fprintln(w, "SENDER\tMESSAGES\tMETHOD\tSTATUS\tFILTER")
+	for _, s := range senders {
+		status := s.Status
+		if s.Status == unsubscribeStatusManual {
+			status = "open " + s.HTTP
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", sanitizeTab(s.Sender), s.Messages, s.Method, sanitizeTab(status), s.FilterID)
+	}
+	flush()
+	u.Err().Printf("unsubscribed: %d, failed: %d, manual: %d, filters: %d", res.Unsubscribed, res.Failed, res.Manual, res.Filters)
+	return gmailUnsubscribeError(res)
+}
+
+func gmailUnsubscribeError(res gmailUnsubscribeResult) error {
+	if res.Failed == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d of %d unsubscribe attempts failed", res.Failed, res.Failed+res.Unsubscribed)
+}
+
+func (*GmailUnsubscribeCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "senders", Result: gmailUnsubscribeResult{}}
+}
+
+// collectUnsubscribeSenders reads the From and List-Unsubscribe headers of
+// ids and groups them by sender, most messages first. Unsubscribe targets
+// come from each sender's newest message.
+func collectUnsubscribeSenders(ctx context.Context, svc *gmail.Service, ids []string, method string) ([]*gmailUnsubscribeSender, error) {
+	msgs, err := workpool.Map(ctx, workpool.Limit(ctx, workpool.DefaultLimit), ids, func(ctx context.Context, id string) (*gmail.Message, error) {
+		msg, err := svc.Users.Messages.Get("me", id).
+			Format(gmailFormatMetadata).
+			MetadataHeaders("From", "Subject", "List-Unsubscribe", "List-Unsubscribe-Post").
+			Fields("id,internalDate,payload(headers)").
+			Context(ctx).Do()
+		if err != nil {
+			return nil, fmt.Errorf("message %s: %w", id, err)
+		}
+		return msg, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	bySender := map[string]*gmailUnsubscribeSender{}
+	latest := map[string]int64{}
+	var senders []*gmailUnsubscribeSender
+	for _, msg := range msgs {
+		if msg == nil {
+			continue
+		}
+		from := headerValue(msg.Payload, "From")
+		email, name := strings.ToLower(strings.TrimSpace(from)), ""
+		if addr, parseErr := mail.ParseAddress(from); parseErr == nil {
+			email, name = strings.ToLower(addr.Address), addr.Name
+		}
+		if email == "" {
+			continue
+		}
+
+		s, ok := bySender[email]
+		if !ok {
+			s = &gmailUnsubscribeSender{Sender: email, Name: name}
+			bySender[email] = s
+			senders = append(senders, s)
+		}
+		s.Messages++
+		if ok && msg.InternalDate <= latest[email] {
+			continue
+		}
+		latest[email] = msg.InternalDate
+		s.MessageID = msg.Id
+		s.Subject = headerValue(msg.Payload, "Subject")
+		s.HTTP, s.Mailto, s.OneClick = unsubscribeTargets(headerValue(msg.Payload, "List-Unsubscribe"), headerValue(msg.Payload, "List-Unsubscribe-Post"))
+	}
+
+	for _, s := range senders {
+		s.Method = chooseUnsubscribeMethod(s, method)
+	}
+	sort.SliceStable(senders, func(i, j int) bool {
+		if senders[i].Messages != senders[j].Messages {
+			return senders[i].Messages > senders[j].Messages
+		}
+		return senders[i].Sender < senders[j].Sender
+	})
+
+	return senders, nil
+}
+
+// unsubscribeTargets returns the preferred HTTP(S) link (HTTPS first) and
+// the first mailto: link of a List-Unsubscribe header, and whether
+// List-Unsubscribe-Post allows an RFC 8058 one-click POST (HTTPS only).
+func unsubscribeTargets(header, post string) (httpURL, mailto string, oneClick bool) {
+	for _, link := range parseListUnsubscribe(header) {
+		lower := strings.ToLower(link)
+		switch {
+		case strings.HasPrefix(lower, "mailto:"):
+			if mailto == "" {
+				mailto = link
+			}
+		case strings.HasPrefix(lower, "https://"):
+			if !strings.HasPrefix(strings.ToLower(httpURL), "https://") {
+				httpURL = link
+			}
+		case httpURL == "":
+			httpURL = link
+		}
+	}
+	oneClick = strings.HasPrefix(strings.ToLower(httpURL), "https://") &&
+		strings.EqualFold(strings.ReplaceAll(strings.TrimSpace(post), " ", ""), "List-Unsubscribe=One-Click")
+	return httpURL, mailto, oneClick
+}
+
+func chooseUnsubscribeMethod(s *gmailUnsubscribeSender, method string) string {
+	switch {
+	case s.OneClick && method != unsubscribeMethodMailto:
+		return unsubscribeMethodPost
+	case s.Mailto != "" && method != unsubscribeMethodPost:
+		return unsubscribeMethodMailto
+	case s.HTTP != "":
+		// A plain link may need a browser (confirmation pages); report it.
+		return unsubscribeMethodManual
+	default:
+		return unsubscribeMethodNone
+	}
+}
+
+func postOneClickUnsubscribe(ctx context.Context, target string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, strings.NewReader("List-Unsubscribe=One-Click"))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", "gog/"+VersionString())
+
+	resp, err := unsubscribeHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("one-click POST: HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// sendMailtoUnsubscribe sends the message described by a mailto: URI
+// (RFC 6068), defaulting subject and body to "unsubscribe".
+func sendMailtoUnsubscribe(ctx context.Context, svc *gmail.Service, fromAddr, uri string) error {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return fmt.Errorf("invalid mailto URI: %w", err)
+	}
+	to, err := url.PathUnescape(parsed.Opaque)
+	if err != nil {
+		return fmt.Errorf("invalid mailto URI: %w", err)
+	}
+	q := parsed.Query()
+	recipients := splitCSV(to)
+	if extra := q.Get("to"); extra != "" {
+		recipients = append(recipients, splitCSV(extra)...)
+	}
+	if len(recipients) == 0 {
+		return fmt.Errorf("mailto URI without address: %s", uri)
+	}
+	subject := strings.TrimSpace(q.Get("subject"))
+	if subject == "" {
+		subject = "unsubscribe"
+	}
+	body := q.Get("body")
+	if strings.TrimSpace(body) == "" {
+		body = "unsubscribe"
+	}
+
+	raw, err := buildRFC822(mailOptions{
+		From:    fromAddr,
+		To:      recipients,
+		Subject: subject,
+		Body:    body,
+	}, nil)
+	if err != nil {
+		return err
+	}
+	_, err = svc.Users.Messages.Send("me", &gmail.Message{Raw: base64.RawURLEncoding.EncodeToString(raw)}).Context(ctx).Do()
+	return err
+}
+
+// ensureUnsubscribeFilter creates a from:<sender> filter that archives or
+// trashes future mail, reusing an identical existing filter.
+func ensureUnsubscribeFilter(ctx context.Context, svc *gmail.Service, existing []*gmail.Filter, sender, mode string) (string, error) {
+	action := &gmail.FilterAction{RemoveLabelIds: []string{"INBOX"}}
+	if mode == "trash" {
+		action = &gmail.FilterAction{AddLabelIds: []string{"TRASH"}}
+	}
+
+	for _, f := range existing {
+		if f == nil || f.Criteria == nil || f.Action == nil || !strings.EqualFold(strings.TrimSpace(f.Criteria.From), sender) {
+			continue
+		}
+		if strings.Join(f.Action.AddLabelIds, ",") == strings.Join(action.AddLabelIds, ",") &&
+			strings.Join(f.Action.RemoveLabelIds, ",") == strings.Join(action.RemoveLabelIds, ",") {
+			return f.Id, nil
+		}
+	}
+
+	created, err := svc.Users.Settings.Filters.Create("me", &gmail.Filter{
+		Criteria: &gmail.FilterCriteria{From: sender},
+		Action:   action,
+	}).Context(ctx).Do()
+	if err != nil {
+		return "", err
+	}
+	return created.Id, nil
+}
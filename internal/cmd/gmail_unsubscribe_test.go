@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+func TestUnsubscribeTargets(t *testing.T) {
+	httpURL, mailto, oneClick := unsubscribeTargets("<mailto:u@example.com?subject=stop>, <http://example.com/u>, <https://example.com/u>", "List-Unsubscribe=One-Click")
+	if httpURL != "https://example.com/u" || mailto != "mailto:u@example.com?subject=stop" || !oneClick {
+		t.Fatalf("unexpected targets: %q %q %v", httpURL, mailto, oneClick)
+	}
+	if _, _, oneClick := unsubscribeTargets("<http://example.com/u>", "List-Unsubscribe=One-Click"); oneClick {
+		t.Fatalf("one-click requires https")
+	}
+	if _, _, oneClick := unsubscribeTargets("<https://example.com/u>", ""); oneClick {
+		t.Fatalf("one-click requires List-Unsubscribe-Post")
+	}
+}
+
+func TestGmailUnsubscribe_DryRunAndExecute(t *testing.T) {
+	origNew := newGmailService
+	origHTTP := unsubscribeHTTPClient
+	t.Cleanup(func() {
+		newGmailService = origNew
+		unsubscribeHTTPClient = origHTTP
+	})
+
+	var (
+		mu        sync.Mutex
+		posts     []string
+		mails     []string
+		filters   []string
+		failPosts bool
+	)
+	unsub := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		defer mu.Unlock()
+		if failPosts {
+			http.Error(w, "gone", http.StatusInternalServerError)
+			return
+		}
+		posts = append(posts, r.Method+" "+r.URL.Path+" "+string(data))
+	}))
+	defer unsub.Close()
+	unsubscribeHTTPClient = unsub.Client()
+
+	headers := map[string][]map[string]string{
+		"m1": {{"name": "From", "value": "News <news@a.com>"}, {"name": "List-Unsubscribe", "value": "<" + unsub.URL + "/u/1>, <mailto:x@a.com>"}, {"name": "List-Unsubscribe-Post", "value": "List-Unsubscribe=One-Click"}},
+		"m2": {{"name": "From", "value": "news@a.com"}, {"name": "List-Unsubscribe", "value": "<" + unsub.URL + "/u/2>"}, {"name": "List-Unsubscribe-Post", "value": "List-Unsubscribe=One-Click"}},
+		"m3": {{"name": "From", "value": "List <list@b.com>"}, {"name": "List-Unsubscribe", "value": "<mailto:leave@b.com?subject=remove%20me>"}},
+		"m4": {{"name": "From", "value": "person@c.com"}},
+	}
+	internalDates := map[string]string{"m1": "200", "m2": "100", "m3": "100", "m4": "100"}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := r.URL.Path
+		var body any
+		switch {
+		case strings.HasSuffix(p, "/users/me/messages") && r.Method == http.MethodGet:
+			if r.URL.Query().Get("q") != "category:promotions" {
+				http.Error(w, "bad query", http.StatusBadRequest)
+				return
+			}
+			body = map[string]any{"messages": []map[string]any{{"id": "m1"}, {"id": "m2"}, {"id": "m3"}, {"id": "m4"}}}
+		case strings.Contains(p, "/users/me/messages/m"):
+			id := p[strings.LastIndex(p, "/")+1:]
+			body = map[string]any{"id": id, "internalDate": internalDates[id], "payload": map[string]any{"headers": headers[id]}}
+		case strings.HasSuffix(p, "/settings/sendAs"):
+			body = map[string]any{"sendAs": []map[string]any{}}
+		case strings.HasSuffix(p, "/messages/send"):
+			var msg gmail.Message
+			_ = json.NewDecoder(r.Body).Decode(&msg)
+			raw, _ := base64.RawURLEncoding.DecodeString(msg.Raw)
+			mu.Lock()
+			mails = append(mails, string(raw))
+			mu.Unlock()
+			body = map[string]any{"id": "s1"}
+		case strings.HasSuffix(p, "/settings/filters") && r.Method == http.MethodGet:
+			body = map[string]any{"filter": []map[string]any{{"id": "f-existing", "criteria": map[string]any{"from": "person@c.com"}, "action": map[string]any{"removeLabelIds": []string{"INBOX"}}}}}
+		case strings.HasSuffix(p, "/settings/filters") && r.Method == http.MethodPost:
+			var f gmail.Filter
+			_ = json.NewDecoder(r.Body).Decode(&f)
+			mu.Lock()
+			filters = append(filters, f.Criteria.From+":"+strings.Join(f.Action.RemoveLabelIds, ","))
+			mu.Unlock()
+			body = map[string]any{"id": "f-" + f.Criteria.From}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer srv.Close()
+
+	svc, err := gmail.NewService(context.Background(),
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL+"/"),
+	)
+	if err != nil {
+		t.Fatalf("gmail.NewService: %v", err)
+	}
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return svc, nil }
+
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{JSON: true})
+	args := []string{"--query", "category:promotions", "--filter", "archive"}
+
+	out := captureStdout(t, func() {
+		err := runKong(t, &GmailUnsubscribeCmd{}, args, ctx, &RootFlags{Account: "a@b.com", DryRun: true})
+		var exitErr *ExitError
+		if err != nil && (!errors.As(err, &exitErr) || exitErr.Code != 0) {
+			t.Fatalf("dry-run: %v", err)
+		}
+	})
+	var dry struct {
+		Request struct {
+			Senders []gmailUnsubscribeSender `json:"senders"`
+		} `json:"request"`
+	}
+	if err := json.Unmarshal([]byte(out), &dry); err != nil {
+		t.Fatalf("json parse: %v\n%s", err, out)
+	}
+	got := dry.Request.Senders
+	if len(got) != 3 || got[0].Sender != "news@a.com" || got[0].Messages != 2 || got[0].Method != unsubscribeMethodPost ||
+		got[0].HTTP != unsub.URL+"/u/1" || got[1].Method != unsubscribeMethodMailto || got[2].Method != unsubscribeMethodNone {
+		t.Fatalf("unexpected dry-run report: %s", out)
+	}
+	if len(posts) != 0 || len(mails) != 0 || len(filters) != 0 {
+		t.Fatalf("dry-run must not act")
+	}
+
+	// --explain only intercepts Google API calls; the one-click POST goes
+	// elsewhere, so a single-sender dry run must still stop at the report.
+	_ = captureStdout(t, func() {
+		err := runKong(t, &GmailUnsubscribeCmd{}, []string{"m1"}, ctx, &RootFlags{Account: "a@b.com", DryRun: true, Explain: true})
+		var exitErr *ExitError
+		if !errors.As(err, &exitErr) || exitErr.Code != 0 {
+			t.Fatalf("dry-run --explain: %v", err)
+		}
+	})
+	if len(posts) != 0 || len(mails) != 0 || len(filters) != 0 {
+		t.Fatalf("dry-run --explain must not act")
+	}
+
+	out = captureStdout(t, func() {
+		if err := runKong(t, &GmailUnsubscribeCmd{}, args, ctx, &RootFlags{Account: "a@b.com", Force: true}); err != nil {
+			t.Fatalf("unsubscribe: %v", err)
+		}
+	})
+	var res gmailUnsubscribeResult
+	if err := json.Unmarshal([]byte(out), &res); err != nil {
+		t.Fatalf("json parse: %v\n%s", err, out)
+	}
+	if res.Unsubscribed != 2 || res.Failed != 0 || res.Filters != 3 {
+		t.Fatalf("unexpected result: %s", out)
+	}
+	if len(posts) != 1 || posts[0] != "POST /u/1 List-Unsubscribe=One-Click" {
+		t.Fatalf("unexpected one-click posts: %v", posts)
+	}
+	if len(mails) != 1 || !strings.Contains(mails[0], "To: leave@b.com") || !strings.Contains(mails[0], "Subject: remove me") {
+		t.Fatalf("unexpected mailto send: %v", mails)
+	}
+	if strings.Join(filters, " ") != "news@a.com:INBOX list@b.com:INBOX" || res.Senders[2].FilterID != "f-existing" {
+		t.Fatalf("unexpected filters: %v (%s)", filters, out)
+	}
+
+	// A single one-click POST still needs confirmation.
+	_ = captureStdout(t, func() {
+		err := runKong(t, &GmailUnsubscribeCmd{}, []string{"m1"}, ctx, &RootFlags{Account: "a@b.com", NoInput: true})
+		if err == nil || !strings.Contains(err.Error(), "refusing to unsubscribe from 1 senders") {
+			t.Fatalf("expected confirmation to be required, got %v", err)
+		}
+	})
+	if len(posts) != 1 {
+		t.Fatalf("unconfirmed unsubscribe must not act: %v", posts)
+	}
+
+	mu.Lock()
+	failPosts = true
+	mu.Unlock()
+	out = captureStdout(t, func() {
+		if err := runKong(t, &GmailUnsubscribeCmd{}, []string{"m1"}, ctx, &RootFlags{Account: "a@b.com", Force: true}); err == nil {
+			t.Fatalf("expected failed unsubscribe to return an error")
+		}
+	})
+	if err := json.Unmarshal([]byte(out), &res); err != nil {
+		t.Fatalf("json parse: %v\n%s", err, out)
+	}
+	if res.Failed != 1 || res.Unsubscribed != 0 {
+		t.Fatalf("unexpected failed result: %s", out)
+	}
+}
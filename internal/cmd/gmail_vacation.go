@@ -56,6 +56,12 @@ func (c *GmailVacationGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*GmailVacationGetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Vacation *gmail.VacationSettings `json:"vacation"`
+	}{}}
+}
+
 type GmailVacationUpdateCmd struct {
 	Enable       bool   `name:"enable" help:"Enable vacation responder"`
 	Disable      bool   `name:"disable" help:"Disable vacation responder"`
@@ -205,6 +211,12 @@ func (c *GmailVacationUpdateCmd) Run(ctx context.Context, kctx *kong.Context, fl
 	return nil
 }
 
+func (*GmailVacationUpdateCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Vacation *gmail.VacationSettings `json:"vacation"`
+	}{}}
+}
+
 func parseRFC3339ToMillis(rfc3339 string) (int64, error) {
 	if rfc3339 == "" {
 		return 0, nil
@@ -107,6 +107,10 @@ func (c *GmailWatchStartCmd) Run(ctx context.Context, kctx *kong.Context, flags
 	return writeWatchState(ctx, state)
 }
 
+func (*GmailWatchStartCmd) jsonOutput() commandOutput {
+	return watchStateOutput()
+}
+
 type GmailWatchStatusCmd struct{}
 
 func (c *GmailWatchStatusCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -121,6 +125,10 @@ func (c *GmailWatchStatusCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return writeWatchState(ctx, store.Get())
 }
 
+func (*GmailWatchStatusCmd) jsonOutput() commandOutput {
+	return watchStateOutput()
+}
+
 type GmailWatchRenewCmd struct {
 	TTL string `name:"ttl" help:"Renew after duration (seconds or Go duration)"`
 }
@@ -180,6 +188,10 @@ func (c *GmailWatchRenewCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return writeWatchState(ctx, updated)
 }
 
+func (*GmailWatchRenewCmd) jsonOutput() commandOutput {
+	return watchStateOutput()
+}
+
 type GmailWatchStopCmd struct{}
 
 func (c *GmailWatchStopCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -212,6 +224,12 @@ func (c *GmailWatchStopCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*GmailWatchStopCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Stopped bool `json:"stopped"`
+	}{}}
+}
+
 type GmailWatchServeCmd struct {
 	Bind          string   `name:"bind" help:"Bind address" default:"127.0.0.1"`
 	Port          int      `name:"port" help:"Listen port" default:"8788"`
@@ -368,6 +386,13 @@ func (c *GmailWatchServeCmd) Run(ctx context.Context, kctx *kong.Context, flags
 	return listenAndServe(httpServer)
 }
 
+// watchStateOutput declares what writeWatchState prints.
+func watchStateOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Watch gmailWatchState `json:"watch"`
+	}{}}
+}
+
 func writeWatchState(ctx context.Context, state gmailWatchState) error {
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"watch": state})
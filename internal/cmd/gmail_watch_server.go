@@ -16,6 +16,8 @@ import (
 	"google.golang.org/api/gmail/v1"
 	"google.golang.org/api/googleapi"
 	"google.golang.org/api/idtoken"
+
+	"github.com/steipete/gogcli/internal/workpool"
 )
 
 var errNoNewMessages = errors.New("no new messages")
@@ -274,9 +276,9 @@ func (s *gmailWatchServer) fetchMessages(ctx context.Context, svc *gmail.Service
 	if s.cfg.IncludeBody {
 		format = gmailFormatFull
 	}
-	for _, id := range ids {
+	fetched, err := workpool.Map(ctx, workpool.Limit(ctx, workpool.DefaultLimit), ids, func(ctx context.Context, id string) (*gmail.Message, error) {
 		if strings.TrimSpace(id) == "" {
-			continue
+			return nil, nil
 		}
 		msg, err := svc.Users.Messages.Get("me", id).
 			Format(format).
@@ -285,10 +287,16 @@ func (s *gmailWatchServer) fetchMessages(ctx context.Context, svc *gmail.Service
 			Do()
 		if err != nil {
 			if isNotFoundAPIError(err) {
-				continue
+				return nil, nil
 			}
-			return nil, excluded, err
+			return nil, err
 		}
+		return msg, nil
+	})
+	if err != nil {
+		return nil, excluded, err
+	}
+	for _, msg := range fetched {
 		if msg == nil {
 			continue
 		}
@@ -28,6 +28,12 @@ type GroupsCmd struct {
 	Members GroupsMembersCmd `cmd:"" name:"members" help:"List members of a group"`
 }
 
+type groupItem struct {
+	GroupName   string `json:"groupName"`
+	DisplayName string `json:"displayName,omitempty"`
+	Role        string `json:"role,omitempty"`
+}
+
 type GroupsListCmd struct {
 	Max       int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
 	Page      string `name:"page" aliases:"cursor" help:"Page token"`
@@ -81,17 +87,12 @@ func (c *GroupsListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}
 
 	if outfmt.IsJSON(ctx) {
-		type item struct {
-			GroupName   string `json:"groupName"`
-			DisplayName string `json:"displayName,omitempty"`
-			Role        string `json:"role,omitempty"`
-		}
-		items := make([]item, 0, len(memberships))
+		items := make([]groupItem, 0, len(memberships))
 		for _, m := range memberships {
 			if m == nil {
 				continue
 			}
-			items = append(items, item{
+			items = append(items, groupItem{
 				GroupName:   m.GroupKey.Id,
 				DisplayName: m.DisplayName,
 				Role:        getRelationType(m.RelationType),
@@ -131,6 +132,13 @@ func (c *GroupsListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*GroupsListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "groups", Result: struct {
+		Groups        []groupItem `json:"groups"`
+		NextPageToken string      `json:"nextPageToken"`
+	}{}}
+}
+
 // wrapCloudIdentityError provides helpful error messages for common Cloud Identity API issues.
 func wrapCloudIdentityError(err error, account string) error {
 	errStr := err.Error()
@@ -160,6 +168,12 @@ func getRelationType(relationType string) string {
 	}
 }
 
+type groupMemberItem struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+	Type  string `json:"type"`
+}
+
 type GroupsMembersCmd struct {
 	GroupEmail string `arg:"" name:"groupEmail" help:"Group email (e.g., engineering@company.com)"`
 	Max        int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
@@ -223,17 +237,12 @@ func (c *GroupsMembersCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}
 
 	if outfmt.IsJSON(ctx) {
-		type item struct {
-			Email string `json:"email"`
-			Role  string `json:"role"`
-			Type  string `json:"type"`
-		}
-		items := make([]item, 0, len(memberships))
+		items := make([]groupMemberItem, 0, len(memberships))
 		for _, m := range memberships {
 			if m == nil || m.PreferredMemberKey == nil {
 				continue
 			}
-			items = append(items, item{
+			items = append(items, groupMemberItem{
 				Email: m.PreferredMemberKey.Id,
 				Role:  getMemberRole(m.Roles),
 				Type:  m.Type,
@@ -273,6 +282,13 @@ func (c *GroupsMembersCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*GroupsMembersCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "members", Result: struct {
+		Members       []groupMemberItem `json:"members"`
+		NextPageToken string            `json:"nextPageToken"`
+	}{}}
+}
+
 // lookupGroupByEmail finds a group by its email address and returns its resource name.
 func lookupGroupByEmail(ctx context.Context, svc *cloudidentity.Service, email string) (string, error) {
 	resp, err := svc.Groups.Lookup().
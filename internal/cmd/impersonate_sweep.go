@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"google.golang.org/api/people/v1"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/googleapi"
+)
+
+const usersFromGroupPrefix = "group:"
+
+var (
+	listWorkspaceUsers = workspaceUserEmails
+	listGroupUsers     = groupUserEmails
+)
+
+// isUserSweep reports whether --impersonate-all or --users-from was given.
+func isUserSweep(flags *RootFlags) bool {
+	return flags != nil && (flags.ImpersonateAll || strings.TrimSpace(flags.UsersFrom) != "")
+}
+
+// runSelectedForUsers runs the selected read command as every Workspace user
+// (domain-wide delegation) and merges the user-tagged results. The service
+// account configured for --account acts for all users.
+func runSelectedForUsers(ctx context.Context, kctx *kong.Context, flags *RootFlags) error {
+	if isMultiAccount(flags.Account) {
+		return usage("--impersonate-all/--users-from cannot be combined with several --account values")
+	}
+
+	runner, err := selectedFanoutRunner(kctx)
+	if err != nil {
+		return err
+	}
+
+	admin, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+
+	if keyPath, _, ok := bestServiceAccountPathAndMtime(normalizeEmail(admin)); ok {
+		ctx = googleapi.WithServiceAccountKey(ctx, keyPath)
+	} else if !googleapi.UseADC() {
+		return usagef("impersonating users requires a service account with domain-wide delegation; run: gog auth service-account set %s --key <service-account.json>", admin)
+	}
+
+	users, err := sweepUsers(ctx, admin, flags.UsersFrom)
+	if err != nil {
+		return err
+	}
+
+	return runForAccounts(ctx, runner, flags, users, fanoutTagUser)
+}
+
+func sweepUsers(ctx context.Context, admin string, from string) ([]string, error) {
+	from = strings.TrimSpace(from)
+
+	var (
+		users []string
+		err   error
+	)
+	switch {
+	case from == "":
+		users, err = listWorkspaceUsers(ctx, admin)
+	case strings.HasPrefix(strings.ToLower(from), usersFromGroupPrefix):
+		group := strings.TrimSpace(from[len(usersFromGroupPrefix):])
+		if group == "" {
+			return nil, usage("empty group in --users-from group:<email>")
+		}
+		users, err = listGroupUsers(ctx, admin, group)
+	default:
+		users, err = readUsersFile(from)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{}, len(users))
+	out := make([]string, 0, len(users))
+	for _, user := range users {
+		user = normalizeEmail(user)
+		if user == "" {
+			continue
+		}
+		if _, ok := seen[user]; ok {
+			continue
+		}
+		seen[user] = struct{}{}
+		out = append(out, user)
+	}
+	if len(out) == 0 {
+		return nil, usage("no users to impersonate")
+	}
+	return out, nil
+}
+
+// readUsersFile reads one email per line; blank lines and # comments are ignored.
+func readUsersFile(path string) ([]string, error) {
+	path, err := config.ExpandPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path) //nolint:gosec // user-provided path
+	if err != nil {
+		return nil, fmt.Errorf("read users file: %w", err)
+	}
+	defer f.Close()
+
+	var users []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		users = append(users, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read users file: %w", err)
+	}
+	return users, nil
+}
+
+// workspaceUserEmails lists the domain directory (same source as `calendar users`).
+func workspaceUserEmails(ctx context.Context, account string) ([]string, error) {
+	svc, err := newPeopleDirectoryService(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	peopleList, err := collectAllPages("", func(pageToken string) ([]*people.Person, string, error) {
+		call := svc.People.ListDirectoryPeople().
+			Sources("DIRECTORY_SOURCE_TYPE_DOMAIN_PROFILE").
+			ReadMask("emailAddresses").
+			PageSize(1000).
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.People, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list workspace users: %w", err)
+	}
+
+	emails := make([]string, 0, len(peopleList))
+	for _, p := range peopleList {
+		if p == nil {
+			continue
+		}
+		if email := primaryEmail(p); email != "" {
+			emails = append(emails, email)
+		}
+	}
+	return emails, nil
+}
+
+// groupUserEmails lists the (transitive) user members of a group via Cloud Identity.
+func groupUserEmails(ctx context.Context, account string, group string) ([]string, error) {
+	svc, err := newCloudIdentityService(ctx, account)
+	if err != nil {
+		return nil, wrapCloudIdentityError(err, account)
+	}
+	return collectGroupMemberEmails(ctx, svc, group)
+}
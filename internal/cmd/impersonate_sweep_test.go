@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSweepUsers_FromFileAndGroup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.txt")
+	if err := os.WriteFile(path, []byte("# mailboxes\nA@example.com\n\nb@example.com\na@example.com\n"), 0o600); err != nil {
+		t.Fatalf("write users: %v", err)
+	}
+
+	got, err := sweepUsers(context.Background(), "admin@example.com", path)
+	if err != nil {
+		t.Fatalf("sweepUsers(file): %v", err)
+	}
+	if !reflect.DeepEqual(got, []string{"a@example.com", "b@example.com"}) {
+		t.Fatalf("unexpected users from file: %v", got)
+	}
+
+	origGroup := listGroupUsers
+	t.Cleanup(func() { listGroupUsers = origGroup })
+	listGroupUsers = func(_ context.Context, account string, group string) ([]string, error) {
+		if account != "admin@example.com" || group != "staff@example.com" {
+			t.Fatalf("unexpected group lookup: %s %s", account, group)
+		}
+		return []string{"c@example.com"}, nil
+	}
+
+	got, err = sweepUsers(context.Background(), "admin@example.com", "group:staff@example.com")
+	if err != nil {
+		t.Fatalf("sweepUsers(group): %v", err)
+	}
+	if !reflect.DeepEqual(got, []string{"c@example.com"}) {
+		t.Fatalf("unexpected users from group: %v", got)
+	}
+}
+
+func TestSweepUsers_DirectoryDefault(t *testing.T) {
+	origUsers := listWorkspaceUsers
+	t.Cleanup(func() { listWorkspaceUsers = origUsers })
+	listWorkspaceUsers = func(context.Context, string) ([]string, error) { return nil, nil }
+
+	if _, err := sweepUsers(context.Background(), "admin@example.com", ""); ExitCode(err) != 2 {
+		t.Fatalf("expected usage error for empty directory, got %v", err)
+	}
+}
@@ -0,0 +1,937 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/index"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/internal/workpool"
+)
+
+const (
+	indexModeFull        = "full"
+	indexModeIncremental = "incremental"
+
+	// maxIndexBodyRunes caps stored Gmail bodies (--bodies).
+	maxIndexBodyRunes = 32 << 10
+
+	driveIndexFileFields = "id, name, mimeType, modifiedTime, owners(displayName, emailAddress), webViewLink, description, trashed"
+)
+
+var indexServices = []string{index.ServiceGmail, index.ServiceDrive, index.ServiceCalendar}
+
+type IndexCmd struct {
+	Sync   IndexSyncCmd   `cmd:"" name:"sync" help:"Incrementally mirror Gmail, Drive and Calendar metadata into the local index"`
+	Search IndexSearchCmd `cmd:"" name:"search" aliases:"find,query" help:"Search the local index (offline, no API quota)"`
+	Status IndexStatusCmd `cmd:"" name:"status" help:"Show what the local index holds"`
+}
+
+type IndexSyncCmd struct {
+	Services   string `name:"services" aliases:"service" help:"Comma-separated services to sync: gmail,drive,calendar" default:"gmail,drive,calendar"`
+	Bodies     bool   `name:"bodies" help:"Also index plain-text Gmail bodies (changing this triggers a full Gmail re-sync)"`
+	GmailQuery string `name:"gmail-query" help:"Gmail query bounding the initial full sync" default:"newer_than:1y"`
+	GmailMax   int64  `name:"gmail-max" help:"Max messages fetched by the initial full Gmail sync" default:"5000"`
+	Calendars  string `name:"calendars" aliases:"calendar" help:"Comma-separated calendar IDs to sync, or all" default:"primary"`
+	Full       bool   `name:"full" help:"Discard sync cursors and rebuild from scratch"`
+}
+
+type indexSyncResult struct {
+	Service  string `json:"service"`
+	Mode     string `json:"mode"`
+	Upserted int    `json:"upserted"`
+	Deleted  int    `json:"deleted"`
+	Total    int    `json:"total"`
+}
+
+func (c *IndexSyncCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+
+	services, err := parseIndexServices(c.Services)
+	if err != nil {
+		return err
+	}
+	path, err := indexStorePath(account)
+	if err != nil {
+		return err
+	}
+
+	if err := dryRunExit(ctx, flags, "index.sync", map[string]any{
+		"account":  account,
+		"services": services,
+		"path":     path,
+		"full":     c.Full,
+	}); err != nil {
+		return err
+	}
+
+	store, err := index.Open(path, account)
+	if err != nil {
+		return err
+	}
+
+	results := make([]indexSyncResult, 0, len(services))
+	for _, service := range services {
+		var res indexSyncResult
+		switch service {
+		case index.ServiceGmail:
+			res, err = c.syncGmail(ctx, account, store)
+		case index.ServiceDrive:
+			res, err = c.syncDrive(ctx, account, store)
+		case index.ServiceCalendar:
+			res, err = c.syncCalendar(ctx, account, store)
+		}
+		if err != nil {
+			// Keep what earlier services synced.
+			if saveErr := store.Save(); saveErr != nil {
+				return errors.Join(fmt.Errorf("%s: %w", service, err), saveErr)
+			}
+			return fmt.Errorf("%s: %w", service, err)
+		}
+		res.Total = store.Count()[service]
+		store.MarkSynced(service, time.Now())
+		results = append(results, res)
+	}
+
+	if err := store.Save(); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"account":  account,
+			"path":     store.Path(),
+			"services": results,
+		})
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "SERVICE\tMODE\tUPSERTED\tDELETED\tTOTAL")
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\n", r.Service, r.Mode, r.Upserted, r.Deleted, r.Total)
+	}
+	if !outfmt.IsPlain(ctx) && u != nil {
+		u.Err().Printf("Index: %s", store.Path())
+	}
+	return nil
+}
+
+func (*IndexSyncCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "services", Result: struct {
+		Account  string            `json:"account"`
+		Path     string            `json:"path"`
+		Services []indexSyncResult `json:"services"`
+	}{}}
+}
+
+func (c *IndexSyncCmd) syncGmail(ctx context.Context, account string, store *index.Store) (indexSyncResult, error) {
+	res := indexSyncResult{Service: index.ServiceGmail, Mode: indexModeIncremental}
+
+	svc, err := newGmailService(ctx, account)
+	if err != nil {
+		return res, err
+	}
+	labels, err := fetchLabelIDToName(svc)
+	if err != nil {
+		return res, err
+	}
+
+	full := c.Full || store.State.GmailHistoryID == "" || store.State.GmailBodies != c.Bodies
+	if !full {
+		historyID, fetchIDs, deletedIDs, historyErr := listGmailIndexHistory(ctx, svc, store.State.GmailHistoryID)
+		switch {
+		case historyErr != nil && isStaleHistoryError(historyErr):
+			full = true
+		case historyErr != nil:
+			return res, historyErr
+		default:
+			for _, id := range deletedIDs {
+				if store.Delete(index.ServiceGmail, "", id) {
+					res.Deleted++
+				}
+			}
+			upserted, deleted, err := c.fetchGmailIndexDocs(ctx, svc, account, labels, store, fetchIDs)
+			if err != nil {
+				return res, err
+			}
+			res.Upserted += upserted
+			res.Deleted += deleted
+			if historyID != "" {
+				store.State.GmailHistoryID = historyID
+			}
+		}
+	}
+	if !full {
+		return res, nil
+	}
+
+	res = indexSyncResult{Service: index.ServiceGmail, Mode: indexModeFull}
+	// Record the history cursor first, so changes made while listing are
+	// replayed by the next incremental sync.
+	profile, err := svc.Users.GetProfile("me").Context(ctx).Do()
+	if err != nil {
+		return res, err
+	}
+
+	ids, err := listGmailIndexMessageIDs(ctx, svc, c.GmailQuery, c.GmailMax)
+	if err != nil {
+		return res, err
+	}
+	store.Clear(index.ServiceGmail, "")
+	upserted, _, err := c.fetchGmailIndexDocs(ctx, svc, account, labels, store, ids)
+	if err != nil {
+		return res, err
+	}
+	res.Upserted = upserted
+	store.State.GmailHistoryID = formatHistoryID(profile.HistoryId)
+	store.State.GmailBodies = c.Bodies
+
+	return res, nil
+}
+
+// listGmailIndexHistory replays history since startID and returns the new
+// history ID with the messages to re-fetch and those deleted.
+func listGmailIndexHistory(ctx context.Context, svc *gmail.Service, startID string) (string, []string, []string, error) {
+	start, err := parseHistoryID(startID)
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	historyID := ""
+	var fetchIDs, deletedIDs []string
+	fetchSeen := map[string]bool{}
+	deleted := map[string]bool{}
+
+	_, err = collectAllPages("", func(pageToken string) ([]struct{}, string, error) {
+		call := svc.Users.History.List("me").StartHistoryId(start).MaxResults(500).
+			HistoryTypes("messageAdded", "messageDeleted", "labelAdded", "labelRemoved")
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Context(ctx).Do()
+		if err != nil {
+			return nil, "", err
+		}
+		if resp.HistoryId != 0 {
+			historyID = formatHistoryID(resp.HistoryId)
+		}
+
+		ids := collectHistoryMessageIDs(resp)
+		for _, id := range ids.FetchIDs {
+			if !fetchSeen[id] && !deleted[id] {
+				fetchSeen[id] = true
+				fetchIDs = append(fetchIDs, id)
+			}
+		}
+		for _, id := range ids.DeletedIDs {
+			if !deleted[id] {
+				deleted[id] = true
+				deletedIDs = append(deletedIDs, id)
+			}
+		}
+		return nil, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	kept := fetchIDs[:0]
+	for _, id := range fetchIDs {
+		if !deleted[id] {
+			kept = append(kept, id)
+		}
+	}
+
+	return historyID, kept, deletedIDs, nil
+}
+
+func listGmailIndexMessageIDs(ctx context.Context, svc *gmail.Service, query string, maxMessages int64) ([]string, error) {
+	var ids []string
+	pageToken := ""
+	for maxMessages <= 0 || int64(len(ids)) < maxMessages {
+		pageSize := int64(500)
+		if maxMessages > 0 {
+			pageSize = min(pageSize, maxMessages-int64(len(ids)))
+		}
+		call := svc.Users.Messages.List("me").MaxResults(pageSize)
+		if q := strings.TrimSpace(query); q != "" {
+			call = call.Q(q)
+		}
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Context(ctx).Do()
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range resp.Messages {
+			if m != nil && m.Id != "" {
+				ids = append(ids, m.Id)
+			}
+		}
+		if resp.NextPageToken == "" || resp.NextPageToken == pageToken {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+
+	return ids, nil
+}
+
+// fetchGmailIndexDocs fetches ids and stores them; messages gone meanwhile
+// are removed from the index.
+func (c *IndexSyncCmd) fetchGmailIndexDocs(ctx context.Context, svc *gmail.Service, account string, labels map[string]string, store *index.Store, ids []string) (int, int, error) {
+	docs, err := workpool.Map(ctx, workpool.Limit(ctx, workpool.DefaultLimit), ids, func(ctx context.Context, id string) (*index.Doc, error) {
+		call := svc.Users.Messages.Get("me", id)
+		if c.Bodies {
+			call = call.Format(gmailFormatFull)
+		} else {
+			call = call.Format(gmailFormatMetadata).MetadataHeaders("From", "To", "Cc", "Subject")
+		}
+		msg, err := call.Context(ctx).Do()
+		if err != nil {
+			if isNotFoundAPIError(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return gmailIndexDoc(account, msg, labels, c.Bodies), nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	upserted, deleted := 0, 0
+	for i, doc := range docs {
+		if doc == nil {
+			if store.Delete(index.ServiceGmail, "", ids[i]) {
+				deleted++
+			}
+			continue
+		}
+		store.Put(doc)
+		upserted++
+	}
+
+	return upserted, deleted, nil
+}
+
+func gmailIndexDoc(account string, msg *gmail.Message, labels map[string]string, bodies bool) *index.Doc {
+	labelNames := make([]string, 0, len(msg.LabelIds))
+	for _, id := range msg.LabelIds {
+		if name, ok := labels[id]; ok {
+			labelNames = append(labelNames, name)
+		} else {
+			labelNames = append(labelNames, id)
+		}
+	}
+
+	to := headerValue(msg.Payload, "To")
+	if cc := headerValue(msg.Payload, "Cc"); cc != "" {
+		to = strings.TrimPrefix(to+", "+cc, ", ")
+	}
+
+	text := html.UnescapeString(msg.Snippet)
+	if bodies {
+		if body := bestBodyText(msg.Payload); body != "" {
+			if looksLikeHTML(body) {
+				body = stripHTMLTags(body)
+			}
+			text = truncateRunes(body, maxIndexBodyRunes)
+		}
+	}
+
+	doc := &index.Doc{
+		Service:  index.ServiceGmail,
+		Account:  account,
+		ID:       msg.Id,
+		ThreadID: msg.ThreadId,
+		Title:    headerValue(msg.Payload, "Subject"),
+		Text:     text,
+		URL:      fmt.Sprintf("https://mail.google.com/mail/?authuser=%s#all/%s", url.QueryEscape(account), msg.ThreadId),
+		Fields: compactIndexFields(map[string]string{
+			"from":  headerValue(msg.Payload, "From"),
+			"to":    to,
+			"label": strings.Join(labelNames, ", "),
+		}),
+	}
+	if msg.InternalDate > 0 {
+		doc.Date = time.UnixMilli(msg.InternalDate).UTC()
+	}
+
+	return doc
+}
+
+func (c *IndexSyncCmd) syncDrive(ctx context.Context, account string, store *index.Store) (indexSyncResult, error) {
+	res := indexSyncResult{Service: index.ServiceDrive, Mode: indexModeIncremental}
+
+	svc, err := newDriveService(ctx, account)
+	if err != nil {
+		return res, err
+	}
+
+	if token := store.State.DrivePageToken; token != "" && !c.Full {
+		next, changeErr := applyDriveIndexChanges(ctx, svc, account, store, token, &res)
+		switch {
+		case changeErr != nil && isExpiredCursorError(changeErr):
+			// Fall through to a full sync.
+		case changeErr != nil:
+			return res, changeErr
+		default:
+			store.State.DrivePageToken = next
+			return res, nil
+		}
+	}
+
+	res = indexSyncResult{Service: index.ServiceDrive, Mode: indexModeFull}
+	start, err := svc.Changes.GetStartPageToken().Context(ctx).Do()
+	if err != nil {
+		return res, err
+	}
+
+	files, err := collectAllPages("", func(pageToken string) ([]*drive.File, string, error) {
+		call := svc.Files.List().
+			Q("trashed = false").
+			PageSize(1000).
+			Fields(googleapi.Field("nextPageToken, files(" + driveIndexFileFields + ")"))
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Context(ctx).Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Files, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return res, err
+	}
+
+	store.Clear(index.ServiceDrive, "")
+	for _, f := range files {
+		if f == nil || f.Id == "" {
+			continue
+		}
+		store.Put(driveIndexDoc(account, f))
+		res.Upserted++
+	}
+	store.State.DrivePageToken = start.StartPageToken
+
+	return res, nil
+}
+
+// applyDriveIndexChanges applies the change log since token and returns the
+// token for the next sync.
+func applyDriveIndexChanges(ctx context.Context, svc *drive.Service, account string, store *index.Store, token string, res *indexSyncResult) (string, error) {
+	seen := map[string]bool{}
+	for {
+		if seen[token] {
+			return "", fmt.Errorf("pagination loop: repeated page token %q", token)
+		}
+		seen[token] = true
+
+		resp, err := svc.Changes.List(token).
+			PageSize(1000).
+			IncludeRemoved(true).
+			Fields(googleapi.Field("nextPageToken, newStartPageToken, changes(fileId, removed, file(" + driveIndexFileFields + "))")).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return "", err
+		}
+
+		for _, ch := range resp.Changes {
+			if ch == nil || ch.FileId == "" {
+				continue
+			}
+			if ch.Removed || ch.File == nil || ch.File.Trashed {
+				if store.Delete(index.ServiceDrive, "", ch.FileId) {
+					res.Deleted++
+				}
+				continue
+			}
+			store.Put(driveIndexDoc(account, ch.File))
+			res.Upserted++
+		}
+
+		if resp.NextPageToken == "" {
+			return resp.NewStartPageToken, nil
+		}
+		token = resp.NextPageToken
+	}
+}
+
+func driveIndexDoc(account string, f *drive.File) *index.Doc {
+	owners := make([]string, 0, len(f.Owners))
+	for _, o := range f.Owners {
+		if o == nil {
+			continue
+		}
+		owners = append(owners, strings.TrimSpace(o.DisplayName+" <"+o.EmailAddress+">"))
+	}
+
+	doc := &index.Doc{
+		Service: index.ServiceDrive,
+		Account: account,
+		ID:      f.Id,
+		Title:   f.Name,
+		Text:    f.Description,
+		URL:     f.WebViewLink,
+		Fields: compactIndexFields(map[string]string{
+			"mime":  f.MimeType,
+			"owner": strings.Join(owners, ", "),
+		}),
+	}
+	if t, err := time.Parse(time.RFC3339, f.ModifiedTime); err == nil {
+		doc.Date = t.UTC()
+	}
+
+	return doc
+}
+
+func (c *IndexSyncCmd) syncCalendar(ctx context.Context, account string, store *index.Store) (indexSyncResult, error) {
+	res := indexSyncResult{Service: index.ServiceCalendar, Mode: indexModeIncremental}
+
+	svc, err := newCalendarService(ctx, account)
+	if err != nil {
+		return res, err
+	}
+
+	calendarIDs, err := indexCalendarIDs(ctx, svc, c.Calendars)
+	if err != nil {
+		return res, err
+	}
+	if store.State.CalendarSyncTokens == nil {
+		store.State.CalendarSyncTokens = map[string]string{}
+	}
+
+	for _, calID := range calendarIDs {
+		token := store.State.CalendarSyncTokens[calID]
+		if token != "" && !c.Full {
+			next, syncErr := syncCalendarIndexEvents(ctx, svc, account, calID, token, store, &res)
+			switch {
+			case syncErr != nil && isExpiredCursorError(syncErr):
+				// Fall through to a full sync of this calendar.
+			case syncErr != nil:
+				return res, fmt.Errorf("%s: %w", calID, syncErr)
+			default:
+				store.State.CalendarSyncTokens[calID] = next
+				continue
+			}
+		}
+
+		res.Mode = indexModeFull
+		store.Clear(index.ServiceCalendar, calID)
+		next, err := syncCalendarIndexEvents(ctx, svc, account, calID, "", store, &res)
+		if err != nil {
+			return res, fmt.Errorf("%s: %w", calID, err)
+		}
+		store.State.CalendarSyncTokens[calID] = next
+	}
+
+	return res, nil
+}
+
+func indexCalendarIDs(ctx context.Context, svc *calendar.Service, spec string) ([]string, error) {
+	ids := splitCommaList(spec)
+	if len(ids) == 0 {
+		return []string{primaryCalendarID}, nil
+	}
+	if len(ids) != 1 || !strings.EqualFold(ids[0], "all") {
+		return ids, nil
+	}
+
+	return collectAllPages("", func(pageToken string) ([]string, string, error) {
+		call := svc.CalendarList.List().MaxResults(250)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Context(ctx).Do()
+		if err != nil {
+			return nil, "", err
+		}
+		out := make([]string, 0, len(resp.Items))
+		for _, item := range resp.Items {
+			if item != nil && item.Id != "" {
+				out = append(out, item.Id)
+			}
+		}
+		return out, resp.NextPageToken, nil
+	})
+}
+
+// syncCalendarIndexEvents lists events of calID (all of them without a sync
+// token, the changes since it otherwise) and returns the next sync token.
+func syncCalendarIndexEvents(ctx context.Context, svc *calendar.Service, account, calID, syncToken string, store *index.Store, res *indexSyncResult) (string, error) {
+	nextSyncToken := ""
+	_, err := collectAllPages("", func(pageToken string) ([]struct{}, string, error) {
+		call := svc.Events.List(calID).MaxResults(2500)
+		if syncToken != "" {
+			call = call.SyncToken(syncToken)
+		}
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Context(ctx).Do()
+		if err != nil {
+			return nil, "", err
+		}
+
+		for _, ev := range resp.Items {
+			if ev == nil || ev.Id == "" {
+				continue
+			}
+			if ev.Status == "cancelled" {
+				if store.Delete(index.ServiceCalendar, calID, ev.Id) {
+					res.Deleted++
+				}
+				continue
+			}
+			store.Put(calendarIndexDoc(account, calID, ev))
+			res.Upserted++
+		}
+		if resp.NextPageToken == "" {
+			nextSyncToken = resp.NextSyncToken
+		}
+		return nil, resp.NextPageToken, nil
+	})
+
+	return nextSyncToken, err
+}
+
+func calendarIndexDoc(account, calID string, ev *calendar.Event) *index.Doc {
+	attendees := make([]string, 0, len(ev.Attendees))
+	for _, a := range ev.Attendees {
+		if a != nil && a.Email != "" {
+			attendees = append(attendees, strings.TrimSpace(a.DisplayName+" <"+a.Email+">"))
+		}
+	}
+	organizer := ""
+	if ev.Organizer != nil {
+		organizer = strings.TrimSpace(ev.Organizer.DisplayName + " <" + ev.Organizer.Email + ">")
+	}
+
+	doc := &index.Doc{
+		Service:    index.ServiceCalendar,
+		Account:    account,
+		ID:         ev.Id,
+		CalendarID: calID,
+		Title:      ev.Summary,
+		Text:       ev.Description,
+		URL:        ev.HtmlLink,
+		Fields: compactIndexFields(map[string]string{
+			"location":  ev.Location,
+			"organizer": organizer,
+			"attendee":  strings.Join(attendees, ", "),
+		}),
+	}
+	if ev.Start != nil {
+		if t, err := time.Parse(time.RFC3339, ev.Start.DateTime); err == nil {
+			doc.Date = t.UTC()
+		} else if t, err := time.Parse("2006-01-02", ev.Start.Date); err == nil {
+			doc.Date = t
+		}
+	}
+
+	return doc
+}
+
+// isExpiredCursorError reports a Drive page token or Calendar sync token the
+// API no longer accepts; the caller re-syncs from scratch.
+func isExpiredCursorError(err error) bool {
+	var gerr *googleapi.Error
+	if !errors.As(err, &gerr) {
+		return false
+	}
+
+	return gerr.Code == http.StatusGone || gerr.Code == http.StatusNotFound ||
+		(gerr.Code == http.StatusBadRequest && strings.Contains(strings.ToLower(gerr.Message), "token"))
+}
+
+func compactIndexFields(fields map[string]string) map[string]string {
+	for k, v := range fields {
+		if strings.TrimSpace(v) == "" {
+			delete(fields, k)
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	return fields
+}
+
+type IndexSearchCmd struct {
+	Query     []string `arg:"" name:"query" help:"Search terms: words (ANDed), \"phrases\", -exclude, prefix*, field:value (from, to, label, title, mime, owner, calendar, location, attendee, organizer, id, thread), service:, account:, after:/before:YYYY-MM-DD"`
+	Service   string   `name:"service" aliases:"services" help:"Comma-separated services to search: gmail,drive,calendar"`
+	Max       int      `name:"max" aliases:"limit" help:"Max results" default:"20"`
+	FailEmpty bool     `name:"fail-empty" aliases:"non-empty,require-results" help:"Exit with code 3 if no results"`
+}
+
+type indexHit struct {
+	Service    string            `json:"service"`
+	Account    string            `json:"account"`
+	ID         string            `json:"id"`
+	ThreadID   string            `json:"threadId,omitempty"`
+	CalendarID string            `json:"calendarId,omitempty"`
+	Title      string            `json:"title"`
+	Snippet    string            `json:"snippet,omitempty"`
+	Date       string            `json:"date,omitempty"`
+	URL        string            `json:"url,omitempty"`
+	Fields     map[string]string `json:"fields,omitempty"`
+	Score      float64           `json:"score"`
+	Command    string            `json:"command"`
+}
+
+func (c *IndexSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	raw := strings.TrimSpace(strings.Join(c.Query, " "))
+	if raw == "" {
+		return usage("query is required")
+	}
+	q, err := index.ParseQuery(raw)
+	if err != nil {
+		return usagef("invalid query: %v", err)
+	}
+	if c.Service != "" {
+		services, err := parseIndexServices(c.Service)
+		if err != nil {
+			return err
+		}
+		q.Services = append(q.Services, services...)
+	}
+
+	stores, err := openIndexStores(flags)
+	if err != nil {
+		return err
+	}
+	var docs []*index.Doc
+	for _, s := range stores {
+		for _, d := range s.Docs {
+			docs = append(docs, d)
+		}
+	}
+
+	hits := index.Search(docs, q, c.Max)
+	items := make([]indexHit, 0, len(hits))
+	for _, h := range hits {
+		items = append(items, newIndexHit(h))
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+			"query": raw,
+			"hits":  items,
+		}); err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return failEmptyExit(c.FailEmpty)
+		}
+		return nil
+	}
+
+	if len(items) == 0 {
+		u.Err().Println("No results")
+		return failEmptyExit(c.FailEmpty)
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "SERVICE\tDATE\tTITLE\tID\tSCORE")
+	for _, it := range items {
+		id := it.ID
+		if it.CalendarID != "" {
+			id = it.CalendarID + "/" + it.ID
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%.3f\n", it.Service, it.Date, sanitizeTab(it.Title), id, it.Score)
+	}
+	return nil
+}
+
+func (*IndexSearchCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "hits", Result: struct {
+		Query string     `json:"query"`
+		Hits  []indexHit `json:"hits"`
+	}{}}
+}
+
+func newIndexHit(h index.Hit) indexHit {
+	d := h.Doc
+	out := indexHit{
+		Service:    d.Service,
+		Account:    d.Account,
+		ID:         d.ID,
+		ThreadID:   d.ThreadID,
+		CalendarID: d.CalendarID,
+		Title:      d.Title,
+		Snippet:    h.Snippet,
+		URL:        d.URL,
+		Fields:     d.Fields,
+		Score:      h.Score,
+	}
+	if !d.Date.IsZero() {
+		out.Date = d.Date.Format(time.RFC3339)
+	}
+
+	switch d.Service {
+	case index.ServiceGmail:
+		out.Command = fmt.Sprintf("gog gmail get %s --account %s", d.ID, d.Account)
+	case index.ServiceDrive:
+		out.Command = fmt.Sprintf("gog drive get %s --account %s", d.ID, d.Account)
+	case index.ServiceCalendar:
+		out.Command = fmt.Sprintf("gog calendar event %s %s --account %s", d.CalendarID, d.ID, d.Account)
+	}
+
+	return out
+}
+
+type IndexStatusCmd struct{}
+
+type indexStatus struct {
+	Account   string               `json:"account"`
+	Path      string               `json:"path"`
+	Documents map[string]int       `json:"documents"`
+	Bodies    bool                 `json:"bodies"`
+	SyncedAt  map[string]time.Time `json:"syncedAt,omitempty"`
+}
+
+func (c *IndexStatusCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	stores, err := openIndexStores(flags)
+	if err != nil {
+		return err
+	}
+
+	items := make([]indexStatus, 0, len(stores))
+	for _, s := range stores {
+		items = append(items, indexStatus{
+			Account:   s.Account,
+			Path:      s.Path(),
+			Documents: s.Count(),
+			Bodies:    s.State.GmailBodies,
+			SyncedAt:  s.State.SyncedAt,
+		})
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, os.Stdout, map[string]any{"indexes": items})
+	}
+	if len(items) == 0 {
+		u.Err().Println("No local index; run: gog index sync")
+		return nil
+	}
+
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ACCOUNT\tSERVICE\tDOCUMENTS\tSYNCED")
+	for _, it := range items {
+		for _, service := range indexServices {
+			synced := ""
+			if t, ok := it.SyncedAt[service]; ok {
+				synced = t.Local().Format(time.RFC3339)
+			}
+			if synced == "" && it.Documents[service] == 0 {
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", it.Account, service, it.Documents[service], synced)
+		}
+	}
+	return nil
+}
+
+func (*IndexStatusCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "indexes", Result: struct {
+		Indexes []indexStatus `json:"indexes"`
+	}{}}
+}
+
+// openIndexStores opens the index of --account, or of every synced account.
+func openIndexStores(flags *RootFlags) ([]*index.Store, error) {
+	if flags != nil && strings.TrimSpace(flags.Account) != "" {
+		account, err := requireAccount(flags)
+		if err != nil {
+			return nil, err
+		}
+		path, err := indexStorePath(account)
+		if err != nil {
+			return nil, err
+		}
+		store, err := index.Open(path, account)
+		if err != nil {
+			return nil, err
+		}
+		return []*index.Store{store}, nil
+	}
+
+	dir, err := config.IndexDir()
+	if err != nil {
+		return nil, err
+	}
+	paths, err := index.List(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	stores := make([]*index.Store, 0, len(paths))
+	for _, path := range paths {
+		store, err := index.Open(path, "")
+		if err != nil {
+			return nil, err
+		}
+		stores = append(stores, store)
+	}
+
+	return stores, nil
+}
+
+func indexStorePath(account string) (string, error) {
+	dir, err := config.EnsureIndexDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, sanitizeAccountForPath(account)+index.FileSuffix), nil
+}
+
+func parseIndexServices(spec string) ([]string, error) {
+	var out []string
+	for _, s := range splitCommaList(strings.ToLower(spec)) {
+		valid := false
+		for _, known := range indexServices {
+			if s == known {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, usagef("unknown service %q (expected %s)", s, strings.Join(indexServices, ", "))
+		}
+		out = append(out, s)
+	}
+	if len(out) == 0 {
+		return nil, usagef("no services given (expected %s)", strings.Join(indexServices, ", "))
+	}
+
+	return out, nil
+}
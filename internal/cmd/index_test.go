@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/calendar/v3"
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/option"
+
+	"github.com/steipete/gogcli/internal/index"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+func TestIndexSyncAndSearch_FullThenIncremental(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+
+	origGmail, origDrive, origCalendar := newGmailService, newDriveService, newCalendarService
+	t.Cleanup(func() {
+		newGmailService, newDriveService, newCalendarService = origGmail, origDrive, origCalendar
+	})
+
+	message := func(id, subject string) map[string]any {
+		return map[string]any{
+			"id":           id,
+			"threadId":     "t-" + id,
+			"labelIds":     []string{"INBOX", "Label_1"},
+			"snippet":      "about the " + strings.ToLower(subject),
+			"internalDate": "1772445600000",
+			"payload": map[string]any{"headers": []map[string]any{
+				{"name": "Subject", "value": subject},
+				{"name": "From", "value": "Alice <alice@example.com>"},
+			}},
+		}
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := r.URL.Path
+		var body any
+		switch {
+		case strings.HasSuffix(p, "/users/me/labels"):
+			body = map[string]any{"labels": []map[string]any{{"id": "INBOX", "name": "INBOX"}, {"id": "Label_1", "name": "Finance"}}}
+		case strings.HasSuffix(p, "/users/me/profile"):
+			body = map[string]any{"historyId": "100"}
+		case strings.HasSuffix(p, "/users/me/messages"):
+			body = map[string]any{"messages": []map[string]any{{"id": "m1"}, {"id": "m2"}}}
+		case strings.HasSuffix(p, "/users/me/messages/m1"):
+			body = message("m1", "Quarterly budget")
+		case strings.HasSuffix(p, "/users/me/messages/m2"):
+			body = message("m2", "Lunch")
+		case strings.HasSuffix(p, "/users/me/messages/m3"):
+			body = message("m3", "Budget follow-up")
+		case strings.HasSuffix(p, "/users/me/history"):
+			if r.URL.Query().Get("startHistoryId") != "100" {
+				t.Errorf("unexpected startHistoryId %q", r.URL.Query().Get("startHistoryId"))
+			}
+			body = map[string]any{
+				"historyId": "105",
+				"history": []map[string]any{{
+					"id":              "101",
+					"messagesAdded":   []map[string]any{{"message": map[string]any{"id": "m3"}}},
+					"messagesDeleted": []map[string]any{{"message": map[string]any{"id": "m2"}}},
+				}},
+			}
+		case strings.HasSuffix(p, "/changes/startPageToken"):
+			body = map[string]any{"startPageToken": "t1"}
+		case strings.HasSuffix(p, "/files"):
+			body = map[string]any{"files": []map[string]any{{
+				"id": "f1", "name": "Budget 2026", "mimeType": "application/vnd.google-apps.spreadsheet",
+				"modifiedTime": "2026-03-01T09:00:00Z",
+			}}}
+		case strings.HasSuffix(p, "/changes"):
+			if r.URL.Query().Get("pageToken") != "t1" {
+				t.Errorf("unexpected drive pageToken %q", r.URL.Query().Get("pageToken"))
+			}
+			body = map[string]any{"changes": []map[string]any{{"fileId": "f1", "removed": true}}, "newStartPageToken": "t2"}
+		case strings.HasSuffix(p, "/calendars/primary/events"):
+			if r.URL.Query().Get("syncToken") == "s1" {
+				body = map[string]any{"items": []map[string]any{{"id": "e1", "status": "cancelled"}}, "nextSyncToken": "s2"}
+			} else {
+				body = map[string]any{
+					"items": []map[string]any{{
+						"id": "e1", "summary": "Budget planning", "location": "Room 4",
+						"start": map[string]any{"dateTime": "2026-03-02T10:00:00Z"},
+					}},
+					"nextSyncToken": "s1",
+				}
+			}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer srv.Close()
+
+	opts := []option.ClientOption{
+		option.WithoutAuthentication(),
+		option.WithHTTPClient(srv.Client()),
+		option.WithEndpoint(srv.URL + "/"),
+	}
+	gsvc, err := gmail.NewService(context.Background(), opts...)
+	if err != nil {
+		t.Fatalf("gmail.NewService: %v", err)
+	}
+	dsvc, err := drive.NewService(context.Background(), opts...)
+	if err != nil {
+		t.Fatalf("drive.NewService: %v", err)
+	}
+	csvc, err := calendar.NewService(context.Background(), opts...)
+	if err != nil {
+		t.Fatalf("calendar.NewService: %v", err)
+	}
+	newGmailService = func(context.Context, string) (*gmail.Service, error) { return gsvc, nil }
+	newDriveService = func(context.Context, string) (*drive.Service, error) { return dsvc, nil }
+	newCalendarService = func(context.Context, string) (*calendar.Service, error) { return csvc, nil }
+
+	flags := &RootFlags{Account: "a@b.com"}
+	u, err := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if err != nil {
+		t.Fatalf("ui.New: %v", err)
+	}
+	ctx := outfmt.WithMode(ui.WithUI(context.Background(), u), outfmt.Mode{JSON: true})
+
+	sync := func() []indexSyncResult {
+		t.Helper()
+		out := captureStdout(t, func() {
+			if err := runKong(t, &IndexSyncCmd{}, nil, ctx, flags); err != nil {
+				t.Fatalf("sync: %v", err)
+			}
+		})
+		var parsed struct {
+			Services []indexSyncResult `json:"services"`
+		}
+		if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+			t.Fatalf("json parse: %v\n%s", err, out)
+		}
+		return parsed.Services
+	}
+	search := func(args ...string) []indexHit {
+		t.Helper()
+		out := captureStdout(t, func() {
+			if err := runKong(t, &IndexSearchCmd{}, args, ctx, flags); err != nil {
+				t.Fatalf("search: %v", err)
+			}
+		})
+		var parsed struct {
+			Hits []indexHit `json:"hits"`
+		}
+		if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+			t.Fatalf("json parse: %v\n%s", err, out)
+		}
+		return parsed.Hits
+	}
+	hitIDs := func(hits []indexHit) string {
+		ids := make([]string, 0, len(hits))
+		for _, h := range hits {
+			ids = append(ids, h.Service+":"+h.ID)
+		}
+		return strings.Join(ids, ",")
+	}
+
+	first := sync()
+	if len(first) != 3 || first[0].Mode != indexModeFull || first[0].Total != 2 || first[1].Total != 1 || first[2].Total != 1 {
+		t.Fatalf("unexpected first sync: %#v", first)
+	}
+
+	hits := search("budget")
+	if len(hits) != 3 {
+		t.Fatalf("expected 3 hits, got %s", hitIDs(hits))
+	}
+	if got := hitIDs(search("budget", "label:finance")); got != "gmail:m1" {
+		t.Fatalf("label filter: %s", got)
+	}
+	if h := search("budget", "--service", "calendar"); len(h) != 1 || h[0].Command != "gog calendar event primary e1 --account a@b.com" {
+		t.Fatalf("calendar hit: %#v", h)
+	}
+
+	second := sync()
+	for _, r := range second {
+		if r.Mode != indexModeIncremental {
+			t.Fatalf("expected incremental sync, got %#v", second)
+		}
+	}
+	if got := hitIDs(search("budget")); got != "gmail:m3,gmail:m1" && got != "gmail:m1,gmail:m3" {
+		t.Fatalf("after incremental sync: %s", got)
+	}
+
+	path, err := indexStorePath("a@b.com")
+	if err != nil {
+		t.Fatalf("indexStorePath: %v", err)
+	}
+	store, err := index.Open(path, "")
+	if err != nil {
+		t.Fatalf("index.Open: %v", err)
+	}
+	if store.State.GmailHistoryID != "105" || store.State.DrivePageToken != "t2" || store.State.CalendarSyncTokens["primary"] != "s2" {
+		t.Fatalf("unexpected cursors: %#v", store.State)
+	}
+}
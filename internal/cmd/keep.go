@@ -6,13 +6,16 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
 
 	keepapi "google.golang.org/api/keep/v1"
 
 	"github.com/steipete/gogcli/internal/config"
 	"github.com/steipete/gogcli/internal/googleapi"
 	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/timeparse"
 	"github.com/steipete/gogcli/internal/ui"
 )
 
@@ -33,12 +36,17 @@ type KeepListCmd struct {
 	Page      string `name:"page" aliases:"cursor" help:"Page token"`
 	All       bool   `name:"all" aliases:"all-pages,allpages" help:"Fetch all pages"`
 	FailEmpty bool   `name:"fail-empty" aliases:"non-empty,require-results" help:"Exit with code 3 if no results"`
-	Filter    string `name:"filter" help:"Filter expression (e.g. 'create_time > \"2024-01-01T00:00:00Z\"')"`
+	Filter    string `name:"filter" help:"Filter expression (e.g. 'create_time > \"2024-01-01T00:00:00Z\"'; time values may be natural: 'update_time > \"2 weeks ago\"')"`
 }
 
 func (c *KeepListCmd) Run(ctx context.Context, flags *RootFlags, keep *KeepCmd) error {
 	u := ui.FromContext(ctx)
 
+	filter, err := resolveKeepFilterTimes(strings.TrimSpace(c.Filter))
+	if err != nil {
+		return err
+	}
+
 	svc, err := getKeepService(ctx, flags, keep)
 	if err != nil {
 		return err
@@ -49,8 +57,8 @@ func (c *KeepListCmd) Run(ctx context.Context, flags *RootFlags, keep *KeepCmd)
 		if strings.TrimSpace(pageToken) != "" {
 			call = call.PageToken(pageToken)
 		}
-		if strings.TrimSpace(c.Filter) != "" {
-			call = call.Filter(strings.TrimSpace(c.Filter))
+		if filter != "" {
+			call = call.Filter(filter)
 		}
 		resp, callErr := call.Do()
 		if callErr != nil {
@@ -107,6 +115,13 @@ func (c *KeepListCmd) Run(ctx context.Context, flags *RootFlags, keep *KeepCmd)
 	return nil
 }
 
+func (*KeepListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "notes", Result: struct {
+		Notes         []*keepapi.Note `json:"notes"`
+		NextPageToken string          `json:"nextPageToken"`
+	}{}}
+}
+
 func noteSnippet(n *keepapi.Note) string {
 	if n.Body == nil || n.Body.Text == nil {
 		return "(no content)"
@@ -200,6 +215,14 @@ func (c *KeepSearchCmd) Run(ctx context.Context, flags *RootFlags, keep *KeepCmd
 	return nil
 }
 
+func (*KeepSearchCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "notes", Result: struct {
+		Notes []*keepapi.Note `json:"notes"`
+		Query string          `json:"query"`
+		Count int             `json:"count"`
+	}{}}
+}
+
 type KeepGetCmd struct {
 	NoteID string `arg:"" name:"noteId" help:"Note ID or name (e.g. notes/abc123)"`
 }
@@ -245,6 +268,12 @@ func (c *KeepGetCmd) Run(ctx context.Context, flags *RootFlags, keep *KeepCmd) e
 	return nil
 }
 
+func (*KeepGetCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Note *keepapi.Note `json:"note"`
+	}{}}
+}
+
 type KeepAttachmentCmd struct {
 	AttachmentName string `arg:"" name:"attachmentName" help:"Attachment name (e.g. notes/abc123/attachments/xyz789)"`
 	MimeType       string `name:"mime-type" help:"MIME type of attachment (e.g. image/jpeg)" default:"application/octet-stream"`
@@ -320,6 +349,14 @@ func (c *KeepAttachmentCmd) Run(ctx context.Context, flags *RootFlags, keep *Kee
 	return nil
 }
 
+func (*KeepAttachmentCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Downloaded bool   `json:"downloaded"`
+		Path       string `json:"path"`
+		Bytes      int64  `json:"bytes"`
+	}{}}
+}
+
 func getKeepService(ctx context.Context, flags *RootFlags, keepCmd *KeepCmd) (*keepapi.Service, error) {
 	if keepCmd.ServiceAccount != "" {
 		if keepCmd.Impersonate == "" {
@@ -359,3 +396,50 @@ func getKeepService(ctx context.Context, flags *RootFlags, keepCmd *KeepCmd) (*k
 
 	return nil, usage("Keep is Workspace-only and requires a service account. Configure it with: gog auth service-account set <email> --key <service-account.json> (or legacy: gog auth keep <email> --key <service-account.json>)")
 }
+
+var keepFilterTimeRe = regexp.MustCompile(`\b(create_time|update_time|trash_time)(\s*)(>=|<=|>|<|=)(\s*)"([^"]*)"`)
+
+// resolveKeepFilterTimes rewrites natural time values in --filter comparisons
+// ('update_time > "2 weeks ago"') to the RFC3339 timestamps the API expects.
+// Lower bounds use the start of the expression, upper bounds its end.
+func resolveKeepFilterTimes(filter string) (string, error) {
+	if filter == "" {
+		return "", nil
+	}
+
+	loc, err := resolveOutputLocation("", false)
+	if err != nil {
+		return "", err
+	}
+	weekStart, err := resolveWeekStart("")
+	if err != nil {
+		return "", err
+	}
+	opts := timeparse.Options{Now: calendarNow().In(loc), Location: loc, WeekStart: weekStart}
+
+	var parseErr error
+	out := keepFilterTimeRe.ReplaceAllStringFunc(filter, func(match string) string {
+		m := keepFilterTimeRe.FindStringSubmatch(match)
+		field, op, value := m[1], m[3], m[5]
+		if _, err := time.Parse(time.RFC3339Nano, value); err == nil {
+			return match
+		}
+		span, err := timeparse.ParseNatural(value, opts)
+		if err != nil {
+			if parseErr == nil {
+				parseErr = usagef("invalid %s value in --filter: %v", field, err)
+			}
+			return match
+		}
+		t := span.Start
+		if op == "<" || op == "<=" {
+			t = spanUpperBound(span)
+		}
+		return m[1] + m[2] + op + m[4] + `"` + t.UTC().Format(time.RFC3339) + `"`
+	})
+	if parseErr != nil {
+		return "", parseErr
+	}
+
+	return out, nil
+}
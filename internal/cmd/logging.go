@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steipete/gogcli/internal/config"
+)
+
+const (
+	envLogFormat = "GOG_LOG_FORMAT"
+	envLogFile   = "GOG_LOG_FILE"
+)
+
+// setupLogging installs the default slog logger for one invocation:
+// GOG_LOG_FORMAT picks text (default) or json lines, GOG_LOG_FILE appends to a
+// file instead of stderr. Every line carries invocation_id. The returned
+// close func must be called when the invocation ends.
+func setupLogging(verbose bool, invocationID string) (func(), error) {
+	format := strings.ToLower(strings.TrimSpace(os.Getenv(envLogFormat)))
+	switch format {
+	case "", "text", "json":
+	default:
+		return nil, logConfigErrorf("invalid %s %q (expected text or json)", envLogFormat, os.Getenv(envLogFormat))
+	}
+
+	// Retry, circuit-breaker and token-refresh events are info level: quiet on
+	// stderr by default, recorded when logging to a file.
+	level := slog.LevelWarn
+	var out io.Writer = os.Stderr
+	closeFn := func() {}
+
+	if path := strings.TrimSpace(os.Getenv(envLogFile)); path != "" {
+		expanded, err := config.ExpandPath(path)
+		if err != nil {
+			return nil, logConfigErrorf("invalid %s: %v", envLogFile, err)
+		}
+		if dir := filepath.Dir(expanded); dir != "" {
+			if err := os.MkdirAll(dir, 0o700); err != nil {
+				return nil, logConfigErrorf("%s: %v", envLogFile, err)
+			}
+		}
+		f, err := os.OpenFile(expanded, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600) //nolint:gosec // user-provided log path
+		if err != nil {
+			return nil, logConfigErrorf("%s: %v", envLogFile, err)
+		}
+		out = f
+		level = slog.LevelInfo
+		closeFn = func() { _ = f.Close() }
+	}
+	if verbose {
+		level = slog.LevelDebug
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler = slog.NewTextHandler(out, opts)
+	if format == "json" {
+		handler = slog.NewJSONHandler(out, opts)
+	}
+
+	logger := slog.New(handler)
+	if invocationID != "" {
+		logger = logger.With("invocation_id", invocationID)
+	}
+	slog.SetDefault(logger)
+
+	return closeFn, nil
+}
+
+// logConfigErrorf reports a bad GOG_LOG_* setting: the environment is
+// misconfigured, the command line is not.
+func logConfigErrorf(format string, args ...any) error {
+	return &ExitError{Code: exitCodeConfig, Err: fmt.Errorf(format, args...)}
+}
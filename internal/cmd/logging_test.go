@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExecute_JSONLogFileWithInvocationID(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	prev := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(prev) })
+
+	logPath := filepath.Join(t.TempDir(), "logs", "gog.log")
+	t.Setenv("GOG_LOG_FORMAT", "json")
+	t.Setenv("GOG_LOG_FILE", logPath)
+	t.Setenv("GOG_INVOCATION_ID", "run-42")
+
+	out := captureStdout(t, func() {
+		if err := Execute([]string{"--json", "time", "now", "--timezone", "UTC"}); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+
+	var env map[string]any
+	if err := json.Unmarshal([]byte(out), &env); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, out)
+	}
+	if env["invocation_id"] != "run-42" {
+		t.Fatalf("expected invocation_id in envelope, got %#v", env["invocation_id"])
+	}
+
+	f, err := os.Open(logPath)
+	if err != nil {
+		t.Fatalf("open log: %v", err)
+	}
+	defer f.Close()
+
+	events := map[string]bool{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("log line is not JSON: %q", scanner.Text())
+		}
+		if line["invocation_id"] != "run-42" {
+			t.Fatalf("log line without invocation_id: %q", scanner.Text())
+		}
+		if ev, ok := line["event"].(string); ok {
+			events[ev] = true
+		}
+	}
+	if !events["invocation.start"] || !events["invocation.finish"] {
+		t.Fatalf("expected start/finish events, got %v", events)
+	}
+}
+
+func TestExecute_InvalidLogFormat(t *testing.T) {
+	t.Setenv("GOG_LOG_FORMAT", "xml")
+
+	errOut := captureStderr(t, func() {
+		err := Execute([]string{"--plain", "time", "now"})
+		if ExitCode(err) != exitCodeConfig {
+			t.Fatalf("expected config exit code, got %v", err)
+		}
+	})
+	if errOut == "" {
+		t.Fatalf("expected error on stderr")
+	}
+}
+
+func TestExecute_UnwritableLogFile(t *testing.T) {
+	blocker := filepath.Join(t.TempDir(), "not-a-dir")
+	if err := os.WriteFile(blocker, nil, 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	t.Setenv("GOG_LOG_FILE", filepath.Join(blocker, "gog.log"))
+
+	_ = captureStderr(t, func() {
+		err := Execute([]string{"--plain", "time", "now"})
+		if ExitCode(err) != exitCodeConfig {
+			t.Fatalf("expected config exit code, got %v", err)
+		}
+	})
+}
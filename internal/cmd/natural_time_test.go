@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// setNaturalNow pins the clock and timezone used by natural time parsing.
+func setNaturalNow(t *testing.T, now time.Time) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("GOG_TIMEZONE", "UTC")
+	t.Setenv("GOG_WEEK_START", "")
+
+	orig := calendarNow
+	calendarNow = func() time.Time { return now }
+	t.Cleanup(func() { calendarNow = orig })
+}
+
+func TestResolveEventTimes_Natural(t *testing.T) {
+	// Friday.
+	setNaturalNow(t, time.Date(2026, 2, 13, 15, 45, 0, 0, time.UTC))
+
+	tests := []struct {
+		name       string
+		from, to   string
+		allDay     bool
+		wantFrom   string
+		wantTo     string
+		wantAllDay bool
+	}{
+		{name: "literal passthrough", from: "2026-02-20T10:00:00-08:00", to: "2026-02-20T11:00:00-08:00", wantFrom: "2026-02-20T10:00:00-08:00", wantTo: "2026-02-20T11:00:00-08:00"},
+		{name: "time range", from: "friday 9-11am", wantFrom: "2026-02-13T09:00:00Z", wantTo: "2026-02-13T11:00:00Z"},
+		{name: "for duration", from: "tomorrow 9:30 for 45m", wantFrom: "2026-02-14T09:30:00Z", wantTo: "2026-02-14T10:15:00Z"},
+		{name: "natural both", from: "next tuesday 3pm", to: "next tuesday 4pm", wantFrom: "2026-02-17T15:00:00Z", wantTo: "2026-02-17T16:00:00Z"},
+		{name: "weekday range implies all-day", from: "mon-fri next week", wantFrom: "2026-02-16", wantTo: "2026-02-21", wantAllDay: true},
+		{name: "day fills all-day end", from: "monday", wantFrom: "2026-02-16", wantTo: "2026-02-17", wantAllDay: true},
+		{name: "all-day natural end", from: "monday", to: "wednesday", wantFrom: "2026-02-16", wantTo: "2026-02-19", wantAllDay: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, allDay, err := resolveEventTimes(tt.from, tt.to, tt.allDay)
+			if err != nil {
+				t.Fatalf("resolveEventTimes: %v", err)
+			}
+			if from != tt.wantFrom || to != tt.wantTo || allDay != tt.wantAllDay {
+				t.Fatalf("got from=%q to=%q allDay=%v", from, to, allDay)
+			}
+		})
+	}
+
+	if _, _, _, err := resolveEventTimes("tomorrow 3pm", "friday", false); err == nil {
+		t.Fatalf("expected error for date-only --to on a timed event")
+	}
+	if _, _, _, err := resolveEventTimes("whenever", "", false); err == nil {
+		t.Fatalf("expected error for invalid --from")
+	}
+}
+
+func TestGmailDateQuery(t *testing.T) {
+	setNaturalNow(t, time.Date(2026, 2, 13, 15, 45, 0, 0, time.UTC))
+
+	got, err := gmailDateQuery("from:bob", "last week", "", "", false)
+	if err != nil {
+		t.Fatalf("gmailDateQuery: %v", err)
+	}
+	// Mon 2026-02-02 00:00Z .. Mon 2026-02-09 00:00Z.
+	if got != "from:bob after:1769990400 before:1770595200" {
+		t.Fatalf("unexpected query: %q", got)
+	}
+
+	got, err = gmailDateQuery("", "", "yesterday", "", false)
+	if err != nil {
+		t.Fatalf("gmailDateQuery: %v", err)
+	}
+	if got != "before:1770940800" {
+		t.Fatalf("unexpected query: %q", got)
+	}
+
+	got, err = gmailDateQuery("is:unread", "", "", "", false)
+	if err != nil || got != "is:unread" {
+		t.Fatalf("unexpected passthrough: %q %v", got, err)
+	}
+
+	if _, err := gmailDateQuery("", "someday", "", "", false); err == nil {
+		t.Fatalf("expected error for invalid --since")
+	}
+}
+
+func TestResolveKeepFilterTimes(t *testing.T) {
+	setNaturalNow(t, time.Date(2026, 2, 13, 15, 45, 0, 0, time.UTC))
+
+	got, err := resolveKeepFilterTimes(`update_time > "2 weeks ago" AND create_time < "end of month" AND trash_time > "2024-01-01T00:00:00Z"`)
+	if err != nil {
+		t.Fatalf("resolveKeepFilterTimes: %v", err)
+	}
+	want := `update_time > "2026-01-30T00:00:00Z" AND create_time < "2026-02-28T23:59:59Z" AND trash_time > "2024-01-01T00:00:00Z"`
+	if got != want {
+		t.Fatalf("unexpected filter:\n got %s\nwant %s", got, want)
+	}
+
+	if _, err := resolveKeepFilterTimes(`create_time > "whenever"`); err == nil || !strings.Contains(err.Error(), "create_time") {
+		t.Fatalf("expected create_time error, got %v", err)
+	}
+}
+
+func TestParseTaskDate_Natural(t *testing.T) {
+	setNaturalNow(t, time.Date(2026, 2, 13, 15, 45, 0, 0, time.UTC))
+
+	due, hasTime, err := parseTaskDate("end of month")
+	if err != nil {
+		t.Fatalf("parseTaskDate: %v", err)
+	}
+	if hasTime || !due.Equal(time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected due: %v hasTime=%v", due, hasTime)
+	}
+
+	due, hasTime, err = parseTaskDate("tomorrow 5pm")
+	if err != nil {
+		t.Fatalf("parseTaskDate: %v", err)
+	}
+	if !hasTime || due.Format(time.RFC3339) != "2026-02-14T17:00:00Z" {
+		t.Fatalf("unexpected due: %v hasTime=%v", due, hasTime)
+	}
+}
+
+func TestResolveWeekStart_Configured(t *testing.T) {
+	setNaturalNow(t, time.Now())
+	t.Setenv("GOG_WEEK_START", "sun")
+
+	day, err := resolveWeekStart("")
+	if err != nil || day != time.Sunday {
+		t.Fatalf("unexpected week start: %v %v", day, err)
+	}
+
+	// The flag wins over the environment.
+	day, err = resolveWeekStart("mon")
+	if err != nil || day != time.Monday {
+		t.Fatalf("unexpected week start: %v %v", day, err)
+	}
+}
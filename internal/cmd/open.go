@@ -51,6 +51,14 @@ func (c *OpenCmd) Run(ctx context.Context) error {
 	return nil
 }
 
+func (*OpenCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Input string `json:"input"`
+		Type  string `json:"type"`
+		URL   string `json:"url"`
+	}{}}
+}
+
 func bestEffortWebURL(kind string, input string) string {
 	kind = strings.ToLower(strings.TrimSpace(kind))
 	input = strings.TrimSpace(input)
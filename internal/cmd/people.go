@@ -4,6 +4,8 @@ import (
 	"context"
 	"os"
 
+	"google.golang.org/api/people/v1"
+
 	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
 )
@@ -64,3 +66,14 @@ func (c *PeopleMeCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}
 	return nil
 }
+
+func (*PeopleMeCmd) jsonOutput() commandOutput {
+	return personOutput()
+}
+
+// personOutput declares the {"person": ...} result of people me and get.
+func personOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Person *people.Person `json:"person"`
+	}{}}
+}
@@ -65,6 +65,10 @@ func (c *PeopleGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*PeopleGetCmd) jsonOutput() commandOutput {
+	return personOutput()
+}
+
 type PeopleSearchCmd struct {
 	Query     []string `arg:"" name:"query" help:"Search query"`
 	Max       int64    `name:"max" aliases:"limit" help:"Max results" default:"50"`
@@ -126,17 +130,12 @@ func (c *PeopleSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}
 
 	if outfmt.IsJSON(ctx) {
-		type item struct {
-			Resource string `json:"resource"`
-			Name     string `json:"name,omitempty"`
-			Email    string `json:"email,omitempty"`
-		}
-		items := make([]item, 0, len(peopleList))
+		items := make([]personItem, 0, len(peopleList))
 		for _, p := range peopleList {
 			if p == nil {
 				continue
 			}
-			items = append(items, item{
+			items = append(items, personItem{
 				Resource: p.ResourceName,
 				Name:     primaryName(p),
 				Email:    primaryEmail(p),
@@ -176,6 +175,13 @@ func (c *PeopleSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*PeopleSearchCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "people", Result: struct {
+		People        []personItem `json:"people"`
+		NextPageToken string       `json:"nextPageToken"`
+	}{}}
+}
+
 type PeopleRelationsCmd struct {
 	UserID string `arg:"" optional:"" name:"userId" help:"User ID (people/...)"`
 	Type   string `name:"type" help:"Filter relation type"`
@@ -257,6 +263,14 @@ func (c *PeopleRelationsCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*PeopleRelationsCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "relations", Result: struct {
+		Resource     string             `json:"resource"`
+		Relations    []*people.Relation `json:"relations"`
+		RelationType string             `json:"relationType,omitempty"`
+	}{}}
+}
+
 func peopleServiceForResource(ctx context.Context, account string, resource string) (*people.Service, error) {
 	if resource == peopleMeResource {
 		return newPeopleContactsService(ctx, account)
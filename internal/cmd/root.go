@@ -8,16 +8,20 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/alecthomas/kong"
 
 	"github.com/steipete/gogcli/internal/authclient"
 	"github.com/steipete/gogcli/internal/config"
 	"github.com/steipete/gogcli/internal/errfmt"
+	gogapi "github.com/steipete/gogcli/internal/googleapi"
 	"github.com/steipete/gogcli/internal/googleauth"
+	"github.com/steipete/gogcli/internal/invocation"
 	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/secrets"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/internal/workpool"
 )
 
 const (
@@ -28,7 +32,7 @@ const (
 
 type RootFlags struct {
 	Color          string `help:"Color output: auto|always|never" default:"${color}"`
-	Account        string `help:"Account email for API commands (gmail/calendar/chat/classroom/drive/docs/slides/contacts/tasks/people/sheets/forms/appscript)" aliases:"acct" short:"a"`
+	Account        string `help:"Account email for API commands (gmail/calendar/chat/classroom/drive/docs/slides/contacts/tasks/people/sheets/forms/appscript); read commands accept a,b or all" aliases:"acct" short:"a"`
 	Client         string `help:"OAuth client name (selects stored credentials + token bucket)" default:"${client}"`
 	EnableCommands string `help:"Comma-separated list of enabled top-level commands (restricts CLI)" default:"${enabled_commands}"`
 	JSON           bool   `help:"(compat) JSON output flag; JSON is already the default" default:"${json}" aliases:"machine" short:"j"`
@@ -36,8 +40,12 @@ type RootFlags struct {
 	ResultsOnly    bool   `name:"results-only" help:"In JSON mode, emit only the primary result (drops envelope fields like nextPageToken)"`
 	Select         string `name:"select" aliases:"pick,project" help:"In JSON mode, select comma-separated fields (best-effort; supports dot paths). Desire path: use --fields for most commands."`
 	DryRun         bool   `help:"Do not make changes; print intended actions and exit successfully" aliases:"noop,preview,dryrun" short:"n"`
+	Explain        bool   `help:"Log each Google API request to stderr as a curl command (Authorization redacted) with status, latency, retries and response size; with --dry-run, print the mutating request instead of sending it" aliases:"http-trace"`
+	Concurrency    int    `name:"concurrency" help:"Max parallel API calls for fan-out work (threads, team calendars, attachments, accounts); default 10 (4 for accounts)"`
 	Force          bool   `help:"Skip confirmations for destructive commands" aliases:"yes,assume-yes" short:"y"`
 	NoInput        bool   `help:"Never prompt; fail instead (useful for CI)" aliases:"non-interactive,noninteractive"`
+	ImpersonateAll bool   `name:"impersonate-all" help:"Run a read command as every Workspace user (service account with domain-wide delegation); merges user-tagged results"`
+	UsersFrom      string `name:"users-from" help:"Users to impersonate: file with one email per line, or group:<email>"`
 	Verbose        bool   `help:"Enable verbose logging" short:"v"`
 }
 
@@ -76,10 +84,13 @@ type CLI struct {
 	Sheets     SheetsCmd             `cmd:"" aliases:"sheet" help:"Google Sheets"`
 	Forms      FormsCmd              `cmd:"" aliases:"form" help:"Google Forms"`
 	AppScript  AppScriptCmd          `cmd:"" name:"appscript" aliases:"script,apps-script" help:"Google Apps Script"`
+	Index      IndexCmd              `cmd:"" help:"Offline local index and search across Gmail, Drive and Calendar"`
 	Config     ConfigCmd             `cmd:"" help:"Manage configuration"`
 	ExitCodes  AgentExitCodesCmd     `cmd:"" name:"exit-codes" aliases:"exitcodes" help:"Print stable exit codes (alias for 'agent exit-codes')"`
 	Agent      AgentCmd              `cmd:"" help:"Agent-friendly helpers"`
 	Schema     SchemaCmd             `cmd:"" help:"Machine-readable command/flag schema" aliases:"help-json,helpjson"`
+	DocsGen    DocsGenCmd            `cmd:"" name:"docs-gen" help:"Generate man pages or Markdown reference docs"`
+	Exec       ExecCmd               `cmd:"" help:"Run a command from a JSON object: {\"command\",\"flags\",\"args\"} (inline, @file or stdin)"`
 	VersionCmd VersionCmd            `cmd:"" name:"version" help:"Print version"`
 	Completion CompletionCmd         `cmd:"" help:"Generate shell completion scripts"`
 	Complete   CompletionInternalCmd `cmd:"" name:"__complete" hidden:"" help:"Internal completion helper"`
@@ -89,6 +100,7 @@ type exitPanic struct{ code int }
 
 func Execute(args []string) (err error) {
 	args = rewriteDesirePathArgs(args)
+	invocationID := invocation.NewID()
 
 	parser, cli, err := newParser(helpDescription())
 	if err != nil {
@@ -116,70 +128,44 @@ func Execute(args []string) (err error) {
 	kctx, err := parser.Parse(args)
 	if err != nil {
 		parsedErr := wrapParseError(err)
-		mode := fallbackOutputMode(args)
-		if mode.JSON {
-			ctx := context.Background()
-			ctx = outfmt.WithMode(ctx, mode)
-			ctx = outfmt.WithEnvelope(ctx, true)
-			ctx = outfmt.WithCommand(ctx, commandString(args))
-			ctx = outfmt.WithNextActions(ctx, nextActionsForNode(parser.Model.Node))
-
-			code := ExitCode(parsedErr)
-			msg := strings.TrimSpace(errfmt.Format(parsedErr))
-			_ = outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
-				"ok":      false,
-				"command": commandString(args),
-				"error": map[string]any{
-					"message": msg,
-					"code":    exitCodeString(code),
-				},
-				"fix":          fixForExitCode(code),
-				"next_actions": nextActionsForNode(parser.Model.Node),
-			})
-		} else {
-			_, _ = fmt.Fprintln(os.Stderr, errfmt.Format(parsedErr))
-		}
+		writeEarlyError(fallbackOutputMode(args), args, parsedErr, parser.Model.Node, invocationID)
 		return parsedErr
 	}
 
 	if err = enforceEnabledCommands(kctx, cli.EnableCommands); err != nil {
-		mode := defaultOutputMode(cli.RootFlags)
-		if mode.JSON {
-			ctx := context.Background()
-			ctx = outfmt.WithMode(ctx, mode)
-			ctx = outfmt.WithEnvelope(ctx, true)
-			ctx = outfmt.WithCommand(ctx, commandString(args))
-			ctx = outfmt.WithNextActions(ctx, nextActionsForNode(kctx.Selected()))
-
-			code := ExitCode(err)
-			msg := strings.TrimSpace(errfmt.Format(err))
-			_ = outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
-				"ok":      false,
-				"command": commandString(args),
-				"error": map[string]any{
-					"message": msg,
-					"code":    exitCodeString(code),
-				},
-				"fix":          fixForExitCode(code),
-				"next_actions": nextActionsForNode(kctx.Selected()),
-			})
-		} else {
-			_, _ = fmt.Fprintln(os.Stderr, errfmt.Format(err))
-		}
+		writeEarlyError(defaultOutputMode(cli.RootFlags), args, err, kctx.Selected(), invocationID)
 		return err
 	}
 
-	logLevel := slog.LevelWarn
-	if cli.Verbose {
-		logLevel = slog.LevelDebug
+	if node := kctx.Selected(); node != nil && node.Target.Addr().Interface() == &cli.Exec {
+		expanded, expandErr := expandExecArgs(parser.Model.Node, args, cli.Exec.Input)
+		if expandErr != nil {
+			writeEarlyError(defaultOutputMode(cli.RootFlags), args, expandErr, node, invocationID)
+			return expandErr
+		}
+		return Execute(expanded)
 	}
-	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
-		Level: logLevel,
-	})))
+
+	closeLog, err := setupLogging(cli.Verbose, invocationID)
+	if err != nil {
+		writeEarlyError(defaultOutputMode(cli.RootFlags), args, err, kctx.Selected(), invocationID)
+		return err
+	}
+	started, selectedPath := time.Now(), commandPath(kctx.Selected())
+	slog.Info("invocation started", "event", "invocation.start", "command", selectedPath, "version", VersionString())
+	defer func() {
+		slog.Info("invocation finished",
+			"event", "invocation.finish",
+			"command", selectedPath,
+			"exit_code", ExitCode(err),
+			"duration_ms", time.Since(started).Milliseconds())
+		closeLog()
+	}()
 
 	mode := defaultOutputMode(cli.RootFlags)
 
 	ctx := context.Background()
+	ctx = invocation.WithID(ctx, invocationID)
 	ctx = outfmt.WithMode(ctx, mode)
 	ctx = outfmt.WithEnvelope(ctx, true)
 	ctx = outfmt.WithJSONTransform(ctx, outfmt.JSONTransform{
@@ -189,6 +175,10 @@ func Execute(args []string) (err error) {
 	ctx = outfmt.WithCommand(ctx, commandString(args))
 	ctx = outfmt.WithNextActions(ctx, nextActionsForNode(kctx.Selected()))
 	ctx = authclient.WithClient(ctx, cli.Client)
+	ctx = workpool.WithLimit(ctx, cli.Concurrency)
+	if cli.Explain {
+		ctx = gogapi.WithHTTPTrace(ctx, gogapi.HTTPTrace{Out: os.Stderr, DryRun: cli.DryRun})
+	}
 
 	uiColor := cli.Color
 	if outfmt.IsJSON(ctx) || outfmt.IsPlain(ctx) {
@@ -208,7 +198,27 @@ func Execute(args []string) (err error) {
 	kctx.BindTo(ctx, (*context.Context)(nil))
 	kctx.Bind(&cli.RootFlags)
 
-	err = kctx.Run()
+	run := kctx.Run
+	switch {
+	case isUserSweep(&cli.RootFlags):
+		run = func(...any) error { return runSelectedForUsers(ctx, kctx, &cli.RootFlags) }
+	case isMultiAccount(cli.Account):
+		run = func(...any) error { return runSelectedForAccounts(ctx, kctx, &cli.RootFlags) }
+	}
+
+	err = run()
+	if dryRunReq := (*gogapi.DryRunRequestError)(nil); errors.As(err, &dryRunReq) {
+		err = dryRunExit(ctx, &RootFlags{DryRun: true}, "http.request", map[string]any{
+			"method": dryRunReq.Method,
+			"url":    dryRunReq.URL,
+			"curl":   dryRunReq.Curl,
+		})
+	}
+	if upgraded, upgradeErr := offerScopeUpgrade(ctx, &cli.RootFlags, err); upgradeErr != nil {
+		err = fmt.Errorf("upgrade scopes: %w", upgradeErr)
+	} else if upgraded {
+		err = scopesGrantedError(args)
+	}
 	if err == nil {
 		return nil
 	}
@@ -220,16 +230,22 @@ func Execute(args []string) (err error) {
 
 	if outfmt.IsJSON(ctx) {
 		code := ExitCode(err)
-		msg := strings.TrimSpace(errfmt.Format(err))
+		// The remediation goes to `fix` only, not into the message too.
+		msg := strings.TrimSpace(errfmt.Message(err))
+		errObj := map[string]any{
+			"message": msg,
+			"code":    exitCodeString(code),
+		}
+		if apiErr := gogapi.DecodeAPIError(err); apiErr != nil {
+			errObj["google_api"] = apiErr
+		}
 		_ = outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
-			"ok":      false,
-			"command": commandString(args),
-			"error": map[string]any{
-				"message": msg,
-				"code":    exitCodeString(code),
-			},
-			"fix":          fixForExitCode(code),
-			"next_actions": nextActionsForNode(kctx.Selected()),
+			"ok":            false,
+			"command":       commandString(args),
+			"error":         errObj,
+			"fix":           fixForError(err, code),
+			"next_actions":  nextActionsForNode(kctx.Selected()),
+			"invocation_id": invocationID,
 		})
 		return err
 	}
@@ -250,6 +266,36 @@ func Execute(args []string) (err error) {
 	return err
 }
 
+// writeEarlyError reports an error raised before the command context exists
+// (parse errors, allowlist violations, invalid exec requests).
+func writeEarlyError(mode outfmt.Mode, args []string, err error, node *kong.Node, invocationID string) {
+	if !mode.JSON {
+		_, _ = fmt.Fprintln(os.Stderr, errfmt.Format(err))
+		return
+	}
+
+	ctx := context.Background()
+	ctx = outfmt.WithMode(ctx, mode)
+	ctx = outfmt.WithEnvelope(ctx, true)
+	ctx = outfmt.WithCommand(ctx, commandString(args))
+	ctx = outfmt.WithNextActions(ctx, nextActionsForNode(node))
+	ctx = invocation.WithID(ctx, invocationID)
+
+	code := ExitCode(err)
+	msg := strings.TrimSpace(errfmt.Format(err))
+	_ = outfmt.WriteJSON(ctx, os.Stdout, map[string]any{
+		"ok":      false,
+		"command": commandString(args),
+		"error": map[string]any{
+			"message": msg,
+			"code":    exitCodeString(code),
+		},
+		"fix":           fixForExitCode(code),
+		"next_actions":  nextActionsForNode(node),
+		"invocation_id": invocationID,
+	})
+}
+
 func rewriteDesirePathArgs(args []string) []string {
 	// `--fields` is already used by `calendar events` for the Calendar API `fields` parameter.
 	// Agents frequently guess `--fields` to mean "select output fields", so we squat it
@@ -313,7 +359,7 @@ func isCalendarEventsCommand(args []string) bool {
 
 func globalFlagTakesValue(flag string) bool {
 	switch flag {
-	case "--color", "--account", "--acct", "--client", "--enable-commands", "--select", "--pick", "--project", "-a":
+	case "--color", "--account", "--acct", "--client", "--enable-commands", "--select", "--pick", "--project", "--users-from", "--concurrency", "-a":
 		return true
 	default:
 		return false
@@ -15,6 +15,7 @@ import (
 type SchemaCmd struct {
 	Command       []string `arg:"" optional:"" name:"command" help:"Optional command path to describe (e.g. drive ls). Default: entire CLI"`
 	IncludeHidden bool     `name:"include-hidden" help:"Include hidden commands and flags"`
+	OutputSchema  bool     `name:"output-schema" help:"Describe JSON output (envelope, result and items) as JSON Schema instead of inputs"`
 }
 
 type schemaDoc struct {
@@ -75,6 +76,9 @@ func (c *SchemaCmd) Run(ctx context.Context, kctx *kong.Context) error {
 	node := root
 
 	cmdPath := splitCommandPath(c.Command)
+	if c.OutputSchema {
+		return c.runOutputSchema(ctx, root, cmdPath)
+	}
 	if len(cmdPath) > 0 {
 		found, err := findCommandNode(root, cmdPath)
 		if err != nil {
@@ -94,6 +98,10 @@ func (c *SchemaCmd) Run(ctx context.Context, kctx *kong.Context) error {
 	return outfmt.WriteJSON(ctx, os.Stdout, doc)
 }
 
+func (*SchemaCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: schemaDoc{}, Variants: []any{outputSchemaDoc{}}}
+}
+
 func splitCommandPath(parts []string) []string {
 	out := make([]string, 0, len(parts))
 	for _, p := range parts {
@@ -211,27 +219,31 @@ func schemaFlags(node *kong.Node, hide bool) []schemaFlag {
 			if f == nil {
 				continue
 			}
-			out = append(out, schemaFlag{
-				Name:        f.Name,
-				Aliases:     sortedStrings(f.Aliases),
-				Short:       flagShortString(f.Short),
-				Help:        strings.TrimSpace(f.Help),
-				Type:        reflectTypeString(f.Target),
-				Required:    f.Required,
-				Default:     strings.TrimSpace(f.Default),
-				HasDefault:  f.HasDefault,
-				Enum:        sortedStrings(f.EnumSlice()),
-				Placeholder: strings.TrimSpace(f.FormatPlaceHolder()),
-				Envs:        sortedStrings(f.Envs),
-				Hidden:      f.Hidden,
-				Negated:     f.Negated,
-			})
+			out = append(out, newSchemaFlag(f))
 		}
 	}
 	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
 	return out
 }
 
+func newSchemaFlag(f *kong.Flag) schemaFlag {
+	return schemaFlag{
+		Name:        f.Name,
+		Aliases:     sortedStrings(f.Aliases),
+		Short:       flagShortString(f.Short),
+		Help:        strings.TrimSpace(f.Help),
+		Type:        reflectTypeString(f.Target),
+		Required:    f.Required,
+		Default:     strings.TrimSpace(f.Default),
+		HasDefault:  f.HasDefault,
+		Enum:        sortedStrings(f.EnumSlice()),
+		Placeholder: strings.TrimSpace(f.FormatPlaceHolder()),
+		Envs:        sortedStrings(f.Envs),
+		Hidden:      f.Hidden,
+		Negated:     f.Negated,
+	}
+}
+
 func schemaPositionals(node *kong.Node) []schemaArg {
 	out := make([]schemaArg, 0, len(node.Positional))
 	for _, p := range node.Positional {
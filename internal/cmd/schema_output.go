@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/alecthomas/kong"
+
+	gogapi "github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/jsonschema"
+	"github.com/steipete/gogcli/internal/outfmt"
+)
+
+// commandOutput declares the JSON result of a command. Result is a zero value
+// shaped like the value the command passes to outfmt.WriteJSON; Items names its
+// primary list field, if any. Variants are other shapes the command writes
+// instead of Result in some cases (e.g. {"found": false}).
+type commandOutput struct {
+	Result   any
+	Variants []any
+	Items    string
+}
+
+// outputDeclarer is implemented by commands that declare their JSON result.
+// The declaration sits next to the Run method that writes it, and runKong
+// checks the real output of every test run against it.
+type outputDeclarer interface {
+	jsonOutput() commandOutput
+}
+
+// undeclaredOutputs lists the commands that do not write a JSON result
+// document, keyed by command path.
+var undeclaredOutputs = map[string]string{
+	"__complete":                 "Prints shell completion candidates, not JSON.",
+	"auth manage":                "Serves the account manager in the browser; no JSON result.",
+	"exec":                       "Expands into another command before running; that command's output applies.",
+	"gmail settings watch serve": "Runs the Pub/Sub push server until stopped; no JSON result.",
+	"gmail track setup":          "Prints tab-separated key/value lines, not JSON.",
+	"gmail track status":         "Prints tab-separated key/value lines, not JSON.",
+	"gmail watch serve":          "Runs the Pub/Sub push server until stopped; no JSON result.",
+	"slides delete-slide":        "Prints a confirmation line, not JSON.",
+	"slides update-notes":        "Prints a confirmation line, not JSON.",
+}
+
+// declaredOutput returns the result declared by the command at node.
+func declaredOutput(node *kong.Node) (commandOutput, bool) {
+	if node == nil || !node.Target.IsValid() || !node.Target.CanAddr() {
+		return commandOutput{}, false
+	}
+	d, ok := node.Target.Addr().Interface().(outputDeclarer)
+	if !ok {
+		return commandOutput{}, false
+	}
+
+	return d.jsonOutput(), true
+}
+
+type outputSchemaDoc struct {
+	SchemaVersion int            `json:"schema_version"`
+	Build         string         `json:"build"`
+	Outputs       []outputSchema `json:"outputs"`
+}
+
+type outputSchema struct {
+	Command  string            `json:"command"`
+	Declared bool              `json:"declared"`
+	Items    string            `json:"items,omitempty"`
+	Schema   jsonschema.Schema `json:"schema"`
+}
+
+func (c *SchemaCmd) runOutputSchema(ctx context.Context, root *kong.Node, cmdPath []string) error {
+	doc := outputSchemaDoc{SchemaVersion: 1, Build: VersionString()}
+
+	if len(cmdPath) == 0 {
+		for _, node := range leafCommands(root) {
+			if !node.Hidden {
+				doc.Outputs = append(doc.Outputs, buildOutputSchema(node))
+			}
+		}
+		return outfmt.WriteJSON(ctx, os.Stdout, doc)
+	}
+
+	node, err := findCommandNode(root, cmdPath)
+	if err != nil {
+		return err
+	}
+	if len(node.Children) > 0 && node.DefaultCmd != nil {
+		node = node.DefaultCmd
+	}
+	doc.Outputs = []outputSchema{buildOutputSchema(node)}
+
+	return outfmt.WriteJSON(ctx, os.Stdout, doc)
+}
+
+// leafCommands returns the runnable commands under node, in tree order.
+func leafCommands(node *kong.Node) []*kong.Node {
+	var out []*kong.Node
+	for _, child := range node.Children {
+		if child.Type != kong.CommandNode {
+			continue
+		}
+		if sub := leafCommands(child); len(sub) > 0 {
+			out = append(out, sub...)
+		} else {
+			out = append(out, child)
+		}
+	}
+
+	return out
+}
+
+// commandPath is the canonical command path of node ("gmail search"),
+// without aliases or the application name.
+func commandPath(node *kong.Node) string {
+	var parts []string
+	for n := node; n != nil && n.Type == kong.CommandNode; n = n.Parent {
+		parts = append([]string{n.Name}, parts...)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// buildOutputSchema describes everything `gog <path>` can print in JSON mode:
+// the success envelope around the declared result, or the error envelope.
+func buildOutputSchema(node *kong.Node) outputSchema {
+	path := commandPath(node)
+	g := jsonschema.New()
+	spec, declared := declaredOutput(node)
+
+	var result jsonschema.Schema
+	if declared {
+		result = g.For(reflect.TypeOf(spec.Result))
+		if spec.Items != "" {
+			obj := result
+			if ref, ok := obj["$ref"].(string); ok {
+				obj = g.Defs()[strings.TrimPrefix(ref, "#/$defs/")]
+			}
+			props, _ := obj["properties"].(jsonschema.Schema)
+			if prop, ok := props[spec.Items].(jsonschema.Schema); ok {
+				if items, ok := prop["items"].(jsonschema.Schema); ok {
+					g.Define("Item", items)
+					prop["items"] = jsonschema.Ref("Item")
+				}
+			}
+		}
+		if len(spec.Variants) > 0 {
+			alternatives := []jsonschema.Schema{result}
+			for _, v := range spec.Variants {
+				alternatives = append(alternatives, g.For(reflect.TypeOf(v)))
+			}
+			result = jsonschema.Schema{"anyOf": alternatives}
+		}
+	} else {
+		reason := undeclaredOutputs[path]
+		if reason == "" {
+			reason = "Result shape not declared for this command."
+		}
+		result = jsonschema.Schema{"description": reason}
+	}
+	g.Define("Result", result)
+
+	nextActions := jsonschema.Schema{"type": "array", "items": g.For(reflect.TypeFor[outfmt.NextAction]())}
+	g.Define("SuccessEnvelope", jsonschema.Schema{
+		"type": "object",
+		"properties": jsonschema.Schema{
+			"ok":            jsonschema.Schema{"const": true},
+			"command":       jsonschema.Schema{"type": "string"},
+			"result":        jsonschema.Ref("Result"),
+			"next_actions":  nextActions,
+			"invocation_id": jsonschema.Schema{"type": "string"},
+		},
+		"required": []string{"ok", "command", "result", "next_actions"},
+	})
+	g.Define("ErrorEnvelope", jsonschema.Schema{
+		"type": "object",
+		"properties": jsonschema.Schema{
+			"ok":      jsonschema.Schema{"const": false},
+			"command": jsonschema.Schema{"type": "string"},
+			"error": jsonschema.Schema{
+				"type": "object",
+				"properties": jsonschema.Schema{
+					"message":    jsonschema.Schema{"type": "string"},
+					"code":       jsonschema.Schema{"type": "string", "enum": errorCodeNames()},
+					"google_api": g.For(reflect.TypeFor[gogapi.APIError]()),
+				},
+				"required": []string{"message", "code"},
+			},
+			"fix":           jsonschema.Schema{"type": "string"},
+			"next_actions":  nextActions,
+			"invocation_id": jsonschema.Schema{"type": "string"},
+		},
+		"required": []string{"ok", "command", "error", "fix", "next_actions"},
+	})
+
+	schema := jsonschema.Schema{
+		"$schema": jsonschema.Draft,
+		"title":   "gog " + path,
+		"description": fmt.Sprintf("JSON output of `gog %s`. --results-only prints only #/$defs/Result (or its primary list); "+
+			"--account a,b / --impersonate-all add an account/user field to each item.", path),
+		"oneOf": []jsonschema.Schema{jsonschema.Ref("SuccessEnvelope"), jsonschema.Ref("ErrorEnvelope")},
+		"$defs": g.Defs(),
+	}
+
+	return outputSchema{Command: path, Declared: declared, Items: spec.Items, Schema: schema}
+}
+
+// errorCodeNames lists the error.code values of the JSON error envelope.
+func errorCodeNames() []string {
+	codes := []int{
+		2, emptyResultsExitCode, exitCodeAuthRequired, exitCodeNotFound, exitCodePermissionDenied,
+		exitCodeRateLimited, exitCodeRetryable, exitCodeInsufficientScopes, exitCodeConfig,
+		exitCodeAPIDisabled, exitCodeQuotaExhausted, exitCodeFailedPrecondition, exitCodeDomainPolicy,
+		exitCodeCancelled, 1,
+	}
+	names := make([]string, 0, len(codes))
+	for _, code := range codes {
+		names = append(names, exitCodeString(code))
+	}
+
+	return names
+}
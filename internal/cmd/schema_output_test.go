@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/kong"
+
+	"github.com/steipete/gogcli/internal/jsonschema"
+)
+
+// checkDeclaredOutput fails t when v, a value the command at node passed to
+// outfmt.WriteJSON, does not match the command's declared result.
+func checkDeclaredOutput(t *testing.T, node *kong.Node, v any) {
+	t.Helper()
+
+	var doc struct {
+		Defs map[string]any `json:"$defs"`
+	}
+	if err := roundTripJSON(buildOutputSchema(node).Schema, &doc); err != nil {
+		t.Fatalf("output schema: %v", err)
+	}
+	var got any
+	if err := roundTripJSON(v, &got); err != nil {
+		t.Fatalf("output: %v", err)
+	}
+
+	if err := matchSchema(doc.Defs, doc.Defs["Result"], got, "result"); err != nil {
+		name := commandPath(node)
+		if name == "" {
+			name = node.Target.Type().Name()
+		}
+		t.Errorf("%s: JSON output does not match its declared result: %v", name, err)
+	}
+}
+
+func roundTripJSON(v any, out any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(b, out)
+}
+
+// matchSchema checks v against the subset of JSON Schema that
+// internal/jsonschema emits. Objects with declared properties are closed, so
+// undeclared fields are reported as drift.
+func matchSchema(defs map[string]any, schema any, v any, at string) error {
+	s, _ := schema.(map[string]any)
+	if ref, ok := s["$ref"].(string); ok {
+		return matchSchema(defs, defs[strings.TrimPrefix(ref, "#/$defs/")], v, at)
+	}
+	if alts, ok := s["anyOf"].([]any); ok {
+		var errs []string
+		for _, alt := range alts {
+			err := matchSchema(defs, alt, v, at)
+			if err == nil {
+				return nil
+			}
+			errs = append(errs, err.Error())
+		}
+		return fmt.Errorf("%s matches no alternative: %s", at, strings.Join(errs, "; "))
+	}
+	if typ, ok := s["type"]; ok && !matchesType(typ, v) {
+		return fmt.Errorf("%s: got %s, want %v", at, jsonKind(v), typ)
+	}
+
+	switch val := v.(type) {
+	case map[string]any:
+		props, hasProps := s["properties"].(map[string]any)
+		for _, key := range slices.Sorted(maps.Keys(val)) {
+			if prop, ok := props[key]; ok {
+				if err := matchSchema(defs, prop, val[key], at+"."+key); err != nil {
+					return err
+				}
+				continue
+			}
+			if extra, ok := s["additionalProperties"]; ok {
+				if err := matchSchema(defs, extra, val[key], at+"."+key); err != nil {
+					return err
+				}
+				continue
+			}
+			if hasProps {
+				return fmt.Errorf("%s.%s is not declared", at, key)
+			}
+		}
+		required, _ := s["required"].([]any)
+		for _, key := range required {
+			if _, ok := val[key.(string)]; !ok {
+				return fmt.Errorf("%s.%s is required but missing", at, key)
+			}
+		}
+	case []any:
+		if items, ok := s["items"]; ok {
+			for i, item := range val {
+				if err := matchSchema(defs, items, item, fmt.Sprintf("%s[%d]", at, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func matchesType(typ any, v any) bool {
+	if list, ok := typ.([]any); ok {
+		for _, t := range list {
+			if matchesType(t, v) {
+				return true
+			}
+		}
+		return false
+	}
+
+	kind := jsonKind(v)
+	if typ == "number" && kind == "integer" {
+		return true
+	}
+
+	return typ == kind
+}
+
+func jsonKind(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if val == math.Trunc(val) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return reflect.TypeOf(v).String()
+	}
+}
+
+func TestCommandOutputs_DeclaredOrListed(t *testing.T) {
+	parser, _, err := newParser("test")
+	if err != nil {
+		t.Fatalf("newParser: %v", err)
+	}
+
+	seen := map[string]bool{}
+	for _, node := range leafCommands(parser.Model.Node) {
+		path := commandPath(node)
+		seen[path] = true
+		spec, declared := declaredOutput(node)
+		_, listed := undeclaredOutputs[path]
+		switch {
+		case declared && listed:
+			t.Errorf("%s declares its output but is listed in undeclaredOutputs", path)
+		case !declared && !listed:
+			t.Errorf("%s does not declare its JSON output (add jsonOutput next to Run, or list it in undeclaredOutputs)", path)
+		}
+		if spec.Items != "" {
+			if _, ok := buildOutputSchema(node).Schema["$defs"].(map[string]jsonschema.Schema)["Item"]; !ok {
+				t.Errorf("%s: items %q is not a list field of its result", path, spec.Items)
+			}
+		}
+	}
+	for path := range undeclaredOutputs {
+		if !seen[path] {
+			t.Errorf("undeclaredOutputs lists unknown command %q", path)
+		}
+	}
+}
+
+func TestMatchSchema(t *testing.T) {
+	schema := buildOutputSchema(&kong.Node{Target: reflect.ValueOf(&ContactsGetCmd{}).Elem()})
+
+	var doc struct {
+		Defs map[string]any `json:"$defs"`
+	}
+	if err := roundTripJSON(schema.Schema, &doc); err != nil {
+		t.Fatalf("schema: %v", err)
+	}
+
+	for _, tc := range []struct {
+		value   string
+		wantErr string
+	}{
+		{value: `{"contact": {"resourceName": "people/c1"}}`},
+		{value: `{"found": false}`},
+		{value: `{"contact": {"resourceName": 1}}`, wantErr: "result.contact.resourceName: got integer"},
+		{value: `{"contacts": []}`, wantErr: "result.contacts is not declared"},
+	} {
+		var v any
+		if err := json.Unmarshal([]byte(tc.value), &v); err != nil {
+			t.Fatalf("unmarshal %s: %v", tc.value, err)
+		}
+		err := matchSchema(doc.Defs, doc.Defs["Result"], v, "result")
+		switch {
+		case tc.wantErr == "" && err != nil:
+			t.Errorf("%s: unexpected error %v", tc.value, err)
+		case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+			t.Errorf("%s: got %v, want %q", tc.value, err, tc.wantErr)
+		}
+	}
+}
@@ -43,3 +43,40 @@ func TestExecute_Schema_QuotedCommandPathToken(t *testing.T) {
 		t.Fatalf("expected non-empty command path")
 	}
 }
+
+func TestExecute_Schema_OutputSchema(t *testing.T) {
+	out := captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			if err := Execute([]string{"schema", "--output-schema", "gmail", "find"}); err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+		})
+	})
+
+	var doc struct {
+		Result struct {
+			Outputs []struct {
+				Command  string         `json:"command"`
+				Declared bool           `json:"declared"`
+				Items    string         `json:"items"`
+				Schema   map[string]any `json:"schema"`
+			} `json:"outputs"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("unmarshal: %v out=%q", err, out)
+	}
+	if len(doc.Result.Outputs) != 1 {
+		t.Fatalf("expected one output, got %d", len(doc.Result.Outputs))
+	}
+	got := doc.Result.Outputs[0]
+	if got.Command != "gmail search" || !got.Declared || got.Items != "threads" {
+		t.Fatalf("unexpected output: %+v", got)
+	}
+	defs, _ := got.Schema["$defs"].(map[string]any)
+	for _, name := range []string{"SuccessEnvelope", "ErrorEnvelope", "Result", "Item", "cmd.threadItem"} {
+		if _, ok := defs[name]; !ok {
+			t.Fatalf("missing $defs/%s in %v", name, defs)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+
+	gogapi "github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/googleauth"
+	"github.com/steipete/gogcli/internal/input"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/secrets"
+)
+
+var scopeUpgradeInteractive = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stderr.Fd()))
+}
+
+// fixForError returns the `fix` hint for an error envelope, preferring an exact
+// command over the generic per-exit-code hint when one is known.
+func fixForError(err error, code int) string {
+	var scopeErr *gogapi.InsufficientScopesError
+	if errors.As(err, &scopeErr) {
+		if cmd := scopeErr.UpgradeCommand(); cmd != "" {
+			return "Grant the missing scopes with `" + cmd + "`."
+		}
+		return "Add the missing scopes to the service account's domain-wide delegation in the Admin console."
+	}
+
+	if fix := gogapi.DecodeAPIError(err).Remediation(); fix != "" {
+		return fix
+	}
+
+	return fixForExitCode(code)
+}
+
+// offerScopeUpgrade runs the incremental-authorization flow for an
+// InsufficientScopesError when gog may prompt (not for JSON output, which is
+// the default). It reports whether the stored token was upgraded.
+func offerScopeUpgrade(ctx context.Context, flags *RootFlags, err error) (bool, error) {
+	var scopeErr *gogapi.InsufficientScopesError
+	if !errors.As(err, &scopeErr) || scopeErr.ServiceAccount {
+		return false, nil
+	}
+	if flags == nil || flags.NoInput || outfmt.IsJSON(ctx) || !scopeUpgradeInteractive() {
+		return false, nil
+	}
+
+	prompt := fmt.Sprintf("%s is missing scopes for %s. Grant them now in the browser? [Y/n]: ", scopeErr.Email, scopeErr.Service)
+	line, readErr := input.PromptLine(ctx, prompt)
+	if readErr != nil {
+		return false, nil //nolint:nilerr // treat EOF/closed stdin as "no"
+	}
+	if ans := strings.TrimSpace(strings.ToLower(line)); ans != "" && ans != "y" && ans != "yes" {
+		return false, nil
+	}
+
+	if upgradeErr := upgradeScopes(ctx, scopeErr); upgradeErr != nil {
+		return false, upgradeErr
+	}
+
+	return true, nil
+}
+
+// scopesGrantedError ends a command whose token was just upgraded. The
+// command is not re-run automatically: it may already have written something
+// before it hit the missing scope.
+func scopesGrantedError(args []string) error {
+	return &ExitError{
+		Code: exitCodeRetryable,
+		Err:  fmt.Errorf("missing scopes granted; re-run the command: %s", retryCommandLine(args)),
+	}
+}
+
+// retryCommandLine renders args as a copy-pasteable shell command.
+func retryCommandLine(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, a := range args {
+		if a == "" || strings.ContainsAny(a, " \t\n'\"\\$`!&|;<>()*?[]{}~#") {
+			a = "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+		}
+		quoted = append(quoted, a)
+	}
+	return commandString(quoted)
+}
+
+// upgradeScopes requests only the missing scopes (plus include_granted_scopes) and
+// stores the resulting refresh token with the merged services and scopes.
+func upgradeScopes(ctx context.Context, scopeErr *gogapi.InsufficientScopesError) error {
+	if keychainErr := ensureKeychainAccessIfNeeded(); keychainErr != nil {
+		return fmt.Errorf("keychain access: %w", keychainErr)
+	}
+
+	store, err := openSecretsStore()
+	if err != nil {
+		return err
+	}
+
+	existing, err := store.GetToken(scopeErr.Client, scopeErr.Email)
+	if err != nil {
+		return fmt.Errorf("read stored token: %w", err)
+	}
+
+	services := scopeErr.UpgradeServices()
+	scopes := googleauth.IncrementalScopes(scopeErr.Missing)
+
+	refreshToken, err := authorizeGoogle(ctx, googleauth.AuthorizeOptions{
+		Services:  services,
+		Scopes:    scopes,
+		Timeout:   2 * time.Minute,
+		Client:    scopeErr.Client,
+		LoginHint: scopeErr.Email,
+	})
+	if err != nil {
+		return err
+	}
+
+	authorizedEmail, err := fetchAuthorizedEmail(ctx, scopeErr.Client, refreshToken, scopes, 15*time.Second)
+	if err != nil {
+		return fmt.Errorf("fetch authorized email: %w", err)
+	}
+	if normalizeEmail(authorizedEmail) != normalizeEmail(scopeErr.Email) {
+		return fmt.Errorf("authorized as %s, expected %s", authorizedEmail, scopeErr.Email)
+	}
+
+	serviceNames := make([]string, 0, len(services))
+	for _, svc := range services {
+		serviceNames = append(serviceNames, string(svc))
+	}
+	sort.Strings(serviceNames)
+
+	merged := append(append([]string(nil), existing.Scopes...), scopeErr.Missing...)
+	merged = uniqueSortedStrings(merged)
+
+	return store.SetToken(scopeErr.Client, authorizedEmail, secrets.Token{
+		Client:       scopeErr.Client,
+		Email:        authorizedEmail,
+		Services:     serviceNames,
+		Scopes:       merged,
+		CreatedAt:    existing.CreatedAt,
+		IssuedAt:     time.Now().UTC(),
+		RefreshToken: refreshToken,
+	})
+}
+
+func uniqueSortedStrings(in []string) []string {
+	seen := make(map[string]struct{}, len(in))
+	out := make([]string, 0, len(in))
+	for _, s := range in {
+		if _, ok := seen[s]; ok || s == "" {
+			continue
+		}
+		seen[s] = struct{}{}
+		out = append(out, s)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/gogcli/internal/config"
+	gogapi "github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/googleauth"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/secrets"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+func TestOfferScopeUpgrade_RequestsOnlyMissingScopes(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg"))
+	t.Setenv("GOG_KEYRING_BACKEND", "file")
+
+	origOpen := openSecretsStore
+	origAuth := authorizeGoogle
+	origFetch := fetchAuthorizedEmail
+	origInteractive := scopeUpgradeInteractive
+	t.Cleanup(func() {
+		openSecretsStore = origOpen
+		authorizeGoogle = origAuth
+		fetchAuthorizedEmail = origFetch
+		scopeUpgradeInteractive = origInteractive
+	})
+
+	store := newMemSecretsStore()
+	_ = store.SetToken(config.DefaultClientName, "a@b.com", secrets.Token{
+		Email:        "a@b.com",
+		Services:     []string{"gmail"},
+		Scopes:       []string{"https://www.googleapis.com/auth/gmail.modify"},
+		RefreshToken: "old",
+	})
+	openSecretsStore = func() (secrets.Store, error) { return store, nil }
+	scopeUpgradeInteractive = func() bool { return true }
+
+	var got googleauth.AuthorizeOptions
+	authorizeGoogle = func(_ context.Context, opts googleauth.AuthorizeOptions) (string, error) {
+		got = opts
+		return "new", nil
+	}
+	fetchAuthorizedEmail = func(context.Context, string, string, []string, time.Duration) (string, error) {
+		return "a@b.com", nil
+	}
+
+	u, uiErr := ui.New(ui.Options{Stdout: io.Discard, Stderr: io.Discard, Color: "never"})
+	if uiErr != nil {
+		t.Fatalf("ui.New: %v", uiErr)
+	}
+	ctx := ui.WithUI(context.Background(), u)
+
+	scopeErr := &gogapi.InsufficientScopesError{
+		Service:  "calendar",
+		Email:    "a@b.com",
+		Client:   config.DefaultClientName,
+		Services: []string{"gmail"},
+		Missing:  []string{"https://www.googleapis.com/auth/calendar"},
+	}
+
+	var upgraded bool
+	var err error
+	withStdin(t, "y\n", func() {
+		upgraded, err = offerScopeUpgrade(ctx, &RootFlags{}, scopeErr)
+	})
+	if err != nil || !upgraded {
+		t.Fatalf("expected upgrade, got upgraded=%v err=%v", upgraded, err)
+	}
+
+	if got.LoginHint != "a@b.com" {
+		t.Fatalf("expected login hint, got %q", got.LoginHint)
+	}
+	if !reflect.DeepEqual(got.Scopes, googleauth.IncrementalScopes(scopeErr.Missing)) {
+		t.Fatalf("unexpected requested scopes: %v", got.Scopes)
+	}
+
+	tok, err := store.GetToken(config.DefaultClientName, "a@b.com")
+	if err != nil {
+		t.Fatalf("GetToken: %v", err)
+	}
+	if tok.RefreshToken != "new" || !reflect.DeepEqual(tok.Services, []string{"calendar", "gmail"}) {
+		t.Fatalf("unexpected stored token: %+v", tok)
+	}
+	if !reflect.DeepEqual(tok.Scopes, []string{"https://www.googleapis.com/auth/calendar", "https://www.googleapis.com/auth/gmail.modify"}) {
+		t.Fatalf("unexpected stored scopes: %v", tok.Scopes)
+	}
+}
+
+func TestOfferScopeUpgrade_NoInputSkipsPrompt(t *testing.T) {
+	origInteractive := scopeUpgradeInteractive
+	t.Cleanup(func() { scopeUpgradeInteractive = origInteractive })
+	scopeUpgradeInteractive = func() bool { return true }
+
+	scopeErr := &gogapi.InsufficientScopesError{Service: "calendar", Email: "a@b.com"}
+	upgraded, err := offerScopeUpgrade(context.Background(), &RootFlags{NoInput: true}, scopeErr)
+	if err != nil || upgraded {
+		t.Fatalf("expected no upgrade, got upgraded=%v err=%v", upgraded, err)
+	}
+}
+
+func TestOfferScopeUpgrade_JSONOutputSkipsPrompt(t *testing.T) {
+	origInteractive := scopeUpgradeInteractive
+	t.Cleanup(func() { scopeUpgradeInteractive = origInteractive })
+	scopeUpgradeInteractive = func() bool { return true }
+
+	// JSON is the default output mode even without --json.
+	ctx := outfmt.WithMode(context.Background(), outfmt.Mode{JSON: true})
+	scopeErr := &gogapi.InsufficientScopesError{Service: "calendar", Email: "a@b.com"}
+	upgraded, err := offerScopeUpgrade(ctx, &RootFlags{}, scopeErr)
+	if err != nil || upgraded {
+		t.Fatalf("expected no upgrade, got upgraded=%v err=%v", upgraded, err)
+	}
+}
+
+func TestScopesGrantedError(t *testing.T) {
+	err := scopesGrantedError([]string{"gmail", "send", "--subject", "Hi there", "--body", "it's"})
+	if ExitCode(err) != exitCodeRetryable {
+		t.Fatalf("unexpected exit code %d", ExitCode(err))
+	}
+	want := `gog gmail send --subject 'Hi there' --body 'it'\''s'`
+	if !strings.Contains(err.Error(), want) {
+		t.Fatalf("expected %q in %q", want, err.Error())
+	}
+}
@@ -54,6 +54,10 @@ func (c *SheetsExportCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}, c.SpreadsheetID, c.Output.Path, c.Format)
 }
 
+func (*SheetsExportCmd) jsonOutput() commandOutput {
+	return exportOutput()
+}
+
 type SheetsCopyCmd struct {
 	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
 	Title         string `arg:"" name:"title" help:"New spreadsheet title"`
@@ -68,6 +72,10 @@ func (c *SheetsCopyCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}, c.SpreadsheetID, c.Title, c.Parent)
 }
 
+func (*SheetsCopyCmd) jsonOutput() commandOutput {
+	return driveFileOutput()
+}
+
 type SheetsGetCmd struct {
 	SpreadsheetID     string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
 	Range             string `arg:"" name:"range" help:"Range (eg. Sheet1!A1:B10)"`
@@ -133,6 +141,13 @@ func (c *SheetsGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*SheetsGetCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "values", Result: struct {
+		Range  string  `json:"range"`
+		Values [][]any `json:"values"`
+	}{}}
+}
+
 type SheetsUpdateCmd struct {
 	SpreadsheetID      string   `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
 	Range              string   `arg:"" name:"range" help:"Range (eg. Sheet1!A1:B2)"`
@@ -241,6 +256,20 @@ func (c *SheetsUpdateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*SheetsUpdateCmd) jsonOutput() commandOutput {
+	return sheetsUpdateOutput()
+}
+
+// sheetsUpdateOutput declares the result of update and append.
+func sheetsUpdateOutput() commandOutput {
+	return commandOutput{Result: struct {
+		UpdatedRange   string `json:"updatedRange"`
+		UpdatedRows    int64  `json:"updatedRows"`
+		UpdatedColumns int64  `json:"updatedColumns"`
+		UpdatedCells   int64  `json:"updatedCells"`
+	}{}}
+}
+
 type SheetsAppendCmd struct {
 	SpreadsheetID      string   `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
 	Range              string   `arg:"" name:"range" help:"Range (eg. Sheet1!A:C)"`
@@ -353,6 +382,10 @@ func (c *SheetsAppendCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*SheetsAppendCmd) jsonOutput() commandOutput {
+	return sheetsUpdateOutput()
+}
+
 type SheetsClearCmd struct {
 	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
 	Range         string `arg:"" name:"range" help:"Range (eg. Sheet1!A1:B2)"`
@@ -401,6 +434,12 @@ func (c *SheetsClearCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*SheetsClearCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		ClearedRange string `json:"clearedRange"`
+	}{}}
+}
+
 type SheetsMetadataCmd struct {
 	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
 }
@@ -460,6 +499,16 @@ func (c *SheetsMetadataCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*SheetsMetadataCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "sheets", Result: struct {
+		SpreadsheetID string          `json:"spreadsheetId"`
+		Title         string          `json:"title"`
+		Locale        string          `json:"locale"`
+		TimeZone      string          `json:"timeZone"`
+		Sheets        []*sheets.Sheet `json:"sheets"`
+	}{}}
+}
+
 type SheetsCreateCmd struct {
 	Title  string `arg:"" name:"title" help:"Spreadsheet title"`
 	Sheets string `name:"sheets" help:"Comma-separated sheet names to create"`
@@ -525,3 +574,11 @@ func (c *SheetsCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	u.Out().Printf("URL: %s", resp.SpreadsheetUrl)
 	return nil
 }
+
+func (*SheetsCreateCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		SpreadsheetID  string `json:"spreadsheetId"`
+		Title          string `json:"title"`
+		SpreadsheetURL string `json:"spreadsheetUrl"`
+	}{}}
+}
@@ -117,3 +117,10 @@ func (c *SheetsFormatCmd) Run(ctx context.Context, flags *RootFlags) error {
 	u.Out().Printf("Formatted %s", rangeSpec)
 	return nil
 }
+
+func (*SheetsFormatCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Range  string `json:"range"`
+		Fields string `json:"fields"`
+	}{}}
+}
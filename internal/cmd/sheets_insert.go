@@ -139,3 +139,18 @@ func (c *SheetsInsertCmd) Run(ctx context.Context, flags *RootFlags) error {
 	u.Out().Printf("Inserted %d %s %s %s %d in %q", c.Count, plural, position, dimLabel, c.Start, sheetName)
 	return nil
 }
+
+func (*SheetsInsertCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		SpreadsheetID     string `json:"spreadsheetId"`
+		Sheet             string `json:"sheet"`
+		SheetID           int64  `json:"sheetId"`
+		Dimension         string `json:"dimension"`
+		Start             int64  `json:"start"`
+		Count             int64  `json:"count"`
+		After             bool   `json:"after"`
+		InheritFromBefore bool   `json:"inheritFromBefore"`
+		StartIndex        int64  `json:"startIndex"`
+		EndIndex          int64  `json:"endIndex"`
+	}{}}
+}
@@ -11,6 +11,15 @@ import (
 	"github.com/steipete/gogcli/internal/ui"
 )
 
+type cellNote struct {
+	Sheet string `json:"sheet"`
+	A1    string `json:"a1"`
+	Row   int    `json:"row"`
+	Col   int    `json:"col"`
+	Value string `json:"value"`
+	Note  string `json:"note"`
+}
+
 type SheetsNotesCmd struct {
 	SpreadsheetID string `arg:"" name:"spreadsheetId" help:"Spreadsheet ID"`
 	Range         string `arg:"" name:"range" help:"Range (eg. Sheet1!A1:B10)"`
@@ -46,15 +55,6 @@ func (c *SheetsNotesCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
-	type cellNote struct {
-		Sheet string `json:"sheet"`
-		A1    string `json:"a1"`
-		Row   int    `json:"row"`
-		Col   int    `json:"col"`
-		Value string `json:"value"`
-		Note  string `json:"note"`
-	}
-
 	var notes []cellNote
 
 	for _, sheet := range resp.Sheets {
@@ -123,6 +123,14 @@ func (c *SheetsNotesCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*SheetsNotesCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "notes", Result: struct {
+		SpreadsheetID string     `json:"spreadsheetId"`
+		Range         string     `json:"range"`
+		Notes         []cellNote `json:"notes"`
+	}{}}
+}
+
 var simpleSheetNameRe = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
 
 func formatA1Cell(sheetTitle string, row, col int) string {
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/slides/v1"
 
 	"github.com/steipete/gogcli/internal/googleapi"
 	"github.com/steipete/gogcli/internal/outfmt"
@@ -48,6 +49,10 @@ func (c *SlidesExportCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}, c.PresentationID, c.Output.Path, c.Format)
 }
 
+func (*SlidesExportCmd) jsonOutput() commandOutput {
+	return exportOutput()
+}
+
 type SlidesInfoCmd struct {
 	PresentationID string `arg:"" name:"presentationId" help:"Presentation ID"`
 }
@@ -60,6 +65,10 @@ func (c *SlidesInfoCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}, c.PresentationID)
 }
 
+func (*SlidesInfoCmd) jsonOutput() commandOutput {
+	return driveFileOutput()
+}
+
 type SlidesCreateCmd struct {
 	Title    string `arg:"" name:"title" help:"Presentation title"`
 	Parent   string `name:"parent" help:"Destination folder ID"`
@@ -141,6 +150,10 @@ func (c *SlidesCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*SlidesCreateCmd) jsonOutput() commandOutput {
+	return driveFileOutput()
+}
+
 type SlidesCreateFromMarkdownCmd struct {
 	Title       string `arg:"" name:"title" help:"Presentation title"`
 	Content     string `name:"content" help:"Markdown content (inline)"`
@@ -243,6 +256,13 @@ func (c *SlidesCreateFromMarkdownCmd) Run(ctx context.Context, flags *RootFlags)
 	return nil
 }
 
+func (*SlidesCreateFromMarkdownCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Presentation *slides.Presentation `json:"presentation"`
+		File         *drive.File          `json:"file"`
+	}{}}
+}
+
 type SlidesCopyCmd struct {
 	PresentationID string `arg:"" name:"presentationId" help:"Presentation ID"`
 	Title          string `arg:"" name:"title" help:"New title"`
@@ -256,3 +276,7 @@ func (c *SlidesCopyCmd) Run(ctx context.Context, flags *RootFlags) error {
 		KindLabel:    "Google Slides presentation",
 	}, c.PresentationID, c.Title, c.Parent)
 }
+
+func (*SlidesCopyCmd) jsonOutput() commandOutput {
+	return driveFileOutput()
+}
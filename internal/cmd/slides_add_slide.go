@@ -253,3 +253,17 @@ func (c *SlidesAddSlideCmd) Run(ctx context.Context, flags *RootFlags) error {
 	u.Out().Printf("link\t%s", link)
 	return nil
 }
+
+func (*SlidesAddSlideCmd) jsonOutput() commandOutput {
+	return slideOutput()
+}
+
+// slideOutput declares the result of add-slide and replace-slide.
+func slideOutput() commandOutput {
+	return commandOutput{Result: struct {
+		SlideNumber    int    `json:"slideNumber"`
+		SlideObjectID  string `json:"slideObjectId"`
+		PresentationID string `json:"presentationId"`
+		Link           string `json:"link"`
+	}{}}
+}
@@ -65,3 +65,15 @@ func (c *SlidesListSlidesCmd) Run(ctx context.Context, flags *RootFlags) error {
 	_ = tw.Flush()
 	return nil
 }
+
+func (*SlidesListSlidesCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "slides", Result: struct {
+		PresentationID string `json:"presentationId"`
+		Title          string `json:"title"`
+		SlideCount     int    `json:"slideCount"`
+		Slides         []struct {
+			Number   int    `json:"number"`
+			ObjectID string `json:"objectId"`
+		} `json:"slides"`
+	}{}}
+}
@@ -159,3 +159,20 @@ func (c *SlidesReadSlideCmd) Run(ctx context.Context, flags *RootFlags) error {
 
 	return nil
 }
+
+func (*SlidesReadSlideCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		PresentationID string `json:"presentationId"`
+		SlideNumber    int    `json:"slideNumber"`
+		SlideObjectID  string `json:"slideObjectId"`
+		Notes          string `json:"notes"`
+		TextElements   []struct {
+			ObjectID string `json:"objectId"`
+			Text     string `json:"text"`
+		} `json:"textElements"`
+		Images []struct {
+			ObjectID   string `json:"objectId"`
+			ContentURL string `json:"contentUrl,omitempty"`
+		} `json:"images"`
+	}{}}
+}
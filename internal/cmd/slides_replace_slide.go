@@ -223,3 +223,7 @@ func (c *SlidesReplaceSlideCmd) Run(ctx context.Context, flags *RootFlags) error
 	u.Out().Printf("link\t%s", link)
 	return nil
 }
+
+func (*SlidesReplaceSlideCmd) jsonOutput() commandOutput {
+	return slideOutput()
+}
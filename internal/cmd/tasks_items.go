@@ -137,6 +137,22 @@ func (c *TasksListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*TasksListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "tasks", Result: struct {
+		Tasks         []*tasks.Task `json:"tasks"`
+		NextPageToken string        `json:"nextPageToken"`
+	}{}}
+}
+
+// taskResult is the {"task": ...} result of commands that write one task.
+type taskResult struct {
+	Task *tasks.Task `json:"task"`
+}
+
+func taskOutput() commandOutput {
+	return commandOutput{Result: taskResult{}}
+}
+
 type TasksGetCmd struct {
 	TasklistID string `arg:"" name:"tasklistId" help:"Task list ID"`
 	TaskID     string `arg:"" name:"taskId" help:"Task ID"`
@@ -187,11 +203,15 @@ func (c *TasksGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*TasksGetCmd) jsonOutput() commandOutput {
+	return taskOutput()
+}
+
 type TasksAddCmd struct {
 	TasklistID  string `arg:"" name:"tasklistId" help:"Task list ID"`
 	Title       string `name:"title" help:"Task title (required)"`
 	Notes       string `name:"notes" help:"Task notes/description"`
-	Due         string `name:"due" help:"Due date (RFC3339 or YYYY-MM-DD; time may be ignored by Google Tasks)"`
+	Due         string `name:"due" help:"Due date (RFC3339, YYYY-MM-DD, or natural: friday, end of month; time may be ignored by Google Tasks)"`
 	Parent      string `name:"parent" help:"Parent task ID (create as subtask)"`
 	Previous    string `name:"previous" help:"Previous sibling task ID (controls ordering)"`
 	Repeat      string `name:"repeat" help:"Repeat task: daily, weekly, monthly, yearly"`
@@ -412,12 +432,19 @@ func (c *TasksAddCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*TasksAddCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: taskResult{}, Variants: []any{struct {
+		Tasks []*tasks.Task `json:"tasks"`
+		Count int           `json:"count"`
+	}{}}}
+}
+
 type TasksUpdateCmd struct {
 	TasklistID string `arg:"" name:"tasklistId" help:"Task list ID"`
 	TaskID     string `arg:"" name:"taskId" help:"Task ID"`
 	Title      string `name:"title" help:"New title (set empty to clear)"`
 	Notes      string `name:"notes" help:"New notes (set empty to clear)"`
-	Due        string `name:"due" help:"New due date (RFC3339 or YYYY-MM-DD; time may be ignored; set empty to clear)"`
+	Due        string `name:"due" help:"New due date (RFC3339, YYYY-MM-DD, or natural; time may be ignored; set empty to clear)"`
 	Status     string `name:"status" help:"New status: needsAction|completed (set empty to clear)"`
 }
 
@@ -509,6 +536,10 @@ func (c *TasksUpdateCmd) Run(ctx context.Context, kctx *kong.Context, flags *Roo
 	return nil
 }
 
+func (*TasksUpdateCmd) jsonOutput() commandOutput {
+	return taskOutput()
+}
+
 type TasksDoneCmd struct {
 	TasklistID string `arg:"" name:"tasklistId" help:"Task list ID"`
 	TaskID     string `arg:"" name:"taskId" help:"Task ID"`
@@ -558,6 +589,10 @@ func (c *TasksDoneCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*TasksDoneCmd) jsonOutput() commandOutput {
+	return taskOutput()
+}
+
 type TasksUndoCmd struct {
 	TasklistID string `arg:"" name:"tasklistId" help:"Task list ID"`
 	TaskID     string `arg:"" name:"taskId" help:"Task ID"`
@@ -607,6 +642,10 @@ func (c *TasksUndoCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*TasksUndoCmd) jsonOutput() commandOutput {
+	return taskOutput()
+}
+
 type TasksDeleteCmd struct {
 	TasklistID string `arg:"" name:"tasklistId" help:"Task list ID"`
 	TaskID     string `arg:"" name:"taskId" help:"Task ID"`
@@ -650,6 +689,13 @@ func (c *TasksDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
 	)
 }
 
+func (*TasksDeleteCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Deleted bool   `json:"deleted"`
+		ID      string `json:"id"`
+	}{}}
+}
+
 type TasksClearCmd struct {
 	TasklistID string `arg:"" name:"tasklistId" help:"Task list ID"`
 }
@@ -687,3 +733,10 @@ func (c *TasksClearCmd) Run(ctx context.Context, flags *RootFlags) error {
 		kv("tasklistId", tasklistID),
 	)
 }
+
+func (*TasksClearCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Cleared    bool   `json:"cleared"`
+		TasklistID string `json:"tasklistId"`
+	}{}}
+}
@@ -92,6 +92,13 @@ func (c *TasksListsListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+func (*TasksListsListCmd) jsonOutput() commandOutput {
+	return commandOutput{Items: "tasklists", Result: struct {
+		Tasklists     []*tasks.TaskList `json:"tasklists"`
+		NextPageToken string            `json:"nextPageToken"`
+	}{}}
+}
+
 type TasksListsCreateCmd struct {
 	Title []string `arg:"" name:"title" help:"Task list title"`
 }
@@ -131,3 +138,9 @@ func (c *TasksListsCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
 	u.Out().Printf("title\t%s", created.Title)
 	return nil
 }
+
+func (*TasksListsCreateCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Tasklist *tasks.TaskList `json:"tasklist"`
+	}{}}
+}
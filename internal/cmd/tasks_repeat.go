@@ -43,10 +43,32 @@ func parseTaskDate(value string) (time.Time, bool, error) {
 	}
 
 	parsed, err := timeparse.ParseDateTimeOrDate(value, time.Local)
+	if err == nil {
+		return parsed.Time, parsed.HasTime, nil
+	}
+
+	// Natural expressions ("friday", "end of month", "tomorrow 5pm") resolve in
+	// the configured timezone; date-only results stay dates like ParseDate's.
+	if span, naturalErr := parseNaturalTaskDate(value); naturalErr == nil {
+		if !span.HasTime {
+			start := span.Start
+			return time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC), false, nil
+		}
+		return span.Start, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("invalid date/time %q (expected RFC3339, YYYY-MM-DD, or natural: friday, end of month, tomorrow 5pm)", strings.TrimSpace(value))
+}
+
+func parseNaturalTaskDate(value string) (timeparse.Span, error) {
+	loc, err := resolveOutputLocation("", false)
+	if err != nil {
+		return timeparse.Span{}, err
+	}
+	weekStart, err := resolveWeekStart("")
 	if err != nil {
-		return time.Time{}, false, fmt.Errorf("invalid date/time %q (expected RFC3339 or YYYY-MM-DD)", strings.TrimSpace(value))
+		return timeparse.Span{}, err
 	}
-	return parsed.Time, parsed.HasTime, nil
+	return timeparse.ParseNatural(value, timeparse.Options{Now: calendarNow().In(loc), Location: loc, WeekStart: weekStart})
 }
 
 func expandRepeatSchedule(start time.Time, unit repeatUnit, count int, until *time.Time) []time.Time {
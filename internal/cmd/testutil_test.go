@@ -14,6 +14,7 @@ import (
 	"github.com/alecthomas/kong"
 
 	"github.com/steipete/gogcli/internal/googleauth"
+	"github.com/steipete/gogcli/internal/outfmt"
 )
 
 // withPrimaryCalendar wraps an http.Handler to also respond to primary calendar requests
@@ -161,13 +162,34 @@ func runKong(t *testing.T, cmd any, args []string, ctx context.Context, flags *R
 		return err
 	}
 
-	if ctx != nil {
-		kctx.BindTo(ctx, (*context.Context)(nil))
-	}
 	if flags == nil {
 		flags = &RootFlags{}
 	}
 	kctx.Bind(flags)
 
-	return kctx.Run()
+	// Check JSON results against the command's declared output. Dry runs
+	// print the request instead of the result.
+	node := kctx.Selected()
+	if node == nil {
+		node = kctx.Model.Node
+	}
+	var capture *outfmt.Capture
+	if _, declared := declaredOutput(node); declared && ctx != nil && outfmt.IsJSON(ctx) && !flags.DryRun {
+		capture = &outfmt.Capture{}
+		kctx.BindTo(outfmt.WithCapture(ctx, capture), (*context.Context)(nil))
+	} else if ctx != nil {
+		kctx.BindTo(ctx, (*context.Context)(nil))
+	}
+
+	err = kctx.Run()
+	if capture != nil {
+		for _, v := range capture.Values() {
+			checkDeclaredOutput(t, node, v)
+			if writeErr := outfmt.WriteJSON(ctx, os.Stdout, v); writeErr != nil && err == nil {
+				err = writeErr
+			}
+		}
+	}
+
+	return err
 }
@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -14,13 +15,13 @@ import (
 // TimeRangeFlags provides common time range options for calendar commands.
 // Embed this struct in commands that need time range support.
 type TimeRangeFlags struct {
-	From      string `name:"from" help:"Start time (RFC3339, date, or relative: today, tomorrow, monday)"`
-	To        string `name:"to" help:"End time (RFC3339, date, or relative)"`
+	From      string `name:"from" help:"Start time (RFC3339, date, or natural: today, next tuesday 3pm, 2 weeks ago, mon-fri this week)"`
+	To        string `name:"to" help:"End time (RFC3339, date, or natural: friday, end of month)"`
 	Today     bool   `name:"today" help:"Today only"`
 	Tomorrow  bool   `name:"tomorrow" help:"Tomorrow only"`
 	Week      bool   `name:"week" help:"This week (uses --week-start, default Mon)"`
 	Days      int    `name:"days" help:"Next N days" default:"0"`
-	WeekStart string `name:"week-start" help:"Week start day for --week (sun, mon, ...)" default:""`
+	WeekStart string `name:"week-start" help:"Week start day for --week and natural ranges (sun, mon, ...; default: GOG_WEEK_START, config week_start, mon)" default:""`
 }
 
 // TimeRange represents a resolved time range with timezone.
@@ -92,10 +93,18 @@ type TimeRangeDefaults struct {
 // ResolveTimeRangeWithDefaults resolves the time range flags into absolute times,
 // using provided defaults when --from/--to are not set.
 func ResolveTimeRangeWithDefaults(ctx context.Context, svc *calendar.Service, flags TimeRangeFlags, defaults TimeRangeDefaults) (*TimeRange, error) {
-	loc, err := getUserTimezone(ctx, svc)
+	// An explicitly configured timezone (GOG_TIMEZONE, config default_timezone)
+	// wins over the primary calendar's.
+	loc, err := getConfiguredTimezone("")
 	if err != nil {
 		return nil, err
 	}
+	if loc == nil {
+		loc, err = getUserTimezone(ctx, svc)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	now := time.Now().In(loc)
 	var from, to time.Time
@@ -122,21 +131,27 @@ func ResolveTimeRangeWithDefaults(ctx context.Context, svc *calendar.Service, fl
 		to = endOfDay(now.AddDate(0, 0, flags.Days-1))
 	default:
 		// Parse --from and --to, or use defaults
+		var fromSpan timeparse.Span
 		if flags.From != "" {
-			from, err = parseTimeExpr(flags.From, now, loc)
+			fromSpan, err = parseNaturalExpr(flags.From, now, loc, weekStart)
 			if err != nil {
 				return nil, fmt.Errorf("invalid --from: %w", err)
 			}
+			from = fromSpan.Start
 		} else {
 			from = now.Add(defaults.FromOffset)
 		}
 
 		switch {
 		case flags.To != "":
-			to, err = parseTimeExprEndOfDay(flags.To, now, loc)
+			toSpan, err := parseNaturalExpr(flags.To, now, loc, weekStart)
 			if err != nil {
 				return nil, fmt.Errorf("invalid --to: %w", err)
 			}
+			to = spanUpperBound(toSpan)
+		case fromSpan.IsRange:
+			// "--from 'mon-fri this week'" or "--from 'last week'" names the whole window.
+			to = fromSpan.End
 		case flags.From != "" && defaults.ToFromOffset != 0:
 			to = from.Add(defaults.ToFromOffset)
 		default:
@@ -151,68 +166,19 @@ func ResolveTimeRangeWithDefaults(ctx context.Context, svc *calendar.Service, fl
 	}, nil
 }
 
-// parseTimeExprEndOfDay is like parseTimeExpr but interprets date-only values
-// and relative day expressions as end of day (23:59:59.999999999) instead of
-// start of day. This is useful for --to flags where "2026-01-05" should mean
-// "through the end of Jan 5" rather than "midnight at the start of Jan 5".
-func parseTimeExprEndOfDay(expr string, now time.Time, loc *time.Location) (time.Time, error) {
-	t, err := parseTimeExpr(expr, now, loc)
-	if err != nil {
-		return t, err
-	}
-	// Only adjust to end-of-day for date-only or relative day expressions.
-	// If the input is a full timestamp (contains "T" or "t" indicating time
-	// components), respect the exact time the user specified — even midnight.
-	if isDateOnlyOrRelative(expr) {
-		return endOfDay(t), nil
-	}
-	return t, nil
+// parseNaturalExpr parses --from/--to values, including natural expressions
+// ("next tuesday 3pm", "2 weeks ago", "mon-fri this week"), in loc.
+func parseNaturalExpr(expr string, now time.Time, loc *time.Location, weekStart time.Weekday) (timeparse.Span, error) {
+	return timeparse.ParseNatural(expr, timeparse.Options{Now: now, Location: loc, WeekStart: weekStart})
 }
 
-// isDateOnlyOrRelative returns true if the expression is a date-only string
-// (YYYY-MM-DD) or a relative day keyword (today, tomorrow, yesterday, weekday
-// names). These should be adjusted to end-of-day when used as an upper bound.
-// Point-in-time values like "now" and full timestamps return false.
-func isDateOnlyOrRelative(expr string) bool {
-	trimmed := strings.TrimSpace(expr)
-	lower := strings.ToLower(trimmed)
-
-	// Relative day keywords
-	switch lower {
-	case "today", "tomorrow", "yesterday":
-		return true
+// spanUpperBound is the instant a span ends when used as an upper bound: the
+// end of a day or period, or the exact time for point-in-time values.
+func spanUpperBound(span timeparse.Span) time.Time {
+	if span.HasTime && !span.IsRange {
+		return span.Start
 	}
-
-	// Weekday names: "monday", "next tuesday", etc.
-	candidate := lower
-	candidate = strings.TrimPrefix(candidate, "next ")
-	weekdays := []string{
-		"sunday", "sun", "monday", "mon", "tuesday", "tue",
-		"wednesday", "wed", "thursday", "thu", "friday", "fri", "saturday", "sat",
-	}
-	for _, wd := range weekdays {
-		if candidate == wd {
-			return true
-		}
-	}
-
-	// Date-only: YYYY-MM-DD (exactly 10 chars, no time component)
-	if len(trimmed) == 10 {
-		if _, err := time.Parse("2006-01-02", trimmed); err == nil {
-			return true
-		}
-	}
-
-	return false
-}
-
-// parseTimeExpr parses a time expression which can be:
-// - RFC3339: 2026-01-05T14:00:00-08:00
-// - ISO 8601 with numeric timezone: 2026-01-05T14:00:00-0800 (no colon)
-// - Date only: 2026-01-05 (interpreted as start of day in user's timezone)
-// - Relative: today, tomorrow, monday, next tuesday
-func parseTimeExpr(expr string, now time.Time, loc *time.Location) (time.Time, error) {
-	return timeparse.ParseRangeExpr(expr, now, loc)
+	return span.End
 }
 
 // parseWeekday parses weekday expressions like "monday", "next tuesday"
@@ -301,9 +267,11 @@ func (tr *TimeRange) FormatHuman() string {
 	return fmt.Sprintf("%s to %s", fromDate, toDate)
 }
 
+// resolveWeekStart resolves --week-start, falling back to GOG_WEEK_START, the
+// config week_start key, and Monday.
 func resolveWeekStart(value string) (time.Weekday, error) {
 	if value == "" {
-		return time.Monday, nil
+		return configuredWeekStart(), nil
 	}
 	if wd, ok := parseWeekStart(value); ok {
 		return wd, nil
@@ -311,6 +279,22 @@ func resolveWeekStart(value string) (time.Weekday, error) {
 	return time.Monday, fmt.Errorf("invalid --week-start %q (use sun, mon, ...)", value)
 }
 
+func configuredWeekStart() time.Weekday {
+	if v := strings.TrimSpace(os.Getenv("GOG_WEEK_START")); v != "" {
+		if wd, ok := parseWeekStart(v); ok {
+			return wd
+		}
+		fmt.Fprintf(os.Stderr, "warning: invalid GOG_WEEK_START %q, ignoring\n", v)
+	}
+	if cfg, ok := readConfigOptional(); ok && cfg.WeekStart != "" {
+		if wd, ok := parseWeekStart(cfg.WeekStart); ok {
+			return wd
+		}
+		fmt.Fprintf(os.Stderr, "warning: invalid week_start in config %q, ignoring\n", cfg.WeekStart)
+	}
+	return time.Monday
+}
+
 func parseWeekStart(value string) (time.Weekday, bool) {
 	switch strings.ToLower(strings.TrimSpace(value)) {
 	case "sun", "sunday":
@@ -53,6 +53,15 @@ func (c *TimeNowCmd) Run(ctx context.Context) error {
 	return nil
 }
 
+func (*TimeNowCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Timezone    string `json:"timezone"`
+		CurrentTime string `json:"current_time"`
+		UTCOffset   string `json:"utc_offset"`
+		Formatted   string `json:"formatted"`
+	}{}}
+}
+
 func formatUTCOffset(t time.Time) string {
 	_, offset := t.Zone()
 	sign := "+"
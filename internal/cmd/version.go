@@ -45,3 +45,11 @@ func (c *VersionCmd) Run(ctx context.Context) error {
 	fmt.Fprintln(os.Stdout, VersionString())
 	return nil
 }
+
+func (*VersionCmd) jsonOutput() commandOutput {
+	return commandOutput{Result: struct {
+		Version string `json:"version"`
+		Commit  string `json:"commit"`
+		Date    string `json:"date"`
+	}{}}
+}
@@ -5,6 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
+)
+
+const (
+	// EnvCredentialsJSON holds an OAuth client JSON (Google download or stored format).
+	EnvCredentialsJSON = "GOG_CREDENTIALS_JSON"
+	// EnvCredentialsFile points at a mounted OAuth client JSON file.
+	EnvCredentialsFile = "GOG_CREDENTIALS_FILE"
 )
 
 var (
@@ -87,7 +95,47 @@ func ReadClientCredentials() (ClientCredentials, error) {
 	return ReadClientCredentialsFor(DefaultClientName)
 }
 
+// EnvClientCredentials returns OAuth client credentials supplied via
+// GOG_CREDENTIALS_JSON or GOG_CREDENTIALS_FILE, and where they came from.
+// They apply to every client name and are never written to disk.
+func EnvClientCredentials() (creds ClientCredentials, source string, ok bool, err error) {
+	var b []byte
+
+	if v := strings.TrimSpace(os.Getenv(EnvCredentialsJSON)); v != "" {
+		b, source = []byte(v), EnvCredentialsJSON
+	} else if path := strings.TrimSpace(os.Getenv(EnvCredentialsFile)); path != "" {
+		if path, err = ExpandPath(path); err != nil {
+			return ClientCredentials{}, "", false, err
+		}
+
+		if b, err = os.ReadFile(path); err != nil { //nolint:gosec // user-provided path
+			return ClientCredentials{}, "", false, fmt.Errorf("read %s: %w", EnvCredentialsFile, err)
+		}
+
+		source = path
+	} else {
+		return ClientCredentials{}, "", false, nil
+	}
+
+	if c, parseErr := ParseGoogleOAuthClientJSON(b); parseErr == nil {
+		return c, source, true, nil
+	}
+
+	var c ClientCredentials
+	if err := json.Unmarshal(b, &c); err != nil || c.ClientID == "" || c.ClientSecret == "" {
+		return ClientCredentials{}, "", false, fmt.Errorf("%s: %w", source, errInvalidCredentials)
+	}
+
+	return c, source, true, nil
+}
+
 func ReadClientCredentialsFor(client string) (ClientCredentials, error) {
+	if c, _, ok, err := EnvClientCredentials(); err != nil {
+		return ClientCredentials{}, err
+	} else if ok {
+		return c, nil
+	}
+
 	path, err := ClientCredentialsPathFor(client)
 	if err != nil {
 		return ClientCredentials{}, fmt.Errorf("resolve credentials path: %w", err)
@@ -115,6 +163,12 @@ func ReadClientCredentialsFor(client string) (ClientCredentials, error) {
 }
 
 func ClientCredentialsExists(client string) (bool, error) {
+	if _, _, ok, err := EnvClientCredentials(); err != nil {
+		return false, err
+	} else if ok {
+		return true, nil
+	}
+
 	path, err := ClientCredentialsPathFor(client)
 	if err != nil {
 		return false, err
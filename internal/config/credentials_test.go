@@ -109,3 +109,52 @@ func TestReadClientCredentials_Errors(t *testing.T) {
 		t.Fatalf("expected missing field error")
 	}
 }
+
+func TestReadClientCredentialsFor_Env(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg"))
+
+	t.Run("json", func(t *testing.T) {
+		t.Setenv(EnvCredentialsJSON, `{"installed":{"client_id":"id","client_secret":"sec"}}`)
+
+		got, err := ReadClientCredentialsFor("work")
+		if err != nil {
+			t.Fatalf("ReadClientCredentialsFor: %v", err)
+		}
+
+		if got.ClientID != "id" || got.ClientSecret != "sec" {
+			t.Fatalf("unexpected: %#v", got)
+		}
+
+		if ok, err := ClientCredentialsExists("work"); err != nil || !ok {
+			t.Fatalf("expected env credentials to exist, ok=%v err=%v", ok, err)
+		}
+	})
+
+	t.Run("file stored format", func(t *testing.T) {
+		path := filepath.Join(home, "client.json")
+		if err := os.WriteFile(path, []byte(`{"client_id":"fid","client_secret":"fsec"}`), 0o600); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+
+		t.Setenv(EnvCredentialsFile, path)
+
+		got, source, ok, err := EnvClientCredentials()
+		if err != nil || !ok || source != path {
+			t.Fatalf("EnvClientCredentials: ok=%v source=%q err=%v", ok, source, err)
+		}
+
+		if got.ClientID != "fid" || got.ClientSecret != "fsec" {
+			t.Fatalf("unexpected: %#v", got)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Setenv(EnvCredentialsJSON, `{"nope":{}}`)
+
+		if _, err := ReadClientCredentialsFor(DefaultClientName); !errors.Is(err, errInvalidCredentials) {
+			t.Fatalf("expected invalid credentials, got %v", err)
+		}
+	})
+}
@@ -12,6 +12,7 @@ type Key string
 const (
 	KeyTimezone       Key = "timezone"
 	KeyKeyringBackend Key = "keyring_backend"
+	KeyWeekStart      Key = "week_start"
 )
 
 type KeySpec struct {
@@ -25,6 +26,7 @@ type KeySpec struct {
 var keyOrder = []Key{
 	KeyTimezone,
 	KeyKeyringBackend,
+	KeyWeekStart,
 }
 
 var keySpecs = map[Key]KeySpec{
@@ -63,6 +65,35 @@ var keySpecs = map[Key]KeySpec{
 			return "(not set; default: auto on interactive TTY, file on headless/CI/SSH)"
 		},
 	},
+	KeyWeekStart: {
+		Key: KeyWeekStart,
+		Get: func(cfg File) string {
+			return cfg.WeekStart
+		},
+		Set: func(cfg *File, value string) error {
+			if !validWeekStart(value) {
+				return fmt.Errorf("invalid week start %q (use sun, mon, ..., sat)", value)
+			}
+			cfg.WeekStart = strings.ToLower(strings.TrimSpace(value))
+			return nil
+		},
+		Unset: func(cfg *File) {
+			cfg.WeekStart = ""
+		},
+		EmptyHint: func() string {
+			return "(not set; default: mon)"
+		},
+	},
+}
+
+func validWeekStart(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "sun", "sunday", "mon", "monday", "tue", "tues", "tuesday", "wed", "wednesday",
+		"thu", "thur", "thurs", "thursday", "fri", "friday", "sat", "saturday":
+		return true
+	default:
+		return false
+	}
 }
 
 var (
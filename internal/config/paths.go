@@ -241,6 +241,50 @@ func EnsureGmailWatchDir() (string, error) {
 	return dir, nil
 }
 
+func GmailOutboxDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "state", "gmail-outbox"), nil
+}
+
+func EnsureGmailOutboxDir() (string, error) {
+	dir, err := GmailOutboxDir()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("ensure gmail outbox dir: %w", err)
+	}
+
+	return dir, nil
+}
+
+func IndexDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "index"), nil
+}
+
+func EnsureIndexDir() (string, error) {
+	dir, err := IndexDir()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("ensure index dir: %w", err)
+	}
+
+	return dir, nil
+}
+
 // ExpandPath expands ~ at the beginning of a path to the user's home directory.
 // This is needed because ~ is a shell feature and is not expanded when paths
 // are quoted (e.g., --out "~/Downloads/file.pdf").
@@ -8,13 +8,24 @@ import (
 
 	"github.com/99designs/keyring"
 	"github.com/alecthomas/kong"
-	ggoogleapi "google.golang.org/api/googleapi"
 
 	"github.com/steipete/gogcli/internal/config"
 	gogapi "github.com/steipete/gogcli/internal/googleapi"
 )
 
+// Format renders err for humans, including the remediation hint for Google
+// API and missing-scope errors.
 func Format(err error) string {
+	return format(err, true)
+}
+
+// Message is Format without the remediation hint, for JSON error envelopes
+// that carry the hint separately as `fix`.
+func Message(err error) string {
+	return format(err, false)
+}
+
+func format(err error, withFix bool) string {
 	if err == nil {
 		return ""
 	}
@@ -37,6 +48,11 @@ func Format(err error) string {
 		)
 	}
 
+	var scopeErr *gogapi.InsufficientScopesError
+	if errors.As(err, &scopeErr) {
+		return formatInsufficientScopes(scopeErr, withFix)
+	}
+
 	var credErr *config.CredentialsMissingError
 	if errors.As(err, &credErr) {
 		return fmt.Sprintf(
@@ -58,21 +74,40 @@ func Format(err error) string {
 		return userErr.Message
 	}
 
-	var gerr *ggoogleapi.Error
-	if errors.As(err, &gerr) {
-		reason := ""
-		if len(gerr.Errors) > 0 && gerr.Errors[0].Reason != "" {
-			reason = gerr.Errors[0].Reason
-		}
+	if apiErr := gogapi.DecodeAPIError(err); apiErr != nil {
+		return formatAPIError(apiErr, withFix)
+	}
 
-		if reason != "" {
-			return fmt.Sprintf("Google API error (%d %s): %s", gerr.Code, reason, gerr.Message)
-		}
+	return err.Error()
+}
 
-		return fmt.Sprintf("Google API error (%d): %s", gerr.Code, gerr.Message)
+func formatAPIError(err *gogapi.APIError, withFix bool) string {
+	msg := fmt.Sprintf("Google API error (%d): %s", err.HTTPStatus, err.Message)
+	if err.Reason != "" {
+		msg = fmt.Sprintf("Google API error (%d %s): %s", err.HTTPStatus, err.Reason, err.Message)
 	}
 
-	return err.Error()
+	if fix := err.Remediation(); withFix && fix != "" {
+		msg += "\n\n" + fix
+	}
+
+	return msg
+}
+
+func formatInsufficientScopes(err *gogapi.InsufficientScopesError, withFix bool) string {
+	msg := fmt.Sprintf("Missing OAuth scopes for %s %s", err.Service, err.Email)
+	if len(err.Missing) > 0 {
+		msg += ":\n  " + strings.Join(err.Missing, "\n  ")
+	}
+	if !withFix {
+		return msg
+	}
+
+	if err.ServiceAccount {
+		return msg + "\n\nAdd these scopes to the service account's domain-wide delegation in the Admin console:\n  https://admin.google.com/ac/owl/domainwidedelegation"
+	}
+
+	return msg + "\n\nGrant them (existing access is kept):\n  " + err.UpgradeCommand()
 }
 
 // UserFacingError forces a specific message, while preserving the underlying cause.
@@ -74,6 +74,23 @@ func TestFormat_GoogleAPIError(t *testing.T) {
 	}
 }
 
+func TestMessage_OmitsRemediation(t *testing.T) {
+	err := &ggoogleapi.Error{
+		Code:    403,
+		Message: "nope",
+		Errors: []ggoogleapi.ErrorItem{
+			{Reason: "insufficientPermissions"},
+		},
+	}
+
+	if got := Format(err); !strings.Contains(got, "Re-authorize") {
+		t.Fatalf("Format should include the remediation: %q", got)
+	}
+	if got := Message(err); strings.Contains(got, "Re-authorize") || !containsAll(got, "403", "nope") {
+		t.Fatalf("Message should omit the remediation: %q", got)
+	}
+}
+
 func TestFormat_KongParseError_UnknownFlag(t *testing.T) {
 	// Use real Kong parser to generate a parse error
 	type TestCmd struct {
@@ -148,3 +165,16 @@ func containsAll(s string, subs ...string) bool {
 
 	return true
 }
+
+func TestFormat_GoogleAPIError_Remediation(t *testing.T) {
+	err := &ggoogleapi.Error{
+		Code:    403,
+		Message: "blocked",
+		Errors:  []ggoogleapi.ErrorItem{{Reason: "domainPolicy"}},
+	}
+	got := Format(err)
+
+	if !containsAll(got, "403 domainPolicy", "blocked", "admin.google.com") {
+		t.Fatalf("unexpected: %q", got)
+	}
+}
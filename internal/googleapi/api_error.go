@@ -0,0 +1,309 @@
+package googleapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	ggoogleapi "google.golang.org/api/googleapi"
+)
+
+// APIErrorKind classifies the Google API error reasons gog has remediation for.
+type APIErrorKind string
+
+const (
+	APIErrorKindUnknown                 APIErrorKind = ""
+	APIErrorKindAPIDisabled             APIErrorKind = "api_disabled"
+	APIErrorKindDailyLimit              APIErrorKind = "daily_limit"
+	APIErrorKindFailedPrecondition      APIErrorKind = "failed_precondition"
+	APIErrorKindDomainPolicy            APIErrorKind = "domain_policy"
+	APIErrorKindInsufficientPermissions APIErrorKind = "insufficient_permissions"
+)
+
+const (
+	typeErrorInfo = "type.googleapis.com/google.rpc.ErrorInfo"
+	typeHelp      = "type.googleapis.com/google.rpc.Help"
+)
+
+// HelpLink is a google.rpc.Help link attached to an API error.
+type HelpLink struct {
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url"`
+}
+
+// APIError is the decoded form of a googleapi.Error: the legacy reason/domain,
+// the google.rpc.ErrorInfo metadata (service, consumer, ...) and Help links.
+type APIError struct {
+	HTTPStatus int               `json:"http_status"`
+	Status     string            `json:"status,omitempty"`
+	Reason     string            `json:"reason,omitempty"`
+	Domain     string            `json:"domain,omitempty"`
+	Message    string            `json:"message,omitempty"`
+	Kind       APIErrorKind      `json:"kind,omitempty"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	Help       []HelpLink        `json:"help,omitempty"`
+}
+
+// DecodeAPIError extracts structured details from a wrapped googleapi.Error.
+// It returns nil when err does not wrap one.
+func DecodeAPIError(err error) *APIError {
+	var gerr *ggoogleapi.Error
+	if !errors.As(err, &gerr) {
+		return nil
+	}
+
+	out := &APIError{HTTPStatus: gerr.Code, Message: gerr.Message}
+	if len(gerr.Errors) > 0 {
+		out.Reason = gerr.Errors[0].Reason
+	}
+
+	// googleapi.ErrorItem drops the legacy domain and the RPC status; read them from the body.
+	var body struct {
+		Error struct {
+			Status string `json:"status"`
+			Errors []struct {
+				Domain string `json:"domain"`
+			} `json:"errors"`
+		} `json:"error"`
+	}
+	if gerr.Body != "" && json.Unmarshal([]byte(gerr.Body), &body) == nil {
+		out.Status = body.Error.Status
+		if len(body.Error.Errors) > 0 {
+			out.Domain = body.Error.Errors[0].Domain
+		}
+	}
+
+	for _, raw := range gerr.Details {
+		b, marshalErr := json.Marshal(raw)
+		if marshalErr != nil {
+			continue
+		}
+
+		var detail struct {
+			Type     string            `json:"@type"`
+			Reason   string            `json:"reason"`
+			Domain   string            `json:"domain"`
+			Metadata map[string]string `json:"metadata"`
+			Links    []HelpLink        `json:"links"`
+		}
+		if json.Unmarshal(b, &detail) != nil {
+			continue
+		}
+
+		switch detail.Type {
+		case typeErrorInfo:
+			if out.Reason == "" {
+				out.Reason = detail.Reason
+			}
+			if out.Domain == "" {
+				out.Domain = detail.Domain
+			}
+			if len(detail.Metadata) > 0 {
+				if out.Metadata == nil {
+					out.Metadata = map[string]string{}
+				}
+				for k, v := range detail.Metadata {
+					out.Metadata[k] = v
+				}
+			}
+			// Keep the ErrorInfo reason around when it differs (e.g. SERVICE_DISABLED).
+			if detail.Reason != "" && !strings.EqualFold(detail.Reason, out.Reason) {
+				if out.Metadata == nil {
+					out.Metadata = map[string]string{}
+				}
+				out.Metadata["reason"] = detail.Reason
+			}
+		case typeHelp:
+			out.Help = append(out.Help, detail.Links...)
+		}
+	}
+
+	out.Kind = classifyAPIError(out)
+
+	return out
+}
+
+func classifyAPIError(e *APIError) APIErrorKind {
+	reasons := []string{strings.ToLower(e.Reason), strings.ToLower(e.Metadata["reason"])}
+	for _, reason := range reasons {
+		switch reason {
+		case "accessnotconfigured", "service_disabled":
+			return APIErrorKindAPIDisabled
+		case "dailylimitexceeded", "dailylimitexceededunreg":
+			return APIErrorKindDailyLimit
+		case "failedprecondition":
+			return APIErrorKindFailedPrecondition
+		case "domainpolicy":
+			return APIErrorKindDomainPolicy
+		case "insufficientpermissions", "access_token_scope_insufficient":
+			return APIErrorKindInsufficientPermissions
+		}
+	}
+
+	if strings.EqualFold(e.Status, "FAILED_PRECONDITION") {
+		return APIErrorKindFailedPrecondition
+	}
+
+	return APIErrorKindUnknown
+}
+
+// Service is the API host (e.g. gmail.googleapis.com) from ErrorInfo metadata.
+func (e *APIError) Service() string {
+	if e == nil {
+		return ""
+	}
+
+	return e.Metadata["service"]
+}
+
+// Project is the Cloud project number/id of the consumer ("projects/123").
+func (e *APIError) Project() string {
+	if e == nil {
+		return ""
+	}
+
+	for _, key := range []string{"consumer", "containerInfo"} {
+		if v := strings.TrimSpace(e.Metadata[key]); v != "" {
+			return strings.TrimPrefix(v, "projects/")
+		}
+	}
+
+	return ""
+}
+
+// ConsoleURL is the Cloud/Admin console page where the problem can be fixed.
+func (e *APIError) ConsoleURL() string {
+	if e == nil {
+		return ""
+	}
+
+	switch e.Kind {
+	case APIErrorKindAPIDisabled:
+		if v := e.Metadata["activationUrl"]; v != "" {
+			return v
+		}
+		if link := e.firstHelpURL(); link != "" {
+			return link
+		}
+		return consoleURL("https://console.cloud.google.com/apis/library/"+e.Service(), e.Project())
+	case APIErrorKindDailyLimit:
+		if e.Service() != "" {
+			return consoleURL("https://console.cloud.google.com/apis/api/"+e.Service()+"/quotas", e.Project())
+		}
+		return consoleURL("https://console.cloud.google.com/iam-admin/quotas", e.Project())
+	case APIErrorKindDomainPolicy:
+		return "https://admin.google.com/ac/owl/list?tab=configuredApps"
+	case APIErrorKindFailedPrecondition:
+		if e.WorkspaceOnly() {
+			return "https://workspace.google.com/"
+		}
+		return e.firstHelpURL()
+	default:
+		return e.firstHelpURL()
+	}
+}
+
+// Remediation is a concrete next step for the error kind, or "" when unknown.
+func (e *APIError) Remediation() string {
+	if e == nil {
+		return ""
+	}
+
+	api := e.Service()
+	if api == "" {
+		api = "the API"
+	}
+
+	project := ""
+	if p := e.Project(); p != "" {
+		project = " for project " + p
+	}
+
+	switch e.Kind {
+	case APIErrorKindAPIDisabled:
+		return fmt.Sprintf("Enable %s%s at %s, wait a few minutes, then retry.", api, project, e.ConsoleURL())
+	case APIErrorKindDailyLimit:
+		return fmt.Sprintf("The daily quota of %s%s is exhausted; retry after it resets (midnight Pacific time) or request more at %s.", api, project, e.ConsoleURL())
+	case APIErrorKindFailedPrecondition:
+		if !e.WorkspaceOnly() {
+			return fmt.Sprintf("%s rejected the request because a precondition failed; check the resource's current state (it may have changed since it was read) and retry.", titleAPI(api))
+		}
+		return fmt.Sprintf("%s rejected the request's preconditions; Workspace-only APIs (Keep, Chat, Admin) need a Google Workspace account (see %s), usually via `gog auth service-account set <email> --key <service-account.json>`.", titleAPI(api), e.ConsoleURL())
+	case APIErrorKindDomainPolicy:
+		return fmt.Sprintf("A Workspace admin policy blocks this OAuth client or %s; ask an admin to trust the app in %s.", api, e.ConsoleURL())
+	case APIErrorKindInsufficientPermissions:
+		return "Re-authorize with the required services: `gog auth add <email> --services <services> --force-consent` (see `gog auth doctor`)."
+	default:
+		return ""
+	}
+}
+
+// workspaceOnlyServices are APIs that fail preconditions for consumer
+// (non-Workspace) accounts.
+var workspaceOnlyServices = map[string]bool{
+	"keep.googleapis.com":          true,
+	"chat.googleapis.com":          true,
+	"admin.googleapis.com":         true,
+	"cloudidentity.googleapis.com": true,
+}
+
+// WorkspaceOnly reports a failed precondition caused by the account not
+// being a Google Workspace account, as opposed to any other precondition
+// (stale resource state, invalid combination of fields, ...).
+func (e *APIError) WorkspaceOnly() bool {
+	if e == nil || e.Kind != APIErrorKindFailedPrecondition {
+		return false
+	}
+	if workspaceOnlyServices[e.Service()] {
+		return true
+	}
+
+	msg := strings.ToLower(e.Message)
+	for _, hint := range []string{"mail service not enabled", "google workspace", "g suite", "consumer account"} {
+		if strings.Contains(msg, hint) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SortedMetadataKeys returns metadata keys in stable order (for text output).
+func (e *APIError) SortedMetadataKeys() []string {
+	keys := make([]string, 0, len(e.Metadata))
+	for k := range e.Metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (e *APIError) firstHelpURL() string {
+	for _, link := range e.Help {
+		if link.URL != "" {
+			return link.URL
+		}
+	}
+
+	return ""
+}
+
+func consoleURL(base string, project string) string {
+	if project == "" {
+		return base
+	}
+
+	return base + "?project=" + url.QueryEscape(project)
+}
+
+func titleAPI(api string) string {
+	if api == "" {
+		return ""
+	}
+
+	return strings.ToUpper(api[:1]) + api[1:]
+}
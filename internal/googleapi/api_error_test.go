@@ -0,0 +1,97 @@
+package googleapi
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	ggoogleapi "google.golang.org/api/googleapi"
+)
+
+func googleErrorFromBody(t *testing.T, code int, body string) error {
+	t.Helper()
+
+	resp := &http.Response{
+		StatusCode: code,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+
+	err := ggoogleapi.CheckResponse(resp)
+	if err == nil {
+		t.Fatalf("expected error for %d", code)
+	}
+
+	return fmt.Errorf("list messages: %w", err)
+}
+
+func TestDecodeAPIError_ServiceDisabled(t *testing.T) {
+	err := googleErrorFromBody(t, 403, `{"error":{"code":403,"message":"Gmail API has not been used in project 123 before or it is disabled.","status":"PERMISSION_DENIED",
+		"errors":[{"message":"disabled","domain":"usageLimits","reason":"accessNotConfigured"}],
+		"details":[
+			{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"SERVICE_DISABLED","domain":"googleapis.com","metadata":{"service":"gmail.googleapis.com","consumer":"projects/123"}},
+			{"@type":"type.googleapis.com/google.rpc.Help","links":[{"description":"Google developers console API activation","url":"https://console.developers.google.com/apis/api/gmail.googleapis.com/overview?project=123"}]}
+		]}}`)
+
+	got := DecodeAPIError(err)
+	if got == nil {
+		t.Fatalf("expected decoded error")
+	}
+
+	if got.Kind != APIErrorKindAPIDisabled || got.Reason != "accessNotConfigured" || got.Domain != "usageLimits" || got.Status != "PERMISSION_DENIED" {
+		t.Fatalf("unexpected decode: %+v", got)
+	}
+
+	if got.Service() != "gmail.googleapis.com" || got.Project() != "123" || got.Metadata["reason"] != "SERVICE_DISABLED" {
+		t.Fatalf("unexpected metadata: %+v", got.Metadata)
+	}
+
+	if got.ConsoleURL() != "https://console.developers.google.com/apis/api/gmail.googleapis.com/overview?project=123" {
+		t.Fatalf("unexpected console URL: %q", got.ConsoleURL())
+	}
+
+	if fix := got.Remediation(); !strings.Contains(fix, "Enable gmail.googleapis.com for project 123") {
+		t.Fatalf("unexpected remediation: %q", fix)
+	}
+}
+
+func TestDecodeAPIError_Kinds(t *testing.T) {
+	cases := map[string]APIErrorKind{
+		`{"error":{"code":403,"message":"x","errors":[{"reason":"dailyLimitExceeded","domain":"usageLimits"}]}}`:                APIErrorKindDailyLimit,
+		`{"error":{"code":400,"message":"Precondition check failed.","status":"FAILED_PRECONDITION"}}`:                          APIErrorKindFailedPrecondition,
+		`{"error":{"code":403,"message":"x","errors":[{"reason":"domainPolicy","domain":"global"}]}}`:                           APIErrorKindDomainPolicy,
+		`{"error":{"code":403,"message":"x","errors":[{"reason":"insufficientPermissions","domain":"global"}]}}`:                APIErrorKindInsufficientPermissions,
+		`{"error":{"code":403,"message":"x","errors":[{"reason":"forbidden","domain":"global"}],"status":"PERMISSION_DENIED"}}`: APIErrorKindUnknown,
+	}
+
+	for body, want := range cases {
+		got := DecodeAPIError(googleErrorFromBody(t, 403, body))
+		if got == nil || got.Kind != want {
+			t.Fatalf("body %s: got %+v, want kind %q", body, got, want)
+		}
+	}
+
+	if DecodeAPIError(fmt.Errorf("plain")) != nil {
+		t.Fatalf("expected nil for non-API error")
+	}
+}
+
+func TestAPIError_FailedPreconditionRemediation(t *testing.T) {
+	generic := DecodeAPIError(googleErrorFromBody(t, 400, `{"error":{"code":400,"message":"Precondition check failed.","status":"FAILED_PRECONDITION"}}`))
+	if generic.WorkspaceOnly() || strings.Contains(generic.Remediation(), "Workspace") {
+		t.Fatalf("generic precondition must not suggest Workspace: %q", generic.Remediation())
+	}
+
+	keep := DecodeAPIError(googleErrorFromBody(t, 400, `{"error":{"code":400,"message":"Precondition check failed.","status":"FAILED_PRECONDITION",`+
+		`"details":[{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"FAILED_PRECONDITION","metadata":{"service":"keep.googleapis.com"}}]}}`))
+	if !keep.WorkspaceOnly() || !strings.Contains(keep.Remediation(), "Workspace") || keep.ConsoleURL() != "https://workspace.google.com/" {
+		t.Fatalf("Keep precondition should suggest Workspace: %q", keep.Remediation())
+	}
+
+	gmail := DecodeAPIError(googleErrorFromBody(t, 400, `{"error":{"code":400,"message":"Mail service not enabled","errors":[{"reason":"failedPrecondition","domain":"global"}]}}`))
+	if !gmail.WorkspaceOnly() {
+		t.Fatalf("Mail service not enabled should be Workspace-only: %+v", gmail)
+	}
+}
@@ -34,7 +34,7 @@ func (cb *CircuitBreaker) RecordSuccess() {
 	cb.open = false
 
 	if wasOpen {
-		slog.Info("circuit breaker reset")
+		slog.Info("circuit breaker reset", "event", "circuit_breaker.close")
 	}
 }
 
@@ -47,7 +47,10 @@ func (cb *CircuitBreaker) RecordFailure() bool {
 
 	if cb.failures >= CircuitBreakerThreshold {
 		cb.open = true
-		slog.Warn("circuit breaker opened", "failures", cb.failures)
+		slog.Warn("circuit breaker opened",
+			"event", "circuit_breaker.open",
+			"failures", cb.failures,
+			"reset_after", CircuitBreakerResetTime)
 
 		return true // circuit just opened
 	}
@@ -67,7 +70,7 @@ func (cb *CircuitBreaker) IsOpen() bool {
 		cb.open = false
 		cb.failures = 0
 
-		slog.Info("circuit breaker attempting reset after timeout")
+		slog.Info("circuit breaker attempting reset after timeout", "event", "circuit_breaker.half_open")
 
 		return false
 	}
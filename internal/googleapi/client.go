@@ -50,26 +50,36 @@ func tokenSourceForAccount(ctx context.Context, service googleauth.Service, emai
 }
 
 func tokenSourceForAccountScopes(ctx context.Context, serviceLabel string, email string, client string, clientID string, clientSecret string, requiredScopes []string) (oauth2.TokenSource, error) {
+	tok, err := storedTokenForAccount(serviceLabel, email, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return tokenSourceForStoredToken(ctx, tok, clientID, clientSecret, requiredScopes), nil
+}
+
+func storedTokenForAccount(serviceLabel string, email string, client string) (secrets.Token, error) {
 	var store secrets.Store
 
 	if s, err := openSecretsStore(); err != nil {
-		return nil, fmt.Errorf("open secrets store: %w", err)
+		return secrets.Token{}, fmt.Errorf("open secrets store: %w", err)
 	} else {
 		store = s
 	}
 
-	var tok secrets.Token
-
-	if t, err := store.GetToken(client, email); err != nil {
+	tok, err := store.GetToken(client, email)
+	if err != nil {
 		if errors.Is(err, keyring.ErrKeyNotFound) {
-			return nil, &AuthRequiredError{Service: serviceLabel, Email: email, Client: client, Cause: err}
+			return secrets.Token{}, &AuthRequiredError{Service: serviceLabel, Email: email, Client: client, Cause: err}
 		}
 
-		return nil, fmt.Errorf("get token for %s: %w", email, err)
-	} else {
-		tok = t
+		return secrets.Token{}, fmt.Errorf("get token for %s: %w", email, err)
 	}
 
+	return tok, nil
+}
+
+func tokenSourceForStoredToken(ctx context.Context, tok secrets.Token, clientID string, clientSecret string, requiredScopes []string) oauth2.TokenSource {
 	cfg := oauth2.Config{
 		ClientID:     clientID,
 		ClientSecret: clientSecret,
@@ -80,7 +90,10 @@ func tokenSourceForAccountScopes(ctx context.Context, serviceLabel string, email
 	// Ensure refresh-token exchanges don't hang forever.
 	ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Timeout: defaultHTTPTimeout})
 
-	return cfg.TokenSource(ctx, &oauth2.Token{RefreshToken: tok.RefreshToken}), nil
+	return &refreshRecordingTokenSource{
+		base: cfg.TokenSource(ctx, &oauth2.Token{RefreshToken: tok.RefreshToken}),
+		tok:  tok,
+	}
 }
 
 func optionsForAccount(ctx context.Context, service googleauth.Service, email string) ([]option.ClientOption, error) {
@@ -99,11 +112,18 @@ func optionsForAccountScopes(ctx context.Context, serviceLabel string, email str
 
 	var ts oauth2.TokenSource
 
+	scopeCheck := &scopeCheckTransport{
+		Service:  serviceNameForLabel(serviceLabel),
+		Email:    email,
+		Required: scopes,
+	}
+
 	if serviceAccountTS, saPath, ok, err := tokenSourceForServiceAccountScopes(ctx, email, scopes); err != nil {
 		return nil, fmt.Errorf("service account token source: %w", err)
 	} else if ok {
 		slog.Debug("using service account credentials", "email", email, "path", saPath)
 		ts = serviceAccountTS
+		scopeCheck.ServiceAccount = true
 	} else {
 		client, err := authclient.ResolveClient(ctx, email)
 		if err != nil {
@@ -116,20 +136,28 @@ func optionsForAccountScopes(ctx context.Context, serviceLabel string, email str
 			creds = c
 		}
 
-		if tokenSource, err := tokenSourceForAccountScopes(ctx, serviceLabel, email, client, creds.ClientID, creds.ClientSecret, scopes); err != nil {
+		tok, err := storedTokenForAccount(serviceLabel, email, client)
+		if err != nil {
 			return nil, fmt.Errorf("token source: %w", err)
-		} else {
-			ts = tokenSource
 		}
+
+		ts = tokenSourceForStoredToken(ctx, tok, creds.ClientID, creds.ClientSecret, scopes)
+		scopeCheck.Client = client
+		scopeCheck.Services = tok.Services
+		scopeCheck.Granted = tok.Scopes
 	}
 	baseTransport := newBaseTransport()
 	// Wrap with retry logic for 429 and 5xx errors
 	retryTransport := NewRetryTransport(&oauth2.Transport{
 		Source: ts,
-		Base:   baseTransport,
+		Base:   &invocationTransport{Base: baseTransport},
 	})
+	scopeCheck.Base = retryTransport
+	if trace, ok := httpTraceFromContext(ctx); ok {
+		scopeCheck.Base = &traceTransport{Base: retryTransport, Trace: trace}
+	}
 	c := &http.Client{
-		Transport: retryTransport,
+		Transport: scopeCheck,
 		Timeout:   defaultHTTPTimeout,
 	}
 
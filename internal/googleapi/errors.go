@@ -3,6 +3,7 @@ package googleapi
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -25,6 +26,33 @@ func (e *AuthRequiredError) Unwrap() error {
 	return e.Cause
 }
 
+// InsufficientScopesError indicates the access token lacks OAuth scopes the API
+// requires (403 insufficientPermissions / ACCESS_TOKEN_SCOPE_INSUFFICIENT).
+type InsufficientScopesError struct {
+	Service string
+	Email   string
+	Client  string
+	// Services are the services currently recorded for the stored token.
+	Services []string
+	// Missing are the scopes to request incrementally.
+	Missing        []string
+	ServiceAccount bool
+	Cause          error
+}
+
+func (e *InsufficientScopesError) Error() string {
+	msg := fmt.Sprintf("insufficient OAuth scopes for %s %s", e.Service, e.Email)
+	if len(e.Missing) > 0 {
+		msg += " (missing " + strings.Join(e.Missing, ", ") + ")"
+	}
+
+	return msg
+}
+
+func (e *InsufficientScopesError) Unwrap() error {
+	return e.Cause
+}
+
 // RateLimitError indicates rate limit was exceeded
 type RateLimitError struct {
 	RetryAfter time.Duration
@@ -93,6 +121,12 @@ func IsAuthRequiredError(err error) bool {
 	return errors.As(err, &e)
 }
 
+// IsInsufficientScopesError checks if the error is an insufficient scopes error
+func IsInsufficientScopesError(err error) bool {
+	var e *InsufficientScopesError
+	return errors.As(err, &e)
+}
+
 // IsRateLimitError checks if the error is a rate limit error
 func IsRateLimitError(err error) bool {
 	var e *RateLimitError
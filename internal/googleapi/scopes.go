@@ -0,0 +1,131 @@
+package googleapi
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	ggoogleapi "google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/googleauth"
+)
+
+// maxScopeErrorBody bounds how much of a 403 body is inspected for scope errors.
+const maxScopeErrorBody = 64 << 10
+
+// scopeCheckTransport turns 403 responses caused by missing OAuth scopes into an
+// InsufficientScopesError, so callers can offer an incremental scope upgrade
+// instead of a bare "permission denied".
+type scopeCheckTransport struct {
+	Base    http.RoundTripper
+	Service string
+	Email   string
+	Client  string
+	// Services and Granted describe the stored refresh token (nil for service accounts).
+	Services       []string
+	Granted        []string
+	Required       []string
+	ServiceAccount bool
+}
+
+func (t *scopeCheckTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil || resp == nil || resp.StatusCode != http.StatusForbidden {
+		return resp, err
+	}
+
+	body, readErr := io.ReadAll(io.LimitReader(resp.Body, maxScopeErrorBody))
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(newBytesReader(body))
+
+	if readErr != nil || !isInsufficientScopeResponse(resp.Header, body) {
+		return resp, nil //nolint:nilerr // fall back to the regular API error path
+	}
+
+	cause := ggoogleapi.CheckResponse(&http.Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       io.NopCloser(newBytesReader(body)),
+	})
+
+	return nil, &InsufficientScopesError{
+		Service:        t.Service,
+		Email:          t.Email,
+		Client:         t.Client,
+		Services:       append([]string(nil), t.Services...),
+		Missing:        t.missingScopes(),
+		ServiceAccount: t.ServiceAccount,
+		Cause:          cause,
+	}
+}
+
+func (t *scopeCheckTransport) missingScopes() []string {
+	if t.ServiceAccount {
+		return append([]string(nil), t.Required...)
+	}
+
+	missing := googleauth.MissingScopes(t.Granted, t.Required)
+	if len(missing) == 0 {
+		// The token claims the scopes, so consent was withheld for some of them
+		// (unchecked boxes); ask for all of them again.
+		return append([]string(nil), t.Required...)
+	}
+
+	return missing
+}
+
+func isInsufficientScopeResponse(header http.Header, body []byte) bool {
+	if strings.Contains(header.Get("WWW-Authenticate"), "insufficient_scope") {
+		return true
+	}
+
+	s := string(body)
+
+	return strings.Contains(s, "ACCESS_TOKEN_SCOPE_INSUFFICIENT") ||
+		strings.Contains(s, `"insufficientPermissions"`)
+}
+
+// serviceNameForLabel maps the labels used when building API clients back to
+// the googleauth service names accepted by `gog auth add --services`.
+func serviceNameForLabel(label string) string {
+	if label == "cloudidentity" {
+		return string(googleauth.ServiceGroups)
+	}
+
+	return label
+}
+
+// UpgradeServices returns the services to request when re-authorizing: the ones
+// already granted plus the service that failed.
+func (e *InsufficientScopesError) UpgradeServices() []googleauth.Service {
+	var add []googleauth.Service
+	if svc, err := googleauth.ParseService(e.Service); err == nil {
+		add = append(add, svc)
+	}
+
+	return googleauth.UpgradeServices(e.Services, add)
+}
+
+// UpgradeCommand returns the `gog auth add` invocation that grants the missing
+// scopes. It is empty for service accounts, whose scopes are granted by a
+// Workspace admin.
+func (e *InsufficientScopesError) UpgradeCommand() string {
+	if e.ServiceAccount {
+		return ""
+	}
+
+	services := e.UpgradeServices()
+	names := make([]string, 0, len(services))
+
+	for _, svc := range services {
+		names = append(names, string(svc))
+	}
+
+	cmd := "gog auth add " + e.Email + " --services " + strings.Join(names, ",")
+	if e.Client != "" && e.Client != config.DefaultClientName {
+		cmd += " --client " + e.Client
+	}
+
+	return cmd
+}
@@ -0,0 +1,82 @@
+package googleapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	ggoogleapi "google.golang.org/api/googleapi"
+)
+
+func TestScopeCheckTransport_InsufficientScopes(t *testing.T) {
+	mock := &mockTransport{
+		responses: []*http.Response{{
+			StatusCode: http.StatusForbidden,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body: io.NopCloser(strings.NewReader(`{"error":{"code":403,"message":"Request had insufficient authentication scopes.",` +
+				`"errors":[{"reason":"insufficientPermissions","domain":"global"}],"status":"PERMISSION_DENIED"}}`)),
+		}},
+	}
+
+	rt := &scopeCheckTransport{
+		Base:     mock,
+		Service:  "calendar",
+		Email:    "a@b.com",
+		Client:   "work",
+		Services: []string{"gmail"},
+		Granted:  []string{"https://www.googleapis.com/auth/gmail.modify"},
+		Required: []string{"https://www.googleapis.com/auth/calendar"},
+	}
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://example.com", nil)
+
+	resp, err := rt.RoundTrip(req)
+	if resp != nil {
+		_ = resp.Body.Close()
+		t.Fatalf("expected no response")
+	}
+
+	var scopeErr *InsufficientScopesError
+	if !errors.As(err, &scopeErr) {
+		t.Fatalf("expected InsufficientScopesError, got %v", err)
+	}
+
+	if len(scopeErr.Missing) != 1 || scopeErr.Missing[0] != "https://www.googleapis.com/auth/calendar" {
+		t.Fatalf("unexpected missing scopes: %v", scopeErr.Missing)
+	}
+
+	var gerr *ggoogleapi.Error
+	if !errors.As(err, &gerr) || gerr.Code != http.StatusForbidden {
+		t.Fatalf("expected wrapped googleapi error, got %v", scopeErr.Cause)
+	}
+
+	if got, want := scopeErr.UpgradeCommand(), "gog auth add a@b.com --services gmail,calendar --client work"; got != want {
+		t.Fatalf("UpgradeCommand = %q, want %q", got, want)
+	}
+}
+
+func TestScopeCheckTransport_OtherForbiddenPassesThrough(t *testing.T) {
+	mock := &mockTransport{
+		responses: []*http.Response{{
+			StatusCode: http.StatusForbidden,
+			Body:       io.NopCloser(strings.NewReader(`{"error":{"code":403,"errors":[{"reason":"forbidden"}]}}`)),
+		}},
+	}
+
+	rt := &scopeCheckTransport{Base: mock, Service: "drive", Email: "a@b.com"}
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://example.com", nil)
+
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), `"forbidden"`) {
+		t.Fatalf("expected body to be preserved, got %q", body)
+	}
+}
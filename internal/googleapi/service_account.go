@@ -2,30 +2,213 @@ package googleapi
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
+	"google.golang.org/api/impersonate"
+	"google.golang.org/api/option"
 
 	"github.com/steipete/gogcli/internal/config"
 )
 
+const (
+	credentialTypeServiceAccount = "service_account"
+
+	scopeCloudPlatform = "https://www.googleapis.com/auth/cloud-platform"
+)
+
 var newServiceAccountTokenSource = func(ctx context.Context, keyJSON []byte, subject string, scopes []string) (oauth2.TokenSource, error) {
+	// Ensure token exchanges don't hang forever.
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Timeout: defaultHTTPTimeout})
+
+	if t := credentialType(keyJSON); t != credentialTypeServiceAccount && t != "" {
+		return newFederatedTokenSource(ctx, keyJSON, subject, scopes)
+	}
+
+	if target := impersonateServiceAccount(nil); target != "" {
+		return newImpersonatedTokenSource(ctx, keyJSON, target, subject, scopes)
+	}
+
 	cfg, err := google.JWTConfigFromJSON(keyJSON, scopes...)
 	if err != nil {
 		return nil, fmt.Errorf("parse service account: %w", err)
 	}
 	cfg.Subject = subject
 
-	// Ensure token exchanges don't hang forever.
+	return cfg.TokenSource(ctx), nil
+}
+
+// newFederatedTokenSource handles keyless credential files: external_account
+// (workload identity federation with file-, URL- or executable-sourced subject
+// tokens), impersonated_service_account and authorized_user. When a target
+// service account is known, the user is impersonated through the IAM
+// Credentials API (domain-wide delegation without a private key). Without a
+// target these credentials cannot act as another user, so a subject is an
+// error rather than silently running as the federated or gcloud identity.
+func newFederatedTokenSource(ctx context.Context, credsJSON []byte, subject string, scopes []string) (oauth2.TokenSource, error) {
+	if target := impersonateServiceAccount(credsJSON); target != "" {
+		return newImpersonatedTokenSource(ctx, credsJSON, target, subject, scopes)
+	}
+	if subject != "" {
+		return nil, errCannotActAs(credentialType(credsJSON), subject)
+	}
+
+	creds, err := google.CredentialsFromJSONWithParams(ctx, credsJSON, google.CredentialsParams{Scopes: scopes})
+	if err != nil {
+		return nil, fmt.Errorf("parse credentials: %w", err)
+	}
+
+	return creds.TokenSource, nil
+}
+
+// newImpersonatedTokenSource uses credsJSON (nil for Application Default
+// Credentials) to mint tokens for target, acting as subject when set.
+func newImpersonatedTokenSource(ctx context.Context, credsJSON []byte, target string, subject string, scopes []string) (oauth2.TokenSource, error) {
+	var base *google.Credentials
+
+	var err error
+	if credsJSON == nil {
+		base, err = google.FindDefaultCredentials(ctx, scopeCloudPlatform)
+	} else {
+		base, err = google.CredentialsFromJSONWithParams(ctx, credsJSON, google.CredentialsParams{Scopes: []string{scopeCloudPlatform}})
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("base credentials: %w", err)
+	}
+
+	ts, err := impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+		TargetPrincipal: target,
+		Scopes:          scopes,
+		Subject:         subject,
+	}, option.WithTokenSource(base.TokenSource))
+	if err != nil {
+		return nil, fmt.Errorf("impersonate %s: %w", target, err)
+	}
+
+	return ts, nil
+}
+
+// newADCTokenSource builds a token source from Application Default Credentials
+// (GOOGLE_APPLICATION_CREDENTIALS, gcloud, or the metadata server).
+var newADCTokenSource = func(ctx context.Context, subject string, scopes []string) (oauth2.TokenSource, error) {
 	ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Timeout: defaultHTTPTimeout})
 
-	return cfg.TokenSource(ctx), nil
+	if target := impersonateServiceAccount(nil); target != "" {
+		return newImpersonatedTokenSource(ctx, nil, target, subject, scopes)
+	}
+
+	creds, err := google.FindDefaultCredentialsWithParams(ctx, google.CredentialsParams{Scopes: scopes, Subject: subject})
+	if err != nil {
+		return nil, fmt.Errorf("application default credentials: %w", err)
+	}
+
+	if creds.JSON == nil {
+		// Metadata server: the attached service account has no key to sign
+		// a delegated JWT with.
+		if subject != "" {
+			return nil, errCannotActAs("compute metadata", subject)
+		}
+		return creds.TokenSource, nil
+	}
+	if credentialType(creds.JSON) != credentialTypeServiceAccount {
+		return newFederatedTokenSource(ctx, creds.JSON, subject, scopes)
+	}
+
+	return creds.TokenSource, nil
+}
+
+func errCannotActAs(credType string, subject string) error {
+	return fmt.Errorf("%s credentials cannot act as %s: set GOG_IMPERSONATE_SERVICE_ACCOUNT (or service_account_impersonation_url) to a service account with domain-wide delegation", credType, subject)
+}
+
+// UseADC reports whether GOG_USE_ADC enables Application Default Credentials.
+func UseADC() bool {
+	v, err := strconv.ParseBool(strings.TrimSpace(os.Getenv("GOG_USE_ADC")))
+	return err == nil && v
+}
+
+// impersonateServiceAccount returns the service account to impersonate:
+// GOG_IMPERSONATE_SERVICE_ACCOUNT, else the target of an external account's
+// service_account_impersonation_url.
+func impersonateServiceAccount(credsJSON []byte) string {
+	if v := strings.TrimSpace(os.Getenv("GOG_IMPERSONATE_SERVICE_ACCOUNT")); v != "" {
+		return v
+	}
+
+	if credsJSON == nil {
+		return ""
+	}
+
+	var f struct {
+		ImpersonationURL string `json:"service_account_impersonation_url"`
+	}
+	if err := json.Unmarshal(credsJSON, &f); err != nil {
+		return ""
+	}
+
+	return ServiceAccountFromImpersonationURL(f.ImpersonationURL)
+}
+
+// ServiceAccountFromImpersonationURL extracts the service account email from an
+// IAM Credentials URL like .../serviceAccounts/sa@p.iam.gserviceaccount.com:generateAccessToken.
+func ServiceAccountFromImpersonationURL(raw string) string {
+	_, rest, ok := strings.Cut(raw, "/serviceAccounts/")
+	if !ok {
+		return ""
+	}
+
+	email, _, _ := strings.Cut(rest, ":")
+
+	return strings.TrimSpace(email)
+}
+
+func credentialType(data []byte) string {
+	var f struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &f); err != nil {
+		return ""
+	}
+
+	return f.Type
+}
+
+type serviceAccountKeyCtxKey struct{}
+
+// WithServiceAccountKey makes clients built from ctx impersonate any account
+// with the credentials file at path (domain-wide delegation), instead of the
+// file stored for that account. Used to sweep every user of a Workspace domain.
+func WithServiceAccountKey(ctx context.Context, path string) context.Context {
+	return context.WithValue(ctx, serviceAccountKeyCtxKey{}, path)
+}
+
+func serviceAccountKeyFromContext(ctx context.Context) string {
+	path, _ := ctx.Value(serviceAccountKeyCtxKey{}).(string)
+	return path
 }
 
 func tokenSourceForServiceAccountScopes(ctx context.Context, email string, scopes []string) (oauth2.TokenSource, string, bool, error) {
+	if keyPath := serviceAccountKeyFromContext(ctx); keyPath != "" {
+		data, err := os.ReadFile(keyPath) //nolint:gosec // stored in user config dir
+		if err != nil {
+			return nil, "", false, fmt.Errorf("read service account key: %w", err)
+		}
+
+		ts, err := newServiceAccountTokenSource(ctx, data, email, scopes)
+		if err != nil {
+			return nil, "", false, err
+		}
+
+		return ts, keyPath, true, nil
+	}
+
 	saPath, err := config.ServiceAccountPath(email)
 	if err != nil {
 		return nil, "", false, fmt.Errorf("service account path: %w", err)
@@ -80,5 +263,14 @@ func tokenSourceForServiceAccountScopes(ctx context.Context, email string, scope
 		}
 	}
 
+	if UseADC() {
+		ts, err := newADCTokenSource(ctx, email, scopes)
+		if err != nil {
+			return nil, "", false, err
+		}
+
+		return ts, "adc", true, nil
+	}
+
 	return nil, "", false, nil
 }
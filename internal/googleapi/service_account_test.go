@@ -0,0 +1,142 @@
+package googleapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestNewServiceAccountTokenSource_ExternalAccountFileSourced(t *testing.T) {
+	t.Setenv("GOG_IMPERSONATE_SERVICE_ACCOUNT", "")
+
+	subjectPath := filepath.Join(t.TempDir(), "oidc-token")
+	if err := os.WriteFile(subjectPath, []byte("ci-oidc-token"), 0o600); err != nil {
+		t.Fatalf("write subject token: %v", err)
+	}
+
+	var gotSubject, gotScope string
+
+	sts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("ParseForm: %v", err)
+		}
+
+		gotSubject = r.Form.Get("subject_token")
+		gotScope = r.Form.Get("scope")
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token":      "federated-token",
+			"issued_token_type": "urn:ietf:params:oauth:token-type:access_token",
+			"token_type":        "Bearer",
+			"expires_in":        3600,
+		})
+	}))
+	t.Cleanup(sts.Close)
+
+	credsJSON, err := json.Marshal(map[string]any{
+		"type":               "external_account",
+		"audience":           "//iam.googleapis.com/projects/1/locations/global/workloadIdentityPools/ci/providers/gh",
+		"subject_token_type": "urn:ietf:params:oauth:token-type:jwt",
+		"token_url":          sts.URL + "/v1/token",
+		"credential_source":  map[string]any{"file": subjectPath},
+	})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	// Without an impersonation target the federated identity cannot act as a
+	// user; running as the wrong identity must be an error.
+	if _, err := newServiceAccountTokenSource(context.Background(), credsJSON, "a@b.com", []string{"https://www.googleapis.com/auth/drive"}); err == nil ||
+		!strings.Contains(err.Error(), "cannot act as a@b.com") {
+		t.Fatalf("expected cannot-act-as error, got %v", err)
+	}
+
+	ts, err := newServiceAccountTokenSource(context.Background(), credsJSON, "", []string{"https://www.googleapis.com/auth/drive"})
+	if err != nil {
+		t.Fatalf("newServiceAccountTokenSource: %v", err)
+	}
+
+	tok, err := ts.Token()
+	if err != nil {
+		t.Fatalf("Token: %v", err)
+	}
+
+	if tok.AccessToken != "federated-token" {
+		t.Fatalf("unexpected token: %+v", tok)
+	}
+
+	if gotSubject != "ci-oidc-token" || gotScope != "https://www.googleapis.com/auth/drive" {
+		t.Fatalf("unexpected STS request: subject=%q scope=%q", gotSubject, gotScope)
+	}
+}
+
+func TestTokenSourceForServiceAccountScopes_ADC(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg"))
+	t.Setenv("GOG_USE_ADC", "1")
+
+	origADC := newADCTokenSource
+	t.Cleanup(func() { newADCTokenSource = origADC })
+
+	newADCTokenSource = func(_ context.Context, subject string, _ []string) (oauth2.TokenSource, error) {
+		if subject != "a@b.com" {
+			t.Fatalf("unexpected subject: %q", subject)
+		}
+
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "adc"}), nil
+	}
+
+	ts, path, ok, err := tokenSourceForServiceAccountScopes(context.Background(), "a@b.com", []string{"s1"})
+	if err != nil || !ok || path != "adc" || ts == nil {
+		t.Fatalf("expected ADC token source, got ok=%v path=%q err=%v", ok, path, err)
+	}
+}
+
+func TestServiceAccountFromImpersonationURL(t *testing.T) {
+	got := ServiceAccountFromImpersonationURL("https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/ci@p.iam.gserviceaccount.com:generateAccessToken")
+	if got != "ci@p.iam.gserviceaccount.com" {
+		t.Fatalf("unexpected service account: %q", got)
+	}
+
+	if ServiceAccountFromImpersonationURL("") != "" {
+		t.Fatalf("expected empty result")
+	}
+}
+
+func TestTokenSourceForServiceAccountScopes_ContextKey(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg"))
+
+	keyPath := filepath.Join(home, "sa.json")
+	if err := os.WriteFile(keyPath, []byte(`{"type":"service_account"}`), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+
+	origSA := newServiceAccountTokenSource
+	t.Cleanup(func() { newServiceAccountTokenSource = origSA })
+
+	newServiceAccountTokenSource = func(_ context.Context, _ []byte, subject string, _ []string) (oauth2.TokenSource, error) {
+		if subject != "user@b.com" {
+			t.Fatalf("unexpected subject: %q", subject)
+		}
+
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "sa"}), nil
+	}
+
+	ctx := WithServiceAccountKey(context.Background(), keyPath)
+
+	_, path, ok, err := tokenSourceForServiceAccountScopes(ctx, "user@b.com", []string{"s1"})
+	if err != nil || !ok || path != keyPath {
+		t.Fatalf("expected shared key, got ok=%v path=%q err=%v", ok, path, err)
+	}
+}
@@ -0,0 +1,90 @@
+package googleapi
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/steipete/gogcli/internal/secrets"
+)
+
+// refreshRecordInterval throttles keyring writes of Token.LastRefreshAt.
+const refreshRecordInterval = time.Hour
+
+var timeNow = time.Now
+
+// refreshRecordingTokenSource stamps the stored token's LastRefreshAt after the
+// first successful refresh in this process (best effort; failures are logged).
+// Every refresh (a new access token from base) is logged as an
+// auth.token_refresh event.
+type refreshRecordingTokenSource struct {
+	base oauth2.TokenSource
+	tok  secrets.Token
+	once sync.Once
+
+	mu          sync.Mutex
+	accessToken string
+}
+
+func (s *refreshRecordingTokenSource) Token() (*oauth2.Token, error) {
+	t, err := s.base.Token()
+	if err != nil {
+		slog.Warn("token refresh failed", "event", "auth.token_refresh_failed", "email", s.tok.Email, "err", err)
+		return t, err
+	}
+
+	s.logRefresh(t)
+	s.once.Do(s.record)
+
+	return t, nil
+}
+
+func (s *refreshRecordingTokenSource) logRefresh(t *oauth2.Token) {
+	s.mu.Lock()
+	refreshed := t.AccessToken != s.accessToken
+	s.accessToken = t.AccessToken
+	s.mu.Unlock()
+
+	if refreshed {
+		slog.Info("access token refreshed", "event", "auth.token_refresh", "email", s.tok.Email, "expiry", t.Expiry)
+	}
+}
+
+func (s *refreshRecordingTokenSource) record() {
+	now := timeNow().UTC()
+	if now.Sub(s.tok.LastRefreshAt) < refreshRecordInterval {
+		return
+	}
+
+	if err := RecordTokenRefresh(s.tok.Client, s.tok.Email, s.tok.RefreshToken, now); err != nil {
+		slog.Debug("record token refresh failed", "email", s.tok.Email, "err", err)
+	}
+}
+
+// RecordTokenRefresh stores at as the last successful refresh of the given
+// refresh token. It is a no-op when the stored token changed meanwhile.
+func RecordTokenRefresh(client string, email string, refreshToken string, at time.Time) error {
+	store, err := openSecretsStore()
+	if err != nil {
+		return err
+	}
+
+	if _, ok := store.(*secrets.EnvStore); ok {
+		return nil
+	}
+
+	current, err := store.GetToken(client, email)
+	if err != nil {
+		return err
+	}
+
+	if current.RefreshToken != refreshToken {
+		return nil
+	}
+
+	current.LastRefreshAt = at.UTC()
+
+	return store.SetToken(client, email, current)
+}
@@ -0,0 +1,195 @@
+package googleapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// maxTraceBody caps request bodies echoed into curl commands.
+const maxTraceBody = 64 << 10
+
+// HTTPTrace configures --explain: each outgoing API request is logged to Out
+// as a curl command. With DryRun, requests that are not GET/HEAD are logged
+// but not sent; the transport returns a *DryRunRequestError instead.
+type HTTPTrace struct {
+	Out    io.Writer
+	DryRun bool
+
+	mu *sync.Mutex
+}
+
+type httpTraceKey struct{}
+
+// WithHTTPTrace enables request tracing for API clients created from ctx.
+func WithHTTPTrace(ctx context.Context, trace HTTPTrace) context.Context {
+	if trace.Out == nil {
+		return ctx
+	}
+	trace.mu = &sync.Mutex{}
+
+	return context.WithValue(ctx, httpTraceKey{}, trace)
+}
+
+func httpTraceFromContext(ctx context.Context) (HTTPTrace, bool) {
+	if ctx == nil {
+		return HTTPTrace{}, false
+	}
+	trace, ok := ctx.Value(httpTraceKey{}).(HTTPTrace)
+
+	return trace, ok
+}
+
+// DryRunRequestError is returned instead of sending a mutating request in
+// --explain --dry-run mode.
+type DryRunRequestError struct {
+	Method string
+	URL    string
+	Curl   string
+}
+
+func (e *DryRunRequestError) Error() string {
+	return "dry run: not sending " + e.Method + " " + e.URL
+}
+
+// traceTransport logs requests as curl commands and their outcome. It sits
+// above RetryTransport, so one line covers all retry attempts.
+type traceTransport struct {
+	Base  http.RoundTripper
+	Trace HTTPTrace
+}
+
+type retryCountKey struct{}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := ensureReplayableBody(req); err != nil {
+		return nil, err
+	}
+
+	curl := curlCommand(req)
+	if t.Trace.DryRun && req.Method != http.MethodGet && req.Method != http.MethodHead {
+		t.printf("# dry run (not sent)\n%s\n", curl)
+		return nil, &DryRunRequestError{Method: req.Method, URL: req.URL.String(), Curl: curl}
+	}
+	t.printf("%s\n", curl)
+
+	retries := 0
+	req = req.WithContext(context.WithValue(req.Context(), retryCountKey{}, &retries))
+	start := time.Now()
+
+	resp, err := t.Base.RoundTrip(req)
+	latency := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		t.printf("# -> error after %s (retries: %d): %v\n", latency, retries, err)
+		return resp, err
+	}
+
+	summary := fmt.Sprintf("# -> %s in %s (retries: %d)", resp.Status, latency, retries)
+	if resp.ContentLength >= 0 || resp.Body == nil {
+		t.printf("%s, %d bytes\n", summary, max(resp.ContentLength, 0))
+		return resp, nil
+	}
+
+	// Size is unknown until the caller has read the (chunked/compressed) body.
+	resp.Body = &countingBody{ReadCloser: resp.Body, done: func(n int64) {
+		t.printf("%s, %d bytes\n", summary, n)
+	}}
+
+	return resp, nil
+}
+
+func (t *traceTransport) printf(format string, args ...any) {
+	t.Trace.mu.Lock()
+	defer t.Trace.mu.Unlock()
+	_, _ = fmt.Fprintf(t.Trace.Out, format, args...)
+}
+
+// recordRetry counts a retry for the enclosing traceTransport, if any.
+func recordRetry(req *http.Request) {
+	if n, ok := req.Context().Value(retryCountKey{}).(*int); ok {
+		*n++
+	}
+}
+
+type countingBody struct {
+	io.ReadCloser
+	n    int64
+	once sync.Once
+	done func(int64)
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	if err == io.EOF {
+		b.once.Do(func() { b.done(b.n) })
+	}
+
+	return n, err
+}
+
+func (b *countingBody) Close() error {
+	b.once.Do(func() { b.done(b.n) })
+	return b.ReadCloser.Close()
+}
+
+// curlCommand renders req as a copy-pasteable curl command. The bearer token
+// is never available here (oauth2.Transport sits below), so Authorization is
+// always a placeholder.
+func curlCommand(req *http.Request) string {
+	var b strings.Builder
+	b.WriteString("curl -X " + req.Method + " " + shellQuote(req.URL.String()))
+	b.WriteString(" \\\n  -H " + shellQuote("Authorization: Bearer REDACTED"))
+
+	keys := make([]string, 0, len(req.Header))
+	for k := range req.Header {
+		switch http.CanonicalHeaderKey(k) {
+		case "Authorization", "Content-Length", "Accept-Encoding":
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		for _, v := range req.Header[k] {
+			b.WriteString(" \\\n  -H " + shellQuote(k+": "+v))
+		}
+	}
+
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(io.LimitReader(body, maxTraceBody+1))
+			_ = body.Close()
+			switch {
+			case len(data) == 0:
+			case len(data) > maxTraceBody || !utf8.Valid(data):
+				fmt.Fprintf(&b, " \\\n  --data-binary @body.bin  # %s body omitted", bodySizeLabel(req, len(data)))
+			default:
+				b.WriteString(" \\\n  --data-binary " + shellQuote(string(data)))
+			}
+		}
+	}
+
+	return b.String()
+}
+
+func bodySizeLabel(req *http.Request, read int) string {
+	if req.ContentLength > 0 {
+		return fmt.Sprintf("%d-byte", req.ContentLength)
+	}
+	if read > maxTraceBody {
+		return fmt.Sprintf(">%d-byte", maxTraceBody)
+	}
+
+	return fmt.Sprintf("%d-byte binary", read)
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
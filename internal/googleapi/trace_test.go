@@ -0,0 +1,108 @@
+package googleapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestTraceTransport_LogsCurlStatusRetriesAndSize(t *testing.T) {
+	calls := 0
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		if req.Header.Get("Authorization") != "" {
+			t.Fatalf("trace must not see credentials")
+		}
+		if calls == 1 {
+			return newTestResponse(http.StatusTooManyRequests, "slow down"), nil
+		}
+		resp := newTestResponse(http.StatusOK, `{"id":"e1"}`)
+		resp.Status = "200 OK"
+		resp.ContentLength = -1
+		return resp, nil
+	})
+
+	var out bytes.Buffer
+	ctx := WithHTTPTrace(context.Background(), HTTPTrace{Out: &out})
+	trace, _ := httpTraceFromContext(ctx)
+	rt := &traceTransport{
+		Base:  &RetryTransport{Base: base, MaxRetries429: 1, MaxRetries5xx: 1},
+		Trace: trace,
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://www.googleapis.com/calendar/v3/calendars/primary/events?alt=json", strings.NewReader(`{"summary":"it's on"}`))
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("round trip: %v", err)
+	}
+	_, _ = io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	got := out.String()
+	for _, want := range []string{
+		`curl -X POST 'https://www.googleapis.com/calendar/v3/calendars/primary/events?alt=json'`,
+		`-H 'Authorization: Bearer REDACTED'`,
+		`-H 'Content-Type: application/json'`,
+		`--data-binary '{"summary":"it'\''s on"}'`,
+		"# -> 200 OK in ",
+		"(retries: 1), 11 bytes",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("trace missing %q:\n%s", want, got)
+		}
+	}
+}
+
+func TestTraceTransport_DryRunBlocksMutations(t *testing.T) {
+	sent := 0
+	base := roundTripFunc(func(*http.Request) (*http.Response, error) {
+		sent++
+		return newTestResponse(http.StatusOK, "{}"), nil
+	})
+
+	var out bytes.Buffer
+	ctx := WithHTTPTrace(context.Background(), HTTPTrace{Out: &out, DryRun: true})
+	trace, _ := httpTraceFromContext(ctx)
+	rt := &traceTransport{Base: base, Trace: trace}
+
+	get, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://example.com/items", nil)
+	resp, err := rt.RoundTrip(get)
+	if err != nil {
+		t.Fatalf("GET should be sent: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	del, _ := http.NewRequestWithContext(ctx, http.MethodDelete, "https://example.com/items/1", nil)
+	_, err = rt.RoundTrip(del)
+	var dryErr *DryRunRequestError
+	if !errors.As(err, &dryErr) || dryErr.Method != http.MethodDelete || !strings.Contains(dryErr.Curl, "curl -X DELETE") {
+		t.Fatalf("expected DryRunRequestError, got %v", err)
+	}
+	if sent != 1 {
+		t.Fatalf("expected only the GET to be sent, got %d", sent)
+	}
+	if !strings.Contains(out.String(), "# dry run (not sent)") {
+		t.Fatalf("expected dry-run marker:\n%s", out.String())
+	}
+}
+
+func TestCurlCommand_OmitsBinaryBody(t *testing.T) {
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "https://example.com/upload", bytes.NewReader([]byte{0xff, 0xfe, 0x00}))
+	if err := ensureReplayableBody(req); err != nil {
+		t.Fatalf("replayable: %v", err)
+	}
+
+	got := curlCommand(req)
+	if !strings.Contains(got, "--data-binary @body.bin  # 3-byte body omitted") {
+		t.Fatalf("unexpected curl: %s", got)
+	}
+}
@@ -8,7 +8,10 @@ import (
 	"math/rand/v2"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
+
+	"github.com/steipete/gogcli/internal/invocation"
 )
 
 // RetryTransport wraps an http.RoundTripper with retry logic for
@@ -19,6 +22,9 @@ type RetryTransport struct {
 	MaxRetries5xx  int
 	BaseDelay      time.Duration
 	CircuitBreaker *CircuitBreaker
+	// Backoff is shared by every request through this transport, so concurrent
+	// workers (see workpool) wait out a 429 together instead of piling on.
+	Backoff *BackoffGate
 }
 
 // NewRetryTransport creates a RetryTransport with sensible defaults.
@@ -33,12 +39,19 @@ func NewRetryTransport(base http.RoundTripper) *RetryTransport {
 		MaxRetries5xx:  Max5xxRetries,
 		BaseDelay:      RateLimitBaseDelay,
 		CircuitBreaker: NewCircuitBreaker(),
+		Backoff:        &BackoffGate{},
 	}
 }
 
 // RoundTrip implements http.RoundTripper with retry logic.
 func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	if t.CircuitBreaker != nil && t.CircuitBreaker.IsOpen() {
+		slog.Warn("circuit breaker open, request rejected",
+			"event", "circuit_breaker.reject",
+			"method", req.Method,
+			"host", req.URL.Host,
+			"path", req.URL.Path)
+
 		return nil, &CircuitBreakerError{}
 	}
 
@@ -52,6 +65,10 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	retries5xx := 0
 
 	for {
+		if err := t.Backoff.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+
 		// Reset body for retry
 		if req.GetBody != nil {
 			if req.Body != nil {
@@ -86,7 +103,14 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 			}
 
 			delay := t.calculateBackoff(retries429, resp)
-			slog.Debug("rate limited, retrying",
+			t.Backoff.Pause(delay)
+			slog.Info("rate limited, retrying",
+				"event", "http.retry",
+				"reason", "rate_limited",
+				"status", resp.StatusCode,
+				"method", req.Method,
+				"host", req.URL.Host,
+				"path", req.URL.Path,
 				"delay", delay,
 				"attempt", retries429+1,
 				"max_retries", t.MaxRetries429)
@@ -98,6 +122,7 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 			}
 
 			retries429++
+			recordRetry(req)
 
 			continue
 		}
@@ -112,9 +137,16 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 				return resp, nil
 			}
 
-			slog.Debug("server error, retrying",
+			slog.Info("server error, retrying",
+				"event", "http.retry",
+				"reason", "server_error",
 				"status", resp.StatusCode,
-				"attempt", retries5xx+1)
+				"method", req.Method,
+				"host", req.URL.Host,
+				"path", req.URL.Path,
+				"delay", ServerErrorRetryDelay,
+				"attempt", retries5xx+1,
+				"max_retries", t.MaxRetries5xx)
 
 			drainAndClose(resp.Body)
 
@@ -123,6 +155,7 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 			}
 
 			retries5xx++
+			recordRetry(req)
 
 			continue
 		}
@@ -191,6 +224,63 @@ func (t *RetryTransport) sleep(ctx context.Context, d time.Duration) error {
 	}
 }
 
+// invocationTransport sends the invocation ID of the request context to
+// Google as the X-Request-Id header.
+type invocationTransport struct {
+	Base http.RoundTripper
+}
+
+func (t *invocationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if id := invocation.ID(req.Context()); id != "" && req.Header.Get(invocation.Header) == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set(invocation.Header, id)
+	}
+
+	return t.Base.RoundTrip(req)
+}
+
+// BackoffGate holds back all requests of a transport until a rate-limit
+// backoff has elapsed. The zero value (and nil) never blocks.
+type BackoffGate struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+// Pause blocks new attempts for d (extending, never shortening, a pause in effect).
+func (g *BackoffGate) Pause(d time.Duration) {
+	if g == nil || d <= 0 {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if until := time.Now().Add(d); until.After(g.until) {
+		g.until = until
+	}
+}
+
+// Wait sleeps until the current pause (if any) is over.
+func (g *BackoffGate) Wait(ctx context.Context) error {
+	if g == nil {
+		return nil
+	}
+	g.mu.Lock()
+	remaining := time.Until(g.until)
+	g.mu.Unlock()
+	if remaining <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(remaining)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("rate-limit backoff interrupted: %w", ctx.Err())
+	}
+}
+
 // bytesReader is a simple bytes.Reader replacement to avoid import
 type bytesReader struct {
 	data []byte
@@ -383,3 +383,29 @@ func TestRetryTransportRoundTripError(t *testing.T) {
 		t.Fatalf("expected error")
 	}
 }
+
+func TestBackoffGate_PausesSharedRequests(t *testing.T) {
+	var nilGate *BackoffGate
+	if err := nilGate.Wait(context.Background()); err != nil {
+		t.Fatalf("nil gate: %v", err)
+	}
+
+	g := &BackoffGate{}
+	g.Pause(40 * time.Millisecond)
+	g.Pause(time.Millisecond) // never shortens
+
+	start := time.Now()
+	if err := g.Wait(context.Background()); err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Fatalf("expected to wait out the pause, waited %s", elapsed)
+	}
+
+	g.Pause(time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := g.Wait(ctx); err == nil {
+		t.Fatalf("expected canceled wait to fail")
+	}
+}
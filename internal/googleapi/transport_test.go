@@ -8,6 +8,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/steipete/gogcli/internal/invocation"
 )
 
 // mockTransport implements http.RoundTripper for testing
@@ -442,3 +444,26 @@ func TestEnsureReplayableBody(t *testing.T) {
 		t.Fatalf("unexpected body replay: %q %q", string(first), string(second))
 	}
 }
+
+func TestInvocationTransport_SetsRequestID(t *testing.T) {
+	var got string
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		got = req.Header.Get(invocation.Header)
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(""))}, nil
+	})
+
+	ctx := invocation.WithID(context.Background(), "abc123")
+	req, _ := http.NewRequestWithContext(ctx, "GET", "https://example.com", nil)
+	resp, err := (&invocationTransport{Base: base}).RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if got != "abc123" {
+		t.Fatalf("expected request id header, got %q", got)
+	}
+	if req.Header.Get(invocation.Header) != "" {
+		t.Fatalf("original request must not be modified")
+	}
+}
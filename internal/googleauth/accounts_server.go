@@ -48,9 +48,17 @@ type ManageServer struct {
 	store      secrets.Store
 	fetchEmail func(ctx context.Context, tok *oauth2.Token) (string, error)
 	oauthState string
+	upgrade    *scopeUpgrade
 	resultCh   chan error
 }
 
+// scopeUpgrade records an in-flight incremental authorization started via
+// /auth/upgrade?services=..., so the callback stores the merged grant.
+type scopeUpgrade struct {
+	services []Service
+	scopes   []string
+}
+
 var (
 	openDefaultStore          = secrets.OpenDefault
 	resolveKeyringBackendInfo = secrets.ResolveKeyringBackendInfo
@@ -236,6 +244,7 @@ func (ms *ManageServer) handleAuthStart(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	ms.oauthState = state
+	ms.upgrade = nil
 
 	services := manageServices(ms.opts.Services)
 
@@ -290,6 +299,21 @@ func (ms *ManageServer) handleAuthUpgrade(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	ms.upgrade = nil
+
+	// Incremental upgrade: request only the added services' scopes and keep
+	// the account's existing grant (include_granted_scopes).
+	if raw := strings.TrimSpace(r.URL.Query().Get("services")); raw != "" {
+		upgrade, requested, upgradeErr := ms.incrementalUpgrade(email, raw)
+		if upgradeErr != nil {
+			http.Error(w, upgradeErr.Error(), http.StatusBadRequest)
+			return
+		}
+
+		ms.upgrade = upgrade
+		scopes = IncrementalScopes(requested)
+	}
+
 	port := ms.listener.Addr().(*net.TCPAddr).Port
 	redirectURI := fmt.Sprintf("http://127.0.0.1:%d/oauth2/callback", port)
 
@@ -310,6 +334,50 @@ func (ms *ManageServer) handleAuthUpgrade(w http.ResponseWriter, r *http.Request
 	http.Redirect(w, r, authURL, http.StatusFound)
 }
 
+func (ms *ManageServer) incrementalUpgrade(email string, servicesCSV string) (*scopeUpgrade, []string, error) {
+	var add []Service
+
+	for _, part := range strings.Split(servicesCSV, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+
+		svc, err := ParseService(part)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		add = append(add, svc)
+	}
+
+	add = manageServices(add)
+
+	requested, err := ScopesForServices(add)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var existing secrets.Token
+	if ms.store != nil {
+		if tok, getErr := ms.store.GetToken(ms.client, email); getErr == nil {
+			existing = tok
+		}
+	}
+
+	services := UpgradeServices(existing.Services, add)
+	if len(existing.Scopes) == 0 {
+		// No usable record of the old grant; store what the merged services imply.
+		if all, allErr := ScopesForManage(services); allErr == nil {
+			existing.Scopes = all
+		}
+	}
+
+	return &scopeUpgrade{
+		services: services,
+		scopes:   mergeScopes(existing.Scopes, IncrementalScopes(requested)),
+	}, requested, nil
+}
+
 func (ms *ManageServer) handleOAuthCallback(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query()
 
@@ -355,6 +423,12 @@ func (ms *ManageServer) handleOAuthCallback(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if up := ms.upgrade; up != nil {
+		services = up.services
+		scopes = up.scopes
+		ms.upgrade = nil
+	}
+
 	port := ms.listener.Addr().(*net.TCPAddr).Port
 	redirectURI := fmt.Sprintf("http://127.0.0.1:%d/oauth2/callback", port)
 
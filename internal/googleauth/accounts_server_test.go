@@ -57,7 +57,15 @@ func (s *fakeStore) SetToken(client string, email string, tok secrets.Token) err
 
 	return nil
 }
-func (s *fakeStore) GetToken(string, string) (secrets.Token, error) { return secrets.Token{}, nil }
+func (s *fakeStore) GetToken(_ string, email string) (secrets.Token, error) {
+	for _, tok := range s.tokens {
+		if tok.Email == email {
+			return tok, nil
+		}
+	}
+
+	return secrets.Token{}, nil
+}
 func (s *fakeStore) DeleteToken(client string, email string) error {
 	s.deleteClient = client
 	s.deleteCalled = email
@@ -953,6 +961,72 @@ func TestManageServer_HandleAuthUpgrade(t *testing.T) {
 	}
 }
 
+func TestManageServer_HandleAuthUpgrade_IncrementalServices(t *testing.T) {
+	origRead := readClientCredentials
+	origState := randomStateFn
+	origEndpoint := oauthEndpoint
+
+	t.Cleanup(func() {
+		readClientCredentials = origRead
+		randomStateFn = origState
+		oauthEndpoint = origEndpoint
+	})
+
+	readClientCredentials = func(string) (config.ClientCredentials, error) {
+		return config.ClientCredentials{ClientID: "id", ClientSecret: "secret"}, nil
+	}
+	randomStateFn = func() (string, error) { return "state789", nil }
+	oauthEndpoint = oauth2.Endpoint{AuthURL: "http://example.com/auth", TokenURL: "http://example.com/token"}
+
+	ln, err := (&net.ListenConfig{}).Listen(context.Background(), "tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	t.Cleanup(func() { _ = ln.Close() })
+
+	gmailScopes, _ := Scopes(ServiceGmail)
+	ms := &ManageServer{
+		listener: ln,
+		opts:     ManageServerOptions{Services: []Service{ServiceGmail}},
+		store: &fakeStore{tokens: []secrets.Token{{
+			Email:    "test@example.com",
+			Services: []string{"gmail"},
+			Scopes:   gmailScopes,
+		}}},
+	}
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/auth/upgrade?email=test@example.com&services=calendar", nil)
+	ms.handleAuthUpgrade(rr, req)
+
+	if rr.Code != http.StatusFound {
+		t.Fatalf("status: %d", rr.Code)
+	}
+
+	parsed, err := url.Parse(rr.Header().Get("Location"))
+	if err != nil {
+		t.Fatalf("parse location: %v", err)
+	}
+
+	scope := parsed.Query().Get("scope")
+	if !strings.Contains(scope, "https://www.googleapis.com/auth/calendar") || strings.Contains(scope, "gmail.modify") {
+		t.Fatalf("expected only calendar + identity scopes, got %q", scope)
+	}
+
+	if parsed.Query().Get("include_granted_scopes") != "true" {
+		t.Fatalf("expected include_granted_scopes")
+	}
+
+	if ms.upgrade == nil || len(ms.upgrade.services) != 2 {
+		t.Fatalf("expected merged services, got %#v", ms.upgrade)
+	}
+
+	stored := strings.Join(ms.upgrade.scopes, " ")
+	if !strings.Contains(stored, "gmail.modify") || !strings.Contains(stored, "auth/calendar") {
+		t.Fatalf("expected merged scopes, got %q", stored)
+	}
+}
+
 func TestManageServer_HandleAuthUpgrade_MissingEmail(t *testing.T) {
 	ms := &ManageServer{}
 	rr := httptest.NewRecorder()
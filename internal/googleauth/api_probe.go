@@ -0,0 +1,92 @@
+package googleauth
+
+// APIProbe describes a cheap, read-only request that tells whether a service's
+// Google API is enabled for the OAuth client's Cloud project.
+//
+// Probes for APIs without a list/self endpoint target a placeholder resource ID:
+// a 404 still proves the API is enabled, while a disabled API answers 403
+// accessNotConfigured before the resource is looked up.
+type APIProbe struct {
+	API string `json:"api"`
+	URL string `json:"url"`
+}
+
+const probePlaceholderID = "gog-doctor-probe"
+
+var apiProbes = map[Service]APIProbe{
+	ServiceGmail: {
+		API: "gmail.googleapis.com",
+		URL: "https://gmail.googleapis.com/gmail/v1/users/me/profile",
+	},
+	ServiceCalendar: {
+		API: "calendar-json.googleapis.com",
+		URL: "https://www.googleapis.com/calendar/v3/users/me/calendarList?maxResults=1",
+	},
+	ServiceChat: {
+		API: "chat.googleapis.com",
+		URL: "https://chat.googleapis.com/v1/spaces?pageSize=1",
+	},
+	ServiceClassroom: {
+		API: "classroom.googleapis.com",
+		URL: "https://classroom.googleapis.com/v1/courses?pageSize=1",
+	},
+	ServiceDrive: {
+		API: "drive.googleapis.com",
+		URL: "https://www.googleapis.com/drive/v3/about?fields=user",
+	},
+	ServiceDocs: {
+		API: "docs.googleapis.com",
+		URL: "https://docs.googleapis.com/v1/documents/" + probePlaceholderID,
+	},
+	ServiceSlides: {
+		API: "slides.googleapis.com",
+		URL: "https://slides.googleapis.com/v1/presentations/" + probePlaceholderID,
+	},
+	ServiceContacts: {
+		API: "people.googleapis.com",
+		URL: "https://people.googleapis.com/v1/people/me/connections?pageSize=1&personFields=names",
+	},
+	ServiceTasks: {
+		API: "tasks.googleapis.com",
+		URL: "https://tasks.googleapis.com/tasks/v1/users/@me/lists?maxResults=1",
+	},
+	ServicePeople: {
+		API: "people.googleapis.com",
+		URL: "https://people.googleapis.com/v1/people/me?personFields=names",
+	},
+	ServiceSheets: {
+		API: "sheets.googleapis.com",
+		URL: "https://sheets.googleapis.com/v4/spreadsheets/" + probePlaceholderID,
+	},
+	ServiceForms: {
+		API: "forms.googleapis.com",
+		URL: "https://forms.googleapis.com/v1/forms/" + probePlaceholderID,
+	},
+	ServiceAppScript: {
+		API: "script.googleapis.com",
+		URL: "https://script.googleapis.com/v1/projects/" + probePlaceholderID,
+	},
+	ServiceGroups: {
+		API: "cloudidentity.googleapis.com",
+		URL: "https://cloudidentity.googleapis.com/v1/groups?parent=customers/my_customer&pageSize=1",
+	},
+	ServiceKeep: {
+		API: "keep.googleapis.com",
+		URL: "https://keep.googleapis.com/v1/notes?pageSize=1",
+	},
+}
+
+// ProbeForService returns the API probe for a service.
+func ProbeForService(service Service) (APIProbe, bool) {
+	p, ok := apiProbes[service]
+	return p, ok
+}
+
+// EnableAPIURL returns the Cloud console page where an API can be enabled.
+func EnableAPIURL(api string) string {
+	if api == "" {
+		return "https://console.cloud.google.com/apis/library"
+	}
+
+	return "https://console.cloud.google.com/apis/library/" + api
+}
@@ -29,6 +29,8 @@ type AuthorizeOptions struct {
 	AuthCode     string
 	AuthURL      string
 	RequireState bool
+	// LoginHint pre-selects the account on Google's consent screen.
+	LoginHint string
 }
 
 type ManualAuthURLResult struct {
@@ -204,7 +206,7 @@ func authorizeServer(ctx context.Context, opts AuthorizeOptions, creds config.Cl
 		}
 	}()
 
-	authURL := cfg.AuthCodeURL(state, authURLParams(opts.ForceConsent)...)
+	authURL := cfg.AuthCodeURL(state, authCodeOptions(opts)...)
 
 	fmt.Fprintln(os.Stderr, "Opening browser for authorization…")
 	fmt.Fprintln(os.Stderr, "If the browser doesn't open, visit this URL:")
@@ -257,6 +259,15 @@ func authURLParams(forceConsent bool) []oauth2.AuthCodeOption {
 	return opts
 }
 
+func authCodeOptions(opts AuthorizeOptions) []oauth2.AuthCodeOption {
+	params := authURLParams(opts.ForceConsent)
+	if hint := strings.TrimSpace(opts.LoginHint); hint != "" {
+		params = append(params, oauth2.SetAuthURLParam("login_hint", hint))
+	}
+
+	return params
+}
+
 func randomState() (string, error) {
 	b := make([]byte, 32)
 	if _, err := rand.Read(b); err != nil {
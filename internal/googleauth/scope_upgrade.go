@@ -0,0 +1,34 @@
+package googleauth
+
+// IncrementalScopes returns the scopes to request when adding missing scopes to
+// an existing grant. Together with include_granted_scopes (see authURLParams)
+// the resulting refresh token covers both the old and the new scopes.
+func IncrementalScopes(missing []string) []string {
+	return mergeScopes(missing, []string{scopeOpenID, scopeEmail, scopeUserinfoEmail})
+}
+
+// UpgradeServices merges the services recorded for a stored token with newly
+// requested ones, in canonical service order. Unknown names are dropped.
+func UpgradeServices(existing []string, add []Service) []Service {
+	want := make(map[Service]struct{}, len(existing)+len(add))
+
+	for _, name := range existing {
+		if svc, err := ParseService(name); err == nil {
+			want[svc] = struct{}{}
+		}
+	}
+
+	for _, svc := range add {
+		want[svc] = struct{}{}
+	}
+
+	out := make([]Service, 0, len(want))
+
+	for _, svc := range serviceOrder {
+		if _, ok := want[svc]; ok {
+			out = append(out, svc)
+		}
+	}
+
+	return out
+}
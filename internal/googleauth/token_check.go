@@ -4,19 +4,28 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 	"time"
 
 	"golang.org/x/oauth2"
 )
 
 func CheckRefreshToken(ctx context.Context, client string, refreshToken string, scopes []string, timeout time.Duration) error {
+	_, err := RefreshAccessToken(ctx, client, refreshToken, scopes, timeout)
+	return err
+}
+
+// RefreshAccessToken exchanges a refresh token for an access token using the stored
+// client credentials. The returned token carries the granted scopes (see GrantedScopes).
+func RefreshAccessToken(ctx context.Context, client string, refreshToken string, scopes []string, timeout time.Duration) (*oauth2.Token, error) {
 	if timeout <= 0 {
 		timeout = 15 * time.Second
 	}
 
 	creds, err := readClientCredentials(client)
 	if err != nil {
-		return fmt.Errorf("read credentials: %w", err)
+		return nil, fmt.Errorf("read credentials: %w", err)
 	}
 
 	cfg := oauth2.Config{
@@ -32,9 +41,62 @@ func CheckRefreshToken(ctx context.Context, client string, refreshToken string,
 	ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Timeout: timeout})
 
 	ts := cfg.TokenSource(ctx, &oauth2.Token{RefreshToken: refreshToken})
-	if _, err := ts.Token(); err != nil {
-		return fmt.Errorf("refresh access token: %w", err)
+
+	tok, err := ts.Token()
+	if err != nil {
+		return nil, fmt.Errorf("refresh access token: %w", err)
+	}
+
+	return tok, nil
+}
+
+// GrantedScopes returns the space-separated "scope" field Google includes in token
+// responses, sorted. It returns nil when the response did not include scopes.
+func GrantedScopes(tok *oauth2.Token) []string {
+	if tok == nil {
+		return nil
+	}
+
+	raw, _ := tok.Extra("scope").(string)
+
+	scopes := strings.Fields(raw)
+	if len(scopes) == 0 {
+		return nil
 	}
 
-	return nil
+	sort.Strings(scopes)
+
+	return scopes
+}
+
+// MissingScopes returns the scopes in required that are not present in granted.
+func MissingScopes(granted []string, required []string) []string {
+	have := make(map[string]struct{}, len(granted))
+	for _, s := range granted {
+		have[s] = struct{}{}
+	}
+
+	var missing []string
+
+	for _, s := range required {
+		if _, ok := have[s]; ok {
+			continue
+		}
+
+		if alias, ok := scopeAliases[s]; ok {
+			if _, ok := have[alias]; ok {
+				continue
+			}
+		}
+
+		missing = append(missing, s)
+	}
+
+	return missing
+}
+
+// Google echoes some OIDC shorthand scopes back in their long form.
+var scopeAliases = map[string]string{
+	scopeEmail: scopeUserinfoEmail,
+	"profile":  "https://www.googleapis.com/auth/userinfo.profile",
 }
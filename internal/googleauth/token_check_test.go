@@ -92,3 +92,23 @@ func TestCheckRefreshTokenReadCredentialsError(t *testing.T) {
 		t.Fatalf("expected error")
 	}
 }
+
+func TestGrantedAndMissingScopes(t *testing.T) {
+	tok := (&oauth2.Token{AccessToken: "a"}).WithExtra(map[string]any{
+		"scope": "https://www.googleapis.com/auth/userinfo.email https://www.googleapis.com/auth/calendar openid",
+	})
+
+	granted := GrantedScopes(tok)
+	if len(granted) != 3 || granted[0] != "https://www.googleapis.com/auth/calendar" {
+		t.Fatalf("unexpected granted scopes: %v", granted)
+	}
+
+	missing := MissingScopes(granted, []string{"email", "https://www.googleapis.com/auth/calendar", "https://www.googleapis.com/auth/tasks"})
+	if len(missing) != 1 || missing[0] != "https://www.googleapis.com/auth/tasks" {
+		t.Fatalf("unexpected missing scopes: %v", missing)
+	}
+
+	if got := GrantedScopes(&oauth2.Token{AccessToken: "a"}); got != nil {
+		t.Fatalf("expected nil scopes, got %v", got)
+	}
+}
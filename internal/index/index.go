@@ -0,0 +1,232 @@
+// Package index is the local, offline mirror behind `gog index`: one
+// gzip-compressed JSON store per account holding Gmail, Drive and Calendar
+// documents plus the incremental sync cursors used to keep them current.
+package index
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Services that can be indexed.
+const (
+	ServiceGmail    = "gmail"
+	ServiceDrive    = "drive"
+	ServiceCalendar = "calendar"
+)
+
+// FormatVersion is bumped when the stored layout changes incompatibly; older
+// stores are discarded and re-synced.
+const FormatVersion = 1
+
+// Doc is one indexed item. Fields holds the filterable metadata (from, to,
+// label, mime, owner, location, attendee, ...).
+type Doc struct {
+	Service    string            `json:"service"`
+	Account    string            `json:"account"`
+	ID         string            `json:"id"`
+	ThreadID   string            `json:"threadId,omitempty"`
+	CalendarID string            `json:"calendarId,omitempty"`
+	Title      string            `json:"title,omitempty"`
+	Text       string            `json:"text,omitempty"`
+	Date       time.Time         `json:"date,omitzero"`
+	URL        string            `json:"url,omitempty"`
+	Fields     map[string]string `json:"fields,omitempty"`
+}
+
+// Key identifies d within its store. Calendar events are keyed per calendar,
+// since one event can appear on several calendars.
+func (d *Doc) Key() string {
+	return docKey(d.Service, d.CalendarID, d.ID)
+}
+
+func docKey(service, calendarID, id string) string {
+	if calendarID != "" {
+		return service + "/" + calendarID + "/" + id
+	}
+
+	return service + "/" + id
+}
+
+// State holds the incremental sync cursors of a store.
+type State struct {
+	GmailHistoryID     string               `json:"gmailHistoryId,omitempty"`
+	GmailBodies        bool                 `json:"gmailBodies,omitempty"`
+	DrivePageToken     string               `json:"drivePageToken,omitempty"`
+	CalendarSyncTokens map[string]string    `json:"calendarSyncTokens,omitempty"`
+	SyncedAt           map[string]time.Time `json:"syncedAt,omitempty"`
+}
+
+// Store is the index of one account. It is loaded and saved as a whole.
+type Store struct {
+	Version int             `json:"version"`
+	Account string          `json:"account"`
+	State   State           `json:"state"`
+	Docs    map[string]*Doc `json:"docs"`
+
+	path string
+}
+
+// Open loads the store at path, or returns an empty one when it does not
+// exist yet (or was written by an incompatible version).
+func Open(path string, account string) (*Store, error) {
+	s := &Store{Version: FormatVersion, Account: account, Docs: map[string]*Doc{}, path: path}
+
+	f, err := os.Open(path) //nolint:gosec // index path under the config dir
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return s, nil
+		}
+		return nil, fmt.Errorf("open index: %w", err)
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("read index %s: %w", path, err)
+	}
+	defer zr.Close()
+
+	var loaded Store
+	if err := json.NewDecoder(zr).Decode(&loaded); err != nil {
+		return nil, fmt.Errorf("decode index %s: %w", path, err)
+	}
+	if loaded.Version != FormatVersion {
+		return s, nil
+	}
+	if loaded.Docs == nil {
+		loaded.Docs = map[string]*Doc{}
+	}
+	if loaded.Account == "" {
+		loaded.Account = account
+	}
+	loaded.path = path
+
+	return &loaded, nil
+}
+
+// Path is the file the store is saved to.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Save writes the store atomically (temp file + rename).
+func (s *Store) Save() error {
+	if s.path == "" {
+		return errors.New("missing index path")
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("ensure index dir: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".index-*.tmp")
+	if err != nil {
+		return fmt.Errorf("create index temp file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	zw := gzip.NewWriter(tmp)
+	if err := json.NewEncoder(zw).Encode(s); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("encode index: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("write index: %w", err)
+	}
+	if err := tmp.Chmod(0o600); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("chmod index: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close index: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("replace index: %w", err)
+	}
+
+	return nil
+}
+
+// Put inserts or replaces d.
+func (s *Store) Put(d *Doc) {
+	if d == nil || d.ID == "" {
+		return
+	}
+	if d.Account == "" {
+		d.Account = s.Account
+	}
+	s.Docs[d.Key()] = d
+}
+
+// Delete removes a document; it reports whether it existed.
+func (s *Store) Delete(service, calendarID, id string) bool {
+	key := docKey(service, calendarID, id)
+	if _, ok := s.Docs[key]; !ok {
+		return false
+	}
+	delete(s.Docs, key)
+
+	return true
+}
+
+// Clear removes every document of service (limited to calendarID for
+// calendar, when set) ahead of a full re-sync.
+func (s *Store) Clear(service, calendarID string) {
+	for key, d := range s.Docs {
+		if d.Service == service && (calendarID == "" || d.CalendarID == calendarID) {
+			delete(s.Docs, key)
+		}
+	}
+}
+
+// Count returns the number of documents per service.
+func (s *Store) Count() map[string]int {
+	out := map[string]int{}
+	for _, d := range s.Docs {
+		out[d.Service]++
+	}
+
+	return out
+}
+
+// MarkSynced records a successful sync of service.
+func (s *Store) MarkSynced(service string, at time.Time) {
+	if s.State.SyncedAt == nil {
+		s.State.SyncedAt = map[string]time.Time{}
+	}
+	s.State.SyncedAt[service] = at.UTC()
+}
+
+// List returns the paths of every store file in dir, sorted.
+func List(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("list index dir: %w", err)
+	}
+
+	var out []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), FileSuffix) {
+			continue
+		}
+		out = append(out, filepath.Join(dir, e.Name()))
+	}
+	sort.Strings(out)
+
+	return out, nil
+}
+
+// FileSuffix is the extension of store files.
+const FileSuffix = ".json.gz"
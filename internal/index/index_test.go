@@ -0,0 +1,158 @@
+package index
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStore_SaveOpenRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "a_b_com"+FileSuffix)
+
+	s, err := Open(path, "a@b.com")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	s.Put(&Doc{Service: ServiceGmail, ID: "m1", Title: "Hello"})
+	s.Put(&Doc{Service: ServiceCalendar, CalendarID: "primary", ID: "e1", Title: "Standup"})
+	s.Put(&Doc{Service: ServiceCalendar, CalendarID: "team", ID: "e1", Title: "Standup"})
+	s.State.GmailHistoryID = "42"
+	s.MarkSynced(ServiceGmail, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	loaded, err := Open(path, "")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if loaded.Account != "a@b.com" || loaded.State.GmailHistoryID != "42" {
+		t.Fatalf("unexpected state: %#v", loaded)
+	}
+	if got := loaded.Count(); got[ServiceGmail] != 1 || got[ServiceCalendar] != 2 {
+		t.Fatalf("unexpected counts: %v", got)
+	}
+	if loaded.Docs["gmail/m1"].Account != "a@b.com" {
+		t.Fatalf("expected account on doc, got %#v", loaded.Docs["gmail/m1"])
+	}
+
+	loaded.Clear(ServiceCalendar, "team")
+	if !loaded.Delete(ServiceGmail, "", "m1") || loaded.Delete(ServiceGmail, "", "m1") {
+		t.Fatalf("expected a single successful delete")
+	}
+	if got := loaded.Count(); got[ServiceGmail] != 0 || got[ServiceCalendar] != 1 {
+		t.Fatalf("unexpected counts after clear/delete: %v", got)
+	}
+
+	paths, err := List(filepath.Dir(path))
+	if err != nil || len(paths) != 1 || paths[0] != path {
+		t.Fatalf("List = %v, %v", paths, err)
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	q, err := ParseQuery(`budget "q3 plan" -draft rep* from:Alice service:gmail after:2026-01-01 http://x.test`)
+	if err != nil {
+		t.Fatalf("ParseQuery: %v", err)
+	}
+	if want := []string{"budget", "rep*", "http", "x", "test"}; !equalStrings(q.Terms, want) {
+		t.Fatalf("terms = %v, want %v", q.Terms, want)
+	}
+	if !equalStrings(q.Phrases, []string{"q3 plan"}) || !equalStrings(q.Exclude, []string{"draft"}) {
+		t.Fatalf("phrases/exclude = %v / %v", q.Phrases, q.Exclude)
+	}
+	if !equalStrings(q.Filters["from"], []string{"alice"}) || !equalStrings(q.Services, []string{"gmail"}) {
+		t.Fatalf("filters = %v services = %v", q.Filters, q.Services)
+	}
+	if q.After.Year() != 2026 {
+		t.Fatalf("after = %v", q.After)
+	}
+
+	if _, err := ParseQuery("before:soon"); err == nil {
+		t.Fatalf("expected invalid date error")
+	}
+}
+
+func TestSearch_RanksAndFilters(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC) }
+	docs := []*Doc{
+		{Service: ServiceGmail, ID: "m1", Title: "Budget review", Text: "numbers for the q3 plan", Date: day(1), Fields: map[string]string{"from": "Alice <alice@example.com>"}},
+		{Service: ServiceGmail, ID: "m2", Title: "Lunch", Text: "also mentions the budget once", Date: day(5), Fields: map[string]string{"from": "Bob <bob@example.com>"}},
+		{Service: ServiceDrive, ID: "f1", Title: "Budget draft", Date: day(3), Fields: map[string]string{"mime": "application/pdf"}},
+		{Service: ServiceCalendar, CalendarID: "primary", ID: "e1", Title: "Reporting sync", Date: day(4)},
+	}
+
+	search := func(s string) []string {
+		t.Helper()
+		q, err := ParseQuery(s)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q): %v", s, err)
+		}
+		var ids []string
+		for _, h := range Search(docs, q, 0) {
+			ids = append(ids, h.Doc.ID)
+		}
+		return ids
+	}
+
+	if got := search("budget"); !equalStrings(got, []string{"m1", "f1", "m2"}) && !equalStrings(got, []string{"f1", "m1", "m2"}) {
+		t.Fatalf("title matches should rank above text matches, got %v", got)
+	}
+	if got := search("budget from:alice"); !equalStrings(got, []string{"m1"}) {
+		t.Fatalf("from filter: %v", got)
+	}
+	if got := search("budget -draft"); equalStrings(got, nil) || contains(got, "f1") {
+		t.Fatalf("exclusion: %v", got)
+	}
+	if got := search(`"q3 plan"`); !equalStrings(got, []string{"m1"}) {
+		t.Fatalf("phrase: %v", got)
+	}
+	if got := search("report*"); !equalStrings(got, []string{"e1"}) {
+		t.Fatalf("prefix: %v", got)
+	}
+	if got := search("service:gmail after:2026-03-02"); !equalStrings(got, []string{"m2"}) {
+		t.Fatalf("filter-only query: %v", got)
+	}
+
+	q, _ := ParseQuery("budget")
+	hits := Search(docs, q, 1)
+	if len(hits) != 1 || hits[0].Score <= 0 {
+		t.Fatalf("expected one scored hit, got %#v", hits)
+	}
+	if hits[0].Doc.ID == "m1" && hits[0].Snippet != "numbers for the q3 plan" {
+		t.Fatalf("unexpected snippet %q", hits[0].Snippet)
+	}
+}
+
+func TestSnippet_CentersOnMatch(t *testing.T) {
+	long := ""
+	for range 60 {
+		long += "filler "
+	}
+	got := snippet(long+"needle at the end "+long, []string{"needle"})
+	if !strings.HasPrefix(got, "…") || !strings.Contains(got, "needle") {
+		t.Fatalf("unexpected snippet %q", got)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,424 @@
+package index
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Field weights for ranking: a match in the title counts more than one in
+// the filterable metadata, which counts more than one in the body text.
+const (
+	titleWeight = 3.0
+	metaWeight  = 1.5
+	textWeight  = 1.0
+
+	bm25K1 = 1.2
+	bm25B  = 0.75
+
+	snippetLen = 160
+)
+
+// FilterFields are the field:value filters understood by ParseQuery, besides
+// service:, account:, after: and before:.
+var FilterFields = []string{
+	"title", "from", "to", "label", "mime", "owner",
+	"calendar", "location", "attendee", "organizer", "id", "thread",
+}
+
+// Query is a parsed search query: terms are ANDed, filters narrow the
+// candidate documents before ranking.
+type Query struct {
+	Terms    []string
+	Phrases  []string
+	Exclude  []string
+	Filters  map[string][]string
+	Services []string
+	Accounts []string
+	After    time.Time
+	Before   time.Time
+}
+
+// Hit is one ranked search result.
+type Hit struct {
+	Doc     *Doc
+	Score   float64
+	Snippet string
+}
+
+// ParseQuery parses free text with optional "quoted phrases", -excluded
+// terms, field:value filters (see FilterFields), service:, account:,
+// after:YYYY-MM-DD and before:YYYY-MM-DD. A trailing * makes a term match
+// word prefixes.
+func ParseQuery(s string) (Query, error) {
+	q := Query{Filters: map[string][]string{}}
+
+	for _, raw := range splitQuery(s) {
+		if strings.HasPrefix(raw, `"`) {
+			if phrase := strings.Join(tokenize(strings.Trim(raw, `"`)), " "); phrase != "" {
+				q.Phrases = append(q.Phrases, phrase)
+			}
+			continue
+		}
+		if strings.HasPrefix(raw, "-") && len(raw) > 1 {
+			q.Exclude = append(q.Exclude, tokenize(raw[1:])...)
+			continue
+		}
+
+		if field, value, ok := strings.Cut(raw, ":"); ok && value != "" {
+			field = strings.ToLower(field)
+			value = strings.Trim(value, `"`)
+			switch field {
+			case "service", "in":
+				q.Services = append(q.Services, strings.ToLower(value))
+				continue
+			case "account":
+				q.Accounts = append(q.Accounts, strings.ToLower(value))
+				continue
+			case "after", "before":
+				t, err := parseQueryDate(value)
+				if err != nil {
+					return Query{}, fmt.Errorf("%s: %w", field, err)
+				}
+				if field == "after" {
+					q.After = t
+				} else {
+					q.Before = t
+				}
+				continue
+			}
+			if isFilterField(field) {
+				q.Filters[field] = append(q.Filters[field], strings.ToLower(value))
+				continue
+			}
+		}
+
+		prefix := strings.HasSuffix(raw, "*")
+		tokens := tokenize(raw)
+		if prefix && len(tokens) > 0 {
+			tokens[len(tokens)-1] += "*"
+		}
+		q.Terms = append(q.Terms, tokens...)
+	}
+
+	return q, nil
+}
+
+// Empty reports whether q neither ranks nor filters anything.
+func (q Query) Empty() bool {
+	return len(q.Terms) == 0 && len(q.Phrases) == 0 && len(q.Exclude) == 0 && len(q.Filters) == 0 &&
+		len(q.Services) == 0 && len(q.Accounts) == 0 && q.After.IsZero() && q.Before.IsZero()
+}
+
+type candidate struct {
+	doc    *Doc
+	tf     []float64
+	length float64
+}
+
+// Search ranks docs against q (BM25 over title, metadata and text) and
+// returns at most limit hits (all when limit <= 0). Without terms, matches
+// are ordered newest first.
+func Search(docs []*Doc, q Query, limit int) []Hit {
+	var cands []candidate
+	var totalLen float64
+	df := make([]int, len(q.Terms))
+
+	for _, d := range docs {
+		if !q.matchesFilters(d) {
+			continue
+		}
+
+		title := tokenize(d.Title)
+		meta := tokenize(metaText(d))
+		text := tokenize(d.Text)
+
+		if !q.matchesPhrasesAndExclusions(title, meta, text) {
+			continue
+		}
+
+		c := candidate{doc: d, tf: make([]float64, len(q.Terms))}
+		matched := true
+		for i, term := range q.Terms {
+			c.tf[i] = titleWeight*countTerm(title, term) + metaWeight*countTerm(meta, term) + textWeight*countTerm(text, term)
+			if c.tf[i] == 0 {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+
+		for i := range q.Terms {
+			df[i]++
+		}
+		c.length = titleWeight*float64(len(title)) + metaWeight*float64(len(meta)) + textWeight*float64(len(text))
+		totalLen += c.length
+		cands = append(cands, c)
+	}
+
+	hits := make([]Hit, 0, len(cands))
+	if len(cands) == 0 {
+		return hits
+	}
+
+	n := float64(len(cands))
+	avgLen := totalLen / n
+	if avgLen == 0 {
+		avgLen = 1
+	}
+	for _, c := range cands {
+		score := 0.0
+		for i, tf := range c.tf {
+			idf := math.Log(1 + (n-float64(df[i])+0.5)/(float64(df[i])+0.5))
+			score += idf * tf * (bm25K1 + 1) / (tf + bm25K1*(1-bm25B+bm25B*c.length/avgLen))
+		}
+		hits = append(hits, Hit{Doc: c.doc, Score: math.Round(score*1000) / 1000})
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		if !hits[i].Doc.Date.Equal(hits[j].Doc.Date) {
+			return hits[i].Doc.Date.After(hits[j].Doc.Date)
+		}
+		return hits[i].Doc.Key() < hits[j].Doc.Key()
+	})
+
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+	for i := range hits {
+		hits[i].Snippet = snippet(hits[i].Doc.Text, append(append([]string{}, q.Terms...), q.Phrases...))
+	}
+
+	return hits
+}
+
+func (q Query) matchesFilters(d *Doc) bool {
+	if len(q.Services) > 0 && !containsFold(q.Services, d.Service) {
+		return false
+	}
+	if len(q.Accounts) > 0 && !anySubstring(strings.ToLower(d.Account), q.Accounts) {
+		return false
+	}
+	if !q.After.IsZero() && (d.Date.IsZero() || d.Date.Before(q.After)) {
+		return false
+	}
+	if !q.Before.IsZero() && (d.Date.IsZero() || !d.Date.Before(q.Before)) {
+		return false
+	}
+	for field, values := range q.Filters {
+		have := strings.ToLower(fieldValue(d, field))
+		for _, v := range values {
+			if !strings.Contains(have, v) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (q Query) matchesPhrasesAndExclusions(title, meta, text []string) bool {
+	if len(q.Phrases) == 0 && len(q.Exclude) == 0 {
+		return true
+	}
+	joined := " " + strings.Join(title, " ") + " | " + strings.Join(meta, " ") + " | " + strings.Join(text, " ") + " "
+	for _, p := range q.Phrases {
+		if !strings.Contains(joined, " "+p+" ") {
+			return false
+		}
+	}
+	for _, term := range q.Exclude {
+		if countTerm(title, term)+countTerm(meta, term)+countTerm(text, term) > 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func fieldValue(d *Doc, field string) string {
+	switch field {
+	case "title":
+		return d.Title
+	case "id":
+		return d.ID
+	case "thread":
+		return d.ThreadID
+	case "calendar":
+		return d.CalendarID
+	default:
+		return d.Fields[field]
+	}
+}
+
+// metaText is the searchable text of the filterable fields, in stable order.
+func metaText(d *Doc) string {
+	if len(d.Fields) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(d.Fields))
+	for k := range d.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(d.Fields[k])
+		b.WriteByte(' ')
+	}
+
+	return b.String()
+}
+
+func countTerm(tokens []string, term string) float64 {
+	prefix := strings.HasSuffix(term, "*")
+	term = strings.TrimSuffix(term, "*")
+
+	n := 0.0
+	for _, t := range tokens {
+		if t == term || (prefix && strings.HasPrefix(t, term)) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// tokenize lowercases s and splits it into letter/digit runs.
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// splitQuery splits on whitespace, keeping "quoted phrases" (and
+// field:"quoted values") together.
+func splitQuery(s string) []string {
+	var out []string
+	var cur strings.Builder
+	inQuote := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			cur.WriteRune(r)
+		case unicode.IsSpace(r) && !inQuote:
+			if cur.Len() > 0 {
+				out = append(out, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if cur.Len() > 0 {
+		out = append(out, cur.String())
+	}
+
+	return out
+}
+
+func parseQueryDate(s string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02", "2006/01/02"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q (use YYYY-MM-DD)", s)
+}
+
+func snippet(text string, terms []string) string {
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	if len(runes) == 0 {
+		return ""
+	}
+
+	// Lowercase rune by rune so positions line up with runes.
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	start := -1
+	for _, term := range terms {
+		if i := indexRunes(lower, []rune(strings.TrimSuffix(term, "*"))); i >= 0 && (start < 0 || i < start) {
+			start = i
+		}
+	}
+
+	from := 0
+	if start > snippetLen/4 {
+		from = start - snippetLen/4
+	}
+	to := min(from+snippetLen, len(runes))
+
+	out := string(runes[from:to])
+	if from > 0 {
+		out = "…" + out
+	}
+	if to < len(runes) {
+		out += "…"
+	}
+
+	return out
+}
+
+func indexRunes(s, sub []rune) int {
+	if len(sub) == 0 {
+		return -1
+	}
+	for i := 0; i+len(sub) <= len(s); i++ {
+		match := true
+		for j := range sub {
+			if s[i+j] != sub[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func isFilterField(field string) bool {
+	for _, f := range FilterFields {
+		if f == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func anySubstring(s string, list []string) bool {
+	for _, v := range list {
+		if strings.Contains(s, v) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,71 @@
+// Package invocation carries the per-run correlation ID that tags log lines,
+// JSON envelopes and outgoing API requests, so parallel gog runs can be told
+// apart in interleaved logs.
+package invocation
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"strings"
+)
+
+// EnvVar lets a caller supply the ID, e.g. to correlate several gog runs
+// made for one agent task.
+const EnvVar = "GOG_INVOCATION_ID"
+
+// Header is the request header carrying the ID to Google APIs.
+const Header = "X-Request-Id"
+
+const maxIDLen = 128
+
+type idKey struct{}
+
+// NewID returns GOG_INVOCATION_ID when set, otherwise a random 16-hex-digit ID.
+func NewID() string {
+	if id := sanitize(os.Getenv(EnvVar)); id != "" {
+		return id
+	}
+
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+
+	return hex.EncodeToString(b[:])
+}
+
+// WithID stores id in ctx. An empty id leaves ctx unchanged.
+func WithID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, idKey{}, id)
+}
+
+// ID returns the invocation ID stored in ctx, or "".
+func ID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(idKey{}).(string)
+
+	return id
+}
+
+// sanitize keeps caller-supplied IDs safe to place in headers and log lines.
+func sanitize(id string) string {
+	id = strings.TrimSpace(id)
+	if len(id) > maxIDLen {
+		id = id[:maxIDLen]
+	}
+	for _, r := range id {
+		if r <= ' ' || r > '~' {
+			return ""
+		}
+	}
+
+	return id
+}
@@ -0,0 +1,33 @@
+package invocation
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNewID(t *testing.T) {
+	t.Setenv(EnvVar, "")
+	a, b := NewID(), NewID()
+	if len(a) != 16 || a == b {
+		t.Fatalf("expected distinct 16-char ids, got %q %q", a, b)
+	}
+
+	t.Setenv(EnvVar, " agent-task-7 ")
+	if got := NewID(); got != "agent-task-7" {
+		t.Fatalf("expected env id, got %q", got)
+	}
+
+	t.Setenv(EnvVar, "bad\nid")
+	if got := NewID(); got == "bad\nid" || len(got) != 16 {
+		t.Fatalf("expected unsafe env id to be replaced, got %q", got)
+	}
+}
+
+func TestWithID(t *testing.T) {
+	if got := ID(context.Background()); got != "" {
+		t.Fatalf("expected empty id, got %q", got)
+	}
+	if got := ID(WithID(context.Background(), "x1")); got != "x1" {
+		t.Fatalf("expected x1, got %q", got)
+	}
+}
@@ -0,0 +1,235 @@
+// Package jsonschema derives JSON Schemas (draft 2020-12) from Go types the
+// way encoding/json would serialize them, including google-api-go structs.
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect emitted by Generator.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema object.
+type Schema = map[string]any
+
+var (
+	timeType      = reflect.TypeFor[time.Time]()
+	rawJSONType   = reflect.TypeFor[json.RawMessage]()
+	marshalerType = reflect.TypeFor[json.Marshaler]()
+
+	versionRe = regexp.MustCompile(`^v\d+((alpha|beta)\d*)?$`)
+)
+
+// Generator builds schemas and collects named struct types into $defs, so
+// recursive types (e.g. gmail.MessagePart) terminate.
+type Generator struct {
+	defs map[string]Schema
+}
+
+func New() *Generator {
+	return &Generator{defs: map[string]Schema{}}
+}
+
+// Defs returns the collected definitions, keyed by "pkg.Type".
+func (g *Generator) Defs() map[string]Schema {
+	return g.defs
+}
+
+// Define stores s under name and returns a $ref to it.
+func (g *Generator) Define(name string, s Schema) Schema {
+	g.defs[name] = s
+	return Ref(name)
+}
+
+// Ref returns a $ref to a definition.
+func Ref(name string) Schema {
+	return Schema{"$ref": "#/$defs/" + name}
+}
+
+// For returns the schema of values of type t.
+func (g *Generator) For(t reflect.Type) Schema {
+	if t == nil {
+		return Schema{}
+	}
+
+	switch t {
+	case timeType:
+		return Schema{"type": "string", "format": "date-time"}
+	case rawJSONType:
+		return Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.For(t.Elem())
+	case reflect.Interface:
+		return Schema{}
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return Schema{"type": "string", "contentEncoding": "base64"}
+		}
+		return Schema{"type": "array", "items": g.For(t.Elem())}
+	case reflect.Map:
+		return Schema{"type": "object", "additionalProperties": g.For(t.Elem())}
+	case reflect.Struct:
+		return g.structSchema(t)
+	default:
+		return Schema{}
+	}
+}
+
+func (g *Generator) structSchema(t reflect.Type) Schema {
+	// A MarshalJSON promoted from an embedded field replaces the outer
+	// struct's encoding entirely, so the embedded type is what appears.
+	if inner, ok := promotedMarshaler(t); ok {
+		return g.For(inner)
+	}
+
+	name := defName(t)
+	if name == "" {
+		return g.objectSchema(t)
+	}
+	if _, ok := g.defs[name]; !ok {
+		g.defs[name] = Schema{} // placeholder breaks recursion
+		g.defs[name] = g.objectSchema(t)
+	}
+
+	return Ref(name)
+}
+
+func (g *Generator) objectSchema(t reflect.Type) Schema {
+	props := Schema{}
+	var required []string
+	g.addFields(t, props, &required)
+
+	out := Schema{"type": "object", "properties": props}
+	if len(required) > 0 {
+		out["required"] = required
+	}
+
+	return out
+}
+
+func (g *Generator) addFields(t reflect.Type, props Schema, required *[]string) {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.addFields(ft, props, required)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		var s Schema
+		if hasOpt(opts, "string") {
+			s = Schema{"type": "string"}
+		} else {
+			s = g.For(f.Type)
+		}
+
+		optional := hasOpt(opts, "omitempty") || hasOpt(opts, "omitzero")
+		if !optional && nilable(f.Type) {
+			s = Nullable(s)
+		}
+		props[name] = s
+		if !optional {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// Nullable allows null in addition to s.
+func Nullable(s Schema) Schema {
+	if typ, ok := s["type"].(string); ok {
+		out := Schema{}
+		for k, v := range s {
+			out[k] = v
+		}
+		out["type"] = []string{typ, "null"}
+		return out
+	}
+	if len(s) == 0 {
+		return s
+	}
+
+	return Schema{"anyOf": []Schema{s, {"type": "null"}}}
+}
+
+func promotedMarshaler(t reflect.Type) (reflect.Type, bool) {
+	if !t.Implements(marshalerType) && !reflect.PointerTo(t).Implements(marshalerType) {
+		return nil, false
+	}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.Anonymous && f.Tag.Get("json") != "-" && f.Type.Implements(marshalerType) {
+			return f.Type, true
+		}
+	}
+
+	return nil, false
+}
+
+func defName(t reflect.Type) string {
+	if t.Name() == "" || strings.Contains(t.Name(), "[") {
+		return ""
+	}
+	// google.golang.org/api/gmail/v1 -> gmail
+	parts := strings.Split(t.PkgPath(), "/")
+	pkg := parts[len(parts)-1]
+	if len(parts) > 1 && versionRe.MatchString(pkg) {
+		pkg = parts[len(parts)-2]
+	}
+	if pkg == "" {
+		return t.Name()
+	}
+
+	return pkg + "." + t.Name()
+}
+
+func nilable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		return true
+	default:
+		return false
+	}
+}
+
+func hasOpt(opts string, want string) bool {
+	for _, o := range strings.Split(opts, ",") {
+		if o == want {
+			return true
+		}
+	}
+
+	return false
+}
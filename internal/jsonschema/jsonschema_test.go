@@ -0,0 +1,83 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type node struct {
+	Name     string    `json:"name"`
+	ID       int64     `json:"id,omitempty,string"`
+	Children []*node   `json:"children,omitempty"`
+	Tags     []string  `json:"tags"`
+	At       time.Time `json:"at"`
+	Data     []byte    `json:"data,omitempty"`
+	Extra    any       `json:"extra,omitempty"`
+	Skipped  string    `json:"-"`
+	hidden   string
+}
+
+type inner struct {
+	Value string `json:"value"`
+}
+
+func (i inner) MarshalJSON() ([]byte, error) { return json.Marshal(i.Value) }
+
+type wrapped struct {
+	inner
+	Dropped string `json:"dropped"`
+}
+
+func TestFor_Struct(t *testing.T) {
+	g := New()
+	got := g.For(reflect.TypeFor[node]())
+	if got["$ref"] != "#/$defs/jsonschema.node" {
+		t.Fatalf("unexpected ref: %v", got)
+	}
+
+	def := g.Defs()["jsonschema.node"]
+	props := def["properties"].(Schema)
+	if _, ok := props["Skipped"]; ok {
+		t.Fatalf("json:\"-\" field leaked: %v", props)
+	}
+	if _, ok := props["hidden"]; ok {
+		t.Fatalf("unexported field leaked: %v", props)
+	}
+	if props["id"].(Schema)["type"] != "string" {
+		t.Fatalf("expected ,string int64 as string: %v", props["id"])
+	}
+	if props["children"].(Schema)["items"].(Schema)["$ref"] != "#/$defs/jsonschema.node" {
+		t.Fatalf("expected recursive ref: %v", props["children"])
+	}
+	if props["at"].(Schema)["format"] != "date-time" {
+		t.Fatalf("expected date-time: %v", props["at"])
+	}
+	if props["data"].(Schema)["contentEncoding"] != "base64" {
+		t.Fatalf("expected base64 bytes: %v", props["data"])
+	}
+	if typ, _ := props["tags"].(Schema)["type"].([]string); len(typ) != 2 || typ[1] != "null" {
+		t.Fatalf("expected nullable slice: %v", props["tags"])
+	}
+	if !reflect.DeepEqual(def["required"], []string{"name", "tags", "at"}) {
+		t.Fatalf("unexpected required: %v", def["required"])
+	}
+}
+
+func TestFor_PromotedMarshalerWins(t *testing.T) {
+	g := New()
+	got := g.For(reflect.TypeFor[wrapped]())
+	if got["$ref"] != "#/$defs/jsonschema.inner" {
+		t.Fatalf("expected embedded type schema, got %v", got)
+	}
+}
+
+func TestDefName_StripsAPIVersion(t *testing.T) {
+	if got := defName(reflect.TypeFor[time.Time]()); got != "time.Time" {
+		t.Fatalf("defName: %q", got)
+	}
+	if !versionRe.MatchString("v1") || !versionRe.MatchString("v2beta1") || versionRe.MatchString("vault") {
+		t.Fatalf("versionRe mismatch")
+	}
+}
@@ -0,0 +1,40 @@
+package outfmt
+
+import (
+	"context"
+	"sync"
+)
+
+// Capture collects the values passed to WriteJSON instead of encoding them.
+// It lets a caller run a command several times (e.g. once per account) and
+// merge the results before writing a single document.
+type Capture struct {
+	mu     sync.Mutex
+	values []any
+}
+
+// Values returns the captured values in write order.
+func (c *Capture) Values() []any {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]any(nil), c.values...)
+}
+
+func (c *Capture) add(v any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.values = append(c.values, v)
+}
+
+type captureCtxKey struct{}
+
+func WithCapture(ctx context.Context, c *Capture) context.Context {
+	return context.WithValue(ctx, captureCtxKey{}, c)
+}
+
+func captureFromContext(ctx context.Context) *Capture {
+	c, _ := ctx.Value(captureCtxKey{}).(*Capture)
+	return c
+}
@@ -28,6 +28,8 @@ type SuccessEnvelope struct {
 	Command     string       `json:"command"`
 	Result      any          `json:"result"`
 	NextActions []NextAction `json:"next_actions"`
+	// InvocationID matches the invocation_id of this run's log lines.
+	InvocationID string `json:"invocation_id,omitempty"`
 }
 
 type ErrorEnvelope struct {
@@ -36,6 +38,8 @@ type ErrorEnvelope struct {
 	Error       ErrorBody    `json:"error"`
 	Fix         string       `json:"fix"`
 	NextActions []NextAction `json:"next_actions"`
+	// InvocationID matches the invocation_id of this run's log lines.
+	InvocationID string `json:"invocation_id,omitempty"`
 }
 
 type commandCtxKey struct{}
@@ -8,6 +8,8 @@ import (
 	"os"
 	"strconv"
 	"strings"
+
+	"github.com/steipete/gogcli/internal/invocation"
 )
 
 type Mode struct {
@@ -80,6 +82,11 @@ func JSONTransformFromContext(ctx context.Context) (JSONTransform, bool) {
 }
 
 func WriteJSON(ctx context.Context, w io.Writer, v any) error {
+	if c := captureFromContext(ctx); c != nil {
+		c.add(v)
+		return nil
+	}
+
 	transformedApplied := false
 	if t, ok := JSONTransformFromContext(ctx); ok && (t.ResultsOnly || len(t.Select) > 0) {
 		transformed, err := applyJSONTransform(v, t)
@@ -121,10 +128,11 @@ func wrapSuccessEnvelope(ctx context.Context, v any) any {
 	}
 
 	return SuccessEnvelope{
-		Ok:          true,
-		Command:     cmd,
-		Result:      v,
-		NextActions: actions,
+		Ok:           true,
+		Command:      cmd,
+		Result:       v,
+		NextActions:  actions,
+		InvocationID: invocation.ID(ctx),
 	}
 }
 
@@ -0,0 +1,188 @@
+package secrets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/99designs/keyring"
+
+	"github.com/steipete/gogcli/internal/config"
+)
+
+const (
+	// EnvRefreshToken supplies a refresh token directly (ephemeral containers).
+	EnvRefreshToken = "GOG_REFRESH_TOKEN"
+	// EnvTokenFile points at a mounted token file: a `gog auth tokens export`
+	// JSON document or a bare refresh token.
+	EnvTokenFile = "GOG_TOKEN_FILE"
+)
+
+var errEnvStoreReadOnly = errors.New("refresh token comes from " + EnvRefreshToken + "/" + EnvTokenFile + " (env mode); unset them to use the keyring")
+
+// EnvToken returns the refresh token supplied via the environment, and where it
+// came from. ok is false when neither GOG_REFRESH_TOKEN nor GOG_TOKEN_FILE is set.
+func EnvToken() (tok Token, source string, ok bool, err error) {
+	if v := strings.TrimSpace(os.Getenv(EnvRefreshToken)); v != "" {
+		return Token{Email: envAccount(), RefreshToken: v}, EnvRefreshToken, true, nil
+	}
+
+	path := strings.TrimSpace(os.Getenv(EnvTokenFile))
+	if path == "" {
+		return Token{}, "", false, nil
+	}
+
+	path, err = config.ExpandPath(path)
+	if err != nil {
+		return Token{}, "", false, err
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // user-provided path
+	if err != nil {
+		return Token{}, "", false, fmt.Errorf("read %s: %w", EnvTokenFile, err)
+	}
+
+	tok, err = parseTokenFile(data)
+	if err != nil {
+		return Token{}, "", false, fmt.Errorf("parse %s: %w", EnvTokenFile, err)
+	}
+
+	return tok, path, true, nil
+}
+
+func parseTokenFile(data []byte) (Token, error) {
+	raw := strings.TrimSpace(string(data))
+	if raw == "" {
+		return Token{}, errMissingRefreshToken
+	}
+
+	if !strings.HasPrefix(raw, "{") {
+		return Token{Email: envAccount(), RefreshToken: raw}, nil
+	}
+
+	var ex struct {
+		Email        string   `json:"email"`
+		Services     []string `json:"services,omitempty"`
+		Scopes       []string `json:"scopes,omitempty"`
+		CreatedAt    string   `json:"created_at,omitempty"`
+		IssuedAt     string   `json:"issued_at,omitempty"`
+		RefreshToken string   `json:"refresh_token"`
+	}
+	if err := json.Unmarshal([]byte(raw), &ex); err != nil {
+		return Token{}, fmt.Errorf("decode token file: %w", err)
+	}
+
+	if strings.TrimSpace(ex.RefreshToken) == "" {
+		return Token{}, errMissingRefreshToken
+	}
+
+	tok := Token{
+		Email:        normalize(ex.Email),
+		Services:     ex.Services,
+		Scopes:       ex.Scopes,
+		RefreshToken: strings.TrimSpace(ex.RefreshToken),
+	}
+	if tok.Email == "" {
+		tok.Email = envAccount()
+	}
+
+	if created := strings.TrimSpace(ex.CreatedAt); created != "" {
+		if t, err := time.Parse(time.RFC3339, created); err == nil {
+			tok.CreatedAt = t
+		}
+	}
+
+	tok.IssuedAt = tok.CreatedAt
+	if issued := strings.TrimSpace(ex.IssuedAt); issued != "" {
+		if t, err := time.Parse(time.RFC3339, issued); err == nil {
+			tok.IssuedAt = t
+		}
+	}
+
+	return tok, nil
+}
+
+func envAccount() string {
+	v := normalize(os.Getenv("GOG_ACCOUNT"))
+	if !strings.Contains(v, "@") {
+		return ""
+	}
+
+	return v
+}
+
+// EnvStore is a read-only Store backed by a refresh token from the environment.
+// It never touches the keyring or the disk.
+type EnvStore struct {
+	tok    Token
+	source string
+}
+
+func NewEnvStore(tok Token, source string) *EnvStore {
+	return &EnvStore{tok: tok, source: source}
+}
+
+// Source names the variable or file the token came from.
+func (s *EnvStore) Source() string {
+	return s.source
+}
+
+func (s *EnvStore) Keys() ([]string, error) {
+	if s.tok.Email == "" {
+		return nil, nil
+	}
+
+	return []string{tokenKey(config.DefaultClientName, s.tok.Email)}, nil
+}
+
+func (s *EnvStore) SetToken(string, string, Token) error {
+	return errEnvStoreReadOnly
+}
+
+func (s *EnvStore) GetToken(client string, email string) (Token, error) {
+	email = normalize(email)
+	if email == "" {
+		return Token{}, errMissingEmail
+	}
+
+	// Without a known email the token serves whichever account is requested.
+	if s.tok.Email != "" && s.tok.Email != email {
+		return Token{}, fmt.Errorf("read token: %s holds a token for %s: %w", s.source, s.tok.Email, keyring.ErrKeyNotFound)
+	}
+
+	tok := s.tok
+	tok.Email = email
+	tok.Client = client
+
+	return tok, nil
+}
+
+func (s *EnvStore) DeleteToken(string, string) error {
+	return errEnvStoreReadOnly
+}
+
+func (s *EnvStore) ListTokens() ([]Token, error) {
+	if s.tok.Email == "" {
+		return []Token{}, nil
+	}
+
+	tok := s.tok
+	tok.Client = config.DefaultClientName
+
+	return []Token{tok}, nil
+}
+
+func (s *EnvStore) GetDefaultAccount(string) (string, error) {
+	if s.tok.Email == "" {
+		return "", keyring.ErrKeyNotFound
+	}
+
+	return s.tok.Email, nil
+}
+
+func (s *EnvStore) SetDefaultAccount(string, string) error {
+	return errEnvStoreReadOnly
+}
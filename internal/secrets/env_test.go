@@ -0,0 +1,71 @@
+package secrets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/99designs/keyring"
+)
+
+func TestOpenDefault_EnvRefreshToken(t *testing.T) {
+	t.Setenv(EnvRefreshToken, "rt-env")
+	t.Setenv("GOG_ACCOUNT", "")
+
+	orig := openKeyringFunc
+	t.Cleanup(func() { openKeyringFunc = orig })
+
+	openKeyringFunc = func() (keyring.Keyring, error) {
+		t.Fatalf("keyring must not be opened in env mode")
+		return nil, nil
+	}
+
+	store, err := OpenDefault()
+	if err != nil {
+		t.Fatalf("OpenDefault: %v", err)
+	}
+
+	tok, err := store.GetToken("default", "A@B.com")
+	if err != nil {
+		t.Fatalf("GetToken: %v", err)
+	}
+
+	if tok.RefreshToken != "rt-env" || tok.Email != "a@b.com" || tok.Client != "default" {
+		t.Fatalf("unexpected token: %+v", tok)
+	}
+
+	if err := store.SetToken("default", "a@b.com", tok); !errors.Is(err, errEnvStoreReadOnly) {
+		t.Fatalf("expected read-only error, got %v", err)
+	}
+}
+
+func TestEnvToken_ExportFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.json")
+	data := `{"email":"a@b.com","services":["gmail"],"created_at":"2026-01-02T03:04:05Z","refresh_token":"rt-file"}`
+
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	t.Setenv(EnvRefreshToken, "")
+	t.Setenv(EnvTokenFile, path)
+
+	tok, source, ok, err := EnvToken()
+	if err != nil || !ok || source != path {
+		t.Fatalf("EnvToken: ok=%v source=%q err=%v", ok, source, err)
+	}
+
+	if tok.Email != "a@b.com" || tok.RefreshToken != "rt-file" || tok.CreatedAt.IsZero() {
+		t.Fatalf("unexpected token: %+v", tok)
+	}
+
+	store := NewEnvStore(tok, source)
+	if _, err := store.GetToken("default", "c@d.com"); !errors.Is(err, keyring.ErrKeyNotFound) {
+		t.Fatalf("expected not found for other account, got %v", err)
+	}
+
+	if def, err := store.GetDefaultAccount("default"); err != nil || def != "a@b.com" {
+		t.Fatalf("GetDefaultAccount: %q %v", def, err)
+	}
+}
@@ -30,12 +30,16 @@ type KeyringStore struct {
 }
 
 type Token struct {
-	Client       string    `json:"client,omitempty"`
-	Email        string    `json:"email"`
-	Services     []string  `json:"services,omitempty"`
-	Scopes       []string  `json:"scopes,omitempty"`
-	CreatedAt    time.Time `json:"created_at,omitempty"`
-	RefreshToken string    `json:"-"`
+	Client    string    `json:"client,omitempty"`
+	Email     string    `json:"email"`
+	Services  []string  `json:"services,omitempty"`
+	Scopes    []string  `json:"scopes,omitempty"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// IssuedAt is when Google minted this refresh token (defaults to CreatedAt).
+	IssuedAt time.Time `json:"issued_at,omitempty"`
+	// LastRefreshAt is the last successful access-token refresh.
+	LastRefreshAt time.Time `json:"last_refresh_at,omitempty"`
+	RefreshToken  string    `json:"-"`
 }
 
 func keyringItem(key string, data []byte) keyring.Item {
@@ -274,6 +278,12 @@ func openKeyringWithTimeout(cfg keyring.Config, timeout time.Duration) (keyring.
 }
 
 func OpenDefault() (Store, error) {
+	if tok, source, ok, err := EnvToken(); err != nil {
+		return nil, err
+	} else if ok {
+		return NewEnvStore(tok, source), nil
+	}
+
 	ring, err := openKeyringFunc()
 	if err != nil {
 		return nil, err
@@ -329,10 +339,12 @@ func (s *KeyringStore) Keys() ([]string, error) {
 }
 
 type storedToken struct {
-	RefreshToken string    `json:"refresh_token"`
-	Services     []string  `json:"services,omitempty"`
-	Scopes       []string  `json:"scopes,omitempty"`
-	CreatedAt    time.Time `json:"created_at,omitempty"`
+	RefreshToken  string    `json:"refresh_token"`
+	Services      []string  `json:"services,omitempty"`
+	Scopes        []string  `json:"scopes,omitempty"`
+	CreatedAt     time.Time `json:"created_at,omitempty"`
+	IssuedAt      time.Time `json:"issued_at,omitempty"`
+	LastRefreshAt time.Time `json:"last_refresh_at,omitempty"`
 }
 
 func (s *KeyringStore) SetToken(client string, email string, tok Token) error {
@@ -354,11 +366,17 @@ func (s *KeyringStore) SetToken(client string, email string, tok Token) error {
 		tok.CreatedAt = time.Now().UTC()
 	}
 
+	if tok.IssuedAt.IsZero() {
+		tok.IssuedAt = tok.CreatedAt
+	}
+
 	payload, err := json.Marshal(storedToken{
-		RefreshToken: tok.RefreshToken,
-		Services:     tok.Services,
-		Scopes:       tok.Scopes,
-		CreatedAt:    tok.CreatedAt,
+		RefreshToken:  tok.RefreshToken,
+		Services:      tok.Services,
+		Scopes:        tok.Scopes,
+		CreatedAt:     tok.CreatedAt,
+		IssuedAt:      tok.IssuedAt,
+		LastRefreshAt: tok.LastRefreshAt,
 	})
 	if err != nil {
 		return fmt.Errorf("encode token: %w", err)
@@ -409,13 +427,20 @@ func (s *KeyringStore) GetToken(client string, email string) (Token, error) {
 		return Token{}, fmt.Errorf("decode token: %w", err)
 	}
 
+	issuedAt := st.IssuedAt
+	if issuedAt.IsZero() {
+		issuedAt = st.CreatedAt
+	}
+
 	return Token{
-		Client:       normalizedClient,
-		Email:        email,
-		Services:     st.Services,
-		Scopes:       st.Scopes,
-		CreatedAt:    st.CreatedAt,
-		RefreshToken: st.RefreshToken,
+		Client:        normalizedClient,
+		Email:         email,
+		Services:      st.Services,
+		Scopes:        st.Scopes,
+		CreatedAt:     st.CreatedAt,
+		IssuedAt:      issuedAt,
+		LastRefreshAt: st.LastRefreshAt,
+		RefreshToken:  st.RefreshToken,
 	}, nil
 }
 
@@ -273,3 +273,22 @@ func TestSetSecretSetsLabel(t *testing.T) {
 		t.Fatalf("expected label %q, got %q", config.AppName, it.Label)
 	}
 }
+
+func TestKeyringStore_TokenHealthMetadata(t *testing.T) {
+	store := &KeyringStore{ring: keyring.NewArrayKeyring(nil)}
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	refreshed := created.Add(48 * time.Hour)
+
+	if err := store.SetToken("default", "a@b.com", Token{RefreshToken: "rt", CreatedAt: created, LastRefreshAt: refreshed}); err != nil {
+		t.Fatalf("SetToken: %v", err)
+	}
+
+	tok, err := store.GetToken("default", "a@b.com")
+	if err != nil {
+		t.Fatalf("GetToken: %v", err)
+	}
+
+	if !tok.IssuedAt.Equal(created) || !tok.LastRefreshAt.Equal(refreshed) {
+		t.Fatalf("unexpected metadata: issued=%v last=%v", tok.IssuedAt, tok.LastRefreshAt)
+	}
+}
@@ -0,0 +1,542 @@
+package timeparse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Options configures ParseNatural.
+type Options struct {
+	// Now anchors relative expressions; defaults to time.Now().
+	Now time.Time
+	// Location is the timezone expressions are interpreted in; defaults to Now's location.
+	Location *time.Location
+	// WeekStart is the first day of the week for "this week", "mon-fri" etc.
+	// The zero value is Sunday.
+	WeekStart time.Weekday
+}
+
+// Span is a parsed natural-language expression. Point-in-time inputs have
+// Start == End; day-level inputs cover the whole day; ranges ("9-11am",
+// "mon-fri this week", "tomorrow 9:30 for 45m", "last week") cover the interval.
+// End is inclusive.
+type Span struct {
+	Start time.Time
+	End   time.Time
+	// HasTime reports whether the input carried an explicit clock time.
+	HasTime bool
+	// IsRange reports whether the input named an interval rather than a point or a day.
+	IsRange bool
+}
+
+const naturalHint = "try: tomorrow 3pm, next tuesday, in 2 hours, 2 weeks ago, end of month, mon-fri this week, 9-11am"
+
+var (
+	reTimeRange    = regexp.MustCompile(`^(?:(.*?)\s+)?(\d{1,2}(?::\d{2})?)\s*(am|pm)?\s*-\s*(\d{1,2}(?::\d{2})?)\s*(am|pm)$|^(?:(.*?)\s+)?(\d{1,2}:\d{2})\s*-\s*(\d{1,2}:\d{2})$`)
+	reWeekdayRange = regexp.MustCompile(`^([a-z]+)\s*-\s*([a-z]+)(?:\s+(this|next|last)\s+week)?$`)
+	reInOffset     = regexp.MustCompile(`^in\s+(\d+|an?)\s+([a-z]+)$`)
+	reAgoOffset    = regexp.MustCompile(`^(\d+|an?)\s+([a-z]+)\s+ago$`)
+	reBoundary     = regexp.MustCompile(`^(start|beginning|end)\s+of\s+(?:the\s+)?(?:(this|next|last)\s+)?(day|week|month|year)$`)
+	rePeriod       = regexp.MustCompile(`^(this|next|last)\s+(week|month|year)$`)
+	reClock        = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?\s*(am|pm)?$`)
+)
+
+var weekdayNames = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"sun":       time.Sunday,
+	"monday":    time.Monday,
+	"mon":       time.Monday,
+	"tuesday":   time.Tuesday,
+	"tue":       time.Tuesday,
+	"tues":      time.Tuesday,
+	"wednesday": time.Wednesday,
+	"wed":       time.Wednesday,
+	"thursday":  time.Thursday,
+	"thu":       time.Thursday,
+	"thur":      time.Thursday,
+	"thurs":     time.Thursday,
+	"friday":    time.Friday,
+	"fri":       time.Friday,
+	"saturday":  time.Saturday,
+	"sat":       time.Saturday,
+}
+
+// ParseNatural parses absolute forms (see ParseDateTimeOrDate) and natural
+// expressions such as "next tuesday 3pm", "in 2 hours", "tomorrow 9:30 for 45m",
+// "end of month", "last week", "2 weeks ago", "mon-fri this week" and "9-11am".
+func ParseNatural(expr string, opts Options) (Span, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return Span{}, ErrEmptyTimeExpr
+	}
+
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	if opts.Location == nil {
+		opts.Location = opts.Now.Location()
+	}
+
+	opts.Now = opts.Now.In(opts.Location)
+
+	if parsed, err := ParseDateTimeOrDate(expr, opts.Location); err == nil {
+		if parsed.HasTime {
+			return Span{Start: parsed.Time, End: parsed.Time, HasTime: true}, nil
+		}
+
+		return daySpan(parsed.Time), nil
+	}
+
+	norm := normalizeNatural(expr)
+
+	if span, ok, err := parseNaturalNormalized(norm, opts); ok || err != nil {
+		return span, err
+	}
+
+	return Span{}, fmt.Errorf("%w: %q (%s)", ErrInvalidTimeExpr, expr, naturalHint)
+}
+
+func normalizeNatural(expr string) string {
+	expr = strings.ToLower(expr)
+	expr = strings.ReplaceAll(expr, ",", " ")
+	expr = strings.ReplaceAll(expr, "–", "-")
+
+	return strings.Join(strings.Fields(expr), " ")
+}
+
+func parseNaturalNormalized(expr string, opts Options) (Span, bool, error) {
+	// "<expr> for <duration>"
+	if i := strings.LastIndex(expr, " for "); i > 0 {
+		base, durText := expr[:i], expr[i+len(" for "):]
+
+		d, ok := parseNaturalDuration(durText)
+		if !ok {
+			return Span{}, true, fmt.Errorf("%w: invalid duration %q", ErrInvalidTimeExpr, durText)
+		}
+
+		span, ok, err := parseNaturalNormalized(base, opts)
+		if !ok || err != nil {
+			return span, ok, err
+		}
+
+		if !span.HasTime {
+			return Span{}, true, fmt.Errorf("%w: %q needs a start time before \"for\"", ErrInvalidTimeExpr, base)
+		}
+
+		return Span{Start: span.Start, End: span.Start.Add(d), HasTime: true, IsRange: true}, true, nil
+	}
+
+	if span, ok := parseTimeRange(expr, opts); ok {
+		return span, true, nil
+	}
+
+	if span, ok := parseWeekdayRange(expr, opts); ok {
+		return span, true, nil
+	}
+
+	if span, ok := parsePeriod(expr, opts); ok {
+		return span, true, nil
+	}
+
+	if span, ok := parseBoundary(expr, opts); ok {
+		return span, true, nil
+	}
+
+	if span, ok := parseOffset(expr, opts); ok {
+		return span, true, nil
+	}
+
+	if span, ok := parseDayAndClock(expr, opts); ok {
+		return span, true, nil
+	}
+
+	return Span{}, false, nil
+}
+
+// parseTimeRange handles "9-11am", "1pm-3pm", "tomorrow 9:30-11" and "fri 14:00-15:30".
+func parseTimeRange(expr string, opts Options) (Span, bool) {
+	m := reTimeRange.FindStringSubmatch(expr)
+	if m == nil {
+		return Span{}, false
+	}
+
+	dayText, from, fromSuffix, to, toSuffix := m[1], m[2], m[3], m[4], m[5]
+	if from == "" {
+		dayText, from, to = m[6], m[7], m[8]
+	}
+
+	// "9-11am": the trailing meridiem applies to the start unless that would
+	// put the start after the end ("11-1pm" means 11am to 1pm).
+	if fromSuffix == "" {
+		fromSuffix = toSuffix
+	}
+
+	endH, endM, ok := parseClock(to + toSuffix)
+	if !ok {
+		return Span{}, false
+	}
+
+	startH, startM, ok := parseClock(from + fromSuffix)
+	if !ok {
+		return Span{}, false
+	}
+
+	if m[3] == "" && toSuffix == "pm" && startH*60+startM > endH*60+endM {
+		startH -= 12
+	}
+
+	day := startOfDay(opts.Now)
+	if dayText != "" {
+		span, ok := parseDay(dayText, opts)
+		if !ok {
+			return Span{}, false
+		}
+
+		day = span.Start
+	}
+
+	start := atClock(day, startH, startM)
+	end := atClock(day, endH, endM)
+
+	if !end.After(start) {
+		return Span{}, false
+	}
+
+	return Span{Start: start, End: end, HasTime: true, IsRange: true}, true
+}
+
+// parseWeekdayRange handles "mon-fri", "mon-fri this week", "tue-thu next week".
+func parseWeekdayRange(expr string, opts Options) (Span, bool) {
+	m := reWeekdayRange.FindStringSubmatch(expr)
+	if m == nil {
+		return Span{}, false
+	}
+
+	first, ok := weekdayNames[m[1]]
+	if !ok {
+		return Span{}, false
+	}
+
+	last, ok := weekdayNames[m[2]]
+	if !ok {
+		return Span{}, false
+	}
+
+	weekStart := startOfWeekDay(opts.Now, opts.WeekStart)
+
+	switch m[3] {
+	case "next":
+		weekStart = weekStart.AddDate(0, 0, 7)
+	case "last":
+		weekStart = weekStart.AddDate(0, 0, -7)
+	}
+
+	startOffset := weekdayOffset(first, opts.WeekStart)
+
+	endOffset := weekdayOffset(last, opts.WeekStart)
+	if endOffset < startOffset {
+		endOffset += 7
+	}
+
+	return Span{
+		Start:   weekStart.AddDate(0, 0, startOffset),
+		End:     endOfDayTime(weekStart.AddDate(0, 0, endOffset)),
+		IsRange: true,
+	}, true
+}
+
+// parsePeriod handles "this week", "next month", "last year".
+func parsePeriod(expr string, opts Options) (Span, bool) {
+	m := rePeriod.FindStringSubmatch(expr)
+	if m == nil {
+		return Span{}, false
+	}
+
+	start, end := periodBounds(m[2], shiftFor(m[1]), opts)
+
+	return Span{Start: start, End: end, IsRange: true}, true
+}
+
+// parseBoundary handles "end of month", "start of next week", "end of the year".
+func parseBoundary(expr string, opts Options) (Span, bool) {
+	m := reBoundary.FindStringSubmatch(expr)
+	if m == nil {
+		return Span{}, false
+	}
+
+	start, end := periodBounds(m[3], shiftFor(m[2]), opts)
+	if m[1] == "end" {
+		return daySpan(end), true
+	}
+
+	return daySpan(start), true
+}
+
+// parseOffset handles "in 2 hours", "in a week", "2 weeks ago", "an hour ago".
+func parseOffset(expr string, opts Options) (Span, bool) {
+	sign := 1
+
+	m := reInOffset.FindStringSubmatch(expr)
+	if m == nil {
+		m = reAgoOffset.FindStringSubmatch(expr)
+		sign = -1
+	}
+
+	if m == nil {
+		return Span{}, false
+	}
+
+	n := 1
+	if m[1] != "a" && m[1] != "an" {
+		v, err := strconv.Atoi(m[1])
+		if err != nil {
+			return Span{}, false
+		}
+
+		n = v
+	}
+
+	n *= sign
+
+	switch strings.TrimSuffix(m[2], "s") {
+	case "second", "sec":
+		t := opts.Now.Add(time.Duration(n) * time.Second)
+		return Span{Start: t, End: t, HasTime: true}, true
+	case "minute", "min":
+		t := opts.Now.Add(time.Duration(n) * time.Minute)
+		return Span{Start: t, End: t, HasTime: true}, true
+	case "hour", "hr":
+		t := opts.Now.Add(time.Duration(n) * time.Hour)
+		return Span{Start: t, End: t, HasTime: true}, true
+	case "day":
+		return daySpan(opts.Now.AddDate(0, 0, n)), true
+	case "week":
+		return daySpan(opts.Now.AddDate(0, 0, 7*n)), true
+	case "month":
+		return daySpan(opts.Now.AddDate(0, n, 0)), true
+	case "year":
+		return daySpan(opts.Now.AddDate(n, 0, 0)), true
+	default:
+		return Span{}, false
+	}
+}
+
+// parseDayAndClock handles "<day>", "<clock>", "<day> [at] <clock>" and "<clock> <day>".
+func parseDayAndClock(expr string, opts Options) (Span, bool) {
+	if expr == "now" {
+		return Span{Start: opts.Now, End: opts.Now, HasTime: true}, true
+	}
+
+	if span, ok := parseDay(expr, opts); ok {
+		return span, true
+	}
+
+	if h, m, ok := parseClock(expr); ok {
+		t := atClock(startOfDay(opts.Now), h, m)
+		return Span{Start: t, End: t, HasTime: true}, true
+	}
+
+	fields := strings.Fields(expr)
+	for split := 1; split < len(fields); split++ {
+		head := strings.Join(fields[:split], " ")
+		tail := strings.Join(fields[split:], " ")
+
+		// "<day> [at] <clock>"
+		if h, m, ok := parseClock(strings.TrimPrefix(tail, "at ")); ok {
+			if day, ok := parseDay(strings.TrimSuffix(head, " at"), opts); ok {
+				t := atClock(day.Start, h, m)
+				return Span{Start: t, End: t, HasTime: true}, true
+			}
+		}
+
+		// "<clock> <day>"
+		if h, m, ok := parseClock(head); ok {
+			if day, ok := parseDay(tail, opts); ok {
+				t := atClock(day.Start, h, m)
+				return Span{Start: t, End: t, HasTime: true}, true
+			}
+		}
+	}
+
+	return Span{}, false
+}
+
+// parseDay resolves day-level expressions to a whole-day span.
+func parseDay(expr string, opts Options) (Span, bool) {
+	switch expr {
+	case "today", "tonight":
+		return daySpan(opts.Now), true
+	case "tomorrow", "tmrw":
+		return daySpan(opts.Now.AddDate(0, 0, 1)), true
+	case "yesterday":
+		return daySpan(opts.Now.AddDate(0, 0, -1)), true
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", expr, opts.Location); err == nil {
+		return daySpan(t), true
+	}
+
+	if strings.HasPrefix(expr, "last ") {
+		if wd, ok := weekdayNames[strings.TrimPrefix(expr, "last ")]; ok {
+			back := int(opts.Now.Weekday()) - int(wd)
+			if back <= 0 {
+				back += 7
+			}
+
+			return daySpan(opts.Now.AddDate(0, 0, -back)), true
+		}
+
+		return Span{}, false
+	}
+
+	if t, ok := parseWeekday(strings.TrimPrefix(expr, "this "), opts.Now); ok {
+		return daySpan(t), true
+	}
+
+	return Span{}, false
+}
+
+// parseClock parses "3pm", "3:30pm", "15:00", "9:30", "noon" and "midnight".
+func parseClock(value string) (hour int, minute int, ok bool) {
+	value = strings.TrimSpace(value)
+
+	switch value {
+	case "noon", "midday":
+		return 12, 0, true
+	case "midnight":
+		return 0, 0, true
+	}
+
+	m := reClock.FindStringSubmatch(strings.ReplaceAll(value, " ", ""))
+	if m == nil {
+		return 0, 0, false
+	}
+
+	// A bare number ("9") is only a clock with a meridiem or minutes.
+	if m[2] == "" && m[3] == "" {
+		return 0, 0, false
+	}
+
+	hour, _ = strconv.Atoi(m[1])
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+
+	if minute > 59 {
+		return 0, 0, false
+	}
+
+	switch m[3] {
+	case "am":
+		if hour < 1 || hour > 12 {
+			return 0, 0, false
+		}
+
+		if hour == 12 {
+			hour = 0
+		}
+	case "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, false
+		}
+
+		if hour != 12 {
+			hour += 12
+		}
+	default:
+		if hour > 23 {
+			return 0, 0, false
+		}
+	}
+
+	return hour, minute, true
+}
+
+// parseNaturalDuration parses Go durations ("45m", "1h30m") and "<n> <unit>".
+func parseNaturalDuration(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return d, true
+	}
+
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return 0, false
+	}
+
+	n := 1
+	if fields[0] != "a" && fields[0] != "an" {
+		v, err := strconv.Atoi(fields[0])
+		if err != nil || v <= 0 {
+			return 0, false
+		}
+
+		n = v
+	}
+
+	switch strings.TrimSuffix(fields[1], "s") {
+	case "minute", "min":
+		return time.Duration(n) * time.Minute, true
+	case "hour", "hr":
+		return time.Duration(n) * time.Hour, true
+	case "day":
+		return time.Duration(n) * 24 * time.Hour, true
+	default:
+		return 0, false
+	}
+}
+
+func periodBounds(unit string, shift int, opts Options) (time.Time, time.Time) {
+	now := opts.Now
+
+	switch unit {
+	case "day":
+		day := startOfDay(now.AddDate(0, 0, shift))
+		return day, endOfDayTime(day)
+	case "week":
+		start := startOfWeekDay(now, opts.WeekStart).AddDate(0, 0, 7*shift)
+		return start, endOfDayTime(start.AddDate(0, 0, 6))
+	case "month":
+		start := time.Date(now.Year(), now.Month()+time.Month(shift), 1, 0, 0, 0, 0, now.Location())
+		return start, endOfDayTime(start.AddDate(0, 1, -1))
+	default: // year
+		start := time.Date(now.Year()+shift, time.January, 1, 0, 0, 0, 0, now.Location())
+		return start, endOfDayTime(start.AddDate(1, 0, -1))
+	}
+}
+
+func shiftFor(word string) int {
+	switch word {
+	case "next":
+		return 1
+	case "last":
+		return -1
+	default:
+		return 0
+	}
+}
+
+func startOfWeekDay(t time.Time, weekStart time.Weekday) time.Time {
+	return startOfDay(t.AddDate(0, 0, -weekdayOffset(t.Weekday(), weekStart)))
+}
+
+func weekdayOffset(day time.Weekday, weekStart time.Weekday) int {
+	return (int(day) - int(weekStart) + 7) % 7
+}
+
+func daySpan(t time.Time) Span {
+	start := startOfDay(t)
+	return Span{Start: start, End: endOfDayTime(start)}
+}
+
+func atClock(day time.Time, hour int, minute int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, day.Location())
+}
+
+func endOfDayTime(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 23, 59, 59, 999999999, t.Location())
+}
@@ -0,0 +1,95 @@
+package timeparse
+
+import (
+	"testing"
+	"time"
+)
+
+//nolint:wsl_v5
+func TestParseNatural(t *testing.T) {
+	t.Parallel()
+
+	// Friday.
+	now := time.Date(2026, 2, 13, 15, 45, 0, 0, time.UTC)
+	const layout = "2006-01-02 15:04"
+
+	testCases := []struct {
+		name      string
+		value     string
+		weekStart time.Weekday
+		wantStart string
+		wantEnd   string
+		wantTime  bool
+		wantRange bool
+		wantErr   bool
+	}{
+		{name: "weekday with time", value: "next tuesday 3pm", weekStart: time.Monday, wantStart: "2026-02-17 15:00", wantEnd: "2026-02-17 15:00", wantTime: true},
+		{name: "time before day", value: "3pm tomorrow", weekStart: time.Monday, wantStart: "2026-02-14 15:00", wantEnd: "2026-02-14 15:00", wantTime: true},
+		{name: "at noon", value: "Tomorrow at noon", weekStart: time.Monday, wantStart: "2026-02-14 12:00", wantEnd: "2026-02-14 12:00", wantTime: true},
+		{name: "in hours", value: "in 2 hours", weekStart: time.Monday, wantStart: "2026-02-13 17:45", wantEnd: "2026-02-13 17:45", wantTime: true},
+		{name: "in a week", value: "in a week", weekStart: time.Monday, wantStart: "2026-02-20 00:00", wantEnd: "2026-02-20 23:59"},
+		{name: "ago", value: "2 weeks ago", weekStart: time.Monday, wantStart: "2026-01-30 00:00", wantEnd: "2026-01-30 23:59"},
+		{name: "for duration", value: "tomorrow 9:30 for 45m", weekStart: time.Monday, wantStart: "2026-02-14 09:30", wantEnd: "2026-02-14 10:15", wantTime: true, wantRange: true},
+		{name: "for words", value: "monday 10am for 2 hours", weekStart: time.Monday, wantStart: "2026-02-16 10:00", wantEnd: "2026-02-16 12:00", wantTime: true, wantRange: true},
+		{name: "end of month", value: "end of month", weekStart: time.Monday, wantStart: "2026-02-28 00:00", wantEnd: "2026-02-28 23:59"},
+		{name: "start of next month", value: "start of next month", weekStart: time.Monday, wantStart: "2026-03-01 00:00", wantEnd: "2026-03-01 23:59"},
+		{name: "end of week sunday start", value: "end of the week", weekStart: time.Sunday, wantStart: "2026-02-14 00:00", wantEnd: "2026-02-14 23:59"},
+		{name: "last week", value: "last week", weekStart: time.Monday, wantStart: "2026-02-02 00:00", wantEnd: "2026-02-08 23:59", wantRange: true},
+		{name: "this week sunday start", value: "this week", weekStart: time.Sunday, wantStart: "2026-02-08 00:00", wantEnd: "2026-02-14 23:59", wantRange: true},
+		{name: "next month", value: "next month", weekStart: time.Monday, wantStart: "2026-03-01 00:00", wantEnd: "2026-03-31 23:59", wantRange: true},
+		{name: "last weekday", value: "last monday", weekStart: time.Monday, wantStart: "2026-02-09 00:00", wantEnd: "2026-02-09 23:59"},
+		{name: "weekday range", value: "mon-fri this week", weekStart: time.Monday, wantStart: "2026-02-09 00:00", wantEnd: "2026-02-13 23:59", wantRange: true},
+		{name: "weekday range next week", value: "Mon - Fri next week", weekStart: time.Monday, wantStart: "2026-02-16 00:00", wantEnd: "2026-02-20 23:59", wantRange: true},
+		{name: "weekday range wraps", value: "fri-mon", weekStart: time.Monday, wantStart: "2026-02-13 00:00", wantEnd: "2026-02-16 23:59", wantRange: true},
+		{name: "clock range shared meridiem", value: "9-11am", weekStart: time.Monday, wantStart: "2026-02-13 09:00", wantEnd: "2026-02-13 11:00", wantTime: true, wantRange: true},
+		{name: "clock range across noon", value: "11-1pm", weekStart: time.Monday, wantStart: "2026-02-13 11:00", wantEnd: "2026-02-13 13:00", wantTime: true, wantRange: true},
+		{name: "clock range with day", value: "tue 14:00-15:30", weekStart: time.Monday, wantStart: "2026-02-17 14:00", wantEnd: "2026-02-17 15:30", wantTime: true, wantRange: true},
+		{name: "bare clock", value: "15:00", weekStart: time.Monday, wantStart: "2026-02-13 15:00", wantEnd: "2026-02-13 15:00", wantTime: true},
+		{name: "absolute date", value: "2026-03-01", weekStart: time.Monday, wantStart: "2026-03-01 00:00", wantEnd: "2026-03-01 23:59"},
+		{name: "absolute datetime", value: "2026-03-01T10:30:00Z", weekStart: time.Monday, wantStart: "2026-03-01 10:30", wantEnd: "2026-03-01 10:30", wantTime: true},
+		{name: "for without time", value: "tomorrow for 45m", weekStart: time.Monday, wantErr: true},
+		{name: "bad duration", value: "3pm for ever", weekStart: time.Monday, wantErr: true},
+		{name: "bad clock", value: "13pm", weekStart: time.Monday, wantErr: true},
+		{name: "invalid", value: "yolo", weekStart: time.Monday, wantErr: true},
+		{name: "empty", value: " ", weekStart: time.Monday, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseNatural(tc.value, Options{Now: now, Location: time.UTC, WeekStart: tc.weekStart})
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseNatural: %v", err)
+			}
+			if got.Start.Format(layout) != tc.wantStart || got.End.Format(layout) != tc.wantEnd {
+				t.Fatalf("unexpected span: %s .. %s", got.Start.Format(layout), got.End.Format(layout))
+			}
+			if got.HasTime != tc.wantTime || got.IsRange != tc.wantRange {
+				t.Fatalf("unexpected flags: hasTime=%v isRange=%v", got.HasTime, got.IsRange)
+			}
+		})
+	}
+}
+
+func TestParseNatural_UsesLocation(t *testing.T) {
+	t.Parallel()
+
+	loc := time.FixedZone("Offset", -8*3600)
+	// 2026-02-14 03:00 UTC is still Friday evening at -08:00.
+	now := time.Date(2026, 2, 14, 3, 0, 0, 0, time.UTC)
+
+	got, err := ParseNatural("tomorrow 9am", Options{Now: now, Location: loc})
+	if err != nil {
+		t.Fatalf("ParseNatural: %v", err)
+	}
+
+	if got.Start.Format(time.RFC3339) != "2026-02-14T09:00:00-08:00" {
+		t.Fatalf("unexpected start: %s", got.Start.Format(time.RFC3339))
+	}
+}
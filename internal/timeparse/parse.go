@@ -93,8 +93,10 @@ func ParseDateTimeOrDate(value string, loc *time.Location) (ParsedDateTime, erro
 
 // ParseRangeExpr parses calendar range expressions.
 // Supported: absolute datetime/date forms from ParseDateTimeOrDate plus
-// relative forms (now/today/tomorrow/yesterday/monday/next monday).
-func ParseRangeExpr(expr string, now time.Time, loc *time.Location) (time.Time, error) {
+// relative forms (now/today/tomorrow/yesterday/monday/next monday) and the
+// natural expressions of ParseNatural (weeks start on weekStart), resolved to
+// their start.
+func ParseRangeExpr(expr string, now time.Time, loc *time.Location, weekStart time.Weekday) (time.Time, error) {
 	expr = strings.TrimSpace(expr)
 	if expr == "" {
 		return time.Time{}, ErrEmptyTimeExpr
@@ -121,7 +123,12 @@ func ParseRangeExpr(expr string, now time.Time, loc *time.Location) (time.Time,
 		return parsed.Time, nil
 	}
 
-	return time.Time{}, fmt.Errorf("%w: %q (try: 2026-01-05, today, tomorrow, monday)", ErrInvalidTimeExpr, expr)
+	span, err := ParseNatural(expr, Options{Now: now, Location: loc, WeekStart: weekStart})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return span.Start, nil
 }
 
 // ParseSince parses --since values for tracking style queries.
@@ -105,6 +105,7 @@ func TestParseRangeExpr(t *testing.T) {
 		wantMonth time.Month
 		wantHour  int
 		wantWeek  time.Weekday
+		weekStart time.Weekday
 	}{
 		{name: "now", value: "now", wantDay: 13, wantMonth: time.February, wantHour: 15, wantWeek: time.Friday},
 		{name: "today", value: "today", wantDay: 13, wantMonth: time.February, wantHour: 0, wantWeek: time.Friday},
@@ -113,13 +114,16 @@ func TestParseRangeExpr(t *testing.T) {
 		{name: "next weekday", value: "next friday", wantDay: 20, wantMonth: time.February, wantHour: 0, wantWeek: time.Friday},
 		{name: "date", value: "2026-02-01", wantDay: 1, wantMonth: time.February, wantHour: 0, wantWeek: time.Sunday},
 		{name: "datetime", value: "2026-02-01T10:30:00", wantDay: 1, wantMonth: time.February, wantHour: 10, wantWeek: time.Sunday},
+		{name: "natural", value: "end of month", wantDay: 28, wantMonth: time.February, wantHour: 0, wantWeek: time.Saturday},
+		{name: "week monday", value: "this week", wantDay: 9, wantMonth: time.February, wantHour: 0, wantWeek: time.Monday, weekStart: time.Monday},
+		{name: "week sunday", value: "this week", wantDay: 8, wantMonth: time.February, wantHour: 0, wantWeek: time.Sunday, weekStart: time.Sunday},
 		{name: "invalid", value: "yolo", wantErr: true},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
-			got, err := ParseRangeExpr(tc.value, now, loc)
+			got, err := ParseRangeExpr(tc.value, now, loc, tc.weekStart)
 			if tc.wantErr {
 				if err == nil {
 					t.Fatalf("expected error")
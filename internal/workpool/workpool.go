@@ -0,0 +1,106 @@
+// Package workpool runs bounded, ordered fan-out work (per calendar, per
+// message, per account, ...) with cancellation on the first error.
+package workpool
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultLimit is the concurrency used when neither the caller nor --concurrency sets one.
+const DefaultLimit = 10
+
+type limitKey struct{}
+
+// WithLimit stores the --concurrency override in ctx. Values <= 0 are ignored.
+func WithLimit(ctx context.Context, limit int) context.Context {
+	if limit <= 0 {
+		return ctx
+	}
+
+	return context.WithValue(ctx, limitKey{}, limit)
+}
+
+// Limit returns the --concurrency override from ctx, or fallback when unset.
+func Limit(ctx context.Context, fallback int) int {
+	if ctx != nil {
+		if v, ok := ctx.Value(limitKey{}).(int); ok && v > 0 {
+			return v
+		}
+	}
+	if fallback <= 0 {
+		return DefaultLimit
+	}
+
+	return fallback
+}
+
+// Map calls fn for every item with at most limit calls in flight and returns
+// the results in input order. The first error cancels the context passed to
+// in-flight calls, stops dispatching the rest, and is returned together with
+// the results collected so far. Errors an item should survive (a warning, a
+// skipped entry) belong in R, not in the returned error.
+func Map[T, R any](ctx context.Context, limit int, items []T, fn func(ctx context.Context, item T) (R, error)) ([]R, error) {
+	results := make([]R, len(items))
+	if len(items) == 0 {
+		return results, nil
+	}
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	limit = min(limit, len(items))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	next := make(chan int)
+
+	for range limit {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				r, err := fn(ctx, items[i])
+				if err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				results[i] = r
+			}
+		}()
+	}
+
+dispatch:
+	for i := range items {
+		select {
+		case next <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(next)
+	wg.Wait()
+
+	if firstErr != nil {
+		return results, firstErr
+	}
+
+	return results, ctx.Err()
+}
+
+// Each is Map for work without results.
+func Each[T any](ctx context.Context, limit int, items []T, fn func(ctx context.Context, item T) error) error {
+	_, err := Map(ctx, limit, items, func(ctx context.Context, item T) (struct{}, error) {
+		return struct{}{}, fn(ctx, item)
+	})
+
+	return err
+}
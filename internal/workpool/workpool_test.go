@@ -0,0 +1,98 @@
+package workpool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMap_OrderedAndBounded(t *testing.T) {
+	items := make([]int, 50)
+	for i := range items {
+		items[i] = i
+	}
+
+	var inFlight, peak atomic.Int32
+	got, err := Map(context.Background(), 3, items, func(_ context.Context, n int) (int, error) {
+		cur := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			old := peak.Load()
+			if cur <= old || peak.CompareAndSwap(old, cur) {
+				break
+			}
+		}
+		// Finish out of order.
+		time.Sleep(time.Duration(50-n) * 50 * time.Microsecond)
+		return n * n, nil
+	})
+	if err != nil {
+		t.Fatalf("Map: %v", err)
+	}
+	for i, v := range got {
+		if v != i*i {
+			t.Fatalf("result %d = %d, want %d", i, v, i*i)
+		}
+	}
+	if p := peak.Load(); p > 3 {
+		t.Fatalf("expected at most 3 in flight, saw %d", p)
+	}
+}
+
+func TestMap_FirstErrorCancels(t *testing.T) {
+	items := make([]int, 100)
+	for i := range items {
+		items[i] = i
+	}
+	boom := errors.New("boom")
+
+	var calls atomic.Int32
+	_, err := Map(context.Background(), 2, items, func(ctx context.Context, n int) (int, error) {
+		calls.Add(1)
+		if n == 1 {
+			return 0, boom
+		}
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(5 * time.Millisecond):
+			return n, nil
+		}
+	})
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected boom, got %v", err)
+	}
+	if c := calls.Load(); c > 10 {
+		t.Fatalf("expected dispatch to stop after the error, got %d calls", c)
+	}
+}
+
+func TestMap_ParentCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := Each(ctx, 2, []int{1, 2, 3}, func(ctx context.Context, _ int) error {
+		return ctx.Err()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestLimit(t *testing.T) {
+	ctx := context.Background()
+	if got := Limit(ctx, 4); got != 4 {
+		t.Fatalf("fallback: got %d", got)
+	}
+	if got := Limit(ctx, 0); got != DefaultLimit {
+		t.Fatalf("default: got %d", got)
+	}
+	if got := Limit(WithLimit(ctx, 25), 4); got != 25 {
+		t.Fatalf("override: got %d", got)
+	}
+	if got := Limit(WithLimit(ctx, 0), 4); got != 4 {
+		t.Fatalf("zero override should be ignored: got %d", got)
+	}
+}